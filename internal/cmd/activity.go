@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	activitySince  string
+	activityLimit  int
+	activityFormat string
+)
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Summarize your recent Gerrit activity",
+	Long: `Summarize everything you did in a time window (patchsets uploaded, reviews
+given, comments written, changes submitted) and everything that happened to
+your changes, built from change queries and their message history.
+
+Handy for standup notes and weekly reports.
+
+Examples:
+  gerry activity
+  gerry activity --since 1d
+  gerry activity --since 2w --format json`,
+	Args: cobra.NoArgs,
+	RunE: runActivity,
+}
+
+func init() {
+	activityCmd.Flags().StringVar(&activitySince, "since", "7d", "How far back to look (e.g. 24h, 7d, 2w)")
+	activityCmd.Flags().IntVarP(&activityLimit, "limit", "n", 200, "Maximum number of changes to scan per query")
+	activityCmd.Flags().StringVar(&activityFormat, "format", "table", "Output format: table, json")
+}
+
+// activityEntry is one message-derived event in the feed.
+type activityEntry struct {
+	Time    string `json:"time"`
+	Change  int    `json:"change"`
+	Project string `json:"project"`
+	Subject string `json:"subject"`
+	Kind    string `json:"kind"` // uploaded, reviewed, commented, submitted, abandoned, restored, other
+	Actor   string `json:"actor"`
+	Detail  string `json:"detail,omitempty"`
+
+	Comments int `json:"comments,omitempty"`
+	at       time.Time
+}
+
+// activityReport is the output of `gerry activity`.
+type activityReport struct {
+	Since      string          `json:"since"`
+	Uploaded   int             `json:"patchsets_uploaded"`
+	Reviews    int             `json:"reviews_given"`
+	Comments   int             `json:"comments_written"`
+	Submitted  int             `json:"changes_submitted"`
+	Mine       []activityEntry `json:"by_me"`
+	OnMyChange []activityEntry `json:"on_my_changes"`
+}
+
+var (
+	voteRe         = regexp.MustCompile(`^Patch Set \d+: .*[A-Za-z-]+[+-]\d`)
+	commentCountRe = regexp.MustCompile(`\((\d+) (?:inline )?comments?\)`)
+)
+
+func runActivity(cmd *cobra.Command, args []string) error {
+	window, err := utils.ParseDuration(activitySince)
+	if err != nil {
+		return err
+	}
+	if activityFormat != "table" && activityFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", activityFormat)
+	}
+	if activityFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	// -age:N matches changes updated within N; hours keep fractional windows.
+	age := fmt.Sprintf("-age:%dh", int(math.Ceil(window.Hours())))
+	queries := []string{
+		fmt.Sprintf("owner:%s %s", cfg.User, age),
+		fmt.Sprintf("(reviewedby:%s OR commentby:%s) -owner:%s %s", cfg.User, cfg.User, cfg.User, age),
+	}
+
+	spinner := utils.NewSpinner("Collecting activity...")
+	var changes []gerrit.Change
+	for _, q := range queries {
+		utils.Debugf("Query: %s", q)
+		result, err := client.ListChangesWithOptions(url.QueryEscape(q), activityLimit, "MESSAGES")
+		if err != nil {
+			spinner.Stop()
+			return fmt.Errorf("failed to list changes: %w", err)
+		}
+		changes = append(changes, result...)
+	}
+	spinner.Stop()
+
+	since := time.Now().UTC().Add(-window)
+	report := buildActivityReport(changes, gerrit.Account{Username: cfg.User}, since)
+	report.Since = since.Format(time.RFC3339)
+
+	if activityFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayActivityReport(report)
+	return nil
+}
+
+// buildActivityReport turns change messages posted after since into feed
+// entries: everything me wrote anywhere, and everything others did on
+// changes me owns. Entries are oldest first.
+func buildActivityReport(changes []gerrit.Change, me gerrit.Account, since time.Time) activityReport {
+	report := activityReport{Mine: []activityEntry{}, OnMyChange: []activityEntry{}}
+	seen := make(map[string]bool)
+
+	for _, change := range changes {
+		ownChange := isSameAccount(change.Owner, me)
+		for _, msg := range change.Messages {
+			if msg.ID != "" {
+				if seen[msg.ID] {
+					continue
+				}
+				seen[msg.ID] = true
+			}
+			at, err := utils.ParseGerritTime(msg.Date)
+			if err != nil || at.Before(since) {
+				continue
+			}
+
+			byMe := isSameAccount(msg.Author, me)
+			if !byMe && !ownChange {
+				continue
+			}
+
+			entry := classifyActivityMessage(msg)
+			entry.Time = at.Format(time.RFC3339)
+			entry.Change = change.ChangeNumber()
+			entry.Project = change.Project
+			entry.Subject = change.Subject
+			entry.Actor = msg.Author.DisplayName()
+			if msg.Author == (gerrit.Account{}) {
+				entry.Actor = "Gerrit"
+			}
+			entry.at = at
+
+			if !byMe {
+				report.OnMyChange = append(report.OnMyChange, entry)
+				continue
+			}
+			report.Mine = append(report.Mine, entry)
+			report.Comments += entry.Comments
+			switch entry.Kind {
+			case "uploaded":
+				report.Uploaded++
+			case "submitted":
+				report.Submitted++
+			case "reviewed":
+				if !ownChange {
+					report.Reviews++
+				}
+			}
+		}
+	}
+
+	for _, entries := range [][]activityEntry{report.Mine, report.OnMyChange} {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].at.Before(entries[j].at)
+		})
+	}
+	return report
+}
+
+// classifyActivityMessage works out what kind of action a change message
+// records, from its tag where Gerrit sets one and its text otherwise.
+func classifyActivityMessage(msg gerrit.ChangeMessageInfo) activityEntry {
+	entry := activityEntry{Kind: "other", Detail: firstLine(msg.Message)}
+	if m := commentCountRe.FindStringSubmatch(msg.Message); m != nil {
+		entry.Comments, _ = strconv.Atoi(m[1])
+	}
+
+	switch {
+	case msg.Tag == "autogenerated:gerrit:newPatchSet" || strings.HasPrefix(msg.Message, "Uploaded patch set"):
+		entry.Kind = "uploaded"
+	case msg.Tag == "autogenerated:gerrit:merged" || strings.HasPrefix(msg.Message, "Change has been successfully"):
+		entry.Kind = "submitted"
+	case msg.Tag == "autogenerated:gerrit:abandon" || strings.HasPrefix(msg.Message, "Abandoned"):
+		entry.Kind = "abandoned"
+	case msg.Tag == "autogenerated:gerrit:restore" || strings.HasPrefix(msg.Message, "Restored"):
+		entry.Kind = "restored"
+	case voteRe.MatchString(msg.Message):
+		entry.Kind = "reviewed"
+	case entry.Comments > 0 || strings.HasPrefix(msg.Message, "Patch Set"):
+		entry.Kind = "commented"
+	}
+	return entry
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func displayActivityReport(r activityReport) {
+	fmt.Printf("%s %d patchset(s) uploaded, %d review(s) given, %d comment(s) written, %d change(s) submitted\n",
+		utils.BoldCyan("You:"), r.Uploaded, r.Reviews, r.Comments, r.Submitted)
+
+	fmt.Printf("\n%s\n", utils.BoldCyan("What you did:"))
+	displayActivityEntries(r.Mine, false)
+
+	fmt.Printf("\n%s\n", utils.BoldCyan("On your changes:"))
+	displayActivityEntries(r.OnMyChange, true)
+}
+
+func displayActivityEntries(entries []activityEntry, showActor bool) {
+	if len(entries) == 0 {
+		fmt.Printf("  %s\n", utils.Gray("Nothing"))
+		return
+	}
+
+	headers := []string{"When", "Change", "Kind", "Detail"}
+	if showActor {
+		headers = []string{"When", "Change", "Who", "Kind", "Detail"}
+	}
+	var rows [][]string
+	for _, e := range entries {
+		row := []string{
+			e.at.Local().Format("Mon 15:04"),
+			utils.BoldCyan(strconv.Itoa(e.Change)),
+		}
+		if showActor {
+			row = append(row, utils.TruncateString(e.Actor, 20))
+		}
+		row = append(row, e.Kind, utils.TruncateString(e.Detail, 60))
+		rows = append(rows, row)
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+}
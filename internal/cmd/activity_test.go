@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestClassifyActivityMessage(t *testing.T) {
+	tests := []struct {
+		msg          gerrit.ChangeMessageInfo
+		wantKind     string
+		wantComments int
+	}{
+		{gerrit.ChangeMessageInfo{Message: "Uploaded patch set 2.", Tag: "autogenerated:gerrit:newPatchSet"}, "uploaded", 0},
+		{gerrit.ChangeMessageInfo{Message: "Change has been successfully merged", Tag: "autogenerated:gerrit:merged"}, "submitted", 0},
+		{gerrit.ChangeMessageInfo{Message: "Patch Set 2: Code-Review+2\n\n(3 comments)"}, "reviewed", 3},
+		{gerrit.ChangeMessageInfo{Message: "Patch Set 1:\n\n(1 comment)"}, "commented", 1},
+		{gerrit.ChangeMessageInfo{Message: "Abandoned"}, "abandoned", 0},
+		{gerrit.ChangeMessageInfo{Message: "Build started"}, "other", 0},
+	}
+
+	for _, tt := range tests {
+		got := classifyActivityMessage(tt.msg)
+		if got.Kind != tt.wantKind || got.Comments != tt.wantComments {
+			t.Errorf("classifyActivityMessage(%q) = %s/%d, want %s/%d",
+				tt.msg.Message, got.Kind, got.Comments, tt.wantKind, tt.wantComments)
+		}
+	}
+}
+
+func TestBuildActivityReport(t *testing.T) {
+	me := gerrit.Account{Username: "alice"}
+	bob := gerrit.Account{Username: "bob", Name: "Bob"}
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	own := gerrit.Change{Number: 1, Owner: me, Messages: []gerrit.ChangeMessageInfo{
+		{ID: "a", Author: me, Date: "2024-01-09 12:00:00.000000000", Message: "Uploaded patch set 1."},
+		{ID: "b", Author: me, Date: "2024-01-11 12:00:00.000000000", Message: "Uploaded patch set 2."},
+		{ID: "c", Author: bob, Date: "2024-01-12 12:00:00.000000000", Message: "Patch Set 2: Code-Review+2"},
+		{ID: "d", Author: me, Date: "2024-01-13 12:00:00.000000000", Message: "Change has been successfully merged", Tag: "autogenerated:gerrit:merged"},
+	}}
+	other := gerrit.Change{Number: 2, Owner: bob, Messages: []gerrit.ChangeMessageInfo{
+		{ID: "e", Author: me, Date: "2024-01-11 09:00:00.000000000", Message: "Patch Set 1: Code-Review-1\n\n(2 comments)"},
+		{ID: "f", Author: bob, Date: "2024-01-11 10:00:00.000000000", Message: "Uploaded patch set 2."},
+	}}
+
+	// The second query can return a change again; its messages count once.
+	r := buildActivityReport([]gerrit.Change{own, other, other}, me, since)
+
+	if r.Uploaded != 1 || r.Reviews != 1 || r.Comments != 2 || r.Submitted != 1 {
+		t.Errorf("counts = uploaded %d, reviews %d, comments %d, submitted %d; want 1, 1, 2, 1",
+			r.Uploaded, r.Reviews, r.Comments, r.Submitted)
+	}
+	if len(r.Mine) != 3 || r.Mine[0].Change != 2 {
+		t.Errorf("Mine = %+v, want 3 entries starting with change 2", r.Mine)
+	}
+	if len(r.OnMyChange) != 1 || r.OnMyChange[0].Actor != "Bob" || r.OnMyChange[0].Kind != "reviewed" {
+		t.Errorf("OnMyChange = %+v, want Bob's review", r.OnMyChange)
+	}
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	adminFormat      string
+	adminFlushCaches []string
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Server administration and troubleshooting",
+	Long: `Commands for Gerrit server administrators. These wrap Gerrit's admin SSH
+commands and require the corresponding server capabilities.
+
+Subcommands:
+  caches       Show cache statistics, optionally flushing caches
+  connections  Show open SSH connections
+  reindex      Reindex changes missing from search results
+  gc           Run git garbage collection on projects
+  ban-commit   Ban commits from being pushed to a project
+  user-refs    List the refs of a project a user can see`,
+}
+
+var adminCachesCmd = &cobra.Command{
+	Use:   "caches",
+	Short: "Show server cache statistics",
+	Long: `Show cache statistics by wrapping the SSH 'gerrit show-caches' command.
+Use --flush to flush one or more caches first (wraps 'gerrit flush-caches');
+pass --flush all to flush every cache.
+
+Requires the View Caches capability (Flush Caches to flush).
+
+Examples:
+  gerry admin caches
+  gerry admin caches --format json
+  gerry admin caches --flush accounts --flush groups
+  gerry admin caches --flush all`,
+	Args: cobra.NoArgs,
+	RunE: runAdminCaches,
+}
+
+var adminConnectionsCmd = &cobra.Command{
+	Use:   "connections",
+	Short: "Show open SSH connections",
+	Long: `Show open SSH sessions by wrapping the SSH 'gerrit show-connections -w'
+command. Requires the View Connections capability.
+
+Examples:
+  gerry admin connections
+  gerry admin connections --format json`,
+	Args: cobra.NoArgs,
+	RunE: runAdminConnections,
+}
+
+func init() {
+	adminCmd.PersistentFlags().StringVar(&adminFormat, "format", "table", "Output format: table, json")
+	adminCachesCmd.Flags().StringArrayVar(&adminFlushCaches, "flush", nil, "Flush the named cache before listing (repeatable, or \"all\")")
+
+	adminCmd.AddCommand(adminCachesCmd)
+	adminCmd.AddCommand(adminConnectionsCmd)
+}
+
+// cacheStat is one parsed row of `gerrit show-caches` output.
+type cacheStat struct {
+	Name         string `json:"name"`
+	Disk         bool   `json:"disk"`
+	MemEntries   string `json:"mem_entries,omitempty"`
+	DiskEntries  string `json:"disk_entries,omitempty"`
+	Space        string `json:"space,omitempty"`
+	AvgGet       string `json:"avg_get,omitempty"`
+	MemHitRatio  string `json:"mem_hit_ratio,omitempty"`
+	DiskHitRatio string `json:"disk_hit_ratio,omitempty"`
+}
+
+// sshConnection is one parsed row of `gerrit show-connections` output.
+type sshConnection struct {
+	Session    string `json:"session"`
+	Start      string `json:"start"`
+	Idle       string `json:"idle"`
+	User       string `json:"user"`
+	RemoteHost string `json:"remote_host"`
+}
+
+var connectionLinePattern = regexp.MustCompile(`^([0-9a-f]{8})\s+(\S+)\s+(\d{2}:\d{2}:\d{2})\s+(\S+)\s+(\S+)`)
+
+// validateAdminFormat checks the --format flag up front so a typo fails
+// before any server call.
+func validateAdminFormat() error {
+	switch adminFormat {
+	case "table", "json":
+		return nil
+	default:
+		return fmt.Errorf("unknown format: %s (supported: table, json)", adminFormat)
+	}
+}
+
+// loadAdminSSHClient validates --format, loads configuration, and returns an
+// SSH client.
+func loadAdminSSHClient() (*gerrit.SSHClient, error) {
+	if err := validateAdminFormat(); err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return gerrit.NewSSHClient(cfg), nil
+}
+
+func runAdminCaches(cmd *cobra.Command, args []string) error {
+	client, err := loadAdminSSHClient()
+	if err != nil {
+		return err
+	}
+
+	if len(adminFlushCaches) > 0 {
+		var names []string
+		for _, name := range adminFlushCaches {
+			if name == "all" {
+				names = nil
+				break
+			}
+			names = append(names, name)
+		}
+		if err := client.FlushCaches(names...); err != nil {
+			return fmt.Errorf("failed to flush caches: %w", err)
+		}
+		// Keep stdout to the JSON document alone.
+		status := io.Writer(os.Stdout)
+		if adminFormat == "json" {
+			status = os.Stderr
+		}
+		if names == nil {
+			fmt.Fprintf(status, "%s Flushed all caches\n", utils.Green("✓"))
+		} else {
+			fmt.Fprintf(status, "%s Flushed %s\n", utils.Green("✓"), strings.Join(names, ", "))
+		}
+	}
+
+	output, err := client.ShowCaches()
+	if err != nil {
+		return fmt.Errorf("failed to show caches: %w", err)
+	}
+	caches := parseShowCaches(output)
+
+	if adminFormat == "json" {
+		return printAdminJSON(caches)
+	}
+
+	if len(caches) == 0 {
+		fmt.Println("No caches reported.")
+		return nil
+	}
+
+	headers := []string{"Cache", "Type", "Mem", "Disk", "Space", "AvgGet", "Hit Mem", "Hit Disk"}
+	var rows [][]string
+	for _, c := range caches {
+		kind := "mem"
+		if c.Disk {
+			kind = "disk"
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(c.Name),
+			kind,
+			c.MemEntries,
+			c.DiskEntries,
+			c.Space,
+			c.AvgGet,
+			c.MemHitRatio,
+			c.DiskHitRatio,
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	return nil
+}
+
+func runAdminConnections(cmd *cobra.Command, args []string) error {
+	client, err := loadAdminSSHClient()
+	if err != nil {
+		return err
+	}
+
+	output, err := client.ShowConnections()
+	if err != nil {
+		return fmt.Errorf("failed to show connections: %w", err)
+	}
+	conns := parseShowConnections(output)
+
+	if adminFormat == "json" {
+		return printAdminJSON(conns)
+	}
+
+	if len(conns) == 0 {
+		fmt.Println("No open connections.")
+		return nil
+	}
+
+	headers := []string{"Session", "Start", "Idle", "User", "Remote Host"}
+	var rows [][]string
+	for _, c := range conns {
+		rows = append(rows, []string{utils.BoldCyan(c.Session), c.Start, c.Idle, c.User, c.RemoteHost})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d connection(s)\n", len(conns))
+	return nil
+}
+
+func printAdminJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// parseShowCaches extracts cache rows from the pipe-delimited table printed
+// by `gerrit show-caches`. Rows for persistent caches are prefixed with "D".
+// Header, separator, and trailing JVM/thread sections are ignored.
+func parseShowCaches(output string) []cacheStat {
+	var caches []cacheStat
+	for _, line := range strings.Split(output, "\n") {
+		cols := strings.Split(line, "|")
+		if len(cols) < 4 || strings.Contains(line, "--+--") {
+			continue
+		}
+
+		name := strings.TrimSpace(cols[0])
+		disk := false
+		if strings.HasPrefix(name, "D ") {
+			disk = true
+			name = strings.TrimSpace(name[2:])
+		}
+		if name == "" || name == "Name" {
+			continue
+		}
+
+		c := cacheStat{Name: name, Disk: disk, AvgGet: strings.TrimSpace(cols[2])}
+		entries := strings.Fields(cols[1])
+		if len(entries) > 0 {
+			c.MemEntries = entries[0]
+		}
+		if len(entries) > 1 {
+			c.DiskEntries = entries[1]
+		}
+		if len(entries) > 2 {
+			c.Space = entries[2]
+		}
+		hits := strings.Fields(cols[3])
+		if len(hits) > 0 {
+			c.MemHitRatio = hits[0]
+		}
+		if len(hits) > 1 {
+			c.DiskHitRatio = hits[1]
+		}
+		caches = append(caches, c)
+	}
+	return caches
+}
+
+// parseShowConnections extracts session rows from `gerrit show-connections`.
+func parseShowConnections(output string) []sshConnection {
+	var conns []sshConnection
+	for _, line := range strings.Split(output, "\n") {
+		m := connectionLinePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		conns = append(conns, sshConnection{
+			Session:    m[1],
+			Start:      m[2],
+			Idle:       m[3],
+			User:       m[4],
+			RemoteHost: m[5],
+		})
+	}
+	return conns
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var banReason string
+
+var adminBanCommitCmd = &cobra.Command{
+	Use:   "ban-commit <project> <sha>...",
+	Short: "Ban commits from a project",
+	Long: `Ban one or more commits from a project by wrapping the SSH 'gerrit
+ban-commit' command. A banned commit is rejected if anyone tries to push it
+again, e.g. after a leaked secret has been scrubbed from history.
+
+Banning does not remove a commit that is already reachable from a branch;
+rewrite the branch first. Requires full 40-character SHAs and administrator
+rights (or Push permission on refs/meta/config).
+
+Examples:
+  gerry admin ban-commit canvas-lms 3f2a9c0d4b7e8a1f6c5d2e9b0a7f4c3d8e1b6a2f --reason "leaked secret"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAdminBanCommit,
+}
+
+func init() {
+	adminBanCommitCmd.Flags().StringVarP(&banReason, "reason", "r", "", "Why the commit is banned (recorded in the ban note)")
+
+	adminCmd.AddCommand(adminBanCommitCmd)
+}
+
+var fullSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// banResult is the JSON output of ban-commit.
+type banResult struct {
+	Project string   `json:"project"`
+	Commits []string `json:"commits"`
+	Reason  string   `json:"reason,omitempty"`
+	Output  string   `json:"output,omitempty"`
+}
+
+func runAdminBanCommit(cmd *cobra.Command, args []string) error {
+	project, commits := args[0], args[1:]
+	for i, sha := range commits {
+		commits[i] = strings.ToLower(sha)
+		if !fullSHAPattern.MatchString(commits[i]) {
+			return fmt.Errorf("invalid commit %q: a full 40-character SHA is required", sha)
+		}
+	}
+
+	client, err := loadAdminSSHClient()
+	if err != nil {
+		return err
+	}
+
+	output, err := client.BanCommit(project, banReason, commits...)
+	if err != nil {
+		return fmt.Errorf("failed to ban commit: %w", err)
+	}
+	output = strings.TrimSpace(output)
+
+	if adminFormat == "json" {
+		data, err := json.MarshalIndent(banResult{Project: project, Commits: commits, Reason: banReason, Output: output}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if output != "" {
+		fmt.Println(output)
+	}
+	fmt.Printf("%s Banned %d commit(s) in %s\n", utils.Green("✓"), len(commits), utils.BoldCyan(project))
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcAll        bool
+	gcAggressive bool
+)
+
+var adminGCCmd = &cobra.Command{
+	Use:   "gc [project...]",
+	Short: "Run git garbage collection on projects",
+	Long: `Run git garbage collection on one or more projects by wrapping the SSH
+'gerrit gc' command, streaming its progress as it runs. The exit status is
+non-zero if gc fails, so it can be scheduled from cron or ops tooling.
+
+Requires the Run Garbage Collection capability.
+
+Examples:
+  gerry admin gc canvas-lms
+  gerry admin gc canvas-lms tools/gerry --aggressive
+  gerry admin gc --all`,
+	RunE: runAdminGC,
+}
+
+func init() {
+	adminGCCmd.Flags().BoolVar(&gcAll, "all", false, "Run gc on every project")
+	adminGCCmd.Flags().BoolVar(&gcAggressive, "aggressive", false, "Run an aggressive gc (slower, packs more tightly)")
+
+	adminCmd.AddCommand(adminGCCmd)
+}
+
+func runAdminGC(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !gcAll {
+		return fmt.Errorf("provide at least one project or --all")
+	}
+	if len(args) > 0 && gcAll {
+		return fmt.Errorf("--all cannot be combined with project names")
+	}
+	if adminFormat != "table" {
+		return fmt.Errorf("gc streams plain text output; --format %s is not supported", adminFormat)
+	}
+
+	client, err := loadAdminSSHClient()
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err := client.GC(os.Stdout, gcAll, gcAggressive, args...); err != nil {
+		return fmt.Errorf("gc failed: %w", err)
+	}
+
+	target := "all projects"
+	if !gcAll {
+		target = fmt.Sprintf("%d project(s)", len(args))
+	}
+	fmt.Printf("%s Garbage collection finished for %s in %s\n", utils.Green("✓"), target, time.Since(start).Round(time.Second))
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reindexQuery string
+	reindexLimit int
+)
+
+var adminReindexCmd = &cobra.Command{
+	Use:   "reindex [change-id...]",
+	Short: "Reindex changes that are missing from search results",
+	Long: `Reindex one or more changes, e.g. when a change does not show up in search
+results or shows stale data. Uses the REST index endpoint, falling back to the
+SSH 'gerrit index changes' command.
+
+With --query, every change matching the Gerrit search query is reindexed.
+Requires the Maintain Server capability.
+
+Examples:
+  gerry admin reindex 12345
+  gerry admin reindex 12345 12346 12347
+  gerry admin reindex --query "project:canvas-lms status:open" --limit 500`,
+	RunE: runAdminReindex,
+}
+
+func init() {
+	adminReindexCmd.Flags().StringVarP(&reindexQuery, "query", "q", "", "Reindex all changes matching this Gerrit query")
+	adminReindexCmd.Flags().IntVarP(&reindexLimit, "limit", "n", 100, "Maximum number of changes to reindex with --query")
+
+	adminCmd.AddCommand(adminReindexCmd)
+}
+
+// reindexResult records the outcome for one change, for JSON output.
+type reindexResult struct {
+	Change string `json:"change"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+func runAdminReindex(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && reindexQuery == "" {
+		return fmt.Errorf("provide at least one change-id or --query")
+	}
+	if err := validateAdminFormat(); err != nil {
+		return err
+	}
+	if adminFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	for _, changeID := range args {
+		if err := utils.ValidateChangeID(changeID); err != nil {
+			return fmt.Errorf("invalid change ID: %w", err)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	changeIDs := append([]string{}, args...)
+	if reindexQuery != "" {
+		utils.Debugf("Query: %s", reindexQuery)
+		matches, err := listChangesREST(cfg, reindexQuery, reindexLimit)
+		if err != nil {
+			utils.Warnf("REST API failed: %v", err)
+			utils.Info("Falling back to SSH...")
+			matches, err = listChangesSSH(cfg, reindexQuery, reindexLimit)
+			if err != nil {
+				return fmt.Errorf("failed to search changes: %w", err)
+			}
+		}
+		for _, change := range matches {
+			changeIDs = append(changeIDs, change.ChangeNumberStr())
+		}
+	}
+
+	if len(changeIDs) == 0 {
+		fmt.Println("No changes matched the query.")
+		return nil
+	}
+
+	results := reindexChanges(cfg, changeIDs)
+
+	if adminFormat == "json" {
+		return printAdminJSON(results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+			fmt.Printf("%s %s: %s\n", utils.Red("✗"), r.Change, r.Error)
+		}
+	}
+	fmt.Printf("%s Reindexed %d of %d change(s)\n", utils.Green("✓"), len(results)-failed, len(results))
+	if failed > 0 {
+		return fmt.Errorf("%d change(s) failed to reindex", failed)
+	}
+	return nil
+}
+
+// reindexChanges reindexes each change over REST, retrying a failed change
+// over SSH so that servers without REST credentials still work.
+func reindexChanges(cfg *config.Config, changeIDs []string) []reindexResult {
+	var restClient *gerrit.RESTClient
+	if cfg.HTTPPassword != "" {
+		restClient = gerrit.NewRESTClient(cfg)
+	}
+	sshClient := gerrit.NewSSHClient(cfg)
+
+	progress := utils.NewProgressBar("Reindexing", len(changeIDs))
+	results := make([]reindexResult, 0, len(changeIDs))
+	for i, changeID := range changeIDs {
+		var err error
+		if restClient != nil {
+			err = restClient.IndexChange(changeID)
+			if err != nil {
+				utils.Debugf("REST reindex of %s failed, trying SSH: %v", changeID, err)
+			}
+		}
+		if restClient == nil || err != nil {
+			err = sshClient.IndexChanges(changeID)
+		}
+
+		result := reindexResult{Change: changeID, OK: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+		progress.Set(i + 1)
+	}
+	progress.Stop()
+	return results
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowCaches(t *testing.T) {
+	output := `  Name                          |Entries              |  AvgGet |Hit Ratio|
+                                |   Mem   Disk   Space|         |Mem  Disk|
+--------------------------------+---------------------+---------+---------+
+  accounts                      |  4096               |   3.4ms | 99%     |
+D diff                          |    12   1234  12.4m |  55.3ms | 82%  91%|
+--------------------------------+---------------------+---------+---------+
+`
+	caches := parseShowCaches(output)
+	if len(caches) != 2 {
+		t.Fatalf("expected 2 caches, got %d: %+v", len(caches), caches)
+	}
+
+	if c := caches[0]; c.Name != "accounts" || c.Disk || c.MemEntries != "4096" || c.AvgGet != "3.4ms" || c.MemHitRatio != "99%" {
+		t.Errorf("unexpected memory cache row: %+v", c)
+	}
+	if c := caches[1]; c.Name != "diff" || !c.Disk || c.DiskEntries != "1234" || c.Space != "12.4m" || c.DiskHitRatio != "91%" {
+		t.Errorf("unexpected disk cache row: %+v", c)
+	}
+}
+
+func TestParseShowConnections(t *testing.T) {
+	output := `Session     Start   Idle   User            Remote Host
+--------------------------------------------------------------
+3abf31e6 20:09:02 00:00:00  jdoe            jdoe-desktop.example.com
+80cc1a7e Apr-14   01:12:44  a/1000023       10.0.0.7
+--------------------------------------------------------------
+`
+	conns := parseShowConnections(output)
+	if len(conns) != 2 {
+		t.Fatalf("expected 2 connections, got %d", len(conns))
+	}
+	if c := conns[0]; c.Session != "3abf31e6" || c.User != "jdoe" || c.RemoteHost != "jdoe-desktop.example.com" {
+		t.Errorf("unexpected first connection: %+v", c)
+	}
+	if c := conns[1]; c.Start != "Apr-14" || c.Idle != "01:12:44" || c.User != "a/1000023" {
+		t.Errorf("unexpected second connection: %+v", c)
+	}
+}
+
+func TestFilterChangeRefs(t *testing.T) {
+	refs := parseUserRefs("refs/heads/main\nrefs/changes/45/12345/1\nrefs/changes/45/12345/meta\nrefs/changes/45/112345/1\n\n")
+	got := filterChangeRefs(refs, "12345")
+	want := []string{"refs/changes/45/12345/1", "refs/changes/45/12345/meta"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterChangeRefs() = %v, want %v", got, want)
+	}
+	if got := filterChangeRefs(refs, "999"); len(got) != 0 {
+		t.Errorf("filterChangeRefs(999) = %v, want none", got)
+	}
+}
+
+func TestAdminCachesFlushJSON(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GERRIT_SERVER", "review.example.com")
+	t.Setenv("GERRIT_USER", "admin")
+
+	// A fake ssh that answers show-caches and accepts flush-caches.
+	bin := t.TempDir()
+	table := filepath.Join(bin, "show-caches.txt")
+	output := "  accounts                      |  4096               |   3.4ms | 99%     |\n"
+	if err := os.WriteFile(table, []byte(output), 0600); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ncase \"$*\" in *show-caches*) cat '" + table + "' ;; esac\n"
+	if err := os.WriteFile(filepath.Join(bin, "ssh"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	defer func() {
+		adminFlushCaches, adminFormat = nil, "table"
+		rootCmd.SetArgs(nil)
+	}()
+	rootCmd.SetArgs([]string{"admin", "caches", "--flush", "all", "--format", "json"})
+	out, err := captureStdout(t, rootCmd.Execute)
+	if err != nil {
+		t.Fatalf("gerry admin caches: %v", err)
+	}
+
+	var caches []cacheStat
+	if err := json.Unmarshal(out, &caches); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, out)
+	}
+	if len(caches) != 1 || caches[0].Name != "accounts" {
+		t.Errorf("caches = %+v, want the accounts cache", caches)
+	}
+}
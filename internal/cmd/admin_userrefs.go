@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	userRefsProject   string
+	userRefsUser      string
+	userRefsOnlyHeads bool
+	userRefsChange    string
+)
+
+var adminUserRefsCmd = &cobra.Command{
+	Use:   "user-refs",
+	Short: "List the refs of a project a user can see",
+	Long: `List exactly which refs of a project a user can see by wrapping the SSH
+'gerrit ls-user-refs' command — the fastest way to debug "I can't see that
+change" reports. With --change, only that change's refs are listed and the
+command says whether the user can see it.
+
+Requires administrator rights.
+
+Examples:
+  gerry admin user-refs --project canvas-lms --user bob
+  gerry admin user-refs -p canvas-lms -u bob --only-heads
+  gerry admin user-refs -p canvas-lms -u bob --change 12345`,
+	Args: cobra.NoArgs,
+	RunE: runAdminUserRefs,
+}
+
+func init() {
+	adminUserRefsCmd.Flags().StringVarP(&userRefsProject, "project", "p", "", "Project to inspect (required)")
+	adminUserRefsCmd.Flags().StringVarP(&userRefsUser, "user", "u", "", "User whose visibility to check (required)")
+	adminUserRefsCmd.Flags().BoolVar(&userRefsOnlyHeads, "only-heads", false, "Only list branches (refs/heads/*)")
+	adminUserRefsCmd.Flags().StringVar(&userRefsChange, "change", "", "Only list refs of this change and report whether it is visible")
+	adminUserRefsCmd.MarkFlagRequired("project")
+	adminUserRefsCmd.MarkFlagRequired("user")
+
+	adminCmd.AddCommand(adminUserRefsCmd)
+}
+
+// userRefsResult is the JSON output of user-refs.
+type userRefsResult struct {
+	Project string   `json:"project"`
+	User    string   `json:"user"`
+	Change  string   `json:"change,omitempty"`
+	Visible *bool    `json:"visible,omitempty"`
+	Refs    []string `json:"refs"`
+}
+
+func runAdminUserRefs(cmd *cobra.Command, args []string) error {
+	if userRefsChange != "" {
+		if err := utils.ValidateChangeID(userRefsChange); err != nil {
+			return fmt.Errorf("invalid change ID: %w", err)
+		}
+		if userRefsOnlyHeads {
+			return fmt.Errorf("--change cannot be combined with --only-heads")
+		}
+	}
+
+	client, err := loadAdminSSHClient()
+	if err != nil {
+		return err
+	}
+
+	output, err := client.ListUserRefs(userRefsProject, userRefsUser, userRefsOnlyHeads)
+	if err != nil {
+		return fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	result := userRefsResult{Project: userRefsProject, User: userRefsUser, Refs: parseUserRefs(output)}
+	if userRefsChange != "" {
+		result.Change = userRefsChange
+		result.Refs = filterChangeRefs(result.Refs, userRefsChange)
+		visible := len(result.Refs) > 0
+		result.Visible = &visible
+	}
+
+	if adminFormat == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, ref := range result.Refs {
+		fmt.Println(ref)
+	}
+	if result.Visible != nil {
+		if *result.Visible {
+			fmt.Printf("%s %s can see change %s\n", utils.Green("✓"), userRefsUser, utils.BoldCyan(userRefsChange))
+		} else {
+			fmt.Printf("%s %s cannot see change %s in %s\n", utils.Red("✗"), userRefsUser, utils.BoldCyan(userRefsChange), userRefsProject)
+		}
+		return nil
+	}
+	fmt.Printf("\n%d ref(s) visible to %s in %s\n", len(result.Refs), userRefsUser, userRefsProject)
+	return nil
+}
+
+// parseUserRefs splits ls-user-refs output into refs.
+func parseUserRefs(output string) []string {
+	refs := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			refs = append(refs, line)
+		}
+	}
+	return refs
+}
+
+// filterChangeRefs keeps the refs belonging to a change: its patchsets
+// (refs/changes/NN/<change>/<ps>) and its meta ref.
+func filterChangeRefs(refs []string, changeID string) []string {
+	prefix := fmt.Sprintf("refs/changes/%s/%s/", getChangePrefix(changeID), changeID)
+	matched := []string{}
+	for _, ref := range refs {
+		if strings.HasPrefix(ref, prefix) {
+			matched = append(matched, ref)
+		}
+	}
+	return matched
+}
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -19,12 +23,26 @@ import (
 var (
 	analyzeStartDate string
 	analyzeEndDate   string
-	analyzeRepo      string
+	analyzeRepos     []string
+	analyzeOwner     string
+	analyzeBranch    string
+	analyzeQuery     string
 	analyzeFormat    string
 	analyzeOutput    string
 	analyzePageSize  int
 	analyzeMaxLimit  int
 	analyzeTimeout   int
+	analyzeSpool     bool
+	analyzeStream    bool
+	analyzeRefresh   bool
+	analyzeFromCache bool
+	analyzeWithSize  bool
+	analyzeTopDirs   int
+	analyzeColumns   string
+
+	analyzeComparePrevious bool
+	analyzeBaselineStart   string
+	analyzeBaselineEnd     string
 )
 
 var analyzeCmd = &cobra.Command{
@@ -34,6 +52,18 @@ var analyzeCmd = &cobra.Command{
 This command fetches all merged changes and generates statistics about contributions,
 repositories, and timelines. Supports pagination for large result sets.
 
+Statistics are aggregated page by page, so markdown reports never hold the
+fetched changes in memory. JSON and CSV reports list every change; use --spool
+to keep those in a temporary file instead of memory, or --stream to write JSON
+as newline-delimited change records, followed by a summary line, as they are
+read.
+
+Merged changes are kept in a local store under ~/.gerry/analyze, one per server
+and set of filters. The first run fetches every change merged since --start-date; later
+runs whose range the store covers only fetch changes updated since the last
+run. Use --refresh to re-fetch everything, or --from-cache to report from the
+store without contacting the server.
+
 Examples:
   # Analyze all repositories for the current year
   gerry analyze --start-date 2025-01-01 --end-date 2025-12-31
@@ -42,13 +72,36 @@ Examples:
   gerry analyze --repo canvas-lms --start-date 2025-01-01
 
   # Save output to a file
-  gerry analyze --start-date 2025-01-01 --output analysis.md
+  gerry analyze --start-date 2025-01-01 --output-file analysis.md
+
+  # Share a self-contained HTML report with sortable tables and charts
+  gerry analyze --start-date 2025-01-01 --format html --output-file analysis.html
 
   # Get JSON output
-  gerry analyze --start-date 2025-01-01 --format json --output changes.json
+  gerry analyze --start-date 2025-01-01 --format json --output-file changes.json
+
+  # Scope a report to a team's repositories and a release branch
+  gerry analyze --repo canvas-lms --repo outcomes --branch release/2025-06 --start-date 2025-01-01
+
+  # Changes by one owner, narrowed further with any Gerrit search terms
+  gerry analyze --owner alice@example.com --query "-topic:chore" --start-date 2025-01-01
 
   # Analyze last 30 days in a specific repo
   gerry analyze --repo canvas-lms --start-date 2025-11-10 --end-date 2025-12-10
+
+  # Export a very large range without holding every change in memory
+  gerry analyze --start-date 2024-01-01 --max-changes 500000 --format csv --spool -o changes.csv
+  gerry analyze --start-date 2024-01-01 --max-changes 500000 --format json --stream -o changes.ndjson
+
+  # Include lines changed per author, repository, and directory
+  gerry analyze --start-date 2025-01-01 --with-size --top-dirs 30
+
+  # Compare with the previous period of the same length, or a chosen one
+  gerry analyze --start-date 2025-07-01 --end-date 2025-10-01 --compare-to-previous
+  gerry analyze --start-date 2025-01-01 --baseline-start 2024-01-01 --baseline-end 2024-12-31
+
+  # Re-run a report offline from the last fetch
+  gerry analyze --start-date 2025-01-01 --from-cache
 `,
 	RunE: runAnalyze,
 }
@@ -59,24 +112,52 @@ func init() {
 
 	analyzeCmd.Flags().StringVarP(&analyzeStartDate, "start-date", "s", startOfYear.Format("2006-01-02"), "Start date (YYYY-MM-DD)")
 	analyzeCmd.Flags().StringVarP(&analyzeEndDate, "end-date", "e", now.Format("2006-01-02"), "End date (YYYY-MM-DD)")
-	analyzeCmd.Flags().StringVarP(&analyzeRepo, "repo", "r", "", "Filter by specific repository (project)")
-	analyzeCmd.Flags().StringVarP(&analyzeFormat, "format", "f", "markdown", "Output format: markdown, json, csv")
-	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output", "o", "", "Output file (default: stdout)")
+	analyzeCmd.Flags().StringArrayVarP(&analyzeRepos, "repo", "r", nil, "Filter by repository (project; repeatable)")
+	analyzeCmd.Flags().StringVar(&analyzeOwner, "owner", "", "Filter by change owner (username, email, or name)")
+	analyzeCmd.Flags().StringVar(&analyzeBranch, "branch", "", "Filter by target branch")
+	analyzeCmd.Flags().StringVar(&analyzeQuery, "query", "", "Additional Gerrit search terms, e.g. \"topic:release OR hashtag:q3\"")
+	analyzeCmd.Flags().StringVarP(&analyzeFormat, "format", "f", "markdown", "Output format: markdown, json, csv, html")
+	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output-file", "o", "", "Output file (default: stdout)")
 	analyzeCmd.Flags().IntVar(&analyzePageSize, "page-size", 500, "Number of results per page")
 	analyzeCmd.Flags().IntVar(&analyzeMaxLimit, "max-changes", 10000, "Maximum total changes to fetch (safety limit)")
 	analyzeCmd.Flags().IntVar(&analyzeTimeout, "timeout", 300, "Request timeout in seconds (default: 300)")
+	analyzeCmd.Flags().BoolVar(&analyzeSpool, "spool", false, "Spool fetched changes to a temporary file instead of memory (json and csv formats)")
+	analyzeCmd.Flags().BoolVar(&analyzeStream, "stream", false, "Write changes as newline-delimited JSON as they are read, with the summary last (json format)")
+	analyzeCmd.Flags().BoolVar(&analyzeRefresh, "refresh", false, "Ignore the local store and re-fetch every change since the start date")
+	analyzeCmd.Flags().BoolVar(&analyzeFromCache, "from-cache", false, "Report from the local store without contacting the server")
+	analyzeCmd.Flags().BoolVar(&analyzeWithSize, "with-size", false, "Include lines-changed statistics (fetches each change's file list, which is slower)")
+	analyzeCmd.Flags().IntVar(&analyzeTopDirs, "top-dirs", 20, "Number of most-churned directories to list with --with-size")
+	analyzeCmd.Flags().StringVar(&analyzeColumns, "columns", "", "Comma-separated CSV columns, including label:<name> for votes (csv format)")
+	analyzeCmd.Flags().BoolVar(&analyzeComparePrevious, "compare-to-previous", false, "Compare with the period of the same length just before --start-date")
+	analyzeCmd.Flags().StringVar(&analyzeBaselineStart, "baseline-start", "", "Start date of the period to compare with (YYYY-MM-DD)")
+	analyzeCmd.Flags().StringVar(&analyzeBaselineEnd, "baseline-end", "", "End date of the period to compare with (YYYY-MM-DD)")
+	analyzeCmd.MarkFlagsMutuallyExclusive("refresh", "from-cache")
+	analyzeCmd.MarkFlagsMutuallyExclusive("stream", "spool")
+	analyzeCmd.MarkFlagsMutuallyExclusive("compare-to-previous", "baseline-start")
+	analyzeCmd.MarkFlagsRequiredTogether("baseline-start", "baseline-end")
 }
 
 type AnalysisData struct {
-	StartDate    string         `json:"start_date"`
-	EndDate      string         `json:"end_date"`
-	Repository   string         `json:"repository,omitempty"`
-	GeneratedAt  string         `json:"generated_at"`
-	TotalChanges int            `json:"total_changes"`
-	Changes      []gerrit.Change `json:"changes"`
+	StartDate    string
+	EndDate      string
+	Repository   string // set when the report covers a single repository
+	Filter       analyzeFilter
+	GeneratedAt  string
+	TotalChanges int
+	WithSize     bool
+	TopDirs      int
+	Columns      []string // CSV columns; nil for the defaults
+	ExcludedBots int
+	Comparison   *periodComparison
+	Identities   *identityResolver
+	Stats        *changeStats
+	Changes      *changeStore
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
+	if err := rejectStructuredOutput(cmd); err != nil {
+		return err
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
@@ -86,106 +167,417 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	if _, err := time.Parse("2006-01-02", analyzeStartDate); err != nil {
+	start, err := time.Parse("2006-01-02", analyzeStartDate)
+	if err != nil {
 		return fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
 	}
-	if _, err := time.Parse("2006-01-02", analyzeEndDate); err != nil {
+	end, err := time.Parse("2006-01-02", analyzeEndDate)
+	if err != nil {
 		return fmt.Errorf("invalid end date format (use YYYY-MM-DD): %w", err)
 	}
 
+	// The baseline period, if any, is compared with [start, end]. The store
+	// is synced from whichever period starts first.
+	var baselineStart, baselineEnd time.Time
+	comparing := analyzeComparePrevious || analyzeBaselineStart != ""
+	if analyzeComparePrevious {
+		baselineStart, baselineEnd = previousPeriod(start, end)
+	} else if comparing {
+		if baselineStart, err = time.Parse("2006-01-02", analyzeBaselineStart); err != nil {
+			return fmt.Errorf("invalid baseline start date format (use YYYY-MM-DD): %w", err)
+		}
+		if baselineEnd, err = time.Parse("2006-01-02", analyzeBaselineEnd); err != nil {
+			return fmt.Errorf("invalid baseline end date format (use YYYY-MM-DD): %w", err)
+		}
+	}
+	from := analyzeStartDate
+	if comparing && baselineStart.Before(start) {
+		from = baselineStart.Format("2006-01-02")
+	}
+
+	format := strings.ToLower(analyzeFormat)
+	var fetchOptions []string
+	switch format {
+	case "markdown", "md", "csv", "html":
+		// Review latency is worked out from the change messages.
+		fetchOptions = []string{"DETAILED_ACCOUNTS", "MESSAGES"}
+	case "json":
+		// The JSON report lists changes as Gerrit returns them.
+		fetchOptions = []string{"DETAILED_ACCOUNTS", "DETAILED_LABELS", "MESSAGES"}
+	default:
+		return fmt.Errorf("unknown format: %s (supported: markdown, json, csv, html)", analyzeFormat)
+	}
+	if analyzeWithSize {
+		if analyzeTopDirs < 1 {
+			return fmt.Errorf("--top-dirs must be at least 1")
+		}
+		fetchOptions = append(fetchOptions, "CURRENT_REVISION", "CURRENT_FILES")
+	}
+	var columns []string
+	if analyzeColumns != "" {
+		if format != "csv" {
+			return fmt.Errorf("--columns only applies to --format csv")
+		}
+		if columns, err = parseCSVColumns(analyzeColumns); err != nil {
+			return err
+		}
+		fetchOptions = mergeOptions(fetchOptions, csvColumnOptions(columns))
+	}
+	if analyzeStream && format != "json" {
+		return fmt.Errorf("--stream only applies to --format json")
+	}
+	// A JSON or CSV report on stdout is meant to be piped; keep log
+	// messages out of it.
+	if analyzeOutput == "" && (format == "json" || format == "csv") {
+		utils.SetLogOutput(os.Stderr)
+	}
+
 	utils.Infof("Analyzing changes from %s to %s", analyzeStartDate, analyzeEndDate)
-	if analyzeRepo != "" {
-		utils.Infof("Repository filter: %s", analyzeRepo)
+	if comparing {
+		utils.Infof("Comparing with %s to %s", baselineStart.Format("2006-01-02"), baselineEnd.Format("2006-01-02"))
+	}
+	filter := analyzeFilter{Repos: analyzeRepos, Owner: analyzeOwner, Branch: analyzeBranch, Query: analyzeQuery}
+	if len(filter.Repos) > 0 {
+		utils.Infof("Repository filter: %s", strings.Join(filter.Repos, ", "))
 	} else {
 		utils.Info("Analyzing all repositories")
 	}
+	if filter.Owner != "" {
+		utils.Infof("Owner filter: %s", filter.Owner)
+	}
+	if filter.Branch != "" {
+		utils.Infof("Branch filter: %s", filter.Branch)
+	}
+	if filter.Query != "" {
+		utils.Infof("Query filter: %s", filter.Query)
+	}
+
+	// Machine-readable reports are usually piped; keep the terminal quiet.
+	if format == "json" {
+		utils.DisableProgress()
+	}
+
+	identities := newIdentityResolver(cfg)
+	stats := newChangeStats()
+	stats.identities = identities
+	if analyzeWithSize {
+		stats.churn = newChurnStats()
+	}
+	// --stream writes each change as it is collected, so its output is
+	// opened up front; other reports are written once collection is done.
+	var out *reportOutput
+	var stream *ndjsonReport
+	if analyzeStream {
+		if out, err = openAnalyzeOutput(); err != nil {
+			return err
+		}
+		defer out.file.Close()
+		stream = newNDJSONReport(out.w)
+	}
+	var store *changeStore
+	if (format == "json" && !analyzeStream) || format == "csv" {
+		store, err = newChangeStore(analyzeSpool)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+	}
 
-	timeout := time.Duration(analyzeTimeout) * time.Second
-	utils.Debugf("Using timeout: %v", timeout)
-	client := gerrit.NewRESTClientWithTimeout(cfg, timeout)
-	changes, err := fetchAllChangesWithPagination(client)
+	storeDir, err := analyzeStoreDir()
+	if err != nil {
+		return err
+	}
+	local, err := openAnalyzeStore(storeDir, cfg.Server, filter.storeScope())
 	if err != nil {
+		return err
+	}
+
+	baseline := newChangeStats()
+	baselineTotal := 0
+
+	total, bots := 0, 0
+	collect := func(change gerrit.Change) error {
+		inRange := inAnalyzeRange(change, start, end)
+		inBaseline := comparing && inAnalyzeRange(change, baselineStart, baselineEnd)
+		if !inRange && !inBaseline {
+			return nil
+		}
+		if identities.isBot(change.Owner) {
+			if inRange {
+				bots++
+			}
+			return nil
+		}
+		change.Owner = identities.resolve(change.Owner)
+		if inBaseline {
+			baselineTotal++
+			baseline.Add(change)
+		}
+		if !inRange {
+			return nil
+		}
+		total++
+		stats.Add(change)
+		if store != nil {
+			return store.Add([]gerrit.Change{change})
+		}
+		if stream != nil {
+			return stream.Add([]gerrit.Change{change})
+		}
+		return nil
+	}
+
+	// Ctrl+C aborts the fetch and reports on the changes fetched so far.
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+
+	if analyzeFromCache {
+		if !local.covers(from, fetchOptions) {
+			return fmt.Errorf("the local store does not cover this report; run without --from-cache to fetch it")
+		}
+		utils.Infof("Using the local store (last synced %s)", utils.FormatTimeAgo(local.synced()))
+		err = local.Each(collect)
+		if err != nil {
+			return err
+		}
+	} else {
+		timeout := time.Duration(analyzeTimeout) * time.Second
+		utils.Debugf("Using timeout: %v", timeout)
+		client := gerrit.NewRESTClientWithTimeout(cfg, timeout).WithContext(ctx)
+		err = syncAnalyzeStore(ctx, client, local, cfg.Server, filter, from, fetchOptions, collect)
+	}
+	stop()
+	interrupted := err != nil && ctx.Err() != nil
+	if err != nil && !(interrupted && total > 0) {
 		return fmt.Errorf("failed to fetch changes: %w", err)
 	}
+	if interrupted {
+		utils.Warnf("Interrupted; the report covers only the first %d changes", total)
+	}
 
-	if len(changes) == 0 {
+	if bots > 0 {
+		utils.Infof("Left out %d change(s) owned by bots (bot_patterns)", bots)
+	}
+	if total == 0 {
 		utils.Info("No changes found in the specified date range")
 		return nil
 	}
 
-	fmt.Printf("%s Fetched %d total changes\n", color.GreenString("✓"), len(changes))
+	found := io.Writer(os.Stdout)
+	if analyzeOutput == "" {
+		// The report itself goes to stdout; keep the count out of it.
+		found = os.Stderr
+	}
+	fmt.Fprintf(found, "%s Found %d changes in range\n", color.GreenString("✓"), total)
 
 	analysisData := AnalysisData{
 		StartDate:    analyzeStartDate,
 		EndDate:      analyzeEndDate,
-		Repository:   analyzeRepo,
+		Repository:   filter.repository(),
+		Filter:       filter,
 		GeneratedAt:  time.Now().Format(time.RFC3339),
-		TotalChanges: len(changes),
-		Changes:      changes,
+		TotalChanges: total,
+		WithSize:     analyzeWithSize,
+		TopDirs:      analyzeTopDirs,
+		Columns:      columns,
+		ExcludedBots: bots,
+		Identities:   identities,
+		Stats:        stats,
+		Changes:      store,
 	}
 
-	var output string
-	switch strings.ToLower(analyzeFormat) {
-	case "markdown", "md":
-		output = generateMarkdownReport(analysisData)
-	case "json":
-		output = generateJSONReport(analysisData)
-	case "csv":
-		output = generateCSVReport(analysisData)
-	default:
-		return fmt.Errorf("unknown format: %s (supported: markdown, json, csv)", analyzeFormat)
+	if comparing {
+		analysisData.Comparison = comparePeriods(stats, baseline, total, baselineTotal,
+			baselineStart.Format("2006-01-02"), baselineEnd.Format("2006-01-02"))
+		if format == "csv" {
+			utils.Warnf("The CSV report lists changes only; use markdown, json, or html to see the comparison")
+		}
 	}
 
-	if analyzeOutput != "" {
-		if err := utils.WriteFile(analyzeOutput, []byte(output)); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+	if out == nil {
+		if out, err = openAnalyzeOutput(); err != nil {
+			return err
 		}
+		defer out.file.Close()
+	}
+	w := out.w
+
+	switch {
+	case stream != nil:
+		err = stream.WriteSummary(analysisData)
+	case format == "markdown" || format == "md":
+		err = writeMarkdownReport(w, analysisData)
+	case format == "html":
+		err = writeHTMLReport(w, analysisData)
+	case format == "json":
+		err = writeJSONReport(w, analysisData)
+	case format == "csv":
+		err = writeCSVReport(w, analysisData)
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if out.file != nil {
 		fmt.Printf("%s Report saved to: %s\n", color.GreenString("✓"), analyzeOutput)
-	} else {
-		fmt.Print(output)
 	}
 	return nil
 }
 
-func fetchAllChangesWithPagination(client *gerrit.RESTClient) ([]gerrit.Change, error) {
-	var queryParts []string
-	queryParts = append(queryParts, "status:merged")
-	queryParts = append(queryParts, fmt.Sprintf("after:%s", analyzeStartDate))
-	queryParts = append(queryParts, fmt.Sprintf("before:%s", analyzeEndDate))
+// reportOutput is where the report is written: the --output-file path, or
+// stdout when file is nil.
+type reportOutput struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+func openAnalyzeOutput() (*reportOutput, error) {
+	if analyzeOutput == "" {
+		return &reportOutput{w: bufio.NewWriter(os.Stdout)}, nil
+	}
+	file, err := os.Create(analyzeOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write output file: %w", err)
+	}
+	return &reportOutput{file: file, w: bufio.NewWriter(file)}, nil
+}
 
-	if analyzeRepo != "" {
-		queryParts = append(queryParts, fmt.Sprintf("project:%s", analyzeRepo))
+// Close flushes the report and closes the output file, if any.
+func (o *reportOutput) Close() error {
+	if err := o.w.Flush(); err != nil {
+		return err
+	}
+	if o.file != nil {
+		return o.file.Close()
+	}
+	return nil
+}
+
+// syncAnalyzeStore brings the local store up to date and hands every stored
+// change to each. The store holds the changes matching filter. When it
+// covers from (YYYY-MM-DD) with the needed options, only changes updated
+// since its last sync are fetched; otherwise every change merged since from
+// is. If the fetch is interrupted, each still sees what was fetched and the
+// fetch error is returned.
+func syncAnalyzeStore(ctx context.Context, client *gerrit.RESTClient, local *analyzeStore, server string, filter analyzeFilter, from string, options []string, each func(gerrit.Change) error) error {
+	w, err := local.create()
+	if err != nil {
+		return err
+	}
+	defer w.Abort()
+
+	now := time.Now().UTC()
+	queryParts := append([]string{"status:merged"}, filter.queryTerms()...)
+	meta := analyzeStoreMeta{Server: server, Repo: filter.repository(), From: from, Synced: now.Format(time.RFC3339)}
+	if filter.storeScope() != filter.repository() {
+		meta.Filter = strings.Join(queryParts[1:], " ")
+	}
+
+	incremental := !analyzeRefresh && local.covers(from, options)
+	if incremental {
+		meta.From = local.meta.From
+		meta.Options = local.meta.Options
+		since := local.synced().Add(-analyzeStoreOverlap)
+		utils.Infof("Fetching changes updated since the last run (%s)", utils.FormatTimeAgo(local.synced()))
+		queryParts = append(queryParts, fmt.Sprintf("after:%q", since.Format("2006-01-02 15:04:05 -0700")))
+	} else {
+		if local.meta != nil {
+			options = mergeOptions(options, local.meta.Options)
+		}
+		meta.Options = options
+		queryParts = append(queryParts, fmt.Sprintf("after:%s", from))
 	}
 
-	query := strings.Join(queryParts, " ")
+	updated := make(map[int]bool)
+	fetched, fetchErr := paginateChanges(client, strings.Join(queryParts, " "), options, analyzePageSize, analyzeMaxLimit, func(page []gerrit.Change) error {
+		for _, change := range page {
+			updated[change.Number] = true
+		}
+		return w.Add(page)
+	})
+	if fetchErr != nil && ctx.Err() == nil {
+		return fetchErr
+	}
+	complete := fetchErr == nil && fetched < analyzeMaxLimit
+	if fetchErr == nil && !complete {
+		utils.Warnf("Stopped at --max-changes (%d); the local store was not brought up to date", analyzeMaxLimit)
+	}
+
+	switch {
+	case incremental:
+		// Everything fetched is current, so keep it even if the fetch
+		// stopped early; only a complete fetch moves the sync time on.
+		err := local.Each(func(change gerrit.Change) error {
+			if updated[change.Number] {
+				return nil
+			}
+			return w.Add([]gerrit.Change{change})
+		})
+		if err != nil {
+			return err
+		}
+		if !complete {
+			meta.Synced = local.meta.Synced
+		}
+		if err := w.Commit(meta); err != nil {
+			return err
+		}
+		utils.Debugf("Fetched %d updated changes; the local store holds %d", fetched, w.count)
+		if err := local.Each(each); err != nil {
+			return err
+		}
+	case complete:
+		if err := w.Commit(meta); err != nil {
+			return err
+		}
+		if err := local.Each(each); err != nil {
+			return err
+		}
+	default:
+		// A partial full fetch can't stand in for the store; report on it
+		// and leave the store as it was.
+		if err := w.Each(each); err != nil {
+			return err
+		}
+	}
+	return fetchErr
+}
+
+// paginateChanges runs query page by page, up to maxChanges, handing each
+// page to onPage. It returns the number of changes fetched, which on error
+// counts the pages handed over before it.
+func paginateChanges(client *gerrit.RESTClient, query string, options []string, pageSize, maxChanges int, onPage func([]gerrit.Change) error) (int, error) {
 	utils.Debugf("Query: %s", query)
 
-	var allChanges []gerrit.Change
+	var optionParams string
+	for _, opt := range options {
+		optionParams += "&o=" + opt
+	}
+
+	total := 0
 	start := 0
 
-	for start < analyzeMaxLimit {
+	progress := utils.NewProgressBar("Fetching changes", 0)
+
+	for start < maxChanges {
 		encodedQuery := url.QueryEscape(query)
-		path := fmt.Sprintf("changes/?q=%s&n=%d&start=%d&o=DETAILED_ACCOUNTS&o=DETAILED_LABELS&o=MESSAGES",
-			encodedQuery, analyzePageSize, start)
+		path := fmt.Sprintf("changes/?q=%s&n=%d&start=%d%s", encodedQuery, pageSize, start, optionParams)
 
-		utils.Debugf("Fetching page at offset %d (total so far: %d)", start, len(allChanges))
-
-		if start > 0 {
-			fmt.Printf("\rFetching changes... %d so far", len(allChanges))
-		} else {
-			fmt.Printf("Fetching changes...")
-		}
+		utils.Debugf("Fetching page at offset %d (total so far: %d)", start, total)
 
 		resp, err := client.Get(path)
 		if err != nil {
-			fmt.Println()
-			return nil, err
+			progress.Stop()
+			return total, err
 		}
 
 		var pageChanges []gerrit.Change
 		if err := json.Unmarshal(resp, &pageChanges); err != nil {
-			fmt.Println()
-			return nil, fmt.Errorf("failed to parse changes: %w", err)
+			progress.Stop()
+			return total, fmt.Errorf("failed to parse changes: %w", err)
 		}
 
 		if len(pageChanges) == 0 {
@@ -194,10 +586,15 @@ func fetchAllChangesWithPagination(client *gerrit.RESTClient) ([]gerrit.Change,
 		}
 
 		utils.Debugf("Fetched %d changes in this page", len(pageChanges))
-		allChanges = append(allChanges, pageChanges...)
+		if err := onPage(pageChanges); err != nil {
+			progress.Stop()
+			return total, err
+		}
+		total += len(pageChanges)
+		progress.Set(total)
 
-		if len(pageChanges) < analyzePageSize {
-			utils.Debugf("Received partial page (%d < %d), no more results", len(pageChanges), analyzePageSize)
+		if len(pageChanges) < pageSize {
+			utils.Debugf("Received partial page (%d < %d), no more results", len(pageChanges), pageSize)
 			break
 		}
 
@@ -210,29 +607,127 @@ func fetchAllChangesWithPagination(client *gerrit.RESTClient) ([]gerrit.Change,
 		start += len(pageChanges)
 	}
 
-	if len(allChanges) > 0 {
-		fmt.Printf("\rFetching changes... %d total\n", len(allChanges))
+	if total > 0 {
+		progress.Done(fmt.Sprintf("Fetching changes... %d total", total))
+	} else {
+		progress.Stop()
+	}
+
+	return total, nil
+}
+
+// changeStore keeps the raw changes a JSON or CSV report lists, either in
+// memory or spooled to a temporary file as one JSON document per line.
+type changeStore struct {
+	changes []gerrit.Change
+	spool   *os.File
+	enc     *json.Encoder
+}
+
+func newChangeStore(spool bool) (*changeStore, error) {
+	if !spool {
+		return &changeStore{}, nil
 	}
+	file, err := os.CreateTemp("", "gerry-analyze-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	utils.Debugf("Spooling changes to %s", file.Name())
+	return &changeStore{spool: file, enc: json.NewEncoder(file)}, nil
+}
 
-	return allChanges, nil
+// Add stores a page of changes.
+func (s *changeStore) Add(changes []gerrit.Change) error {
+	if s.spool == nil {
+		s.changes = append(s.changes, changes...)
+		return nil
+	}
+	for _, change := range changes {
+		if err := s.enc.Encode(change); err != nil {
+			return fmt.Errorf("failed to spool changes: %w", err)
+		}
+	}
+	return nil
 }
 
-func generateMarkdownReport(data AnalysisData) string {
+// Each calls fn for every stored change, in the order they were added.
+func (s *changeStore) Each(fn func(gerrit.Change) error) error {
+	if s.spool == nil {
+		for _, change := range s.changes {
+			if err := fn(change); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := s.spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read spool file: %w", err)
+	}
+	dec := json.NewDecoder(bufio.NewReader(s.spool))
+	for {
+		var change gerrit.Change
+		if err := dec.Decode(&change); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read spool file: %w", err)
+		}
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+	// Leave the file positioned for further writes.
+	if _, err := s.spool.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("failed to read spool file: %w", err)
+	}
+	return nil
+}
+
+// Close removes the spool file, if any.
+func (s *changeStore) Close() error {
+	if s.spool == nil {
+		return nil
+	}
+	s.spool.Close()
+	return os.Remove(s.spool.Name())
+}
+
+func writeMarkdownReport(w io.Writer, data AnalysisData) error {
 	var sb strings.Builder
 
 	sb.WriteString("# Gerrit Change Analysis\n\n")
 	sb.WriteString(fmt.Sprintf("**Analysis Period:** %s to %s\n", data.StartDate, data.EndDate))
-	if data.Repository != "" {
+	switch {
+	case data.Repository != "":
 		sb.WriteString(fmt.Sprintf("**Repository:** %s\n", data.Repository))
-	} else {
+	case len(data.Filter.Repos) > 1:
+		sb.WriteString(fmt.Sprintf("**Repositories:** %s\n", strings.Join(data.Filter.Repos, ", ")))
+	default:
 		sb.WriteString("**Repository:** All repositories\n")
 	}
+	if data.Filter.Owner != "" {
+		sb.WriteString(fmt.Sprintf("**Owner:** %s\n", data.Filter.Owner))
+	}
+	if data.Filter.Branch != "" {
+		sb.WriteString(fmt.Sprintf("**Branch:** %s\n", data.Filter.Branch))
+	}
+	if data.Filter.Query != "" {
+		sb.WriteString(fmt.Sprintf("**Query:** `%s`\n", data.Filter.Query))
+	}
 	sb.WriteString(fmt.Sprintf("**Generated:** %s\n", time.Now().Format("2006-01-02 15:04:05")))
-	sb.WriteString(fmt.Sprintf("**Total Changes:** %d\n\n", data.TotalChanges))
+	sb.WriteString(fmt.Sprintf("**Total Changes:** %d\n", data.TotalChanges))
+	if data.ExcludedBots > 0 {
+		sb.WriteString(fmt.Sprintf("**Bot Changes Excluded:** %d\n", data.ExcludedBots))
+	}
+	sb.WriteString("\n")
+
+	if data.Comparison != nil {
+		writeComparisonSection(&sb, data)
+	}
 
 	if data.Repository == "" {
 		sb.WriteString("## Changes by Repository\n\n")
-		repoStats := analyzeByRepository(data.Changes)
+		repoStats := data.Stats.ByRepository()
 		sb.WriteString("| Repository | Change Count |\n")
 		sb.WriteString("|------------|-------------|\n")
 		for _, stat := range repoStats {
@@ -242,7 +737,7 @@ func generateMarkdownReport(data AnalysisData) string {
 	}
 
 	sb.WriteString("## Changes by Author\n\n")
-	authorStats := analyzeByAuthor(data.Changes)
+	authorStats := data.Stats.ByAuthor()
 	sb.WriteString("| Author | Change Count | Repositories |\n")
 	sb.WriteString("|--------|--------------|-------------|\n")
 	for _, stat := range authorStats {
@@ -252,7 +747,7 @@ func generateMarkdownReport(data AnalysisData) string {
 
 	sb.WriteString("## Timeline Analysis\n\n")
 	sb.WriteString("Changes merged per month:\n\n")
-	timelineStats := analyzeTimeline(data.Changes)
+	timelineStats := data.Stats.Timeline()
 	sb.WriteString("| Month | Change Count |\n")
 	sb.WriteString("|-------|-------------|\n")
 	for _, stat := range timelineStats {
@@ -260,6 +755,11 @@ func generateMarkdownReport(data AnalysisData) string {
 	}
 	sb.WriteString("\n")
 
+	writeLatencySection(&sb, data)
+	if data.WithSize {
+		writeChurnSection(&sb, data)
+	}
+
 	sb.WriteString("## Top 20 Contributors\n\n")
 	sb.WriteString("| Rank | Author | Changes | Repositories |\n")
 	sb.WriteString("|------|--------|---------|-------------|\n")
@@ -274,148 +774,391 @@ func generateMarkdownReport(data AnalysisData) string {
 	sb.WriteString("---\n")
 	sb.WriteString("*Generated by gerry analyze*\n")
 
-	return sb.String()
+	_, err := io.WriteString(w, sb.String())
+	return err
 }
 
-func generateJSONReport(data AnalysisData) string {
-	output := map[string]interface{}{
-		"metadata": map[string]interface{}{
-			"start_date":    data.StartDate,
-			"end_date":      data.EndDate,
-			"repository":    data.Repository,
-			"generated_at":  data.GeneratedAt,
-			"total_changes": data.TotalChanges,
-		},
-		"changes": data.Changes,
-		"analysis": map[string]interface{}{
-			"by_author":     analyzeByAuthor(data.Changes),
-			"by_repository": analyzeByRepository(data.Changes),
-			"timeline":      analyzeTimeline(data.Changes),
-		},
+// writeComparisonSection adds the change in counts against the baseline
+// period to the markdown report.
+func writeComparisonSection(sb *strings.Builder, data AnalysisData) {
+	c := data.Comparison
+	sb.WriteString(fmt.Sprintf("## Compared with %s to %s\n\n", c.BaselineStart, c.BaselineEnd))
+	sb.WriteString(fmt.Sprintf("**Total Changes:** %d vs. %d in the baseline, %s\n\n", c.Total.Current, c.Total.Baseline, formatCountDelta(c.Total)))
+
+	table := func(title string, deltas []CountDelta) {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+		sb.WriteString("| Name | Changes | Baseline | Change |\n")
+		sb.WriteString("|------|---------|----------|--------|\n")
+		for _, d := range deltas {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", d.Name, d.Current, d.Baseline, formatCountDelta(d)))
+		}
+		sb.WriteString("\n")
+	}
+	if data.Repository == "" {
+		table("By Repository", c.ByRepository)
 	}
+	table("By Author", c.ByAuthor)
+}
 
-	jsonBytes, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return fmt.Sprintf("Error generating JSON: %v", err)
+// writeLatencySection adds the review latency tables to the markdown report.
+func writeLatencySection(sb *strings.Builder, data AnalysisData) {
+	sb.WriteString("## Review Latency\n\n")
+	sb.WriteString("Time from the first patchset to the first review, the first Code-Review+2, and merge, as median / 90th percentile. Bots and the owner's own messages don't count as a review.\n\n")
+
+	header := "| %s | Changes | First Review | Code-Review+2 | Merge |\n"
+	row := func(stat LatencyStatistic) {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s |\n", stat.Name, stat.Changes,
+			formatLatency(stat.FirstReview), formatLatency(stat.Approval), formatLatency(stat.Merge)))
+	}
+
+	sb.WriteString(fmt.Sprintf(header, "Scope"))
+	sb.WriteString("|-------|---------|--------------|---------------|-------|\n")
+	row(data.Stats.Latency())
+	sb.WriteString("\n")
+
+	if data.Repository == "" {
+		sb.WriteString("### By Repository\n\n")
+		sb.WriteString(fmt.Sprintf(header, "Repository"))
+		sb.WriteString("|------------|---------|--------------|---------------|-------|\n")
+		for _, stat := range data.Stats.LatencyByRepository() {
+			row(stat)
+		}
+		sb.WriteString("\n")
 	}
 
-	return string(jsonBytes)
+	sb.WriteString("### By Author\n\n")
+	sb.WriteString(fmt.Sprintf(header, "Author"))
+	sb.WriteString("|--------|---------|--------------|---------------|-------|\n")
+	for _, stat := range data.Stats.LatencyByAuthor() {
+		row(stat)
+	}
+	sb.WriteString("\n")
 }
 
-func generateCSVReport(data AnalysisData) string {
-	var sb strings.Builder
-	w := csv.NewWriter(&sb)
-
-	w.Write([]string{"change_number", "project", "subject", "owner_name", "owner_email", "status", "created", "updated", "submitted"})
-
-	for _, change := range data.Changes {
-		w.Write([]string{
-			change.ChangeNumberStr(),
-			change.Project,
-			change.Subject,
-			change.Owner.DisplayName(),
-			change.Owner.Email,
-			change.Status,
-			change.Created,
-			change.Updated,
-			change.Submitted,
-		})
+// writeChurnSection adds the lines-changed tables to the markdown report.
+func writeChurnSection(sb *strings.Builder, data AnalysisData) {
+	sb.WriteString("## Code Churn\n\n")
+	total := data.Stats.Churn()
+	sb.WriteString(fmt.Sprintf("**Lines Changed:** +%d / -%d across %d changes (current patchsets, excluding the commit message)\n\n",
+		total.Insertions, total.Deletions, total.Changes))
+
+	row := func(name string, stat ChurnStatistic) {
+		sb.WriteString(fmt.Sprintf("| %s | %d | +%d | -%d |\n", name, stat.Changes, stat.Insertions, stat.Deletions))
 	}
 
-	w.Flush()
-	return sb.String()
+	if data.Repository == "" {
+		sb.WriteString("### By Repository\n\n")
+		sb.WriteString("| Repository | Changes | Insertions | Deletions |\n")
+		sb.WriteString("|------------|---------|------------|-----------|\n")
+		for _, stat := range data.Stats.ChurnByRepository() {
+			row(stat.Name, stat)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### By Author\n\n")
+	sb.WriteString("| Author | Changes | Insertions | Deletions |\n")
+	sb.WriteString("|--------|---------|------------|-----------|\n")
+	for _, stat := range data.Stats.ChurnByAuthor() {
+		row(stat.Name, stat)
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("### Top %d Directories\n\n", data.TopDirs))
+	sb.WriteString("| Directory | Changes | Insertions | Deletions |\n")
+	sb.WriteString("|-----------|---------|------------|-----------|\n")
+	for _, stat := range data.Stats.ChurnByDirectory(data.TopDirs) {
+		name := stat.Name
+		if data.Repository == "" {
+			name = stat.Project + ": " + stat.Name
+		}
+		row(name, stat)
+	}
+	sb.WriteString("\n")
 }
 
-type Statistic struct {
-	Name      string `json:"name"`
-	Count     int    `json:"count"`
-	RepoCount int    `json:"repo_count,omitempty"`
+// jsonReportSummary returns the "analysis" and "metadata" objects of the
+// JSON report.
+func jsonReportSummary(data AnalysisData) (map[string]interface{}, map[string]interface{}) {
+	analysis := map[string]interface{}{
+		"by_author":     data.Stats.ByAuthor(),
+		"by_repository": data.Stats.ByRepository(),
+		"timeline":      data.Stats.Timeline(),
+		"latency": map[string]interface{}{
+			"overall":       data.Stats.Latency(),
+			"by_repository": data.Stats.LatencyByRepository(),
+			"by_author":     data.Stats.LatencyByAuthor(),
+		},
+	}
+	if data.WithSize {
+		analysis["churn"] = map[string]interface{}{
+			"total":         data.Stats.Churn(),
+			"by_repository": data.Stats.ChurnByRepository(),
+			"by_author":     data.Stats.ChurnByAuthor(),
+			"by_directory":  data.Stats.ChurnByDirectory(data.TopDirs),
+		}
+	}
+	metadata := map[string]interface{}{
+		"start_date":    data.StartDate,
+		"end_date":      data.EndDate,
+		"repository":    data.Repository,
+		"generated_at":  data.GeneratedAt,
+		"total_changes": data.TotalChanges,
+	}
+	if len(data.Filter.Repos) > 1 {
+		metadata["repositories"] = data.Filter.Repos
+	}
+	if data.Filter.Owner != "" {
+		metadata["owner"] = data.Filter.Owner
+	}
+	if data.Filter.Branch != "" {
+		metadata["branch"] = data.Filter.Branch
+	}
+	if data.Filter.Query != "" {
+		metadata["query"] = data.Filter.Query
+	}
+	if data.ExcludedBots > 0 {
+		metadata["excluded_bot_changes"] = data.ExcludedBots
+	}
+	if data.Comparison != nil {
+		analysis["comparison"] = data.Comparison
+	}
+	return analysis, metadata
 }
 
-func analyzeByRepository(changes []gerrit.Change) []Statistic {
-	repoCounts := make(map[string]int)
+// writeJSONReport writes the analysis, the changes, and the metadata as one
+// indented JSON object, streaming the changes one at a time.
+func writeJSONReport(w io.Writer, data AnalysisData) error {
+	analysis, metadata := jsonReportSummary(data)
 
-	for _, change := range changes {
-		if change.Project != "" {
-			repoCounts[change.Project]++
+	writeField := func(name string, v interface{}) error {
+		b, err := json.MarshalIndent(v, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
 		}
+		_, err = fmt.Fprintf(w, "  %q: %s", name, b)
+		return err
 	}
 
-	var stats []Statistic
-	for repo, count := range repoCounts {
-		stats = append(stats, Statistic{Name: repo, Count: count})
+	if _, err := io.WriteString(w, "{\n"); err != nil {
+		return err
+	}
+	if err := writeField("analysis", analysis); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, ",\n  \"changes\": ["); err != nil {
+		return err
+	}
+	n := 0
+	err := data.Changes.Each(func(change gerrit.Change) error {
+		b, err := json.MarshalIndent(change, "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		sep := ",\n    "
+		if n == 0 {
+			sep = "\n    "
+		}
+		n++
+		_, err = fmt.Fprintf(w, "%s%s", sep, b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		if _, err := io.WriteString(w, "\n  "); err != nil {
+			return err
+		}
 	}
+	if _, err := io.WriteString(w, "],\n"); err != nil {
+		return err
+	}
+	if err := writeField("metadata", metadata); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n}\n")
+	return err
+}
 
-	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].Count > stats[j].Count
+func writeCSVReport(w io.Writer, data AnalysisData) error {
+	cw := csv.NewWriter(w)
+
+	columns := data.Columns
+	if columns == nil {
+		columns = defaultCSVColumns(data.WithSize)
+	}
+	cw.Write(columns)
+
+	// Line counts come from the file list when it was fetched, so they
+	// match the churn statistics; otherwise from Gerrit's own totals.
+	withFiles := data.WithSize
+	for _, name := range columns {
+		withFiles = withFiles || name == "files"
+	}
+
+	record := make([]string, len(columns))
+	err := data.Changes.Each(func(change gerrit.Change) error {
+		row := csvRow{change: change, latency: measureReviewLatency(change, data.Identities)}
+		if withFiles {
+			churn := measureChurn(change)
+			row.churn = &churn
+		}
+		for i, name := range columns {
+			record[i] = csvValue(name, row)
+		}
+		return cw.Write(record)
 	})
+	if err != nil {
+		return err
+	}
 
-	return stats
+	cw.Flush()
+	return cw.Error()
 }
 
-func analyzeByAuthor(changes []gerrit.Change) []Statistic {
-	authorCounts := make(map[string]int)
-	authorRepos := make(map[string]map[string]bool)
+type Statistic struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	RepoCount int    `json:"repo_count,omitempty"`
+}
 
-	for _, change := range changes {
-		author := change.Owner.DisplayName()
-		if author == "unknown" {
-			continue
-		}
+// changeStats aggregates the report statistics one change at a time, so the
+// changes themselves can be discarded after counting.
+type changeStats struct {
+	repoCounts   map[string]int
+	authorCounts map[string]int
+	authorRepos  map[string]map[string]bool
+	monthCounts  map[string]int
+	latency      *latencyStats
+	identities   *identityResolver
+	churn        *churnStats // nil unless --with-size
+}
+
+func newChangeStats() *changeStats {
+	return &changeStats{
+		repoCounts:   make(map[string]int),
+		authorCounts: make(map[string]int),
+		authorRepos:  make(map[string]map[string]bool),
+		monthCounts:  make(map[string]int),
+		latency:      newLatencyStats(),
+	}
+}
 
-		authorCounts[author]++
+// Add counts one change.
+func (s *changeStats) Add(change gerrit.Change) {
+	if change.Project != "" {
+		s.repoCounts[change.Project]++
+	}
 
+	if author := change.Owner.DisplayName(); author != "unknown" {
+		s.authorCounts[author]++
 		if change.Project != "" {
-			if authorRepos[author] == nil {
-				authorRepos[author] = make(map[string]bool)
+			if s.authorRepos[author] == nil {
+				s.authorRepos[author] = make(map[string]bool)
 			}
-			authorRepos[author][change.Project] = true
+			s.authorRepos[author][change.Project] = true
 		}
 	}
 
-	var stats []Statistic
-	for author, count := range authorCounts {
-		stats = append(stats, Statistic{Name: author, Count: count, RepoCount: len(authorRepos[author])})
+	ts := change.Submitted
+	if ts == "" {
+		ts = change.Updated
+	}
+	if month := changeMonth(ts); month != "" {
+		s.monthCounts[month]++
 	}
 
-	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].Count > stats[j].Count
-	})
+	s.latency.add(change, s.identities)
+	if s.churn != nil {
+		s.churn.add(change)
+	}
+}
 
+// ByRepository returns change counts per project, largest first.
+func (s *changeStats) ByRepository() []Statistic {
+	var stats []Statistic
+	for repo, count := range s.repoCounts {
+		stats = append(stats, Statistic{Name: repo, Count: count})
+	}
+	sortStatisticsByCount(stats)
 	return stats
 }
 
-func analyzeTimeline(changes []gerrit.Change) []Statistic {
-	monthCounts := make(map[string]int)
-
-	for _, change := range changes {
-		ts := change.Submitted
-		if ts == "" {
-			ts = change.Updated
-		}
-
-		if ts != "" {
-			parts := strings.Split(ts, "T")
-			if len(parts) == 0 {
-				parts = strings.Split(ts, " ")
-			}
-			if len(parts) > 0 {
-				dateParts := strings.Split(parts[0], "-")
-				if len(dateParts) >= 2 {
-					monthCounts[dateParts[0]+"-"+dateParts[1]]++
-				}
-			}
-		}
+// ByAuthor returns change and project counts per owner, largest first.
+func (s *changeStats) ByAuthor() []Statistic {
+	var stats []Statistic
+	for author, count := range s.authorCounts {
+		stats = append(stats, Statistic{Name: author, Count: count, RepoCount: len(s.authorRepos[author])})
 	}
+	sortStatisticsByCount(stats)
+	return stats
+}
 
+// Timeline returns change counts per month, oldest first.
+func (s *changeStats) Timeline() []Statistic {
 	var stats []Statistic
-	for month, count := range monthCounts {
+	for month, count := range s.monthCounts {
 		stats = append(stats, Statistic{Name: month, Count: count})
 	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
 
+// Latency returns the review latency of every change counted.
+func (s *changeStats) Latency() LatencyStatistic {
+	return s.latency.overall.statistic("All changes")
+}
+
+// LatencyByRepository returns review latency per project, most changes first.
+func (s *changeStats) LatencyByRepository() []LatencyStatistic {
+	return latencyByGroup(s.latency.byRepo)
+}
+
+// LatencyByAuthor returns review latency per owner, most changes first.
+func (s *changeStats) LatencyByAuthor() []LatencyStatistic {
+	return latencyByGroup(s.latency.byAuthor)
+}
+
+// Churn returns the lines changed by every change counted.
+func (s *changeStats) Churn() ChurnStatistic {
+	return s.churn.total
+}
+
+// ChurnByRepository returns lines changed per project, most first.
+func (s *changeStats) ChurnByRepository() []ChurnStatistic {
+	return churnList(s.churn.byRepo, 0)
+}
+
+// ChurnByAuthor returns lines changed per owner, most first.
+func (s *changeStats) ChurnByAuthor() []ChurnStatistic {
+	return churnList(s.churn.byAuthor, 0)
+}
+
+// ChurnByDirectory returns the limit directories with the most lines
+// changed.
+func (s *changeStats) ChurnByDirectory(limit int) []ChurnStatistic {
+	return churnList(s.churn.byDir, limit)
+}
+
+func sortStatisticsByCount(stats []Statistic) {
 	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
 		return stats[i].Name < stats[j].Name
 	})
+}
 
-	return stats
+// changeMonth returns the YYYY-MM part of a Gerrit timestamp, or "" if it
+// doesn't look like one.
+func changeMonth(ts string) string {
+	date := strings.SplitN(strings.SplitN(ts, "T", 2)[0], " ", 2)[0]
+	parts := strings.Split(date, "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "-" + parts[1]
 }
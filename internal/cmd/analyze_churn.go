@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"path"
+	"sort"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+// ChurnStatistic is the lines changed by a group of changes. Directory
+// statistics also name their project.
+type ChurnStatistic struct {
+	Name       string `json:"name"`
+	Project    string `json:"project,omitempty"`
+	Changes    int    `json:"changes"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+}
+
+// changeChurn is the size of one change's current patchset.
+type changeChurn struct {
+	Files      int
+	Insertions int
+	Deletions  int
+	// byDir holds the lines changed per directory.
+	byDir map[string]*ChurnStatistic
+}
+
+// measureChurn totals the reviewable files of a change's current patchset,
+// as gerry diffstat does. It needs the CURRENT_REVISION and CURRENT_FILES
+// options.
+func measureChurn(change gerrit.Change) changeChurn {
+	churn := changeChurn{byDir: make(map[string]*ChurnStatistic)}
+	files := change.Revisions[change.CurrentRevision].Files
+	for _, p := range reviewablePaths(files) {
+		fi := files[p]
+		churn.Files++
+		churn.Insertions += fi.LinesInserted
+		churn.Deletions += fi.LinesDeleted
+
+		dir := path.Dir(p)
+		if dir == "." {
+			dir = "/"
+		}
+		if churn.byDir[dir] == nil {
+			churn.byDir[dir] = &ChurnStatistic{Name: dir}
+		}
+		churn.byDir[dir].Insertions += fi.LinesInserted
+		churn.byDir[dir].Deletions += fi.LinesDeleted
+	}
+	return churn
+}
+
+// churnStats groups lines changed overall, per project, per owner, and per
+// directory. Directories are keyed by project and path.
+type churnStats struct {
+	total    ChurnStatistic
+	byRepo   map[string]*ChurnStatistic
+	byAuthor map[string]*ChurnStatistic
+	byDir    map[string]*ChurnStatistic
+}
+
+func newChurnStats() *churnStats {
+	return &churnStats{
+		total:    ChurnStatistic{Name: "All changes"},
+		byRepo:   make(map[string]*ChurnStatistic),
+		byAuthor: make(map[string]*ChurnStatistic),
+		byDir:    make(map[string]*ChurnStatistic),
+	}
+}
+
+func (s *churnStats) add(change gerrit.Change) {
+	churn := measureChurn(change)
+	addChurn(&s.total, churn.Insertions, churn.Deletions)
+	if change.Project != "" {
+		if s.byRepo[change.Project] == nil {
+			s.byRepo[change.Project] = &ChurnStatistic{Name: change.Project}
+		}
+		addChurn(s.byRepo[change.Project], churn.Insertions, churn.Deletions)
+	}
+	if author := change.Owner.DisplayName(); author != "unknown" {
+		if s.byAuthor[author] == nil {
+			s.byAuthor[author] = &ChurnStatistic{Name: author}
+		}
+		addChurn(s.byAuthor[author], churn.Insertions, churn.Deletions)
+	}
+	for dir, lines := range churn.byDir {
+		key := change.Project + "\x00" + dir
+		if s.byDir[key] == nil {
+			s.byDir[key] = &ChurnStatistic{Name: dir, Project: change.Project}
+		}
+		addChurn(s.byDir[key], lines.Insertions, lines.Deletions)
+	}
+}
+
+func addChurn(stat *ChurnStatistic, insertions, deletions int) {
+	stat.Changes++
+	stat.Insertions += insertions
+	stat.Deletions += deletions
+}
+
+// sortChurn orders statistics by lines changed, most first.
+func sortChurn(stats []ChurnStatistic) {
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Insertions+a.Deletions != b.Insertions+b.Deletions {
+			return a.Insertions+a.Deletions > b.Insertions+b.Deletions
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Name < b.Name
+	})
+}
+
+// churnList returns the groups by lines changed, at most limit of them if
+// limit is positive.
+func churnList(groups map[string]*ChurnStatistic, limit int) []ChurnStatistic {
+	var stats []ChurnStatistic
+	for _, stat := range groups {
+		stats = append(stats, *stat)
+	}
+	sortChurn(stats)
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats
+}
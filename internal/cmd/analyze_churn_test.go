@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func churnTestChange(number int, project, owner string, files map[string]gerrit.FileInfo) gerrit.Change {
+	return gerrit.Change{
+		Number:          number,
+		Project:         project,
+		Owner:           gerrit.Account{Name: owner},
+		CurrentRevision: "abc",
+		Revisions:       map[string]gerrit.RevisionInfo{"abc": {Files: files}},
+	}
+}
+
+func TestMeasureChurn(t *testing.T) {
+	change := churnTestChange(1, "canvas-lms", "Alice", map[string]gerrit.FileInfo{
+		"/COMMIT_MSG":              {LinesInserted: 10},
+		"app/models/user.rb":       {LinesInserted: 5, LinesDeleted: 2},
+		"app/models/course.rb":     {LinesInserted: 1},
+		"spec/models/user_spec.rb": {LinesInserted: 20, LinesDeleted: 3},
+		"Gemfile":                  {LinesDeleted: 1},
+	})
+
+	churn := measureChurn(change)
+	if churn.Files != 4 || churn.Insertions != 26 || churn.Deletions != 6 {
+		t.Errorf("measureChurn() = %d files +%d -%d, want 4 files +26 -6", churn.Files, churn.Insertions, churn.Deletions)
+	}
+	wantDirs := map[string]ChurnStatistic{
+		"app/models":  {Name: "app/models", Insertions: 6, Deletions: 2},
+		"spec/models": {Name: "spec/models", Insertions: 20, Deletions: 3},
+		"/":           {Name: "/", Deletions: 1},
+	}
+	gotDirs := make(map[string]ChurnStatistic)
+	for dir, stat := range churn.byDir {
+		gotDirs[dir] = *stat
+	}
+	if !reflect.DeepEqual(gotDirs, wantDirs) {
+		t.Errorf("measureChurn() directories = %+v, want %+v", gotDirs, wantDirs)
+	}
+}
+
+func TestChurnStats(t *testing.T) {
+	stats := newChangeStats()
+	stats.churn = newChurnStats()
+	stats.Add(churnTestChange(1, "canvas-lms", "Alice", map[string]gerrit.FileInfo{"app/a.rb": {LinesInserted: 10}}))
+	stats.Add(churnTestChange(2, "canvas-lms", "Bob", map[string]gerrit.FileInfo{"app/b.rb": {LinesInserted: 1, LinesDeleted: 1}}))
+	stats.Add(churnTestChange(3, "outcomes", "Bob", map[string]gerrit.FileInfo{"app/c.rb": {LinesDeleted: 50}}))
+
+	if got, want := stats.Churn(), (ChurnStatistic{Name: "All changes", Changes: 3, Insertions: 11, Deletions: 51}); got != want {
+		t.Errorf("Churn() = %+v, want %+v", got, want)
+	}
+
+	wantAuthors := []ChurnStatistic{
+		{Name: "Bob", Changes: 2, Insertions: 1, Deletions: 51},
+		{Name: "Alice", Changes: 1, Insertions: 10},
+	}
+	if got := stats.ChurnByAuthor(); !reflect.DeepEqual(got, wantAuthors) {
+		t.Errorf("ChurnByAuthor() = %+v, want %+v", got, wantAuthors)
+	}
+
+	// The same directory in two projects is counted separately.
+	wantDirs := []ChurnStatistic{{Name: "app", Project: "outcomes", Changes: 1, Deletions: 50}}
+	if got := stats.ChurnByDirectory(1); !reflect.DeepEqual(got, wantDirs) {
+		t.Errorf("ChurnByDirectory(1) = %+v, want %+v", got, wantDirs)
+	}
+	if got := stats.ChurnByDirectory(10); len(got) != 2 {
+		t.Errorf("ChurnByDirectory(10) returned %d directories, want 2", len(got))
+	}
+}
+
+func TestWriteCSVReportWithSize(t *testing.T) {
+	store, _ := newChangeStore(false)
+	store.Add([]gerrit.Change{churnTestChange(7, "canvas-lms", "Alice", map[string]gerrit.FileInfo{"a.go": {LinesInserted: 3, LinesDeleted: 1}})})
+
+	var buf bytes.Buffer
+	if err := writeCSVReport(&buf, AnalysisData{Changes: store, WithSize: true}); err != nil {
+		t.Fatalf("writeCSVReport: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",files,insertions,deletions") {
+		t.Errorf("header = %q, want the size columns", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",1,3,1") {
+		t.Errorf("row = %q, want 1 file +3 -1", lines[1])
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// CountDelta compares a change count in the analyzed period with the
+// baseline. PercentChange is nil when the baseline count is zero.
+type CountDelta struct {
+	Name          string   `json:"name"`
+	Current       int      `json:"current"`
+	Baseline      int      `json:"baseline"`
+	Delta         int      `json:"delta"`
+	PercentChange *float64 `json:"percent_change,omitempty"`
+}
+
+// periodComparison is the analyzed period measured against a baseline
+// period, per repository and per author.
+type periodComparison struct {
+	BaselineStart string       `json:"baseline_start"`
+	BaselineEnd   string       `json:"baseline_end"`
+	Total         CountDelta   `json:"total"`
+	ByRepository  []CountDelta `json:"by_repository"`
+	ByAuthor      []CountDelta `json:"by_author"`
+}
+
+// previousPeriod returns the period of the same length that ends where
+// [start, end] begins.
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	return start.Add(-end.Sub(start)), start
+}
+
+// newCountDelta compares two counts.
+func newCountDelta(name string, current, baseline int) CountDelta {
+	d := CountDelta{Name: name, Current: current, Baseline: baseline, Delta: current - baseline}
+	if baseline > 0 {
+		pct := math.Round(float64(d.Delta)*1000/float64(baseline)) / 10
+		d.PercentChange = &pct
+	}
+	return d
+}
+
+// compareStatistics pairs the counts of two periods by name, biggest change
+// first, including names that appear in only one of them.
+func compareStatistics(current, baseline []Statistic) []CountDelta {
+	counts := make(map[string][2]int)
+	for _, stat := range current {
+		c := counts[stat.Name]
+		c[0] = stat.Count
+		counts[stat.Name] = c
+	}
+	for _, stat := range baseline {
+		c := counts[stat.Name]
+		c[1] = stat.Count
+		counts[stat.Name] = c
+	}
+
+	var deltas []CountDelta
+	for name, c := range counts {
+		deltas = append(deltas, newCountDelta(name, c[0], c[1]))
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		a, b := deltas[i], deltas[j]
+		if abs(a.Delta) != abs(b.Delta) {
+			return abs(a.Delta) > abs(b.Delta)
+		}
+		if a.Current != b.Current {
+			return a.Current > b.Current
+		}
+		return a.Name < b.Name
+	})
+	return deltas
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// comparePeriods builds the comparison of current against baseline.
+func comparePeriods(current, baseline *changeStats, currentTotal, baselineTotal int, baselineStart, baselineEnd string) *periodComparison {
+	return &periodComparison{
+		BaselineStart: baselineStart,
+		BaselineEnd:   baselineEnd,
+		Total:         newCountDelta("All changes", currentTotal, baselineTotal),
+		ByRepository:  compareStatistics(current.ByRepository(), baseline.ByRepository()),
+		ByAuthor:      compareStatistics(current.ByAuthor(), baseline.ByAuthor()),
+	}
+}
+
+// formatCountDelta renders a delta as "▲ +12 (+40%)", "▼ -3 (-10%)",
+// "▲ +5 (new)", or "= 0".
+func formatCountDelta(d CountDelta) string {
+	if d.Delta == 0 {
+		return "= 0"
+	}
+	arrow := "▲"
+	if d.Delta < 0 {
+		arrow = "▼"
+	}
+	pct := "new"
+	if d.PercentChange != nil {
+		pct = fmt.Sprintf("%+g%%", *d.PercentChange)
+	}
+	return fmt.Sprintf("%s %+d (%s)", arrow, d.Delta, pct)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	gotStart, gotEnd := previousPeriod(start, end)
+	if want := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC); !gotStart.Equal(want) {
+		t.Errorf("previousPeriod() start = %s, want %s", gotStart, want)
+	}
+	if !gotEnd.Equal(start) {
+		t.Errorf("previousPeriod() end = %s, want %s", gotEnd, start)
+	}
+}
+
+func TestCompareStatistics(t *testing.T) {
+	current := []Statistic{{Name: "canvas-lms", Count: 30}, {Name: "outcomes", Count: 8}, {Name: "quizzes", Count: 5}}
+	baseline := []Statistic{{Name: "canvas-lms", Count: 20}, {Name: "outcomes", Count: 10}, {Name: "legacy", Count: 4}}
+
+	var got []string
+	for _, d := range compareStatistics(current, baseline) {
+		got = append(got, d.Name+" "+formatCountDelta(d))
+	}
+	want := []string{
+		"canvas-lms ▲ +10 (+50%)",
+		"quizzes ▲ +5 (new)",
+		"legacy ▼ -4 (-100%)",
+		"outcomes ▼ -2 (-20%)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareStatistics() =\n%v\nwant\n%v", got, want)
+	}
+
+	if got := formatCountDelta(newCountDelta("x", 3, 3)); got != "= 0" {
+		t.Errorf("formatCountDelta(no change) = %q, want \"= 0\"", got)
+	}
+	if got := *newCountDelta("x", 1, 3).PercentChange; got != -66.7 {
+		t.Errorf("percent change = %v, want -66.7", got)
+	}
+}
+
+func TestComparisonInReports(t *testing.T) {
+	current := newChangeStats()
+	for _, change := range analyzeTestChanges() {
+		current.Add(change)
+	}
+	baseline := newChangeStats()
+	for _, change := range analyzeTestChanges()[:1] {
+		baseline.Add(change)
+	}
+	data := AnalysisData{
+		StartDate: "2025-01-01", EndDate: "2025-03-01", TotalChanges: 4, Stats: current,
+		Comparison: comparePeriods(current, baseline, 4, 1, "2024-11-01", "2025-01-01"),
+	}
+
+	var md bytes.Buffer
+	if err := writeMarkdownReport(&md, data); err != nil {
+		t.Fatalf("writeMarkdownReport: %v", err)
+	}
+	for _, want := range []string{
+		"## Compared with 2024-11-01 to 2025-01-01",
+		"**Total Changes:** 4 vs. 1 in the baseline, ▲ +3 (+300%)",
+		"| canvas-lms | 3 | 1 | ▲ +2 (+200%) |",
+		"| outcomes | 1 | 0 | ▲ +1 (new) |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown report is missing %q", want)
+		}
+	}
+
+	var html bytes.Buffer
+	if err := writeHTMLReport(&html, data); err != nil {
+		t.Fatalf("writeHTMLReport: %v", err)
+	}
+	// html/template writes "+" as "&#43;".
+	if want := `<td class="num up" data-sort="2">▲ &#43;2 (&#43;200%)</td>`; !strings.Contains(html.String(), want) {
+		t.Errorf("HTML report is missing %q", want)
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+// labelColumnPrefix names a CSV column holding a change's votes on a label,
+// e.g. "label:Code-Review".
+const labelColumnPrefix = "label:"
+
+// csvRow is one change being written to the CSV report.
+type csvRow struct {
+	change  gerrit.Change
+	latency reviewLatency
+	churn   *changeChurn // nil unless the file list was fetched
+}
+
+// csvColumn is a column the CSV report can include.
+type csvColumn struct {
+	name  string
+	value func(row csvRow) string
+}
+
+// analyzeCSVColumns lists the CSV columns in the order --columns documents
+// them. Label columns are handled separately.
+var analyzeCSVColumns = []csvColumn{
+	{"change_number", func(r csvRow) string { return r.change.ChangeNumberStr() }},
+	{"project", func(r csvRow) string { return r.change.Project }},
+	{"branch", func(r csvRow) string { return r.change.Branch }},
+	{"subject", func(r csvRow) string { return r.change.Subject }},
+	{"owner_name", func(r csvRow) string { return r.change.Owner.DisplayName() }},
+	{"owner_email", func(r csvRow) string { return r.change.Owner.Email }},
+	{"status", func(r csvRow) string { return r.change.Status }},
+	{"created", func(r csvRow) string { return r.change.Created }},
+	{"updated", func(r csvRow) string { return r.change.Updated }},
+	{"submitted", func(r csvRow) string { return r.change.Submitted }},
+	{"first_review_hours", func(r csvRow) string { return latencyHours(r.latency.FirstReview) }},
+	{"approval_hours", func(r csvRow) string { return latencyHours(r.latency.Approval) }},
+	{"merge_hours", func(r csvRow) string { return latencyHours(r.latency.Merge) }},
+	{"files", func(r csvRow) string {
+		if r.churn == nil {
+			return ""
+		}
+		return strconv.Itoa(r.churn.Files)
+	}},
+	{"insertions", func(r csvRow) string {
+		if r.churn == nil {
+			return strconv.Itoa(r.change.Insertions)
+		}
+		return strconv.Itoa(r.churn.Insertions)
+	}},
+	{"deletions", func(r csvRow) string {
+		if r.churn == nil {
+			return strconv.Itoa(r.change.Deletions)
+		}
+		return strconv.Itoa(r.churn.Deletions)
+	}},
+}
+
+// defaultCSVColumns is the columns written when --columns is not given.
+func defaultCSVColumns(withSize bool) []string {
+	columns := []string{"change_number", "project", "subject", "owner_name", "owner_email", "status", "created", "updated", "submitted",
+		"first_review_hours", "approval_hours", "merge_hours"}
+	if withSize {
+		columns = append(columns, "files", "insertions", "deletions")
+	}
+	return columns
+}
+
+func findCSVColumn(name string) (csvColumn, bool) {
+	for _, column := range analyzeCSVColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return csvColumn{}, false
+}
+
+// parseCSVColumns splits a comma-separated --columns value and checks each
+// name.
+func parseCSVColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.HasPrefix(name, labelColumnPrefix) {
+			if name == labelColumnPrefix {
+				return nil, fmt.Errorf("missing label name in column %q (e.g. label:Code-Review)", name)
+			}
+		} else if _, ok := findCSVColumn(name); !ok {
+			var supported []string
+			for _, column := range analyzeCSVColumns {
+				supported = append(supported, column.name)
+			}
+			return nil, fmt.Errorf("unknown column: %s (supported: %s, label:<name>)", name, strings.Join(supported, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// csvColumnOptions returns the query options the columns need beyond the
+// CSV report's defaults: label votes need DETAILED_LABELS and the file count
+// needs the current patchset's file list.
+func csvColumnOptions(columns []string) []string {
+	var options []string
+	for _, name := range columns {
+		switch {
+		case strings.HasPrefix(name, labelColumnPrefix):
+			options = mergeOptions(options, []string{"DETAILED_LABELS"})
+		case name == "files":
+			options = mergeOptions(options, []string{"CURRENT_REVISION", "CURRENT_FILES"})
+		}
+	}
+	return options
+}
+
+// csvValue renders one column of a row.
+func csvValue(name string, row csvRow) string {
+	if strings.HasPrefix(name, labelColumnPrefix) {
+		return labelVoteSummary(row.change, strings.TrimPrefix(name, labelColumnPrefix))
+	}
+	column, _ := findCSVColumn(name)
+	return column.value(row)
+}
+
+// labelVoteSummary renders the vote that decides a label as Gerrit shows it:
+// the lowest vote if anyone voted negatively, otherwise the highest, e.g.
+// "-1" or "+2". It is empty when nobody voted.
+func labelVoteSummary(change gerrit.Change, label string) string {
+	lowest, highest := 0, 0
+	for _, vote := range labelVoteList(change, label) {
+		if vote.Value < lowest {
+			lowest = vote.Value
+		}
+		if vote.Value > highest {
+			highest = vote.Value
+		}
+	}
+	switch {
+	case lowest < 0:
+		return strconv.Itoa(lowest)
+	case highest > 0:
+		return "+" + strconv.Itoa(highest)
+	}
+	return ""
+}
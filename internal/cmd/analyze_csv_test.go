@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestParseCSVColumns(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr string
+	}{
+		{"change_number, subject,label:Code-Review", []string{"change_number", "subject", "label:Code-Review"}, ""},
+		{"insertions,deletions,", []string{"insertions", "deletions"}, ""},
+		{"change_number,reviewer", nil, "unknown column: reviewer"},
+		{"label:", nil, "missing label name"},
+		{" , ", nil, "no columns given"},
+	}
+	for _, tt := range tests {
+		got, err := parseCSVColumns(tt.spec)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseCSVColumns(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCSVColumns(%q): %v", tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCSVColumns(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestCSVColumnOptions(t *testing.T) {
+	got := csvColumnOptions([]string{"subject", "label:Code-Review", "files", "label:Verified", "insertions"})
+	want := []string{"DETAILED_LABELS", "CURRENT_REVISION", "CURRENT_FILES"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("csvColumnOptions() = %v, want %v", got, want)
+	}
+	if got := csvColumnOptions([]string{"subject", "insertions"}); got != nil {
+		t.Errorf("csvColumnOptions() = %v, want nil", got)
+	}
+}
+
+func TestLabelVoteSummary(t *testing.T) {
+	votes := func(values ...float64) gerrit.Change {
+		var all []interface{}
+		for _, v := range values {
+			all = append(all, map[string]interface{}{"value": v, "name": "Someone"})
+		}
+		return gerrit.Change{Labels: map[string]interface{}{"Code-Review": map[string]interface{}{"all": all}}}
+	}
+	tests := []struct {
+		change gerrit.Change
+		want   string
+	}{
+		{votes(1, 2, 0), "+2"},
+		{votes(2, -1), "-1"},
+		{votes(0, 0), ""},
+		{gerrit.Change{}, ""},
+	}
+	for _, tt := range tests {
+		if got := labelVoteSummary(tt.change, "Code-Review"); got != tt.want {
+			t.Errorf("labelVoteSummary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSVReportColumns(t *testing.T) {
+	change := gerrit.Change{
+		Number:     7,
+		Subject:    `Fix "quoted", comma`,
+		Insertions: 12,
+		Deletions:  3,
+		Labels: map[string]interface{}{
+			"Code-Review": map[string]interface{}{"all": []interface{}{map[string]interface{}{"value": float64(2)}}},
+		},
+	}
+	store, _ := newChangeStore(false)
+	store.Add([]gerrit.Change{change})
+
+	var buf bytes.Buffer
+	data := AnalysisData{Changes: store, Columns: []string{"change_number", "subject", "insertions", "deletions", "label:Code-Review", "label:Verified"}}
+	if err := writeCSVReport(&buf, data); err != nil {
+		t.Fatalf("writeCSVReport: %v", err)
+	}
+	want := "change_number,subject,insertions,deletions,label:Code-Review,label:Verified\n" +
+		"7,\"Fix \"\"quoted\"\", comma\",12,3,+2,\n"
+	if buf.String() != want {
+		t.Errorf("writeCSVReport() = %q, want %q", buf.String(), want)
+	}
+}
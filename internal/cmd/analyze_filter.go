@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// analyzeFilter narrows gerry analyze to some repositories, an owner, a
+// branch, and any further Gerrit query terms.
+type analyzeFilter struct {
+	Repos  []string
+	Owner  string
+	Branch string
+	Query  string
+}
+
+// queryTerms returns the filter as Gerrit search operators, to be ANDed
+// with the status and date terms.
+func (f analyzeFilter) queryTerms() []string {
+	var terms []string
+	switch len(f.Repos) {
+	case 0:
+	case 1:
+		terms = append(terms, "project:"+queryValue(f.Repos[0]))
+	default:
+		projects := make([]string, len(f.Repos))
+		for i, repo := range f.Repos {
+			projects[i] = "project:" + queryValue(repo)
+		}
+		terms = append(terms, "("+strings.Join(projects, " OR ")+")")
+	}
+	if f.Owner != "" {
+		terms = append(terms, "owner:"+queryValue(f.Owner))
+	}
+	if f.Branch != "" {
+		terms = append(terms, "branch:"+queryValue(f.Branch))
+	}
+	if f.Query != "" {
+		terms = append(terms, "("+f.Query+")")
+	}
+	return terms
+}
+
+// repository returns the one repository the report is limited to, or "".
+func (f analyzeFilter) repository() string {
+	if len(f.Repos) == 1 {
+		return f.Repos[0]
+	}
+	return ""
+}
+
+// storeScope names the local store for the filter: "" for the whole
+// server, the repository when that is the only filter, and otherwise a
+// digest of the query terms. Repository order does not matter.
+func (f analyzeFilter) storeScope() string {
+	if f.Owner == "" && f.Branch == "" && f.Query == "" && len(f.Repos) <= 1 {
+		return f.repository()
+	}
+	f.Repos = append([]string(nil), f.Repos...)
+	sort.Strings(f.Repos)
+	sum := sha256.Sum256([]byte(strings.Join(f.queryTerms(), " ")))
+	return "query-" + hex.EncodeToString(sum[:6])
+}
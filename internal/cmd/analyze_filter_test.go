@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFilterQueryTerms(t *testing.T) {
+	tests := []struct {
+		filter analyzeFilter
+		want   []string
+	}{
+		{analyzeFilter{}, nil},
+		{analyzeFilter{Repos: []string{"canvas-lms"}}, []string{"project:canvas-lms"}},
+		{
+			analyzeFilter{Repos: []string{"canvas-lms", "outcomes"}, Branch: "release/2025-06"},
+			[]string{"(project:canvas-lms OR project:outcomes)", "branch:release/2025-06"},
+		},
+		{
+			analyzeFilter{Owner: "Jane Doe", Query: "topic:a OR topic:b"},
+			[]string{`owner:"Jane Doe"`, "(topic:a OR topic:b)"},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.queryTerms(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.queryTerms() = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeFilterStoreScope(t *testing.T) {
+	if got := (analyzeFilter{}).storeScope(); got != "" {
+		t.Errorf("storeScope() = %q for no filter, want \"\"", got)
+	}
+	if got := (analyzeFilter{Repos: []string{"canvas-lms"}}).storeScope(); got != "canvas-lms" {
+		t.Errorf("storeScope() = %q for one repository, want canvas-lms", got)
+	}
+
+	ab := analyzeFilter{Repos: []string{"a", "b"}}.storeScope()
+	ba := analyzeFilter{Repos: []string{"b", "a"}}.storeScope()
+	if !strings.HasPrefix(ab, "query-") || ab != ba {
+		t.Errorf("storeScope() = %q and %q for the same repositories in another order", ab, ba)
+	}
+	if owned := (analyzeFilter{Repos: []string{"a"}, Owner: "alice"}).storeScope(); owned == "a" || owned == ab {
+		t.Errorf("storeScope() = %q with an owner filter shares another store", owned)
+	}
+}
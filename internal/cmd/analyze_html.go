@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// htmlChartBar is one bar of a report chart, Percent of the largest.
+type htmlChartBar struct {
+	Label   string
+	Count   int
+	Percent float64
+}
+
+// htmlLatencyCell is one "median / p90" latency cell, sorted by the median.
+type htmlLatencyCell struct {
+	Text   string
+	Median float64
+}
+
+type htmlLatencyRow struct {
+	Name        string
+	Changes     int
+	FirstReview htmlLatencyCell
+	Approval    htmlLatencyCell
+	Merge       htmlLatencyCell
+}
+
+type htmlChurnRow struct {
+	Name       string
+	Changes    int
+	Insertions int
+	Deletions  int
+}
+
+// htmlReport is what the HTML template renders.
+type htmlReport struct {
+	AnalysisData
+	Generated      string
+	Repositories   []Statistic
+	Authors        []Statistic
+	Timeline       []htmlChartBar
+	TopAuthors     []htmlChartBar
+	Latency        []htmlLatencyRow
+	LatencyByRepo  []htmlLatencyRow
+	LatencyByOwner []htmlLatencyRow
+	Churn          ChurnStatistic
+	ChurnByRepo    []htmlChurnRow
+	ChurnByAuthor  []htmlChurnRow
+	ChurnByDir     []htmlChurnRow
+}
+
+// writeHTMLReport writes a self-contained HTML page with the same sections
+// as the markdown report, sortable tables, and bar charts, for sharing with
+// people who won't read markdown.
+func writeHTMLReport(w io.Writer, data AnalysisData) error {
+	report := htmlReport{
+		AnalysisData: data,
+		Generated:    time.Now().Format("2006-01-02 15:04:05"),
+		Repositories: data.Stats.ByRepository(),
+		Authors:      data.Stats.ByAuthor(),
+	}
+
+	report.Timeline = chartBars(data.Stats.Timeline())
+	top := report.Authors
+	if len(top) > 20 {
+		top = top[:20]
+	}
+	report.TopAuthors = chartBars(top)
+
+	report.Latency = htmlLatencyRows([]LatencyStatistic{data.Stats.Latency()})
+	report.LatencyByRepo = htmlLatencyRows(data.Stats.LatencyByRepository())
+	report.LatencyByOwner = htmlLatencyRows(data.Stats.LatencyByAuthor())
+
+	if data.WithSize {
+		report.Churn = data.Stats.Churn()
+		report.ChurnByRepo = htmlChurnRows(data.Stats.ChurnByRepository(), false)
+		report.ChurnByAuthor = htmlChurnRows(data.Stats.ChurnByAuthor(), false)
+		report.ChurnByDir = htmlChurnRows(data.Stats.ChurnByDirectory(data.TopDirs), data.Repository == "")
+	}
+
+	return htmlReportTemplate.Execute(w, report)
+}
+
+func chartBars(stats []Statistic) []htmlChartBar {
+	largest := 0
+	for _, stat := range stats {
+		if stat.Count > largest {
+			largest = stat.Count
+		}
+	}
+	bars := make([]htmlChartBar, len(stats))
+	for i, stat := range stats {
+		bars[i] = htmlChartBar{Label: stat.Name, Count: stat.Count}
+		if largest > 0 {
+			bars[i].Percent = float64(stat.Count) * 100 / float64(largest)
+		}
+	}
+	return bars
+}
+
+func htmlLatencyRows(stats []LatencyStatistic) []htmlLatencyRow {
+	cell := func(s LatencySummary) htmlLatencyCell {
+		// Groups that never reached a milestone sort below any that did.
+		median := -1.0
+		if s.Count > 0 {
+			median = s.MedianHours
+		}
+		return htmlLatencyCell{Text: formatLatency(s), Median: median}
+	}
+	rows := make([]htmlLatencyRow, len(stats))
+	for i, stat := range stats {
+		rows[i] = htmlLatencyRow{
+			Name:        stat.Name,
+			Changes:     stat.Changes,
+			FirstReview: cell(stat.FirstReview),
+			Approval:    cell(stat.Approval),
+			Merge:       cell(stat.Merge),
+		}
+	}
+	return rows
+}
+
+func htmlChurnRows(stats []ChurnStatistic, withProject bool) []htmlChurnRow {
+	rows := make([]htmlChurnRow, len(stats))
+	for i, stat := range stats {
+		name := stat.Name
+		if withProject {
+			name = fmt.Sprintf("%s: %s", stat.Project, stat.Name)
+		}
+		rows[i] = htmlChurnRow{Name: name, Changes: stat.Changes, Insertions: stat.Insertions, Deletions: stat.Deletions}
+	}
+	return rows
+}
+
+// deltaClass colors a comparison cell by whether the count grew or fell.
+func deltaClass(d CountDelta) string {
+	switch {
+	case d.Delta > 0:
+		return "up"
+	case d.Delta < 0:
+		return "down"
+	}
+	return ""
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"delta":      formatCountDelta,
+	"deltaClass": deltaClass,
+	"join":       strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Gerrit Change Analysis {{.StartDate}} to {{.EndDate}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292f; max-width: 1100px; margin: 2em auto; padding: 0 1em; }
+h1 { margin-bottom: 0.2em; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3em; margin-top: 2em; }
+.meta { color: #57606a; }
+.meta span { margin-right: 2em; }
+table { border-collapse: collapse; width: 100%; margin: 1em 0; font-size: 14px; }
+th, td { border: 1px solid #d0d7de; padding: 6px 10px; text-align: left; }
+th { background: #f6f8fa; cursor: pointer; user-select: none; white-space: nowrap; }
+th.asc::after { content: " \25B2"; }
+th.desc::after { content: " \25BC"; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+tr:nth-child(even) td { background: #fbfcfd; }
+.ins { color: #1a7f37; }
+.del { color: #cf222e; }
+.up { color: #1a7f37; font-weight: 600; }
+.down { color: #cf222e; font-weight: 600; }
+.timeline { display: flex; align-items: flex-end; gap: 4px; height: 220px; border-bottom: 1px solid #d0d7de; margin: 1em 0 0.3em; }
+.timeline .col { flex: 1; display: flex; flex-direction: column; justify-content: flex-end; align-items: center; height: 100%; min-width: 0; }
+.timeline .bar { width: 100%; background: #0969da; border-radius: 3px 3px 0 0; }
+.timeline .count { font-size: 11px; color: #57606a; }
+.timeline-labels { display: flex; gap: 4px; }
+.timeline-labels div { flex: 1; font-size: 11px; color: #57606a; text-align: center; overflow: hidden; min-width: 0; }
+.hbar { display: flex; align-items: center; margin: 3px 0; font-size: 14px; }
+.hbar .label { width: 220px; flex-shrink: 0; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.hbar .track { flex: 1; }
+.hbar .bar { background: #0969da; height: 16px; border-radius: 0 3px 3px 0; }
+.hbar .count { width: 60px; text-align: right; flex-shrink: 0; }
+footer { color: #57606a; margin: 3em 0 1em; font-size: 13px; }
+</style>
+</head>
+<body>
+<h1>Gerrit Change Analysis</h1>
+<p class="meta">
+<span><strong>Period:</strong> {{.StartDate}} to {{.EndDate}}</span>
+{{- if .Repository}}
+<span><strong>Repository:</strong> {{.Repository}}</span>
+{{- else if gt (len .Filter.Repos) 1}}
+<span><strong>Repositories:</strong> {{join .Filter.Repos ", "}}</span>
+{{- else}}
+<span><strong>Repository:</strong> All repositories</span>
+{{- end}}
+{{- with .Filter.Owner}}
+<span><strong>Owner:</strong> {{.}}</span>
+{{- end}}
+{{- with .Filter.Branch}}
+<span><strong>Branch:</strong> {{.}}</span>
+{{- end}}
+{{- with .Filter.Query}}
+<span><strong>Query:</strong> <code>{{.}}</code></span>
+{{- end}}
+<span><strong>Total changes:</strong> {{.TotalChanges}}</span>
+{{- if .ExcludedBots}}
+<span><strong>Bot changes excluded:</strong> {{.ExcludedBots}}</span>
+{{- end}}
+<span><strong>Generated:</strong> {{.Generated}}</span>
+</p>
+{{- with .Comparison}}
+
+<h2>Compared with {{.BaselineStart}} to {{.BaselineEnd}}</h2>
+<p><strong>Total changes:</strong> {{.Total.Current}} vs. {{.Total.Baseline}} in the baseline, <span class="{{deltaClass .Total}}">{{delta .Total}}</span></p>
+{{- if not $.Repository}}
+<h3>By Repository</h3>
+{{template "comparison" .ByRepository}}
+{{- end}}
+<h3>By Author</h3>
+{{template "comparison" .ByAuthor}}
+{{- end}}
+
+<h2>Changes Merged per Month</h2>
+<div class="timeline">
+{{- range .Timeline}}
+<div class="col" title="{{.Label}}: {{.Count}}"><div class="count">{{.Count}}</div><div class="bar" style="height: {{printf "%.1f" .Percent}}%"></div></div>
+{{- end}}
+</div>
+<div class="timeline-labels">
+{{- range .Timeline}}
+<div>{{.Label}}</div>
+{{- end}}
+</div>
+
+<h2>Top Contributors</h2>
+{{- range .TopAuthors}}
+<div class="hbar"><div class="label" title="{{.Label}}">{{.Label}}</div><div class="track"><div class="bar" style="width: {{printf "%.1f" .Percent}}%"></div></div><div class="count">{{.Count}}</div></div>
+{{- end}}
+
+{{- if not .Repository}}
+
+<h2>Changes by Repository</h2>
+<table class="sortable">
+<thead><tr><th>Repository</th><th>Changes</th></tr></thead>
+<tbody>
+{{- range .Repositories}}
+<tr><td>{{.Name}}</td><td class="num">{{.Count}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+<h2>Changes by Author</h2>
+<table class="sortable">
+<thead><tr><th>Author</th><th>Changes</th><th>Repositories</th></tr></thead>
+<tbody>
+{{- range .Authors}}
+<tr><td>{{.Name}}</td><td class="num">{{.Count}}</td><td class="num">{{.RepoCount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+
+<h2>Review Latency</h2>
+<p>Time from the first patchset to the first review, the first Code-Review+2, and merge, as median / 90th percentile. Bots and the owner's own messages don't count as a review.</p>
+{{template "latency" .Latency}}
+{{- if not .Repository}}
+<h3>By Repository</h3>
+{{template "latency" .LatencyByRepo}}
+{{- end}}
+<h3>By Author</h3>
+{{template "latency" .LatencyByOwner}}
+
+{{- if .WithSize}}
+
+<h2>Code Churn</h2>
+<p><strong>Lines changed:</strong> <span class="ins">+{{.Churn.Insertions}}</span> / <span class="del">-{{.Churn.Deletions}}</span> across {{.Churn.Changes}} changes (current patchsets, excluding the commit message)</p>
+{{- if not .Repository}}
+<h3>By Repository</h3>
+{{template "churn" .ChurnByRepo}}
+{{- end}}
+<h3>By Author</h3>
+{{template "churn" .ChurnByAuthor}}
+<h3>Top {{.TopDirs}} Directories</h3>
+{{template "churn" .ChurnByDir}}
+{{- end}}
+
+<footer>Generated by gerry analyze</footer>
+<script>
+document.querySelectorAll("table.sortable th").forEach(function (th) {
+  th.addEventListener("click", function () {
+    var table = th.closest("table");
+    var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+    var asc = !th.classList.contains("asc");
+    table.querySelectorAll("th").forEach(function (h) { h.classList.remove("asc", "desc"); });
+    th.classList.add(asc ? "asc" : "desc");
+    var key = function (row) {
+      var cell = row.children[index];
+      var value = cell.getAttribute("data-sort");
+      if (value === null && cell.classList.contains("num")) value = cell.textContent.replace(/[^0-9.\-]/g, "");
+      return value === null ? cell.textContent.toLowerCase() : parseFloat(value);
+    };
+    var body = table.tBodies[0];
+    Array.prototype.slice.call(body.rows)
+      .sort(function (a, b) {
+        var x = key(a), y = key(b);
+        return (x < y ? -1 : x > y ? 1 : 0) * (asc ? 1 : -1);
+      })
+      .forEach(function (row) { body.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+{{define "latency"}}<table class="sortable">
+<thead><tr><th>Name</th><th>Changes</th><th>First Review</th><th>Code-Review+2</th><th>Merge</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Name}}</td><td class="num">{{.Changes}}</td><td class="num" data-sort="{{.FirstReview.Median}}">{{.FirstReview.Text}}</td><td class="num" data-sort="{{.Approval.Median}}">{{.Approval.Text}}</td><td class="num" data-sort="{{.Merge.Median}}">{{.Merge.Text}}</td></tr>
+{{- end}}
+</tbody>
+</table>{{end}}
+{{define "comparison"}}<table class="sortable">
+<thead><tr><th>Name</th><th>Changes</th><th>Baseline</th><th>Change</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Name}}</td><td class="num">{{.Current}}</td><td class="num">{{.Baseline}}</td><td class="num {{deltaClass .}}" data-sort="{{.Delta}}">{{delta .}}</td></tr>
+{{- end}}
+</tbody>
+</table>{{end}}
+{{define "churn"}}<table class="sortable">
+<thead><tr><th>Name</th><th>Changes</th><th>Insertions</th><th>Deletions</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Name}}</td><td class="num">{{.Changes}}</td><td class="num ins" data-sort="{{.Insertions}}">+{{.Insertions}}</td><td class="num del" data-sort="{{.Deletions}}">-{{.Deletions}}</td></tr>
+{{- end}}
+</tbody>
+</table>{{end}}
+`))
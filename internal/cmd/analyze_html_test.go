@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	stats := newChangeStats()
+	changes := append(analyzeTestChanges(), gerrit.Change{
+		Number: 5, Project: "canvas-lms", Status: "MERGED",
+		Owner: gerrit.Account{Name: "<script>alert(1)</script>"}, Submitted: "2025-02-03 09:00:00.000000000",
+	})
+	for _, change := range changes {
+		stats.Add(change)
+	}
+
+	var buf bytes.Buffer
+	data := AnalysisData{StartDate: "2025-01-01", EndDate: "2025-12-31", TotalChanges: len(changes), Stats: stats}
+	if err := writeHTMLReport(&buf, data); err != nil {
+		t.Fatalf("writeHTMLReport: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h2>Changes Merged per Month</h2>",
+		`title="2025-02: 2"`,
+		`style="height: 100.0%"`,
+		"<h2>Changes by Repository</h2>",
+		"<tr><td>canvas-lms</td><td class=\"num\">4</td></tr>",
+		"<h2>Review Latency</h2>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML report is missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script>alert(1)") {
+		t.Error("HTML report does not escape author names")
+	}
+	if strings.Contains(out, "Code Churn") {
+		t.Error("HTML report has a churn section without --with-size")
+	}
+
+	buf.Reset()
+	data.Repository = "canvas-lms"
+	data.WithSize = true
+	data.TopDirs = 5
+	stats.churn = newChurnStats()
+	if err := writeHTMLReport(&buf, data); err != nil {
+		t.Fatalf("writeHTMLReport: %v", err)
+	}
+	out = buf.String()
+	if strings.Contains(out, "Changes by Repository") {
+		t.Error("HTML report for one repository lists repositories")
+	}
+	if !strings.Contains(out, "<h3>Top 5 Directories</h3>") {
+		t.Error("HTML report with --with-size is missing the directory table")
+	}
+}
+
+func TestChartBars(t *testing.T) {
+	bars := chartBars([]Statistic{{Name: "a", Count: 4}, {Name: "b", Count: 1}, {Name: "c"}})
+	want := []float64{100, 25, 0}
+	for i, bar := range bars {
+		if bar.Percent != want[i] {
+			t.Errorf("bar %s = %v%%, want %v%%", bar.Label, bar.Percent, want[i])
+		}
+	}
+}
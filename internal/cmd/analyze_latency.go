@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// approvalMessageRe matches the message Gerrit posts for a Code-Review+2
+// vote, e.g. "Patch Set 3: Code-Review+2 Verified+1".
+var approvalMessageRe = regexp.MustCompile(`^Patch Set \d+:.*\bCode-Review\+2\b`)
+
+// reviewLatency is how long after its first patchset a change got its first
+// review, its first Code-Review+2, and merged. Milestones that were not
+// reached are negative.
+type reviewLatency struct {
+	FirstReview time.Duration
+	Approval    time.Duration
+	Merge       time.Duration
+}
+
+// measureReviewLatency works out a change's review milestones from its
+// messages and timestamps. As in gerry sla, messages from the owner, from
+// the server, and tagged autogenerated:* do not count as a review; nor do
+// messages from accounts matching bot_patterns.
+func measureReviewLatency(change gerrit.Change, identities *identityResolver) reviewLatency {
+	lat := reviewLatency{FirstReview: -1, Approval: -1, Merge: -1}
+	created, err := utils.ParseGerritTime(change.Created)
+	if err != nil {
+		return lat
+	}
+	since := func(ts string) time.Duration {
+		t, err := utils.ParseGerritTime(ts)
+		if err != nil || t.Before(created) {
+			return -1
+		}
+		return t.Sub(created)
+	}
+
+	for _, msg := range change.Messages {
+		if lat.Approval < 0 && approvalMessageRe.MatchString(firstLine(msg.Message)) {
+			lat.Approval = since(msg.Date)
+		}
+		if lat.FirstReview >= 0 || msg.Author == (gerrit.Account{}) {
+			continue
+		}
+		if strings.HasPrefix(msg.Tag, "autogenerated:") || isSameAccount(msg.Author, change.Owner) || identities.isBot(msg.Author) {
+			continue
+		}
+		lat.FirstReview = since(msg.Date)
+	}
+	if change.Submitted != "" {
+		lat.Merge = since(change.Submitted)
+	}
+	return lat
+}
+
+// LatencySummary is the median and 90th percentile, in hours, of one review
+// milestone over the changes that reached it.
+type LatencySummary struct {
+	Count       int     `json:"count"`
+	MedianHours float64 `json:"median_hours"`
+	P90Hours    float64 `json:"p90_hours"`
+}
+
+// LatencyStatistic summarizes the review latency of a group of changes.
+type LatencyStatistic struct {
+	Name        string         `json:"name"`
+	Changes     int            `json:"changes"`
+	FirstReview LatencySummary `json:"first_review"`
+	Approval    LatencySummary `json:"approval"`
+	Merge       LatencySummary `json:"merge"`
+}
+
+// latencySamples collects the milestone times, in hours, of a group of
+// changes.
+type latencySamples struct {
+	changes     int
+	firstReview []float64
+	approval    []float64
+	merge       []float64
+}
+
+func (s *latencySamples) add(lat reviewLatency) {
+	s.changes++
+	if lat.FirstReview >= 0 {
+		s.firstReview = append(s.firstReview, lat.FirstReview.Hours())
+	}
+	if lat.Approval >= 0 {
+		s.approval = append(s.approval, lat.Approval.Hours())
+	}
+	if lat.Merge >= 0 {
+		s.merge = append(s.merge, lat.Merge.Hours())
+	}
+}
+
+func (s *latencySamples) statistic(name string) LatencyStatistic {
+	return LatencyStatistic{
+		Name:        name,
+		Changes:     s.changes,
+		FirstReview: summarizeLatency(s.firstReview),
+		Approval:    summarizeLatency(s.approval),
+		Merge:       summarizeLatency(s.merge),
+	}
+}
+
+func summarizeLatency(hours []float64) LatencySummary {
+	if len(hours) == 0 {
+		return LatencySummary{}
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	return LatencySummary{
+		Count:       len(sorted),
+		MedianHours: roundHours(percentile(sorted, 50)),
+		P90Hours:    roundHours(percentile(sorted, 90)),
+	}
+}
+
+// latencyStats groups review latency overall, per project, and per owner.
+type latencyStats struct {
+	overall  latencySamples
+	byRepo   map[string]*latencySamples
+	byAuthor map[string]*latencySamples
+}
+
+func newLatencyStats() *latencyStats {
+	return &latencyStats{
+		byRepo:   make(map[string]*latencySamples),
+		byAuthor: make(map[string]*latencySamples),
+	}
+}
+
+func (s *latencyStats) add(change gerrit.Change, identities *identityResolver) {
+	lat := measureReviewLatency(change, identities)
+	s.overall.add(lat)
+	if change.Project != "" {
+		addLatencySample(s.byRepo, change.Project, lat)
+	}
+	if author := change.Owner.DisplayName(); author != "unknown" {
+		addLatencySample(s.byAuthor, author, lat)
+	}
+}
+
+func addLatencySample(groups map[string]*latencySamples, name string, lat reviewLatency) {
+	if groups[name] == nil {
+		groups[name] = &latencySamples{}
+	}
+	groups[name].add(lat)
+}
+
+// latencyByGroup returns one statistic per group, most changes first.
+func latencyByGroup(groups map[string]*latencySamples) []LatencyStatistic {
+	var stats []LatencyStatistic
+	for name, samples := range groups {
+		stats = append(stats, samples.statistic(name))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Changes != stats[j].Changes {
+			return stats[i].Changes > stats[j].Changes
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// formatLatency renders a summary as "median / p90" for the markdown report.
+func formatLatency(s LatencySummary) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	return utils.FormatDuration(hoursDuration(s.MedianHours)) + " / " + utils.FormatDuration(hoursDuration(s.P90Hours))
+}
+
+// latencyHours renders a milestone time for the CSV report, empty when it
+// was not reached.
+func latencyHours(d time.Duration) string {
+	if d < 0 {
+		return ""
+	}
+	return strconv.FormatFloat(roundHours(d.Hours()), 'f', -1, 64)
+}
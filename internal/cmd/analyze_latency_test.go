@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestMeasureReviewLatency(t *testing.T) {
+	owner := gerrit.Account{AccountID: 1, Name: "Alice"}
+	bob := gerrit.Account{AccountID: 2, Name: "Bob"}
+	ci := gerrit.Account{AccountID: 3, Name: "CI"}
+
+	change := gerrit.Change{
+		Owner:     owner,
+		Created:   "2025-01-06 09:00:00.000000000",
+		Submitted: "2025-01-08 09:00:00.000000000",
+		Messages: []gerrit.ChangeMessageInfo{
+			{Author: owner, Message: "Uploaded patch set 1.", Date: "2025-01-06 09:00:00.000000000", Tag: "autogenerated:gerrit:newPatchSet"},
+			{Author: ci, Message: "Patch Set 1: Verified+1", Date: "2025-01-06 09:30:00.000000000", Tag: "autogenerated:ci"},
+			{Author: owner, Message: "Patch Set 1:\n\n(1 comment)", Date: "2025-01-06 10:00:00.000000000"},
+			{Author: bob, Message: "Patch Set 1: Code-Review-1\n\n(2 comments)", Date: "2025-01-06 15:00:00.000000000"},
+			{Author: bob, Message: "Patch Set 2: Code-Review+2", Date: "2025-01-07 21:00:00.000000000"},
+			{Message: "Change has been successfully merged", Date: "2025-01-08 09:00:00.000000000", Tag: "autogenerated:gerrit:merged"},
+		},
+	}
+	want := reviewLatency{FirstReview: 6 * time.Hour, Approval: 36 * time.Hour, Merge: 48 * time.Hour}
+	if got := measureReviewLatency(change, nil); got != want {
+		t.Errorf("measureReviewLatency() = %+v, want %+v", got, want)
+	}
+
+	unreviewed := gerrit.Change{Owner: owner, Created: "2025-01-06 09:00:00.000000000"}
+	want = reviewLatency{FirstReview: -1, Approval: -1, Merge: -1}
+	if got := measureReviewLatency(unreviewed, nil); got != want {
+		t.Errorf("measureReviewLatency(unreviewed, nil) = %+v, want %+v", got, want)
+	}
+}
+
+func TestLatencyStats(t *testing.T) {
+	stats := newChangeStats()
+	for i, hours := range []int{1, 2, 3, 4, 100} {
+		created := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+		merged := created.Add(time.Duration(hours) * time.Hour)
+		project := "canvas-lms"
+		if i == 4 {
+			project = "outcomes"
+		}
+		stats.Add(gerrit.Change{
+			Number:    i + 1,
+			Project:   project,
+			Owner:     gerrit.Account{Name: "Alice"},
+			Created:   created.Format("2006-01-02 15:04:05.000000000"),
+			Submitted: merged.Format("2006-01-02 15:04:05.000000000"),
+		})
+	}
+
+	overall := stats.Latency()
+	if overall.Changes != 5 || overall.FirstReview.Count != 0 {
+		t.Errorf("Latency() = %+v, want 5 changes and no reviews", overall)
+	}
+	if want := (LatencySummary{Count: 5, MedianHours: 3, P90Hours: 100}); overall.Merge != want {
+		t.Errorf("Latency().Merge = %+v, want %+v", overall.Merge, want)
+	}
+
+	var repos []string
+	for _, stat := range stats.LatencyByRepository() {
+		repos = append(repos, stat.Name)
+	}
+	if want := []string{"canvas-lms", "outcomes"}; !reflect.DeepEqual(repos, want) {
+		t.Errorf("LatencyByRepository() names = %v, want %v", repos, want)
+	}
+	if got := stats.LatencyByAuthor(); len(got) != 1 || got[0].Merge.MedianHours != 3 {
+		t.Errorf("LatencyByAuthor() = %+v, want one author with a 3h median merge", got)
+	}
+}
+
+func TestFormatLatency(t *testing.T) {
+	if got := formatLatency(LatencySummary{}); got != "-" {
+		t.Errorf("formatLatency(empty) = %q, want \"-\"", got)
+	}
+	if got := formatLatency(LatencySummary{Count: 3, MedianHours: 5, P90Hours: 50}); got != "5h / 2d 2h" {
+		t.Errorf("formatLatency() = %q, want \"5h / 2d 2h\"", got)
+	}
+	if got := latencyHours(90 * time.Minute); got != "1.5" {
+		t.Errorf("latencyHours(90m) = %q, want \"1.5\"", got)
+	}
+	if got := latencyHours(-1); got != "" {
+		t.Errorf("latencyHours(-1) = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// analyzeStoreDirName holds gerry analyze's local stores inside the config
+// dir, one per server and filter.
+const analyzeStoreDirName = "analyze"
+
+// analyzeStoreOverlap is how far before the last sync an incremental fetch
+// starts, so changes updated while that sync ran are not missed.
+const analyzeStoreOverlap = 5 * time.Minute
+
+// analyzeStoreMeta describes a local store: it holds every merged change in
+// its scope updated since From, as of Synced, fetched with Options.
+type analyzeStoreMeta struct {
+	Server  string   `json:"server"`
+	Repo    string   `json:"repo,omitempty"`
+	Filter  string   `json:"filter,omitempty"`
+	From    string   `json:"from"`
+	Synced  string   `json:"synced"`
+	Options []string `json:"options"`
+	Count   int      `json:"count"`
+}
+
+// analyzeStore is the local copy of the merged changes gerry analyze reports
+// on. Changes are kept one JSON document per line next to a metadata file,
+// so reports stream through them like the --spool file.
+type analyzeStore struct {
+	path string
+	meta *analyzeStoreMeta // nil until the first sync
+}
+
+// openAnalyzeStore opens the store for server and scope ("" for all
+// repositories; see analyzeFilter.storeScope) in dir, which need not exist
+// yet.
+func openAnalyzeStore(dir, server, scope string) (*analyzeStore, error) {
+	if scope == "" {
+		scope = "all"
+	}
+	s := &analyzeStore{path: filepath.Join(dir, storeFileName(server)+"_"+storeFileName(scope))}
+
+	data, err := os.ReadFile(s.path + ".json")
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local store: %w", err)
+	}
+	var meta analyzeStoreMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		utils.Warnf("Ignoring corrupt local store %s: %v", s.path, err)
+		return s, nil
+	}
+	s.meta = &meta
+	return s, nil
+}
+
+// analyzeStoreDir returns where local stores are kept.
+func analyzeStoreDir() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, analyzeStoreDirName), nil
+}
+
+// storeFileName makes a server or project name safe to use in a file name.
+func storeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// covers reports whether the store holds every change a report from start
+// needs, with at least the given fetch options.
+func (s *analyzeStore) covers(start string, options []string) bool {
+	if s.meta == nil || s.meta.From > start {
+		return false
+	}
+	have := make(map[string]bool)
+	for _, opt := range s.meta.Options {
+		have[opt] = true
+	}
+	for _, opt := range options {
+		if !have[opt] {
+			return false
+		}
+	}
+	return true
+}
+
+// synced returns when the store was last brought up to date.
+func (s *analyzeStore) synced() time.Time {
+	if s.meta == nil {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, s.meta.Synced)
+	return t
+}
+
+// Each calls fn for every stored change.
+func (s *analyzeStore) Each(fn func(gerrit.Change) error) error {
+	if s.meta == nil {
+		return nil
+	}
+	return eachStoredChange(s.path+".jsonl", fn)
+}
+
+func eachStoredChange(path string, fn func(gerrit.Change) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read local store: %w", err)
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var change gerrit.Change
+		if err := dec.Decode(&change); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read local store: %w", err)
+		}
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+}
+
+// analyzeStoreWriter writes a new version of a store to a temporary file
+// that replaces the store on commit.
+type analyzeStoreWriter struct {
+	store *analyzeStore
+	file  *os.File
+	buf   *bufio.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func (s *analyzeStore) create() (*analyzeStoreWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create local store: %w", err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(s.path), ".tmp-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local store: %w", err)
+	}
+	buf := bufio.NewWriter(file)
+	return &analyzeStoreWriter{store: s, file: file, buf: buf, enc: json.NewEncoder(buf)}, nil
+}
+
+// Add writes changes to the new version.
+func (w *analyzeStoreWriter) Add(changes []gerrit.Change) error {
+	for _, change := range changes {
+		if err := w.enc.Encode(change); err != nil {
+			return fmt.Errorf("failed to write local store: %w", err)
+		}
+		w.count++
+	}
+	return nil
+}
+
+// Each calls fn for every change written so far.
+func (w *analyzeStoreWriter) Each(fn func(gerrit.Change) error) error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write local store: %w", err)
+	}
+	return eachStoredChange(w.file.Name(), fn)
+}
+
+// Commit replaces the store with the new version, described by meta. The
+// old metadata goes first and the new one is renamed into place last, so
+// an interrupted commit leaves a store without metadata, which reads as
+// empty, rather than metadata that doesn't describe the changes.
+func (w *analyzeStoreWriter) Commit(meta analyzeStoreMeta) error {
+	meta.Count = w.count
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = w.buf.Flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		if err = os.Remove(w.store.path + ".json"); os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = os.Rename(w.file.Name(), w.store.path+".jsonl")
+	}
+	if err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to save local store: %w", err)
+	}
+
+	if err := writeFileAtomic(w.store.path+".json", data); err != nil {
+		return fmt.Errorf("failed to save local store: %w", err)
+	}
+	w.store.meta = &meta
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so readers see either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*.json")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+// Abort discards the new version. It is a no-op after Commit.
+func (w *analyzeStoreWriter) Abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// inAnalyzeRange reports whether a change matches the analysis range the
+// way Gerrit's after:start before:end would: last updated between midnight
+// (UTC) of both dates, inclusive.
+func inAnalyzeRange(change gerrit.Change, start, end time.Time) bool {
+	updated, err := utils.ParseGerritTime(change.Updated)
+	if err != nil {
+		return false
+	}
+	return !updated.Before(start) && !updated.After(end)
+}
+
+// mergeOptions returns a followed by the options in b it lacks.
+func mergeOptions(a, b []string) []string {
+	merged := append([]string(nil), a...)
+	for _, opt := range b {
+		found := false
+		for _, have := range merged {
+			if have == opt {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, opt)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestAnalyzeStore(t *testing.T) {
+	dir := t.TempDir()
+	local, err := openAnalyzeStore(dir, "gerrit.example.com", "team/canvas-lms")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	if local.covers("2025-01-01", nil) {
+		t.Error("covers() = true for an empty store")
+	}
+
+	w, err := local.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	changes := analyzeTestChanges()
+	if err := w.Add(changes); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	meta := analyzeStoreMeta{Server: "gerrit.example.com", Repo: "team/canvas-lms", From: "2025-01-01", Synced: "2025-03-01T00:00:00Z", Options: []string{"DETAILED_ACCOUNTS"}}
+	if err := w.Commit(meta); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	w.Abort()
+
+	// Reopen to read it back from disk.
+	local, err = openAnalyzeStore(dir, "gerrit.example.com", "team/canvas-lms")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	if local.meta == nil || local.meta.Count != len(changes) {
+		t.Fatalf("meta = %+v, want Count %d", local.meta, len(changes))
+	}
+	if want := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC); !local.synced().Equal(want) {
+		t.Errorf("synced() = %v, want %v", local.synced(), want)
+	}
+	var got []gerrit.Change
+	if err := local.Each(func(c gerrit.Change) error {
+		got = append(got, c)
+		return nil
+	}); err != nil {
+		t.Fatalf("Each: %v", err)
+	}
+	if !reflect.DeepEqual(got, changes) {
+		t.Errorf("Each() returned %+v, want %+v", got, changes)
+	}
+
+	coverage := []struct {
+		start   string
+		options []string
+		want    bool
+	}{
+		{"2025-01-01", []string{"DETAILED_ACCOUNTS"}, true},
+		{"2025-02-15", nil, true},
+		{"2024-12-31", []string{"DETAILED_ACCOUNTS"}, false},
+		{"2025-01-01", []string{"DETAILED_ACCOUNTS", "MESSAGES"}, false},
+	}
+	for _, tt := range coverage {
+		if got := local.covers(tt.start, tt.options); got != tt.want {
+			t.Errorf("covers(%q, %v) = %v, want %v", tt.start, tt.options, got, tt.want)
+		}
+	}
+
+	other, err := openAnalyzeStore(dir, "gerrit.example.com", "")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	if other.meta != nil {
+		t.Error("the all-repositories store shares the team/canvas-lms store")
+	}
+}
+
+func TestAnalyzeStoreFailedCommit(t *testing.T) {
+	dir := t.TempDir()
+	local, err := openAnalyzeStore(dir, "gerrit.example.com", "")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	meta := analyzeStoreMeta{Server: "gerrit.example.com", From: "2025-01-01", Synced: "2025-03-01T00:00:00Z"}
+	w, err := local.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := w.Commit(meta); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// Make replacing the changes fail: a non-empty directory is in the way.
+	if err := os.Remove(local.path + ".jsonl"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(local.path+".jsonl", "blocker"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	w, err = local.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	w.Add(analyzeTestChanges())
+	meta.From = "2025-02-01"
+	if err := w.Commit(meta); err == nil {
+		t.Fatal("Commit succeeded with the store's path blocked")
+	}
+
+	// The old metadata must not outlive the changes it described.
+	local, err = openAnalyzeStore(dir, "gerrit.example.com", "")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	if local.meta != nil {
+		t.Errorf("meta = %+v after a failed commit, want none", local.meta)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".tmp-") {
+			t.Errorf("temporary file %s left behind", e.Name())
+		}
+	}
+}
+
+func TestInAnalyzeRange(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		updated string
+		want    bool
+	}{
+		{"2025-01-01 00:00:00.000000000", true},
+		{"2025-01-15 10:00:00.000000000", true},
+		{"2025-02-01 00:00:00.000000000", true},
+		{"2025-02-01 00:00:01.000000000", false},
+		{"2024-12-31 23:59:59.000000000", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := inAnalyzeRange(gerrit.Change{Updated: tt.updated}, start, end); got != tt.want {
+			t.Errorf("inAnalyzeRange(%q) = %v, want %v", tt.updated, got, tt.want)
+		}
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	got := mergeOptions([]string{"DETAILED_ACCOUNTS"}, []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "MESSAGES"})
+	want := []string{"DETAILED_ACCOUNTS", "DETAILED_LABELS", "MESSAGES"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeOptions() = %v, want %v", got, want)
+	}
+}
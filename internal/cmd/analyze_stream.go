@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+// ndjsonReport writes a --stream JSON report: one change per line as each
+// is collected, then a last line {"summary": {"analysis": ..., "metadata":
+// ...}} once the statistics are complete. Nothing is held back, so memory
+// stays flat however many changes are exported.
+type ndjsonReport struct {
+	enc *json.Encoder
+}
+
+func newNDJSONReport(w io.Writer) *ndjsonReport {
+	return &ndjsonReport{enc: json.NewEncoder(w)}
+}
+
+// Add writes changes, one line each.
+func (r *ndjsonReport) Add(changes []gerrit.Change) error {
+	for _, change := range changes {
+		if err := r.enc.Encode(change); err != nil {
+			return fmt.Errorf("failed to write change %d: %w", change.ChangeNumber(), err)
+		}
+	}
+	return nil
+}
+
+// WriteSummary writes the summary line, the same analysis and metadata the
+// JSON report has.
+func (r *ndjsonReport) WriteSummary(data AnalysisData) error {
+	analysis, metadata := jsonReportSummary(data)
+	summary := map[string]interface{}{
+		"summary": map[string]interface{}{
+			"analysis": analysis,
+			"metadata": metadata,
+		},
+	}
+	if err := r.enc.Encode(summary); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
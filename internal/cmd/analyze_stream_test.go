@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestNDJSONReport(t *testing.T) {
+	changes := analyzeTestChanges()
+	stats := newChangeStats()
+	var buf bytes.Buffer
+	report := newNDJSONReport(&buf)
+	for _, change := range changes {
+		stats.Add(change)
+		if err := report.Add([]gerrit.Change{change}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	data := AnalysisData{StartDate: "2025-01-01", EndDate: "2025-03-01", TotalChanges: len(changes), Stats: stats}
+	if err := report.WriteSummary(data); err != nil {
+		t.Fatalf("WriteSummary: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(changes)+1 {
+		t.Fatalf("got %d lines, want %d changes and a summary", len(lines), len(changes))
+	}
+	for i, line := range lines[:len(changes)] {
+		var change gerrit.Change
+		if err := json.Unmarshal([]byte(line), &change); err != nil {
+			t.Fatalf("line %d: %v", i+1, err)
+		}
+		if change.Number != changes[i].Number {
+			t.Errorf("line %d is change %d, want %d", i+1, change.Number, changes[i].Number)
+		}
+	}
+
+	var summary struct {
+		Summary struct {
+			Analysis map[string]json.RawMessage `json:"analysis"`
+			Metadata struct {
+				TotalChanges int `json:"total_changes"`
+			} `json:"metadata"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("summary line: %v", err)
+	}
+	if summary.Summary.Metadata.TotalChanges != len(changes) {
+		t.Errorf("summary total_changes = %d, want %d", summary.Summary.Metadata.TotalChanges, len(changes))
+	}
+	if _, ok := summary.Summary.Analysis["by_author"]; !ok {
+		t.Error("summary analysis is missing by_author")
+	}
+}
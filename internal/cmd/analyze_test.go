@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+func analyzeTestChanges() []gerrit.Change {
+	return []gerrit.Change{
+		{Number: 1, Project: "canvas-lms", Subject: "One", Status: "MERGED", Owner: gerrit.Account{Name: "Alice"}, Submitted: "2025-01-15 10:00:00.000000000"},
+		{Number: 2, Project: "outcomes", Subject: "Two", Status: "MERGED", Owner: gerrit.Account{Name: "Alice"}, Submitted: "2025-02-01 09:00:00.000000000"},
+		{Number: 3, Project: "canvas-lms", Subject: "Three", Status: "MERGED", Owner: gerrit.Account{Email: "bob@example.com"}, Updated: "2025-01-20T12:00:00Z"},
+		{Number: 4, Project: "canvas-lms", Subject: "Four", Status: "MERGED"},
+	}
+}
+
+func TestChangeStats(t *testing.T) {
+	stats := newChangeStats()
+	for _, change := range analyzeTestChanges() {
+		stats.Add(change)
+	}
+
+	wantRepos := []Statistic{{Name: "canvas-lms", Count: 3}, {Name: "outcomes", Count: 1}}
+	if got := stats.ByRepository(); !reflect.DeepEqual(got, wantRepos) {
+		t.Errorf("ByRepository() = %+v, want %+v", got, wantRepos)
+	}
+
+	wantAuthors := []Statistic{{Name: "Alice", Count: 2, RepoCount: 2}, {Name: "bob@example.com", Count: 1, RepoCount: 1}}
+	if got := stats.ByAuthor(); !reflect.DeepEqual(got, wantAuthors) {
+		t.Errorf("ByAuthor() = %+v, want %+v", got, wantAuthors)
+	}
+
+	wantTimeline := []Statistic{{Name: "2025-01", Count: 2}, {Name: "2025-02", Count: 1}}
+	if got := stats.Timeline(); !reflect.DeepEqual(got, wantTimeline) {
+		t.Errorf("Timeline() = %+v, want %+v", got, wantTimeline)
+	}
+}
+
+func TestChangeMonth(t *testing.T) {
+	tests := []struct {
+		ts   string
+		want string
+	}{
+		{"2025-01-15 10:00:00.000000000", "2025-01"},
+		{"2025-12-01T08:00:00Z", "2025-12"},
+		{"", ""},
+		{"garbage", ""},
+	}
+	for _, tt := range tests {
+		if got := changeMonth(tt.ts); got != tt.want {
+			t.Errorf("changeMonth(%q) = %q, want %q", tt.ts, got, tt.want)
+		}
+	}
+}
+
+func TestChangeStoreSpool(t *testing.T) {
+	for _, spool := range []bool{false, true} {
+		store, err := newChangeStore(spool)
+		if err != nil {
+			t.Fatalf("newChangeStore(%v): %v", spool, err)
+		}
+		changes := analyzeTestChanges()
+		if err := store.Add(changes[:2]); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := store.Add(changes[2:]); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		var got []gerrit.Change
+		if err := store.Each(func(c gerrit.Change) error {
+			got = append(got, c)
+			return nil
+		}); err != nil {
+			t.Fatalf("Each: %v", err)
+		}
+		if !reflect.DeepEqual(got, changes) {
+			t.Errorf("spool=%v: Each() returned %+v, want %+v", spool, got, changes)
+		}
+		if err := store.Close(); err != nil {
+			t.Errorf("spool=%v: Close: %v", spool, err)
+		}
+	}
+}
+
+// The streamed JSON report must match what encoding the whole report at once
+// would produce.
+func TestWriteJSONReport(t *testing.T) {
+	for _, changes := range [][]gerrit.Change{analyzeTestChanges(), nil} {
+		stats := newChangeStats()
+		store, _ := newChangeStore(true)
+		defer store.Close()
+		for _, change := range changes {
+			stats.Add(change)
+		}
+		store.Add(changes)
+
+		data := AnalysisData{StartDate: "2025-01-01", EndDate: "2025-12-31", GeneratedAt: "2025-12-31T00:00:00Z", TotalChanges: len(changes), Stats: stats, Changes: store}
+		var buf bytes.Buffer
+		if err := writeJSONReport(&buf, data); err != nil {
+			t.Fatalf("writeJSONReport: %v", err)
+		}
+
+		listed := changes
+		if listed == nil {
+			listed = []gerrit.Change{}
+		}
+		want, _ := json.MarshalIndent(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"start_date":    data.StartDate,
+				"end_date":      data.EndDate,
+				"repository":    data.Repository,
+				"generated_at":  data.GeneratedAt,
+				"total_changes": data.TotalChanges,
+			},
+			"changes": listed,
+			"analysis": map[string]interface{}{
+				"by_author":     stats.ByAuthor(),
+				"by_repository": stats.ByRepository(),
+				"timeline":      stats.Timeline(),
+				"latency": map[string]interface{}{
+					"overall":       stats.Latency(),
+					"by_repository": stats.LatencyByRepository(),
+					"by_author":     stats.LatencyByAuthor(),
+				},
+			},
+		}, "", "  ")
+		if got := strings.TrimSuffix(buf.String(), "\n"); got != string(want) {
+			t.Errorf("writeJSONReport() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
+
+func TestWriteCSVReport(t *testing.T) {
+	store, _ := newChangeStore(false)
+	store.Add(analyzeTestChanges()[:1])
+
+	var buf bytes.Buffer
+	if err := writeCSVReport(&buf, AnalysisData{Changes: store}); err != nil {
+		t.Fatalf("writeCSVReport: %v", err)
+	}
+	want := "change_number,project,subject,owner_name,owner_email,status,created,updated,submitted,first_review_hours,approval_hours,merge_hours\n" +
+		"1,canvas-lms,One,Alice,,MERGED,,,2025-01-15 10:00:00.000000000,,,\n"
+	if buf.String() != want {
+		t.Errorf("writeCSVReport() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestAnalyzeJSONStdout(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GERRIT_SERVER", "review.example.com")
+	t.Setenv("GERRIT_USER", "alice")
+
+	storeDir, err := analyzeStoreDir()
+	if err != nil {
+		t.Fatalf("analyzeStoreDir: %v", err)
+	}
+	local, err := openAnalyzeStore(storeDir, "review.example.com", "")
+	if err != nil {
+		t.Fatalf("openAnalyzeStore: %v", err)
+	}
+	w, err := local.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := w.Add(analyzeTestChanges()); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	meta := analyzeStoreMeta{Server: "review.example.com", From: "2025-01-01", Synced: "2025-03-01T00:00:00Z", Options: []string{"DETAILED_ACCOUNTS", "DETAILED_LABELS", "MESSAGES"}}
+	if err := w.Commit(meta); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	defer func() {
+		utils.SetLogOutput(os.Stdout)
+		analyzeFromCache, analyzeFormat = false, "markdown"
+		rootCmd.SetArgs(nil)
+	}()
+	rootCmd.SetArgs([]string{"analyze", "--from-cache", "--format", "json", "--start-date", "2025-01-01", "--end-date", "2025-03-01"})
+	out, err := captureStdout(t, rootCmd.Execute)
+	if err != nil {
+		t.Fatalf("gerry analyze: %v", err)
+	}
+
+	var report map[string]interface{}
+	if err := json.Unmarshal(out, &report); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, out)
+	}
+	if _, ok := report["metadata"]; !ok {
+		t.Errorf("report = %s, want a metadata section", out)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyFromDiff     string
+	applyFromWorktree bool
+	applyMessage      string
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <change-id>",
+	Short: "Upload local modifications as a new patchset of a change",
+	Long: `Upload local modifications as a new patchset of an existing change — the
+"I'll just fix it myself" reviewer move. The change's current patchset is
+amended with your modifications and pushed to refs/for/<branch>, keeping the
+original commit message and Change-Id.
+
+--from-diff applies a patch file (or "-" for stdin) on top of the current
+patchset in a temporary worktree, so your checkout is left untouched.
+
+--from-worktree amends the commit checked out in the current directory with
+all tracked and untracked modifications. HEAD must be the change's current
+patchset (e.g. after 'gerry fetch <change-id>').
+
+Examples:
+  gerry apply 12345 --from-diff fix.patch
+  git diff | gerry apply 12345 --from-diff -
+  gerry fetch 12345 && vim foo.go && gerry apply 12345 --from-worktree -m "Fix typo"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyFromDiff, "from-diff", "", "Patch file to apply, or \"-\" for stdin")
+	applyCmd.Flags().BoolVar(&applyFromWorktree, "from-worktree", false, "Use modifications in the current checkout")
+	applyCmd.Flags().StringVarP(&applyMessage, "message", "m", "", "Patchset description shown in Gerrit")
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	if (applyFromDiff == "") == !applyFromWorktree {
+		return fmt.Errorf("exactly one of --from-diff or --from-worktree is required")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if !isGitRepository() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	change, err := getChangeForFetch(cfg, changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get change details: %w", err)
+	}
+	if change.Branch == "" {
+		return fmt.Errorf("could not determine target branch for change %s", changeID)
+	}
+
+	if applyFromWorktree {
+		return applyFromCheckout(cfg, changeID, change)
+	}
+	return applyPatchFile(cfg, changeID, change, applyFromDiff)
+}
+
+// applyFromCheckout amends HEAD with the working tree and pushes it.
+func applyFromCheckout(cfg *config.Config, changeID string, change *gerrit.Change) error {
+	head, err := gitOutput("", "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if current := currentRevisionSHA(change); current != "" && head != current {
+		return fmt.Errorf("HEAD (%s) is not the current patchset of change %s (%s); run 'gerry fetch %s' first",
+			shortSHA(head), changeID, shortSHA(current), changeID)
+	}
+	if isWorkingDirectoryClean() {
+		return fmt.Errorf("no local modifications to upload")
+	}
+
+	fmt.Print("Amending commit... ")
+	if err := gitRun("", "add", "-A"); err != nil {
+		fmt.Println(color.RedString("FAILED"))
+		return fmt.Errorf("git add failed: %w", err)
+	}
+	if err := gitRun("", "commit", "--amend", "--no-edit", "--quiet"); err != nil {
+		fmt.Println(color.RedString("FAILED"))
+		return fmt.Errorf("git commit --amend failed: %w", err)
+	}
+	fmt.Println(color.GreenString("SUCCESS"))
+
+	return pushNewPatchset(cfg, "", changeID, change)
+}
+
+// applyPatchFile applies a patch on top of the change's current patchset in
+// a temporary worktree and pushes the result.
+func applyPatchFile(cfg *config.Config, changeID string, change *gerrit.Change, source string) error {
+	var patch []byte
+	var err error
+	if source == "-" {
+		patch, err = io.ReadAll(os.Stdin)
+	} else {
+		patch, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read patch: %w", err)
+	}
+	if len(strings.TrimSpace(string(patch))) == 0 {
+		return fmt.Errorf("patch is empty")
+	}
+
+	patchsetNum := getCurrentPatchsetNumber(change)
+	if patchsetNum == "" {
+		return fmt.Errorf("could not determine current patchset")
+	}
+	refsPath := fmt.Sprintf("refs/changes/%s/%s/%s", getChangePrefix(changeID), changeID, patchsetNum)
+
+	fmt.Printf("Fetching change %s (patchset %s)... ", utils.BoldCyan(changeID), utils.BoldYellow(patchsetNum))
+	remote := buildProjectRemoteURL(cfg, change.Project)
+	if err := gitRunEnv("", gitRemoteEnv(cfg, "", remote), "fetch", "--quiet", remote, refsPath); err != nil {
+		fmt.Println(color.RedString("FAILED"))
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	fmt.Println(color.GreenString("SUCCESS"))
+
+	tmpDir, err := os.MkdirTemp("", "gerry-apply-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	worktree := filepath.Join(tmpDir, "worktree")
+	if err := gitRun("", "worktree", "add", "--quiet", "--detach", worktree, "FETCH_HEAD"); err != nil {
+		return fmt.Errorf("failed to create temporary worktree: %w", err)
+	}
+	defer gitRun("", "worktree", "remove", "--force", worktree)
+
+	patchFile := filepath.Join(tmpDir, "local.patch")
+	if err := os.WriteFile(patchFile, patch, 0600); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+
+	fmt.Print("Applying patch... ")
+	if err := gitRun(worktree, "apply", "--index", patchFile); err != nil {
+		fmt.Println(color.RedString("FAILED"))
+		return fmt.Errorf("patch does not apply to patchset %s: %w", patchsetNum, err)
+	}
+	if err := gitRun(worktree, "commit", "--amend", "--no-edit", "--quiet"); err != nil {
+		fmt.Println(color.RedString("FAILED"))
+		return fmt.Errorf("git commit --amend failed: %w", err)
+	}
+	fmt.Println(color.GreenString("SUCCESS"))
+
+	return pushNewPatchset(cfg, worktree, changeID, change)
+}
+
+// pushNewPatchset pushes HEAD of dir to refs/for/<branch> of the change's project.
+func pushNewPatchset(cfg *config.Config, dir, changeID string, change *gerrit.Change) error {
+	args := []string{"push"}
+	if applyMessage != "" {
+		args = append(args, "-o", "message="+applyMessage)
+	}
+	remote := buildProjectRemoteURL(cfg, change.Project)
+	args = append(args, remote, "HEAD:refs/for/"+change.Branch)
+
+	fmt.Printf("Pushing new patchset to %s...\n", utils.BoldCyan("refs/for/"+change.Branch))
+	if err := gitRunEnv(dir, gitRemoteEnv(cfg, dir, remote), args...); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+
+	fmt.Printf("%s Uploaded new patchset for change %s\n", color.GreenString("✓"), utils.BoldCyan(changeID))
+	return nil
+}
+
+// currentRevisionSHA returns the commit SHA of the change's current patchset.
+func currentRevisionSHA(change *gerrit.Change) string {
+	if change.CurrentRevision != "" {
+		return change.CurrentRevision
+	}
+	if change.CurrentPatchSet != nil {
+		return change.CurrentPatchSet.Revision
+	}
+	return ""
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// buildProjectRemoteURL returns the SSH remote URL for a specific project,
+// or the HTTP(S) one when fetching over HTTP, falling back to the configured
+// default project.
+func buildProjectRemoteURL(cfg *config.Config, project string) string {
+	if project == "" {
+		return buildRemoteURL(cfg)
+	}
+	if cfg.FetchesOverHTTP() {
+		return cfg.GetRESTURL(project)
+	}
+	return fmt.Sprintf("ssh://%s@%s:%d/%s", cfg.User, cfg.Server, cfg.Port, project)
+}
+
+// gitRun runs git in dir (or the current directory when dir is empty),
+// streaming its output to the terminal.
+func gitRun(dir string, args ...string) error {
+	return gitRunEnv(dir, nil, args...)
+}
+
+// gitRunEnv is gitRun with env as git's environment, or gerry's own when
+// env is nil (see gitRemoteEnv).
+func gitRunEnv(dir string, env []string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// gitOutput runs git in dir and returns its trimmed stdout.
+func gitOutput(dir string, args ...string) (string, error) {
+	return gitOutputEnv(dir, nil, args...)
+}
+
+// gitOutputEnv is gitOutput with env as git's environment, or gerry's own
+// when env is nil.
+func gitOutputEnv(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attentionAddReason    string
+	attentionRemoveReason string
+)
+
+var attentionCmd = &cobra.Command{
+	Use:   "attention",
+	Short: "Show and change who needs to act on a change",
+	Long: `Show and change a change's attention set: the users Gerrit expects to act
+next. gerry list, gerry search, and gerry team mark changes whose attention
+set includes you with a yellow ● in the @ column (a gray ○ means only others
+are in it).
+
+Examples:
+  gerry attention list 12345
+  gerry attention add 12345 alice -r "Needs your API review"
+  gerry attention remove 12345          # remove yourself
+  gerry attention remove 12345 bob`,
+}
+
+var attentionListCmd = &cobra.Command{
+	Use:   "list <change-id>",
+	Short: "List the attention set of a change",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAttentionList,
+}
+
+var attentionAddCmd = &cobra.Command{
+	Use:   "add <change-id> [user...]",
+	Short: "Add users (default: yourself) to the attention set",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runAttentionAdd,
+}
+
+var attentionRemoveCmd = &cobra.Command{
+	Use:   "remove <change-id> [user...]",
+	Short: "Remove users (default: yourself) from the attention set",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runAttentionRemove,
+}
+
+func init() {
+	attentionAddCmd.Flags().StringVarP(&attentionAddReason, "reason", "r", "Added with gerry", "Reason shown in the web UI")
+	attentionRemoveCmd.Flags().StringVarP(&attentionRemoveReason, "reason", "r", "Removed with gerry", "Reason shown in the web UI")
+
+	attentionCmd.AddCommand(attentionListCmd)
+	attentionCmd.AddCommand(attentionAddCmd)
+	attentionCmd.AddCommand(attentionRemoveCmd)
+}
+
+func runAttentionList(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	set, err := client.GetAttentionSet(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get attention set: %w", err)
+	}
+	if len(set) == 0 {
+		fmt.Printf("Nobody is in the attention set of change %s.\n", changeID)
+		return nil
+	}
+
+	sortAttentionSet(set)
+	headers := []string{"User", "Since", "Reason"}
+	var rows [][]string
+	for _, a := range set {
+		rows = append(rows, []string{
+			a.Account.DisplayName(),
+			utils.FormatTimeAgo(a.LastUpdate),
+			utils.TruncateString(a.Reason, 60),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	return nil
+}
+
+func runAttentionAdd(cmd *cobra.Command, args []string) error {
+	return runAttentionUpdate(args, true)
+}
+
+func runAttentionRemove(cmd *cobra.Command, args []string) error {
+	return runAttentionUpdate(args, false)
+}
+
+func runAttentionUpdate(args []string, add bool) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	users := args[1:]
+	if len(users) == 0 {
+		users = []string{cfg.User}
+	}
+
+	for _, user := range users {
+		if add {
+			err = client.AddToAttentionSet(changeID, user, attentionAddReason)
+		} else {
+			err = client.RemoveFromAttentionSet(changeID, user, attentionRemoveReason)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update attention set for %s: %w", user, err)
+		}
+		if add {
+			fmt.Printf("%s Added %s to the attention set of %s\n", utils.Green("✓"), user, changeID)
+		} else {
+			fmt.Printf("%s Removed %s from the attention set of %s\n", utils.Green("✓"), user, changeID)
+		}
+	}
+	return nil
+}
+
+// sortAttentionSet orders the set by how long each user has been in it,
+// longest first.
+func sortAttentionSet(set []gerrit.AttentionSetInfo) {
+	sort.SliceStable(set, func(i, j int) bool {
+		if set[i].LastUpdate != set[j].LastUpdate {
+			return set[i].LastUpdate < set[j].LastUpdate
+		}
+		return set[i].Account.DisplayName() < set[j].Account.DisplayName()
+	})
+}
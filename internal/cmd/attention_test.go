@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestInAttentionSet(t *testing.T) {
+	change := gerrit.Change{AttentionSet: map[string]gerrit.AttentionSetInfo{
+		"1000": {Account: gerrit.Account{AccountID: 1000, Username: "alice", Email: "alice@example.com"}},
+		"1001": {Account: gerrit.Account{AccountID: 1001, Name: "Bob", Email: "Bob@Example.com"}},
+	}}
+
+	tests := []struct {
+		user string
+		want bool
+	}{
+		{"alice", true},
+		{"bob@example.com", true},
+		{"carol", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := inAttentionSet(change, tt.user); got != tt.want {
+			t.Errorf("inAttentionSet(%q) = %v, want %v", tt.user, got, tt.want)
+		}
+	}
+
+	if got := attentionIndicator(gerrit.Change{}, "alice"); got != "" {
+		t.Errorf("attentionIndicator(empty set) = %q, want empty", got)
+	}
+}
+
+func TestSortAttentionSet(t *testing.T) {
+	set := []gerrit.AttentionSetInfo{
+		{Account: gerrit.Account{Name: "Carol"}, LastUpdate: "2025-03-02 10:00:00.000000000"},
+		{Account: gerrit.Account{Name: "Bob"}, LastUpdate: "2025-03-01 10:00:00.000000000"},
+		{Account: gerrit.Account{Name: "Alice"}, LastUpdate: "2025-03-02 10:00:00.000000000"},
+	}
+	sortAttentionSet(set)
+
+	var got []string
+	for _, a := range set {
+		got = append(got, a.Account.Name)
+	}
+	if want := []string{"Bob", "Alice", "Carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortAttentionSet() order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditProject  string
+	auditSince    string
+	auditUntil    string
+	auditRules    []string
+	auditLabel    string
+	auditMinValue int
+	auditFormat   string
+	auditOutput   string
+	auditMax      int
+)
+
+// Audit rules a merged change can break.
+const (
+	auditSelfApproved = "self-approved"
+	auditNoApproval   = "no-approval"
+	auditUnresolved   = "unresolved"
+)
+
+var auditRuleNames = []string{auditSelfApproved, auditNoApproval, auditUnresolved}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List merged changes that broke review policy",
+	Long: `Check the changes merged in a date range against review policy and list the
+ones that broke it. Rules:
+
+  self-approved  the only approving vote came from the change owner
+  no-approval    merged without an approving vote
+  unresolved     merged with unresolved comment threads
+
+An approving vote is --label at --min-value or above (default Code-Review+2).
+Select rules with --rule; all are checked by default.
+
+Examples:
+  gerry audit --project canvas-lms --since 2025-01-01
+  gerry audit --since 2025-01-01 --until 2025-04-01 --format csv -o q1-audit.csv
+  gerry audit --since 2025-01-01 --rule self-approved --rule no-approval --format json`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringVarP(&auditProject, "project", "p", "", "Limit to a project")
+	auditCmd.Flags().StringVar(&auditSince, "since", "", "Audit changes merged on or after this date (YYYY-MM-DD, required)")
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", "Audit changes merged before this date (YYYY-MM-DD, default: now)")
+	auditCmd.Flags().StringArrayVar(&auditRules, "rule", nil, "Rule to check: self-approved, no-approval, unresolved (repeatable, default: all)")
+	auditCmd.Flags().StringVar(&auditLabel, "label", "Code-Review", "Label that approves a change")
+	auditCmd.Flags().IntVar(&auditMinValue, "min-value", 2, "Lowest vote on --label that counts as approval")
+	auditCmd.Flags().StringVar(&auditFormat, "format", "table", "Output format: table, csv, json")
+	auditCmd.Flags().StringVarP(&auditOutput, "output-file", "o", "", "Write the report to a file instead of stdout")
+	auditCmd.Flags().IntVar(&auditMax, "max-changes", 10000, "Maximum number of merged changes to check")
+	auditCmd.MarkFlagRequired("since")
+}
+
+// auditFinding is a merged change that broke at least one rule.
+type auditFinding struct {
+	Change     int      `json:"change"`
+	Project    string   `json:"project"`
+	Branch     string   `json:"branch"`
+	Subject    string   `json:"subject"`
+	Owner      string   `json:"owner"`
+	Submitted  string   `json:"submitted"`
+	Rules      []string `json:"rules"`
+	Approvers  []string `json:"approvers"`
+	Unresolved int      `json:"unresolved_threads"`
+	URL        string   `json:"url"`
+}
+
+// auditPolicy is the set of rules a change is checked against.
+type auditPolicy struct {
+	rules    map[string]bool
+	label    string
+	minValue int
+}
+
+// labelVote is one account's vote on a label.
+type labelVote struct {
+	Account gerrit.Account
+	Value   int
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	if err := rejectStructuredOutput(cmd); err != nil {
+		return err
+	}
+	if auditFormat != "table" && auditFormat != "csv" && auditFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, csv, json)", auditFormat)
+	}
+	if _, err := time.Parse("2006-01-02", auditSince); err != nil {
+		return fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD)", auditSince)
+	}
+	if auditUntil != "" {
+		if _, err := time.Parse("2006-01-02", auditUntil); err != nil {
+			return fmt.Errorf("invalid --until date %q (expected YYYY-MM-DD)", auditUntil)
+		}
+	}
+	policy, err := newAuditPolicy(auditRules, auditLabel, auditMinValue)
+	if err != nil {
+		return err
+	}
+	if auditFormat != "table" && auditOutput == "" {
+		utils.DisableProgress()
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	var findings []auditFinding
+	checked, err := paginateChanges(client, auditQuery(), []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS"}, 500, auditMax, func(page []gerrit.Change) error {
+		for _, change := range page {
+			if f, ok := policy.check(change); ok {
+				f.URL = changeURL(cfg, change)
+				findings = append(findings, f)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch merged changes: %w", err)
+	}
+	if checked >= auditMax {
+		utils.Warnf("Stopped after %d changes; raise --max-changes to check them all", auditMax)
+	}
+
+	w := io.Writer(os.Stdout)
+	if auditOutput != "" {
+		file, err := os.Create(auditOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	switch auditFormat {
+	case "json":
+		if findings == nil {
+			findings = []auditFinding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "csv":
+		if err := writeAuditCSV(w, findings); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	default:
+		if len(findings) == 0 {
+			utils.Infof("No policy violations in %d merged change(s)", checked)
+			return nil
+		}
+		displayAuditFindings(w, findings)
+		fmt.Fprintf(w, "\n%d of %d merged change(s) broke policy\n", len(findings), checked)
+	}
+
+	if auditOutput != "" {
+		fmt.Printf("%s %d finding(s) in %d merged change(s) saved to: %s\n", utils.Green("✓"), len(findings), checked, auditOutput)
+	}
+	return nil
+}
+
+func auditQuery() string {
+	parts := []string{"status:merged", "mergedafter:" + auditSince}
+	if auditUntil != "" {
+		parts = append(parts, "mergedbefore:"+auditUntil)
+	}
+	if auditProject != "" {
+		parts = append(parts, "project:"+auditProject)
+	}
+	return strings.Join(parts, " ")
+}
+
+func newAuditPolicy(rules []string, label string, minValue int) (auditPolicy, error) {
+	if len(rules) == 0 {
+		rules = auditRuleNames
+	}
+	p := auditPolicy{rules: make(map[string]bool), label: label, minValue: minValue}
+	for _, rule := range rules {
+		known := false
+		for _, name := range auditRuleNames {
+			if rule == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return auditPolicy{}, fmt.Errorf("unknown rule: %s (supported: %s)", rule, strings.Join(auditRuleNames, ", "))
+		}
+		p.rules[rule] = true
+	}
+	return p, nil
+}
+
+// check returns the change's finding, if it broke any rule.
+func (p auditPolicy) check(change gerrit.Change) (auditFinding, bool) {
+	var approvers []string
+	ownerApproved, othersApproved := false, false
+	for _, vote := range labelVoteList(change, p.label) {
+		if vote.Value < p.minValue {
+			continue
+		}
+		approvers = append(approvers, vote.Account.DisplayName())
+		if isSameAccount(vote.Account, change.Owner) {
+			ownerApproved = true
+		} else {
+			othersApproved = true
+		}
+	}
+
+	var broken []string
+	if p.rules[auditSelfApproved] && ownerApproved && !othersApproved {
+		broken = append(broken, auditSelfApproved)
+	}
+	if p.rules[auditNoApproval] && len(approvers) == 0 {
+		broken = append(broken, auditNoApproval)
+	}
+	if p.rules[auditUnresolved] && change.UnresolvedCommentCount > 0 {
+		broken = append(broken, auditUnresolved)
+	}
+	if len(broken) == 0 {
+		return auditFinding{}, false
+	}
+
+	if approvers == nil {
+		approvers = []string{}
+	}
+	return auditFinding{
+		Change:     change.ChangeNumber(),
+		Project:    change.Project,
+		Branch:     change.Branch,
+		Subject:    change.Subject,
+		Owner:      change.Owner.DisplayName(),
+		Submitted:  change.Submitted,
+		Rules:      broken,
+		Approvers:  approvers,
+		Unresolved: change.UnresolvedCommentCount,
+	}, true
+}
+
+// labelVoteList returns every vote on a label with the voter, from the
+// "all" list DETAILED_LABELS returns.
+func labelVoteList(change gerrit.Change, labelName string) []labelVote {
+	labelData, ok := change.Labels[labelName].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	all, _ := labelData["all"].([]interface{})
+
+	var votes []labelVote
+	for _, entry := range all {
+		voteMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := voteMap["value"].(float64)
+		if !ok {
+			continue
+		}
+		var account gerrit.Account
+		if data, err := json.Marshal(voteMap); err == nil {
+			json.Unmarshal(data, &account)
+		}
+		votes = append(votes, labelVote{Account: account, Value: int(value)})
+	}
+	return votes
+}
+
+func writeAuditCSV(w io.Writer, findings []auditFinding) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"change_number", "project", "branch", "subject", "owner", "submitted", "rules", "approvers", "unresolved_threads", "url"})
+	for _, f := range findings {
+		cw.Write([]string{
+			strconv.Itoa(f.Change),
+			f.Project,
+			f.Branch,
+			f.Subject,
+			f.Owner,
+			f.Submitted,
+			strings.Join(f.Rules, ";"),
+			strings.Join(f.Approvers, ";"),
+			strconv.Itoa(f.Unresolved),
+			f.URL,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func displayAuditFindings(w io.Writer, findings []auditFinding) {
+	headers := []string{"Change", "Project", "Owner", "Merged", "Rules", "Subject"}
+	var rows [][]string
+	for _, f := range findings {
+		merged := f.Submitted
+		if t, err := utils.ParseGerritTime(f.Submitted); err == nil {
+			merged = t.Local().Format("2006-01-02")
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(strconv.Itoa(f.Change)),
+			f.Project,
+			f.Owner,
+			merged,
+			utils.Red(strings.Join(f.Rules, ", ")),
+			utils.TruncateString(f.Subject, 50),
+		})
+	}
+	fmt.Fprint(w, utils.FormatTable(headers, rows, 2))
+}
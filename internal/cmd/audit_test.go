@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func votesBy(votes ...labelVote) map[string]interface{} {
+	all := make([]interface{}, 0, len(votes))
+	for _, v := range votes {
+		all = append(all, map[string]interface{}{
+			"_account_id": float64(v.Account.AccountID),
+			"name":        v.Account.Name,
+			"value":       float64(v.Value),
+		})
+	}
+	return map[string]interface{}{"all": all}
+}
+
+func TestAuditPolicyCheck(t *testing.T) {
+	owner := gerrit.Account{AccountID: 1, Name: "Alice"}
+	bob := gerrit.Account{AccountID: 2, Name: "Bob"}
+
+	tests := []struct {
+		name      string
+		rules     []string
+		change    gerrit.Change
+		wantRules []string
+	}{
+		{
+			name:   "approved by a reviewer",
+			change: gerrit.Change{Owner: owner, Labels: map[string]interface{}{"Code-Review": votesBy(labelVote{bob, 2})}},
+		},
+		{
+			name:      "approved only by the owner",
+			change:    gerrit.Change{Owner: owner, Labels: map[string]interface{}{"Code-Review": votesBy(labelVote{owner, 2}, labelVote{bob, 1})}},
+			wantRules: []string{auditSelfApproved},
+		},
+		{
+			name:   "owner and reviewer both approved",
+			change: gerrit.Change{Owner: owner, Labels: map[string]interface{}{"Code-Review": votesBy(labelVote{owner, 2}, labelVote{bob, 2})}},
+		},
+		{
+			name:      "no approval and unresolved threads",
+			change:    gerrit.Change{Owner: owner, UnresolvedCommentCount: 3, Labels: map[string]interface{}{"Code-Review": votesBy(labelVote{bob, 1})}},
+			wantRules: []string{auditNoApproval, auditUnresolved},
+		},
+		{
+			name:      "only selected rules are checked",
+			rules:     []string{auditUnresolved},
+			change:    gerrit.Change{Owner: owner, UnresolvedCommentCount: 1},
+			wantRules: []string{auditUnresolved},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newAuditPolicy(tt.rules, "Code-Review", 2)
+			if err != nil {
+				t.Fatalf("newAuditPolicy() error = %v", err)
+			}
+			f, ok := policy.check(tt.change)
+			if ok != (tt.wantRules != nil) {
+				t.Fatalf("check() ok = %v, finding %+v", ok, f)
+			}
+			if ok && !reflect.DeepEqual(f.Rules, tt.wantRules) {
+				t.Errorf("check() rules = %v, want %v", f.Rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestNewAuditPolicyRejectsUnknownRule(t *testing.T) {
+	if _, err := newAuditPolicy([]string{"no-tests"}, "Code-Review", 2); err == nil {
+		t.Error("newAuditPolicy() accepted an unknown rule")
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/fatih/color"
+)
+
+// autostash stashes uncommitted changes to tracked files, as 'git rebase
+// --autostash' does, and reports whether there were any. Untracked files
+// are left alone.
+func autostash() (bool, error) {
+	status, err := gitOutput("", "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, fmt.Errorf("failed to check for local changes: %s", gitErrorMessage(err))
+	}
+	if status == "" {
+		return false, nil
+	}
+	if _, err := gitOutput("", "stash", "push", "--quiet", "--message", "gerry autostash"); err != nil {
+		return false, fmt.Errorf("failed to stash local changes: %s", gitErrorMessage(err))
+	}
+	fmt.Printf("%s Stashed local changes\n", color.GreenString("✓"))
+	return true, nil
+}
+
+// popAutostash re-applies the changes autostash stashed. If that conflicts,
+// git keeps them in the stash and the user resolves it.
+func popAutostash() {
+	// git explains any conflicts itself.
+	if err := gitRun("", "stash", "pop", "--quiet"); err != nil {
+		utils.Warnf("Re-applying your stashed changes failed; they are kept in the stash. Resolve any conflicts and run 'git stash drop', or 'git stash pop' later")
+		return
+	}
+	fmt.Printf("%s Re-applied stashed changes\n", color.GreenString("✓"))
+}
+
+// keepAutostash tells the user their stashed changes stay stashed because
+// the operation stopped halfway.
+func keepAutostash() {
+	utils.Warnf("Your local changes are stashed; run 'git stash pop' once you are done")
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autosubmitInterval time.Duration
+	autosubmitTimeout  time.Duration
+	autosubmitNoNotify bool
+)
+
+var autosubmitCmd = &cobra.Command{
+	Use:   "autosubmit <change-id>",
+	Short: "Submit a change as soon as it is ready",
+	Long: `Poll a change until it is ready to submit (Verified +1, Code-Review +2, no
+unresolved comment threads, and submittable according to the server), then
+submit it and send a desktop notification. A poor man's submit queue for
+servers without one.
+
+Stops with an error if the change is abandoned, the submit fails, or the
+timeout expires; stops successfully if someone else merges it first.
+
+Examples:
+  gerry autosubmit 12345
+  gerry autosubmit 12345 --interval 5m --timeout 8h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAutosubmit,
+}
+
+func init() {
+	autosubmitCmd.Flags().DurationVar(&autosubmitInterval, "interval", time.Minute, "Polling interval")
+	autosubmitCmd.Flags().DurationVar(&autosubmitTimeout, "timeout", 24*time.Hour, "Give up after this long (0 waits forever)")
+	autosubmitCmd.Flags().BoolVar(&autosubmitNoNotify, "no-notify", false, "Don't send a desktop notification")
+}
+
+func runAutosubmit(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	if autosubmitInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+	// Each poll must see the server's current state, and Ctrl+C stops
+	// waiting.
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+	client = client.WithoutCache().WithContext(ctx)
+
+	var deadline time.Time
+	if autosubmitTimeout > 0 {
+		deadline = time.Now().Add(autosubmitTimeout)
+	}
+
+	spinner := utils.NewSpinner(fmt.Sprintf("Waiting for change %s to become submittable...", changeID))
+	var lastBlockers string
+	for {
+		change, err := getAutosubmitChange(client, changeID)
+		if err != nil {
+			utils.Debugf("Failed to poll change: %v", err)
+		} else {
+			switch change.Status {
+			case "MERGED":
+				spinner.Stop()
+				fmt.Printf("%s Change %s was merged by someone else\n", utils.Green("✓"), changeID)
+				return nil
+			case "ABANDONED":
+				spinner.Stop()
+				autosubmitNotify(fmt.Sprintf("Change %s was abandoned", changeID))
+				return fmt.Errorf("change %s was abandoned; stopped waiting", changeID)
+			}
+
+			blockers := autosubmitBlockers(change)
+			if len(blockers) == 0 {
+				spinner.Stop()
+				return autosubmitChange(client, change)
+			}
+			if joined := strings.Join(blockers, ", "); joined != lastBlockers {
+				spinner.SetMessage(fmt.Sprintf("Change %s waiting on: %s", changeID, joined))
+				utils.Debugf("Change %s blocked: %s", changeID, joined)
+				lastBlockers = joined
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().Add(autosubmitInterval).After(deadline) {
+			spinner.Stop()
+			return fmt.Errorf("timed out after %s; still waiting on: %s", autosubmitTimeout, lastBlockers)
+		}
+		if sleepContext(ctx, autosubmitInterval) != nil {
+			spinner.Stop()
+			return fmt.Errorf("interrupted; change %s was not submitted", changeID)
+		}
+	}
+}
+
+func getAutosubmitChange(client *gerrit.RESTClient, changeID string) (gerrit.Change, error) {
+	changes, err := client.ListChangesWithOptions(url.QueryEscape("change:"+changeID), 1, "SUBMITTABLE", "DETAILED_LABELS")
+	if err != nil {
+		return gerrit.Change{}, err
+	}
+	if len(changes) == 0 {
+		return gerrit.Change{}, fmt.Errorf("change %s not found", changeID)
+	}
+	return changes[0], nil
+}
+
+// autosubmitBlockers lists what still stands between an open change and an
+// automatic submit; it is empty when the change can be submitted now. Labels
+// the change doesn't have are not required.
+func autosubmitBlockers(change gerrit.Change) []string {
+	var blockers []string
+	if change.WorkInProgress {
+		blockers = append(blockers, "work in progress")
+	}
+	if score, present := getLabelScore(change, "Verified"); present && score < 1 {
+		blockers = append(blockers, "Verified +1")
+	}
+	if score, present := getLabelScore(change, "Code-Review"); present && score < 2 {
+		blockers = append(blockers, "Code-Review +2")
+	}
+	if n := change.UnresolvedCommentCount; n > 0 {
+		blockers = append(blockers, fmt.Sprintf("%d unresolved thread(s)", n))
+	}
+	if len(blockers) == 0 && !change.Submittable {
+		blockers = submitBlockers(change)
+	}
+	return blockers
+}
+
+func autosubmitChange(client *gerrit.RESTClient, change gerrit.Change) error {
+	number := change.ChangeNumberStr()
+	if _, err := client.SubmitChange(number); err != nil {
+		autosubmitNotify(fmt.Sprintf("Submitting change %s failed", number))
+		return fmt.Errorf("failed to submit: %w", err)
+	}
+	fmt.Printf("%s Submitted change %s: %s\n", utils.Green("✓"), number, change.Subject)
+	autosubmitNotify(fmt.Sprintf("Submitted change %s: %s", number, change.Subject))
+	return nil
+}
+
+func autosubmitNotify(message string) {
+	if autosubmitNoNotify {
+		return
+	}
+	if err := utils.Notify("gerry autosubmit", message); err != nil {
+		utils.Debugf("Notification failed: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestAutosubmitBlockers(t *testing.T) {
+	tests := []struct {
+		name   string
+		change gerrit.Change
+		want   []string
+	}{
+		{
+			name: "ready",
+			change: gerrit.Change{Status: "NEW", Submittable: true, Labels: map[string]interface{}{
+				"Verified":    labelVotes(1),
+				"Code-Review": labelVotes(2, 1),
+			}},
+			want: nil,
+		},
+		{
+			name: "missing votes and open threads",
+			change: gerrit.Change{Status: "NEW", Submittable: true, UnresolvedCommentCount: 2, Labels: map[string]interface{}{
+				"Verified":    labelVotes(),
+				"Code-Review": labelVotes(1),
+			}},
+			want: []string{"Verified +1", "Code-Review +2", "2 unresolved thread(s)"},
+		},
+		{
+			name:   "labels absent on server",
+			change: gerrit.Change{Status: "NEW", Submittable: true},
+			want:   nil,
+		},
+		{
+			name: "votes in but server says no",
+			change: gerrit.Change{Status: "NEW", Labels: map[string]interface{}{
+				"Verified":    labelVotes(1),
+				"Code-Review": labelVotes(2),
+			}},
+			want: []string{"submit requirements not met"},
+		},
+		{
+			name:   "wip",
+			change: gerrit.Change{Status: "NEW", WorkInProgress: true, Submittable: true},
+			want:   []string{"work in progress"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autosubmitBlockers(tt.change); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("autosubmitBlockers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
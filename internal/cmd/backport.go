@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backportBranches       []string
+	backportTopic          string
+	backportAllowConflicts bool
+	backportNoReviewers    bool
+)
+
+var backportCmd = &cobra.Command{
+	Use:   "backport <change-id>",
+	Short: "Cherry-pick a change onto several branches on the server",
+	Long: `Cherry-pick a change onto each of the given branches on the server, creating
+one new change per branch. The new changes, and the original if it has no
+topic, share a topic so they can be found (and, on servers with
+change.submitWholeTopic, submitted) together. Reviewers and CCs of the original
+are added to each backport.
+
+The topic defaults to the original change's topic, or backport-<number>.
+Branches the change doesn't apply to cleanly are reported as conflicts; pass
+--allow-conflicts to create those changes anyway, with conflict markers.
+
+Examples:
+  gerry backport 12345 --to release/3.1,release/3.2
+  gerry backport 12345 --to release/3.1 --topic fix-grading-rounding
+  gerry backport 12345 --to release/3.1 --allow-conflicts`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBackport,
+}
+
+func init() {
+	backportCmd.Flags().StringSliceVar(&backportBranches, "to", nil, "Branches to backport to (comma-separated or repeatable)")
+	backportCmd.Flags().StringVar(&backportTopic, "topic", "", "Topic linking the backports (default: the change's topic, or backport-<number>)")
+	backportCmd.Flags().BoolVar(&backportAllowConflicts, "allow-conflicts", false, "Create backports that don't apply cleanly, with conflict markers")
+	backportCmd.Flags().BoolVar(&backportNoReviewers, "no-reviewers", false, "Don't copy reviewers and CCs to the backports")
+	backportCmd.MarkFlagRequired("to")
+}
+
+// backportResult is the outcome of backporting to one branch.
+type backportResult struct {
+	Branch    string
+	Change    int
+	Result    string
+	Reviewers int
+	Failed    bool
+}
+
+func runBackport(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	change, err := client.GetChangeWithOptions(changeID, "CURRENT_REVISION", "DETAILED_LABELS", "DETAILED_ACCOUNTS")
+	if err != nil {
+		return fmt.Errorf("failed to get change details: %w", err)
+	}
+
+	topic := backportTopic
+	if topic == "" {
+		topic = defaultBackportTopic(*change)
+	}
+	if change.Topic == "" {
+		if err := client.SetTopic(changeID, topic); err != nil {
+			utils.Warnf("Failed to set topic %s on %s: %v", topic, changeID, err)
+		}
+	} else if change.Topic != topic {
+		utils.Warnf("Change %s keeps its topic %s; the backports use %s", changeID, change.Topic, topic)
+	}
+
+	reviewers := backportReviewers(*change)
+
+	fmt.Printf("Backporting %s %s (topic %s)\n", utils.BoldCyan(change.ChangeNumberStr()), change.Subject, utils.Cyan(topic))
+	var results []backportResult
+	for _, branch := range backportBranches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			continue
+		}
+		if branch == change.Branch {
+			results = append(results, backportResult{Branch: branch, Result: "skipped: change is on this branch"})
+			continue
+		}
+		results = append(results, backportTo(client, *change, branch, topic, reviewers))
+	}
+
+	displayBackportResults(results)
+
+	var links []string
+	failed := 0
+	for _, r := range results {
+		if r.Failed {
+			failed++
+		}
+		if r.Change > 0 {
+			links = append(links, changeURL(cfg, gerrit.Change{Number: r.Change, Project: change.Project}))
+		}
+	}
+	if len(links) > 0 {
+		fmt.Println()
+		for _, link := range links {
+			fmt.Println(link)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d backport(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// backportTo cherry-picks change onto branch, then tags the new change with
+// topic and adds reviewers to it.
+func backportTo(client *gerrit.RESTClient, change gerrit.Change, branch, topic string, reviewers map[string][]gerrit.Account) backportResult {
+	r := backportResult{Branch: branch}
+
+	utils.Debugf("Cherry-picking %d onto %s", change.ChangeNumber(), branch)
+	picked, err := client.CherryPick(change.ChangeNumberStr(), "current", gerrit.CherryPickInput{
+		Destination:    branch,
+		AllowConflicts: backportAllowConflicts,
+	})
+	if err != nil {
+		r.Failed = true
+		if isConflictError(err) {
+			r.Result = "conflict"
+		} else {
+			r.Result = "failed: " + firstLine(err.Error())
+		}
+		return r
+	}
+	r.Change = picked.ChangeNumber()
+	r.Result = "created"
+	if picked.ContainsGitConflicts {
+		r.Result = "created with conflicts"
+	}
+
+	newID := strconv.Itoa(r.Change)
+	if err := client.SetTopic(newID, topic); err != nil {
+		utils.Warnf("Failed to set topic on %s: %v", newID, err)
+	}
+	for _, state := range []string{"REVIEWER", "CC"} {
+		for _, account := range reviewers[state] {
+			if isSameAccount(account, picked.Owner) {
+				continue
+			}
+			if err := client.AddReviewer(newID, accountIdentifier(account), state); err != nil {
+				utils.Warnf("Failed to add %s to %s: %v", account.DisplayName(), newID, err)
+				continue
+			}
+			r.Reviewers++
+		}
+	}
+	return r
+}
+
+// defaultBackportTopic is the change's own topic, or backport-<number>.
+func defaultBackportTopic(change gerrit.Change) string {
+	if change.Topic != "" {
+		return change.Topic
+	}
+	return "backport-" + change.ChangeNumberStr()
+}
+
+// backportReviewers returns the reviewers and CCs to copy, unless
+// --no-reviewers was given.
+func backportReviewers(change gerrit.Change) map[string][]gerrit.Account {
+	if backportNoReviewers {
+		return nil
+	}
+	return change.Reviewers
+}
+
+// accountIdentifier returns the most specific way to name an account to the
+// reviewers API.
+func accountIdentifier(account gerrit.Account) string {
+	switch {
+	case account.AccountID != 0:
+		return strconv.Itoa(account.AccountID)
+	case account.Username != "":
+		return account.Username
+	default:
+		return account.Email
+	}
+}
+
+// isConflictError reports whether a REST error is Gerrit's 409 Conflict,
+// which cherry-pick returns when the change doesn't apply.
+func isConflictError(err error) bool {
+	return strings.Contains(err.Error(), "status 409")
+}
+
+func displayBackportResults(results []backportResult) {
+	headers := []string{"Branch", "Change", "Result", "Reviewers"}
+	var rows [][]string
+	for _, r := range results {
+		number, reviewers := "", ""
+		if r.Change > 0 {
+			number = utils.BoldCyan(strconv.Itoa(r.Change))
+			reviewers = strconv.Itoa(r.Reviewers)
+		}
+		result := r.Result
+		switch {
+		case r.Failed:
+			result = utils.Red(result)
+		case r.Result == "created":
+			result = utils.Green(result)
+		default:
+			result = utils.Yellow(result)
+		}
+		rows = append(rows, []string{r.Branch, number, result, reviewers})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestDefaultBackportTopic(t *testing.T) {
+	if got := defaultBackportTopic(gerrit.Change{Number: 12345}); got != "backport-12345" {
+		t.Errorf("defaultBackportTopic() = %q, want backport-12345", got)
+	}
+	if got := defaultBackportTopic(gerrit.Change{Number: 12345, Topic: "grading"}); got != "grading" {
+		t.Errorf("defaultBackportTopic() = %q, want grading", got)
+	}
+}
+
+func TestAccountIdentifier(t *testing.T) {
+	tests := []struct {
+		account gerrit.Account
+		want    string
+	}{
+		{gerrit.Account{AccountID: 1000, Username: "alice"}, "1000"},
+		{gerrit.Account{Username: "alice", Email: "alice@example.com"}, "alice"},
+		{gerrit.Account{Email: "alice@example.com"}, "alice@example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := accountIdentifier(tt.account); got != tt.want {
+			t.Errorf("accountIdentifier(%+v) = %q, want %q", tt.account, got, tt.want)
+		}
+	}
+}
+
+func TestIsConflictError(t *testing.T) {
+	if !isConflictError(errors.New("request failed with status 409: Cherry pick failed: merge conflict")) {
+		t.Error("isConflictError() = false for a 409")
+	}
+	if isConflictError(errors.New("access forbidden (403) - check your permissions")) {
+		t.Error("isConflictError() = true for a 403")
+	}
+}
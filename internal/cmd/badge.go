@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	badgeFormat string
+	badgeOutput string
+)
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge <change-id>",
+	Short: "Generate a status badge or snippet for a change",
+	Long: `Generate a small status summary of a change (Code-Review and Verified votes,
+mergeability, unresolved threads) for embedding in tracker tickets or wiki
+pages. Run it from cron with --output-file to keep the snippet fresh.
+
+Formats:
+  markdown      one-line Markdown snippet linking to the change
+  shields-json  shields.io endpoint JSON (https://shields.io/badges/endpoint-badge)
+
+Examples:
+  gerry badge 12345
+  gerry badge 12345 --format shields-json --output-file /var/www/badges/12345.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBadge,
+}
+
+func init() {
+	badgeCmd.Flags().StringVar(&badgeFormat, "format", "markdown", "Output format: markdown, shields-json")
+	badgeCmd.Flags().StringVarP(&badgeOutput, "output-file", "o", "", "Write to this file instead of stdout")
+}
+
+// changeBadge is the status summary rendered by `gerry badge`.
+type changeBadge struct {
+	Change     int
+	Subject    string
+	URL        string
+	Status     string
+	CodeReview string
+	Verified   string
+	Mergeable  string
+	Unresolved int
+	Color      string
+}
+
+// shieldsEndpoint is the shields.io endpoint badge schema.
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+func runBadge(cmd *cobra.Command, args []string) error {
+	if err := rejectStructuredOutput(cmd); err != nil {
+		return err
+	}
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	if badgeFormat != "markdown" && badgeFormat != "shields-json" {
+		return fmt.Errorf("unknown format: %s (supported: markdown, shields-json)", badgeFormat)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	query := "change:" + changeID
+	changes, err := listChangesREST(cfg, query, 1)
+	if err != nil {
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to SSH...")
+		changes, err = listChangesSSH(cfg, query, 1)
+		if err != nil {
+			return fmt.Errorf("failed to get change: %w", err)
+		}
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("change %s not found", changeID)
+	}
+
+	badge := buildChangeBadge(changes[0])
+	badge.URL = changeURL(cfg, changes[0])
+
+	var out string
+	switch badgeFormat {
+	case "shields-json":
+		data, err := json.Marshal(shieldsEndpoint{
+			SchemaVersion: 1,
+			Label:         fmt.Sprintf("gerrit %d", badge.Change),
+			Message:       badge.summary(),
+			Color:         badge.Color,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		out = string(data) + "\n"
+	default:
+		out = fmt.Sprintf("[%d: %s](%s) — %s\n", badge.Change, escapeMarkdown(badge.Subject), badge.URL, badge.summary())
+	}
+
+	if badgeOutput == "" {
+		fmt.Print(out)
+		return nil
+	}
+	if err := os.WriteFile(badgeOutput, []byte(out), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", badgeOutput, err)
+	}
+	return nil
+}
+
+// buildChangeBadge summarizes a change and picks a badge color: blue when
+// merged, grey when abandoned, red when blocked, yellow with open threads,
+// green when approved and verified, orange while awaiting review.
+func buildChangeBadge(change gerrit.Change) changeBadge {
+	b := changeBadge{
+		Change:     change.ChangeNumber(),
+		Subject:    change.Subject,
+		Status:     strings.ToUpper(change.Status),
+		CodeReview: "—",
+		Verified:   "—",
+		Mergeable:  "unknown",
+		Unresolved: change.UnresolvedCommentCount,
+	}
+
+	cr, hasCR := getLabelScore(change, "Code-Review")
+	if hasCR {
+		b.CodeReview = formatVote(cr)
+	}
+	v, hasV := getLabelScore(change, "Verified")
+	if hasV {
+		b.Verified = formatVote(v)
+	}
+	known, mergeable := change.MergeableState()
+	if known {
+		b.Mergeable = "no"
+		if mergeable {
+			b.Mergeable = "yes"
+		}
+	}
+
+	switch {
+	case b.Status == "MERGED":
+		b.Color = "blue"
+	case b.Status == "ABANDONED":
+		b.Color = "lightgrey"
+	case (hasCR && cr < 0) || (hasV && v < 0) || (known && !mergeable):
+		b.Color = "red"
+	case b.Unresolved > 0:
+		b.Color = "yellow"
+	case hasCR && cr >= 2 && hasV && v > 0:
+		b.Color = "brightgreen"
+	default:
+		b.Color = "orange"
+	}
+	return b
+}
+
+// summary renders the badge as one short line.
+func (b changeBadge) summary() string {
+	if b.Status == "MERGED" || b.Status == "ABANDONED" {
+		return strings.ToLower(b.Status)
+	}
+	parts := []string{"CR " + b.CodeReview, "V " + b.Verified}
+	switch b.Mergeable {
+	case "yes":
+		parts = append(parts, "mergeable")
+	case "no":
+		parts = append(parts, "merge conflict")
+	}
+	parts = append(parts, fmt.Sprintf("%d unresolved", b.Unresolved))
+	return strings.Join(parts, " · ")
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(s)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestBuildChangeBadge(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		change      gerrit.Change
+		wantColor   string
+		wantSummary string
+	}{
+		{
+			name: "approved and verified",
+			change: gerrit.Change{Status: "NEW", Mergeable: &yes, Labels: map[string]interface{}{
+				"Code-Review": labelVotes(2), "Verified": labelVotes(1),
+			}},
+			wantColor:   "brightgreen",
+			wantSummary: "CR +2 · V +1 · mergeable · 0 unresolved",
+		},
+		{
+			name:        "merge conflict",
+			change:      gerrit.Change{Status: "NEW", Mergeable: &no},
+			wantColor:   "red",
+			wantSummary: "CR — · V — · merge conflict · 0 unresolved",
+		},
+		{
+			name:        "open threads",
+			change:      gerrit.Change{Status: "NEW", UnresolvedCommentCount: 3, Labels: map[string]interface{}{"Code-Review": labelVotes(1)}},
+			wantColor:   "yellow",
+			wantSummary: "CR +1 · V — · 3 unresolved",
+		},
+		{
+			name:        "awaiting review",
+			change:      gerrit.Change{Status: "NEW"},
+			wantColor:   "orange",
+			wantSummary: "CR — · V — · 0 unresolved",
+		},
+		{
+			name:        "merged",
+			change:      gerrit.Change{Status: "MERGED"},
+			wantColor:   "blue",
+			wantSummary: "merged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := buildChangeBadge(tt.change)
+			if b.Color != tt.wantColor {
+				t.Errorf("Color = %q, want %q", b.Color, tt.wantColor)
+			}
+			if got := b.summary(); got != tt.wantSummary {
+				t.Errorf("summary() = %q, want %q", got, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// blameQueryBatch is how many Change-Ids are OR-ed into one search query.
+const blameQueryBatch = 20
+
+var (
+	blameLines  string
+	blameFormat string
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <file>",
+	Short: "Map lines of a file to the Gerrit changes that introduced them",
+	Long: `Run git blame on a file, resolve each commit to its Gerrit change via the
+Change-Id footer, and show the change number, owner, and review URL for each
+range of lines — handy for finding who reviewed a piece of logic. Changes
+are looked up in the checkout's project (from .gitreview or the origin
+remote, else the configured project), since forks share Change-Ids.
+
+Commits without a Change-Id (or whose change is not visible) are shown with
+their abbreviated SHA only.
+
+Examples:
+  gerry blame app/models/user.rb
+  gerry blame -L 120,180 app/models/user.rb
+  gerry blame lib/foo.go --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlame,
+}
+
+func init() {
+	blameCmd.Flags().StringVarP(&blameLines, "lines", "L", "", "Only blame the line range START,END")
+	blameCmd.Flags().StringVar(&blameFormat, "format", "table", "Output format: table, json")
+}
+
+// blameLine is the commit that last touched one line of the file.
+type blameLine struct {
+	Commit string
+	Line   int
+}
+
+// blameRange is a run of consecutive lines last touched by the same commit.
+type blameRange struct {
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Commit  string `json:"commit"`
+	Change  int    `json:"change,omitempty"`
+	Owner   string `json:"owner,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+var (
+	blameHeaderPattern = regexp.MustCompile(`^([0-9a-f]{40}) \d+ (\d+)`)
+	changeIDPattern    = regexp.MustCompile(`(?m)^Change-Id:\s*(I[0-9a-f]{40})\s*$`)
+)
+
+func runBlame(cmd *cobra.Command, args []string) error {
+	file := args[0]
+
+	if blameFormat != "table" && blameFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", blameFormat)
+	}
+	if blameFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if !isGitRepository() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	gitArgs := []string{"blame", "--porcelain"}
+	if blameLines != "" {
+		gitArgs = append(gitArgs, "-L", blameLines)
+	}
+	gitArgs = append(gitArgs, "--", file)
+	output, err := exec.Command("git", gitArgs...).Output()
+	if err != nil {
+		return fmt.Errorf("git blame failed: %w", err)
+	}
+
+	ranges := groupBlameRanges(parseBlamePorcelain(string(output)))
+	if len(ranges) == 0 {
+		fmt.Println("Nothing to blame.")
+		return nil
+	}
+
+	var commits []string
+	seen := map[string]bool{}
+	for _, r := range ranges {
+		if !seen[r.Commit] && !isUncommitted(r.Commit) {
+			seen[r.Commit] = true
+			commits = append(commits, r.Commit)
+		}
+	}
+
+	spinner := utils.NewSpinner("Resolving changes...")
+	changeIDs, err := commitChangeIDs(commits)
+	if err != nil {
+		spinner.Stop()
+		return err
+	}
+	// Forks and copies of the repository share Change-Ids; only this
+	// checkout's project counts.
+	project := cfg.Project
+	if root, err := gitOutput("", "rev-parse", "--show-toplevel"); err == nil {
+		project, _ = checkoutProject(cfg, root)
+	}
+	changes := lookupChangesByKey(cfg, project, changeIDs)
+	spinner.Stop()
+
+	for i := range ranges {
+		key := changeIDs[ranges[i].Commit]
+		change, ok := changes[key]
+		if key == "" || !ok {
+			continue
+		}
+		ranges[i].Change = change.ChangeNumber()
+		ranges[i].Owner = change.Owner.DisplayName()
+		ranges[i].Subject = change.Subject
+		ranges[i].URL = changeURL(cfg, change)
+	}
+
+	if blameFormat == "json" {
+		data, err := json.MarshalIndent(ranges, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	headers := []string{"Lines", "Change", "Owner", "Subject", "URL"}
+	var rows [][]string
+	for _, r := range ranges {
+		lines := strconv.Itoa(r.Start)
+		if r.End != r.Start {
+			lines = fmt.Sprintf("%d-%d", r.Start, r.End)
+		}
+		change := utils.Gray(shortSHA(r.Commit))
+		if isUncommitted(r.Commit) {
+			change = utils.Yellow("uncommitted")
+		} else if r.Change != 0 {
+			change = utils.BoldCyan(strconv.Itoa(r.Change))
+		}
+		rows = append(rows, []string{
+			lines,
+			change,
+			utils.TruncateString(r.Owner, 20),
+			utils.TruncateString(r.Subject, 50),
+			utils.Cyan(r.URL),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	return nil
+}
+
+// parseBlamePorcelain extracts the commit for each final line number from
+// `git blame --porcelain` output.
+func parseBlamePorcelain(output string) []blameLine {
+	var lines []blameLine
+	for _, line := range strings.Split(output, "\n") {
+		m := blameHeaderPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		lines = append(lines, blameLine{Commit: m[1], Line: n})
+	}
+	return lines
+}
+
+// groupBlameRanges merges consecutive lines from the same commit.
+func groupBlameRanges(lines []blameLine) []blameRange {
+	var ranges []blameRange
+	for _, l := range lines {
+		if n := len(ranges); n > 0 && ranges[n-1].Commit == l.Commit && ranges[n-1].End == l.Line-1 {
+			ranges[n-1].End = l.Line
+			continue
+		}
+		ranges = append(ranges, blameRange{Start: l.Line, End: l.Line, Commit: l.Commit})
+	}
+	return ranges
+}
+
+func isUncommitted(commit string) bool {
+	return strings.Trim(commit, "0") == ""
+}
+
+// commitChangeIDs reads the Change-Id footer of each commit. Commits without
+// one are left out of the result.
+func commitChangeIDs(commits []string) (map[string]string, error) {
+	result := map[string]string{}
+	if len(commits) == 0 {
+		return result, nil
+	}
+
+	args := append([]string{"log", "--no-walk=unsorted", "--format=%H%x00%B%x1e"}, commits...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit messages: %w", err)
+	}
+
+	for _, record := range strings.Split(string(output), "\x1e") {
+		sha, body, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x00")
+		if !ok {
+			continue
+		}
+		if key := parseChangeIDFooter(body); key != "" {
+			result[sha] = key
+		}
+	}
+	return result, nil
+}
+
+// parseChangeIDFooter returns the last Change-Id footer in a commit message.
+func parseChangeIDFooter(message string) string {
+	matches := changeIDPattern.FindAllStringSubmatch(message, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[len(matches)-1][1]
+}
+
+// lookupChangesByKey resolves Change-Ids to changes of project, or of any
+// project if it is empty, batching the lookups into OR queries. When a
+// Change-Id exists on several branches, the merged change wins. Lookup
+// failures are logged and leave the key unresolved.
+func lookupChangesByKey(cfg *config.Config, project string, commitKeys map[string]string) map[string]gerrit.Change {
+	var keys []string
+	seen := map[string]bool{}
+	for _, key := range commitKeys {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	changes := map[string]gerrit.Change{}
+	for start := 0; start < len(keys); start += blameQueryBatch {
+		end := start + blameQueryBatch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		query := blameQuery(project, keys[start:end])
+		// Several branches may carry the same Change-Id.
+		limit := (end - start) * 5
+
+		utils.Debugf("Query: %s", query)
+		found, err := listChangesREST(cfg, query, limit)
+		if err != nil {
+			utils.Debugf("REST API failed: %v", err)
+			found, err = listChangesSSH(cfg, query, limit)
+			if err != nil {
+				utils.Warnf("Failed to look up changes: %v", err)
+				continue
+			}
+		}
+
+		addBlameChanges(changes, project, found)
+	}
+	return changes
+}
+
+// blameQuery searches for the changes with any of keys in project, or in
+// any project if it is empty.
+func blameQuery(project string, keys []string) string {
+	terms := make([]string, 0, len(keys))
+	for _, key := range keys {
+		terms = append(terms, "change:"+key)
+	}
+	query := strings.Join(terms, " OR ")
+	if project == "" {
+		return query
+	}
+	return fmt.Sprintf("project:%s (%s)", project, query)
+}
+
+// addBlameChanges adds found to changes by Change-Id, skipping changes
+// outside project (if set) and preferring merged ones.
+func addBlameChanges(changes map[string]gerrit.Change, project string, found []gerrit.Change) {
+	for _, change := range found {
+		if project != "" && change.Project != project {
+			continue
+		}
+		key := change.ChangeKey()
+		if existing, ok := changes[key]; ok && existing.Status == "MERGED" {
+			continue
+		}
+		changes[key] = change
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+const (
+	shaA = "1111111111111111111111111111111111111111"
+	shaB = "2222222222222222222222222222222222222222"
+)
+
+func TestParseBlamePorcelain(t *testing.T) {
+	output := shaA + " 1 1 2\n" +
+		"author Jane\n" +
+		"summary First\n" +
+		"filename foo.go\n" +
+		"\tpackage foo\n" +
+		shaA + " 2 2\n" +
+		"\t\n" +
+		shaB + " 7 3 1\n" +
+		"author John\n" +
+		"filename foo.go\n" +
+		"\tfunc Foo() {}\n"
+
+	got := parseBlamePorcelain(output)
+	want := []blameLine{{shaA, 1}, {shaA, 2}, {shaB, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBlamePorcelain() = %v, want %v", got, want)
+	}
+}
+
+func TestGroupBlameRanges(t *testing.T) {
+	lines := []blameLine{{shaA, 10}, {shaA, 11}, {shaB, 12}, {shaA, 13}, {shaA, 20}}
+	got := groupBlameRanges(lines)
+	want := []blameRange{
+		{Start: 10, End: 11, Commit: shaA},
+		{Start: 12, End: 12, Commit: shaB},
+		{Start: 13, End: 13, Commit: shaA},
+		{Start: 20, End: 20, Commit: shaA},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupBlameRanges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseChangeIDFooter(t *testing.T) {
+	const id1 = "I0123456789abcdef0123456789abcdef01234567"
+	const id2 = "Ifedcba9876543210fedcba9876543210fedcba98"
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"footer", "Fix bug\n\nChange-Id: " + id1 + "\n", id1},
+		{"last footer wins", "Squash\n\nChange-Id: " + id1 + "\n\nChange-Id: " + id2 + "\n", id2},
+		{"mentioned inline only", "Revert Change-Id: " + id1 + " later\n", ""},
+		{"none", "Initial commit\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseChangeIDFooter(tt.message); got != tt.want {
+				t.Errorf("parseChangeIDFooter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlameQuery(t *testing.T) {
+	keys := []string{"Iaaa", "Ibbb"}
+	if got, want := blameQuery("canvas-lms", keys), "project:canvas-lms (change:Iaaa OR change:Ibbb)"; got != want {
+		t.Errorf("blameQuery() = %q, want %q", got, want)
+	}
+	if got, want := blameQuery("", keys), "change:Iaaa OR change:Ibbb"; got != want {
+		t.Errorf("blameQuery() without a project = %q, want %q", got, want)
+	}
+}
+
+func TestAddBlameChanges(t *testing.T) {
+	const key = "I0123456789abcdef0123456789abcdef01234567"
+	// The same Change-Id in a fork, and on two branches of this project.
+	fork := gerrit.Change{Number: 10, ChangeID: key, Project: "canvas-lms-fork", Status: "MERGED"}
+	merged := gerrit.Change{Number: 11, ChangeID: key, Project: "canvas-lms", Status: "MERGED"}
+	open := gerrit.Change{Number: 12, ChangeID: key, Project: "canvas-lms", Status: "NEW"}
+
+	changes := map[string]gerrit.Change{}
+	addBlameChanges(changes, "canvas-lms", []gerrit.Change{fork, merged, open})
+	if got := changes[key].Number; got != 11 {
+		t.Errorf("resolved to change %d, want the merged change 11 of canvas-lms", got)
+	}
+
+	changes = map[string]gerrit.Change{}
+	addBlameChanges(changes, "canvas-lms", []gerrit.Change{fork})
+	if _, ok := changes[key]; ok {
+		t.Error("resolved to a change of another project")
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the REST response cache",
+	Long: `With cache_ttl set in ~/.gerry/config.json (e.g. "cache_ttl": "1m"), REST GET
+responses are reused from ~/.gerry/cache for that long, so repeated commands
+don't hit the server each time. Any change gerry makes on the server clears the
+cache, and --no-cache bypasses it for one command.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached responses",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	removed, err := gerrit.ClearCache()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Cleared %d cached response(s)\n", utils.Green("✓"), removed)
+	return nil
+}
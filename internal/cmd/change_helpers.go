@@ -3,8 +3,11 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 )
@@ -12,6 +15,17 @@ import (
 // getLabelStatus extracts the vote status for any label from a Gerrit change.
 // Checks REST format (labels map) first, then SSH format (currentPatchSet.approvals).
 func getLabelStatus(change gerrit.Change, labelName string) string {
+	score, present := getLabelScore(change, labelName)
+	if !present {
+		return utils.Gray("—")
+	}
+	return utils.FormatScore(labelName, score)
+}
+
+// getLabelScore returns the effective score for a label: the most negative vote
+// if any vote blocks, otherwise the highest vote. present is false when the
+// label does not apply to the change at all; a label with no votes scores 0.
+func getLabelScore(change gerrit.Change, labelName string) (score int, present bool) {
 	// REST API format: labels[labelName] is a LabelInfo-like object
 	if change.Labels != nil {
 		if labelData, exists := change.Labels[labelName].(map[string]interface{}); exists {
@@ -45,27 +59,27 @@ func getLabelStatus(change gerrit.Change, labelName string) string {
 				if hasVote {
 					// A negative vote blocks, so it takes precedence in display.
 					if minScore < 0 {
-						return utils.FormatScore(labelName, minScore)
+						return minScore, true
 					}
-					return utils.FormatScore(labelName, maxScore)
+					return maxScore, true
 				}
 			}
 			// Fallback for the LABELS summary (no "all" array): approved/rejected
 			// carry only the approver account, so the value is inferred.
 			if approved, hasApproved := labelData["approved"].(map[string]interface{}); hasApproved {
-				if value, ok := approved["value"]; ok {
-					return utils.FormatScore(labelName, value)
+				if value, ok := approved["value"].(float64); ok {
+					return int(value), true
 				}
-				return utils.FormatScore(labelName, 1)
+				return 1, true
 			}
 			if rejected, hasRejected := labelData["rejected"].(map[string]interface{}); hasRejected {
-				if value, ok := rejected["value"]; ok {
-					return utils.FormatScore(labelName, value)
+				if value, ok := rejected["value"].(float64); ok {
+					return int(value), true
 				}
-				return utils.FormatScore(labelName, -1)
+				return -1, true
 			}
 			// Label exists but no votes
-			return utils.Gray("0")
+			return 0, true
 		}
 	}
 
@@ -73,13 +87,26 @@ func getLabelStatus(change gerrit.Change, labelName string) string {
 	if change.CurrentPatchSet != nil {
 		for _, approval := range change.CurrentPatchSet.Approvals {
 			if approval.Type == labelName {
-				return utils.FormatScore(labelName, approval.Value)
+				return approval.Value, true
 			}
 		}
 	}
 
 	// Label not present
-	return utils.Gray("—")
+	return 0, false
+}
+
+// needsAttentionQuery matches changes that are blocked on their owner: a
+// negative Code-Review vote or a failing build.
+const needsAttentionQuery = "(label:Code-Review<=-1 OR label:Verified<=-1)"
+
+// queryValue quotes a search operator's value when Gerrit would otherwise
+// split it, e.g. an owner given by full name.
+func queryValue(value string) string {
+	if strings.ContainsAny(value, " \"():") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
 }
 
 // getMergeableStatus renders the mergeable indicator for the table view.
@@ -95,6 +122,53 @@ func getMergeableStatus(change gerrit.Change) string {
 	return utils.Red("✗")
 }
 
+// attentionIndicator renders the attention column for the table view: a
+// yellow dot when user is in the change's attention set, a gray one when only
+// others are, and nothing when the set is empty or unknown (SSH fallback).
+func attentionIndicator(change gerrit.Change, user string) string {
+	if len(change.AttentionSet) == 0 {
+		return ""
+	}
+	if inAttentionSet(change, user) {
+		return utils.BoldYellow("●")
+	}
+	return utils.Gray("○")
+}
+
+// unresolvedIndicator renders a change's unresolved comment count for the
+// UC column, blank when there are none.
+func unresolvedIndicator(change gerrit.Change) string {
+	if change.UnresolvedCommentCount == 0 {
+		return ""
+	}
+	return utils.BoldYellow(strconv.Itoa(change.UnresolvedCommentCount))
+}
+
+// inAttentionSet reports whether user (a username or email) is in the
+// change's attention set.
+func inAttentionSet(change gerrit.Change, user string) bool {
+	if user == "" {
+		return false
+	}
+	for _, a := range change.AttentionSet {
+		if a.Account.Username == user || (a.Account.Email != "" && strings.EqualFold(a.Account.Email, user)) {
+			return true
+		}
+	}
+	return false
+}
+
+// attentionNames returns the display names of the change's attention set,
+// sorted.
+func attentionNames(change gerrit.Change) []string {
+	names := make([]string, 0, len(change.AttentionSet))
+	for _, a := range change.AttentionSet {
+		names = append(names, a.Account.DisplayName())
+	}
+	sort.Strings(names)
+	return names
+}
+
 // formatMergeableDetail renders the mergeable state for the detailed view.
 func formatMergeableDetail(change gerrit.Change) string {
 	known, mergeable := change.MergeableState()
@@ -198,6 +272,12 @@ func displayDetailedChanges(changes []gerrit.Change) {
 		fmt.Printf("%s %s\n", utils.BoldCyan("Owner:"), change.Owner.DisplayName())
 		fmt.Printf("%s %s\n", utils.BoldCyan("Updated:"), utils.FormatTimeAgo(change.UpdatedTime()))
 		fmt.Printf("%s %s\n", utils.BoldCyan("Mergeable:"), formatMergeableDetail(change))
+		if names := attentionNames(change); len(names) > 0 {
+			fmt.Printf("%s %s\n", utils.BoldCyan("Attention:"), strings.Join(names, ", "))
+		}
+		if change.UnresolvedCommentCount > 0 {
+			fmt.Printf("%s %s\n", utils.BoldCyan("Unresolved:"), utils.BoldYellow(strconv.Itoa(change.UnresolvedCommentCount)))
+		}
 
 		// Show review scores if available
 		if len(change.Labels) > 0 {
@@ -224,3 +304,12 @@ func displayDetailedChanges(changes []gerrit.Change) {
 		}
 	}
 }
+
+// changeURL returns the web URL for a change, preferring the one reported
+// by the server (SSH) over one built from the configured HTTP base URL.
+func changeURL(cfg *config.Config, change gerrit.Change) string {
+	if change.URL != "" {
+		return change.URL
+	}
+	return fmt.Sprintf("%s/c/%s/+/%d", cfg.GetHTTPBaseURL(), change.Project, change.ChangeNumber())
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// tableLabels are the votes shown in the change tables, by column name.
+var tableLabels = []struct {
+	column, header, label string
+}{
+	{"cr", "CR", "Code-Review"},
+	{"qr", "QR", "QA-Review"},
+	{"lr", "LR", "Lint-Review"},
+	{"v", "V", "Verified"},
+}
+
+// changeTableSpec is the table of changes shown by list, team, and search.
+// The @ column marks the changes whose attention set includes user; subjects
+// are cut to subjectWidth.
+func changeTableSpec(user string, subjectWidth int, defaults ...string) utils.TableSpec {
+	change := func(row interface{}) gerrit.Change { return row.(gerrit.Change) }
+
+	columns := []utils.TableColumn{
+		{Name: "change", Header: "Change", Value: func(row interface{}) string {
+			return utils.BoldCyan(change(row).ChangeNumberStr())
+		}},
+		{Name: "attention", Header: "@", Value: func(row interface{}) string {
+			return attentionIndicator(change(row), user)
+		}},
+		{Name: "subject", Header: "Subject", Value: func(row interface{}) string {
+			return utils.TruncateString(change(row).Subject, subjectWidth)
+		}},
+		{Name: "owner", Header: "Owner", Value: func(row interface{}) string {
+			return change(row).Owner.DisplayName()
+		}},
+		{Name: "project", Header: "Project", Value: func(row interface{}) string {
+			return change(row).Project
+		}},
+		{Name: "branch", Header: "Branch", Value: func(row interface{}) string {
+			return change(row).Branch
+		}},
+		{Name: "status", Header: "Status", Value: func(row interface{}) string {
+			return utils.FormatChangeStatus(change(row).Status)
+		}},
+	}
+	for _, l := range tableLabels {
+		label := l.label
+		columns = append(columns, utils.TableColumn{Name: l.column, Header: l.header, Value: func(row interface{}) string {
+			return getLabelStatus(change(row), label)
+		}})
+	}
+	columns = append(columns,
+		utils.TableColumn{Name: "unresolved", Header: "UC", Value: func(row interface{}) string {
+			return unresolvedIndicator(change(row))
+		}},
+		utils.TableColumn{Name: "mergeable", Header: "M", Value: func(row interface{}) string {
+			return getMergeableStatus(change(row))
+		}},
+		utils.TableColumn{Name: "updated", Header: "Updated", Value: func(row interface{}) string {
+			return utils.FormatTimeAgoShort(change(row).UpdatedTime())
+		}},
+	)
+
+	return utils.TableSpec{
+		Columns:  columns,
+		Defaults: defaults,
+		Sorts: []utils.TableSort{
+			// Gerrit timestamps sort as strings; most recent first.
+			{Name: "updated", Less: func(a, b interface{}) bool {
+				return change(a).UpdatedTime() > change(b).UpdatedTime()
+			}},
+			{Name: "number", Less: func(a, b interface{}) bool {
+				return change(a).ChangeNumber() > change(b).ChangeNumber()
+			}},
+			{Name: "project", Less: func(a, b interface{}) bool {
+				return change(a).Project < change(b).Project
+			}},
+			// Your turn first, then the most unresolved comments.
+			{Name: "attention", Less: func(a, b interface{}) bool {
+				if mine, theirs := inAttentionSet(change(a), user), inAttentionSet(change(b), user); mine != theirs {
+					return mine
+				}
+				return change(a).UnresolvedCommentCount > change(b).UnresolvedCommentCount
+			}},
+		},
+	}
+}
+
+// listTableDefaults and teamTableDefaults are the columns shown without
+// --columns.
+var (
+	listTableDefaults = []string{"change", "attention", "subject", "cr", "qr", "lr", "v", "unresolved", "mergeable", "updated"}
+	teamTableDefaults = []string{"change", "attention", "subject", "owner", "cr", "qr", "lr", "v", "unresolved", "mergeable", "updated"}
+)
+
+// sortChanges sorts changes in place by less, a TableSpec sort order. A nil
+// less keeps Gerrit's order.
+func sortChanges(changes []gerrit.Change, less func(a, b interface{}) bool) {
+	if less == nil {
+		return
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return less(changes[i], changes[j])
+	})
+}
+
+// displayChangeTable prints changes with the selected columns.
+func displayChangeTable(spec utils.TableSpec, columns []utils.TableColumn, changes []gerrit.Change) {
+	fmt.Print(formatChangeTable(spec, columns, changes))
+}
+
+// formatChangeTable renders changes with the selected columns.
+func formatChangeTable(spec utils.TableSpec, columns []utils.TableColumn, changes []gerrit.Change) string {
+	rows := make([]interface{}, len(changes))
+	for i, change := range changes {
+		rows[i] = change
+	}
+	return spec.Format(columns, rows, 2)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// defaultWatchInterval is the refresh interval of a bare --watch.
+const defaultWatchInterval = "30s"
+
+// minWatchInterval keeps --watch from polling the server too hard.
+const minWatchInterval = 5 * time.Second
+
+// parseWatchInterval parses a --watch value: bare seconds ("60") or a
+// duration ("2m").
+func parseWatchInterval(value string) (time.Duration, error) {
+	var interval time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		interval = time.Duration(secs) * time.Second
+	} else if interval, err = utils.ParseDuration(value); err != nil {
+		return 0, fmt.Errorf("invalid --watch interval %q (use seconds or a duration, e.g. 60 or 2m)", value)
+	}
+	if interval < minWatchInterval {
+		return 0, fmt.Errorf("--watch interval must be at least %s", minWatchInterval)
+	}
+	return interval, nil
+}
+
+// changeTableWatcher re-runs a change query on an interval and redraws its
+// table in place, marking the rows that changed since the last refresh.
+type changeTableWatcher struct {
+	title    string
+	interval time.Duration
+	fetch    func() ([]gerrit.Change, error)
+	format   func([]gerrit.Change) string
+	empty    string
+	out      io.Writer
+	clear    bool
+
+	// updated is each change's update time at the last refresh; nil
+	// before the first.
+	updated map[int]string
+}
+
+// run refreshes until ctx is cancelled.
+func (w *changeTableWatcher) run(ctx context.Context) error {
+	for {
+		changes, err := w.fetch()
+		if ctx.Err() != nil {
+			return nil
+		}
+		fmt.Fprint(w.out, w.frame(changes, err, time.Now()))
+		if sleepContext(ctx, w.interval) != nil {
+			return nil
+		}
+	}
+}
+
+// frame renders one refresh: a status line, then the table, or the error
+// that kept it from being fetched.
+func (w *changeTableWatcher) frame(changes []gerrit.Change, err error, now time.Time) string {
+	var sb strings.Builder
+	if w.clear {
+		sb.WriteString("\033[H\033[2J")
+	}
+
+	status := fmt.Sprintf("%s · every %s · %s", w.title, w.interval, now.Format("15:04:05"))
+	if err != nil {
+		sb.WriteString(utils.BoldWhite(status) + "\n\n")
+		sb.WriteString(utils.Red(fmt.Sprintf("Refresh failed: %v", err)) + "\n")
+		return sb.String()
+	}
+
+	changed := w.changedRows(changes)
+	count := 0
+	for _, c := range changed {
+		if c {
+			count++
+		}
+	}
+	if count > 0 {
+		status += fmt.Sprintf(" · %d changed", count)
+	}
+	sb.WriteString(utils.BoldWhite(status) + "\n\n")
+
+	if len(changes) == 0 {
+		sb.WriteString(w.empty + "\n")
+		return sb.String()
+	}
+	sb.WriteString(markChangedRows(w.format(changes), changed))
+	return sb.String()
+}
+
+// changedRows reports, per change, whether it is new or was updated since
+// the last refresh, and remembers the current state for the next one.
+func (w *changeTableWatcher) changedRows(changes []gerrit.Change) []bool {
+	changed := make([]bool, len(changes))
+	updated := make(map[int]string, len(changes))
+	for i, change := range changes {
+		updated[change.ChangeNumber()] = change.UpdatedTime()
+		if w.updated != nil {
+			previous, ok := w.updated[change.ChangeNumber()]
+			changed[i] = !ok || previous != change.UpdatedTime()
+		}
+	}
+	w.updated = updated
+	return changed
+}
+
+// markChangedRows prefixes each row of a FormatTable table with a marker
+// when it changed, keeping the header and separator aligned.
+func markChangedRows(table string, changed []bool) string {
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		marker := "  "
+		if row := i - 2; row >= 0 && row < len(changed) && changed[row] {
+			marker = utils.BoldGreen("»") + " "
+		}
+		sb.WriteString(marker + line + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestParseWatchInterval(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{"30s", 30 * time.Second, ""},
+		{"60", time.Minute, ""},
+		{"2m", 2 * time.Minute, ""},
+		{"1d", 24 * time.Hour, ""},
+		{"1", 0, "at least"},
+		{"soon", 0, "invalid --watch interval"},
+	}
+	for _, tt := range tests {
+		got, err := parseWatchInterval(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseWatchInterval(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseWatchInterval(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestChangeTableWatcherFrame(t *testing.T) {
+	table := changeTableSpec("alice", 60, "change", "subject")
+	columns, err := table.ParseColumns("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &changeTableWatcher{
+		title:    "gerry list",
+		interval: 30 * time.Second,
+		format: func(changes []gerrit.Change) string {
+			return formatChangeTable(table, columns, changes)
+		},
+		empty: "No changes found.",
+	}
+	now := time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+
+	// marked returns the subjects of the rows marked as changed.
+	marked := func(frame string) []string {
+		var subjects []string
+		for _, line := range strings.Split(frame, "\n") {
+			if strings.Contains(line, "»") {
+				fields := strings.Fields(line)
+				subjects = append(subjects, fields[len(fields)-1])
+			}
+		}
+		return subjects
+	}
+
+	first := []gerrit.Change{
+		{Number: 1, Subject: "one", Updated: "2025-06-01 09:00:00.000000000"},
+		{Number: 2, Subject: "two", Updated: "2025-06-01 09:00:00.000000000"},
+	}
+	frame := w.frame(first, nil, now)
+	if !strings.Contains(frame, "gerry list · every 30s · 09:30:00") {
+		t.Errorf("first frame has no status line:\n%s", frame)
+	}
+	if got := marked(frame); len(got) != 0 {
+		t.Errorf("first frame marks %v, want nothing", got)
+	}
+
+	second := []gerrit.Change{
+		{Number: 1, Subject: "one", Updated: "2025-06-01 09:15:00.000000000"},
+		{Number: 2, Subject: "two", Updated: "2025-06-01 09:00:00.000000000"},
+		{Number: 3, Subject: "three", Updated: "2025-06-01 09:20:00.000000000"},
+	}
+	frame = w.frame(second, nil, now)
+	if got := marked(frame); strings.Join(got, ",") != "one,three" {
+		t.Errorf("second frame marks %v, want [one three]", got)
+	}
+	if !strings.Contains(frame, "2 changed") {
+		t.Errorf("second frame has no change count:\n%s", frame)
+	}
+
+	frame = w.frame(nil, errors.New("connection refused"), now)
+	if !strings.Contains(frame, "Refresh failed: connection refused") {
+		t.Errorf("failed refresh frame:\n%s", frame)
+	}
+	if frame = w.frame(nil, nil, now); !strings.Contains(frame, "No changes found.") {
+		t.Errorf("empty frame:\n%s", frame)
+	}
+}
+
+func TestChangeTableWatcherRunStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out strings.Builder
+	fetches := 0
+	w := &changeTableWatcher{
+		title:    "gerry list",
+		interval: time.Hour,
+		fetch: func() ([]gerrit.Change, error) {
+			fetches++
+			// Interrupt while waiting for the next refresh.
+			time.AfterFunc(10*time.Millisecond, cancel)
+			return nil, nil
+		},
+		empty: "No changes found.",
+		out:   &out,
+	}
+
+	done := make(chan error)
+	go func() { done <- w.run(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() kept waiting after the context was cancelled")
+	}
+	if fetches != 1 || strings.Count(out.String(), "No changes found.") != 1 {
+		t.Errorf("fetches = %d, output %q; want one refresh", fetches, out.String())
+	}
+}
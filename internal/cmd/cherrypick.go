@@ -14,22 +14,34 @@ import (
 )
 
 var (
-	noCommit           bool
-	cherryPickNoVerify bool
+	noCommit            bool
+	cherryPickNoVerify  bool
+	cherryPickRemote    string
+	cherryPickVia       string
+	cherryPickAutostash bool
 )
 
 var cherryPickCmd = &cobra.Command{
 	Use:     "cherry <change-id> [patchset]",
 	Aliases: []string{"cherry-pick"},
 	Short:   "Cherry-pick a change",
-	Long:    `Fetch and cherry-pick a change. If patchset is not specified, uses the current patch set.`,
-	Args:    cobra.RangeArgs(1, 2),
+	Long: `Fetch and cherry-pick a change. If patchset is not specified, uses the current patch set.
+The remote to fetch from is detected as for 'gerry fetch'; --remote overrides it, and
+--via http fetches over HTTP(S) instead of SSH.
+
+The working directory must be clean unless --autostash is given: local
+modifications to tracked files are then stashed before the cherry-pick and
+re-applied afterwards, as 'git rebase --autostash' does.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runCherryPick,
 }
 
 func init() {
 	cherryPickCmd.Flags().BoolVarP(&noCommit, "no-commit", "n", false, "Don't commit the cherry-pick")
 	cherryPickCmd.Flags().BoolVar(&cherryPickNoVerify, "no-verify", false, "Skip git hooks during cherry-pick")
+	cherryPickCmd.Flags().StringVar(&cherryPickRemote, "remote", "", "Git remote or URL to fetch from (default: detected)")
+	cherryPickCmd.Flags().BoolVar(&cherryPickAutostash, "autostash", false, "Stash local changes before cherry-picking and re-apply them afterwards")
+	cherryPickCmd.Flags().StringVar(&cherryPickVia, "via", "", "Fetch over ssh or http (default: fetch_protocol from the config, else ssh)")
 }
 
 func runCherryPick(cmd *cobra.Command, args []string) error {
@@ -56,6 +68,9 @@ func runCherryPick(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	if err := applyFetchVia(cfg, cherryPickVia); err != nil {
+		return err
+	}
 
 	// Check if we're in a git repository
 	if !isGitRepository() {
@@ -63,8 +78,8 @@ func runCherryPick(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if working directory is clean
-	if !isWorkingDirectoryClean() {
-		return fmt.Errorf("working directory is not clean. Please commit or stash your changes")
+	if !cherryPickAutostash && !isWorkingDirectoryClean() {
+		return fmt.Errorf("working directory is not clean. Please commit or stash your changes, or pass --autostash")
 	}
 
 	utils.Debugf("Cherry-picking change %s patchset %s", changeID, patchset)
@@ -92,8 +107,7 @@ func runCherryPick(cmd *cobra.Command, args []string) error {
 		changeID,
 		patchsetNum)
 
-	// Get git remote URL for the server
-	remoteURL := buildRemoteURL(cfg)
+	remoteURL := fetchRemote(cfg, cherryPickRemote)
 
 	fmt.Printf("Cherry-picking change %s (patchset %s) from %s...\n",
 		utils.BoldCyan(changeID),
@@ -106,16 +120,26 @@ func runCherryPick(cmd *cobra.Command, args []string) error {
 
 	// Step 1: Fetch the change
 	fmt.Print("Fetching change... ")
-	if err := gitFetch(remoteURL, refsPath); err != nil {
+	if err := gitFetch(cfg, remoteURL, refsPath); err != nil {
 		fmt.Println(color.RedString("FAILED"))
 		return fmt.Errorf("git fetch failed: %w", err)
 	}
 	fmt.Println(color.GreenString("SUCCESS"))
 
+	stashed := false
+	if cherryPickAutostash {
+		if stashed, err = autostash(); err != nil {
+			return err
+		}
+	}
+
 	// Step 2: Cherry-pick FETCH_HEAD
 	fmt.Print("Cherry-picking... ")
 	if err := gitCherryPick("FETCH_HEAD", noCommit, cherryPickNoVerify); err != nil {
 		fmt.Println(color.RedString("FAILED"))
+		if stashed {
+			keepAutostash()
+		}
 
 		// Check if it's a conflict
 		if isCherryPickConflict(err) {
@@ -133,6 +157,9 @@ func runCherryPick(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cherry-pick failed: %w", err)
 	}
 	fmt.Println(color.GreenString("SUCCESS"))
+	if stashed {
+		popAutostash()
+	}
 
 	// Show the result
 	if noCommit {
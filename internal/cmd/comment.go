@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentMessage    string
+	commentFile       string
+	commentLine       int
+	commentPatchset   int
+	commentUnresolved bool
+	commentEdit       bool
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment <change-id> [<file>:<line> <message> | message]",
+	Short: "Post a message or an inline comment on a change",
+	Long: `Post a plain change message on the current patchset, without votes, or, with
+a location, an inline comment on that line of the file. The location is a
+<file>:<line> argument followed by the message, or --file and --line. Inline
+comments start a resolved thread unless --unresolved is given.
+
+The message is the last argument or --message. Pass "-" as the message to read
+it from stdin, which is handy for scripts and bots; with --edit, or no message
+at all, $VISUAL or $EDITOR is opened to write it. In CI mode a message is
+required.
+
+Top-level messages use the REST API when HTTP credentials are configured,
+falling back to the SSH 'gerrit review' command. Inline comments need the
+REST API.
+
+Examples:
+  gerry comment 12345 -m "rebase please"
+  ./summarize-build.sh | gerry comment 12345 -m -
+  gerry comment 12345 src/app.go:42 "Can this be nil?" --unresolved
+  gerry comment 12345 --file src/app.go --line 42 --patchset 3 --edit`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: runComment,
+}
+
+func init() {
+	commentCmd.Flags().StringVarP(&commentMessage, "message", "m", "", "Message to post (\"-\" reads stdin)")
+	commentCmd.Flags().StringVarP(&commentFile, "file", "f", "", "File to comment on (with --line)")
+	commentCmd.Flags().IntVarP(&commentLine, "line", "l", 0, "Line to comment on (with --file)")
+	commentCmd.Flags().IntVarP(&commentPatchset, "patchset", "p", 0, "Patchset to comment on (default: current)")
+	commentCmd.Flags().BoolVar(&commentUnresolved, "unresolved", false, "Start an unresolved thread (inline comments only)")
+	commentCmd.Flags().BoolVarP(&commentEdit, "edit", "e", false, "Write the message in $EDITOR")
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	file, line, argMessage, err := commentTarget(args, commentFile, commentLine)
+	if err != nil {
+		return err
+	}
+	if argMessage != "" && commentMessage != "" {
+		return fmt.Errorf("give the message either as an argument or with --message, not both")
+	}
+	message := commentMessage
+	if argMessage != "" {
+		message = argMessage
+	}
+	if file == "" && commentUnresolved {
+		return fmt.Errorf("--unresolved only applies to inline comments")
+	}
+	if commentPatchset < 0 {
+		return fmt.Errorf("invalid patchset: %d", commentPatchset)
+	}
+
+	switch {
+	case message == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read message from stdin: %w", err)
+		}
+		message = string(data)
+	case commentEdit || message == "":
+		if utils.CIMode() {
+			return fmt.Errorf("message required (--message or stdin)")
+		}
+		if message, err = editMessage(message, commentTemplate(changeID, file, line)); err != nil {
+			return err
+		}
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("message cannot be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	patchset := ""
+	if commentPatchset > 0 {
+		patchset = strconv.Itoa(commentPatchset)
+	}
+
+	if file == "" {
+		if err := postChangeMessage(cfg, changeID, patchset, message); err != nil {
+			return fmt.Errorf("failed to post comment: %w", err)
+		}
+		fmt.Printf("%s Posted comment on change %s\n", utils.Green("✓"), changeID)
+		return nil
+	}
+
+	revision := patchset
+	if revision == "" {
+		revision = "current"
+	}
+	comments := map[string][]gerrit.ReviewComment{
+		file: {{Line: line, Message: message, Unresolved: boolPtr(commentUnresolved)}},
+	}
+	if err := gerrit.NewRESTClient(cfg).PostReviewWithComments(changeID, revision, comments); err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+
+	state := "resolved"
+	if commentUnresolved {
+		state = "unresolved"
+	}
+	fmt.Printf("%s Comment added to %s:%d on change %s (%s)\n", utils.Green("✓"), file, line, changeID, state)
+	return nil
+}
+
+// parseFileLine splits a <file>:<line> argument, e.g. "src/app.go:42".
+func parseFileLine(raw string) (string, int, error) {
+	i := strings.LastIndex(raw, ":")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid location %q: expected <file>:<line>", raw)
+	}
+	line, err := strconv.Atoi(raw[i+1:])
+	if err != nil || line <= 0 {
+		return "", 0, fmt.Errorf("invalid location %q: expected <file>:<line> with a positive line number", raw)
+	}
+	return raw[:i], line, nil
+}
+
+// commentTarget works out the location and the message argument of gerry
+// comment. A location is either <file>:<line> followed by the message, or
+// fileFlag and lineFlag; a lone second argument is always the message, so
+// "gerry comment 12345 'failed at 10:30'" posts a change message.
+func commentTarget(args []string, fileFlag string, lineFlag int) (string, int, string, error) {
+	if (fileFlag == "") != (lineFlag == 0) {
+		return "", 0, "", fmt.Errorf("--file and --line must be given together")
+	}
+	if lineFlag < 0 {
+		return "", 0, "", fmt.Errorf("invalid line: %d", lineFlag)
+	}
+	switch len(args) {
+	case 2:
+		return fileFlag, lineFlag, args[1], nil
+	case 3:
+		if fileFlag != "" {
+			return "", 0, "", fmt.Errorf("give the location either as <file>:<line> or with --file and --line, not both")
+		}
+		file, line, err := parseFileLine(args[1])
+		if err != nil {
+			return "", 0, "", err
+		}
+		return file, line, args[2], nil
+	}
+	return fileFlag, lineFlag, "", nil
+}
+
+// commentTemplate is the help shown below the message in the editor.
+func commentTemplate(changeID, file string, line int) string {
+	target := "change " + changeID
+	if file != "" {
+		target = fmt.Sprintf("%s:%d on change %s", file, line, changeID)
+	}
+	return fmt.Sprintf("\n# Write your comment on %s.\n# Lines starting with '#' are ignored; an empty message aborts.\n", target)
+}
+
+// editMessage opens $VISUAL or $EDITOR (default vi) on initial followed by
+// template, and returns the saved text without '#' lines.
+func editMessage(initial, template string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "gerry-edit-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create message file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(initial + template)
+	file.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to write message file: %w", err)
+	}
+
+	editCmd := shellCommand(editor + ` "` + file.Name() + `"`)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read message file: %w", err)
+	}
+	return stripCommentLines(string(data)), nil
+}
+
+// stripCommentLines drops the '#' lines of an edited message.
+func stripCommentLines(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// postChangeMessage posts message on patchset (the current one if empty) over
+// REST, or over SSH when REST is unavailable.
+func postChangeMessage(cfg *config.Config, changeID, patchset, message string) error {
+	if cfg.HTTPPassword != "" {
+		revision := patchset
+		if revision == "" {
+			revision = "current"
+		}
+		err := gerrit.NewRESTClient(cfg).PostReview(changeID, revision, gerrit.ReviewInput{Message: message})
+		if err == nil {
+			return nil
+		}
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to SSH...")
+	}
+
+	if patchset == "" {
+		change, err := getChangeForFetch(cfg, changeID)
+		if err != nil {
+			return fmt.Errorf("failed to get change details: %w", err)
+		}
+		patchset = getCurrentPatchsetNumber(change)
+		if patchset == "" {
+			return fmt.Errorf("could not determine current patchset")
+		}
+	}
+	return gerrit.NewSSHClient(cfg).PostReviewMessage(changeID, patchset, message)
+}
@@ -18,7 +18,8 @@ import (
 
 var (
 	replyMessage   string
-	replyThread    int
+	replyThread    string
+	replyResolve   bool
 	addFile        string
 	addLine        int
 	addMessage     string
@@ -39,12 +40,19 @@ type batchCommentInput struct {
 var commentsReplyCmd = &cobra.Command{
 	Use:   "reply <change-id>",
 	Short: "Reply to an inline comment thread",
-	Long: `Reply to an existing inline comment thread on a change.
+	Long: `Reply to an existing inline comment thread on a change. The message is the
+second argument or --message. --resolve marks the thread resolved with the
+reply.
+
+--thread takes a thread index from gerry comments output (unresolved threads)
+or the ID of any comment in the thread, resolved or not.
 
 Examples:
   gerry comments reply 12345 -t 1 -m "Thanks, fixed"
+  gerry comments reply 12345 --thread 2 "Done" --resolve
+  gerry comments reply 12345 --thread 8f3a1c2e_5b7d9f01 "Good catch"
   gerry comments reply 12345   # interactive picker`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runCommentsReply,
 }
 
@@ -100,7 +108,8 @@ Examples:
 
 func init() {
 	commentsReplyCmd.Flags().StringVarP(&replyMessage, "message", "m", "", "Reply message")
-	commentsReplyCmd.Flags().IntVarP(&replyThread, "thread", "t", 0, "Thread index (from gerry comments output)")
+	commentsReplyCmd.Flags().StringVarP(&replyThread, "thread", "t", "", "Thread index (from gerry comments output) or comment ID")
+	commentsReplyCmd.Flags().BoolVar(&replyResolve, "resolve", false, "Mark the thread resolved")
 
 	commentsAddCmd.Flags().StringVarP(&addFile, "file", "f", "", "File path to comment on")
 	commentsAddCmd.Flags().IntVarP(&addLine, "line", "l", 0, "Line number to comment on")
@@ -184,7 +193,7 @@ func selectThread(threads [][]Comment, threadIdx int, label string) ([]Comment,
 		Message: label,
 		Options: options,
 	}
-	if err := survey.AskOne(prompt, &selected); err != nil {
+	if err := askOne(prompt, &selected); err != nil {
 		return nil, fmt.Errorf("cancelled: %w", err)
 	}
 	return threads[selected], nil
@@ -198,7 +207,7 @@ func promptMessage(flagValue string, label string) (string, error) {
 	prompt := &survey.Input{
 		Message: label,
 	}
-	if err := survey.AskOne(prompt, &msg, survey.WithValidator(survey.Required)); err != nil {
+	if err := askOne(prompt, &msg, survey.WithValidator(survey.Required)); err != nil {
 		return "", fmt.Errorf("cancelled: %w", err)
 	}
 	return msg, nil
@@ -210,25 +219,55 @@ func runCommentsReply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid change ID: %w", err)
 	}
 
+	messageFlag := replyMessage
+	if len(args) > 1 {
+		if replyMessage != "" {
+			return fmt.Errorf("give the message either as an argument or with --message, not both")
+		}
+		messageFlag = args[1]
+	}
+
 	cfg, client, err := loadConfigAndClient()
 	if err != nil {
 		return err
 	}
 
-	threads, err := getOrderedThreads(cfg, changeID, false)
+	// Indexes refer to the default (unresolved) listing; comment IDs can
+	// name any thread.
+	byID := replyThread != "" && !isDigits(replyThread)
+	threads, err := getOrderedThreads(cfg, changeID, byID)
 	if err != nil {
 		return err
 	}
 	if len(threads) == 0 {
+		if byID {
+			return fmt.Errorf("no comment threads on change %s", changeID)
+		}
 		fmt.Println("No unresolved comment threads found.")
 		return nil
 	}
 
-	thread, err := selectThread(threads, replyThread, "Select thread to reply to:")
+	var thread []Comment
+	if byID {
+		thread, err = findThreadByCommentID(threads, replyThread)
+	} else {
+		index := 0
+		if replyThread != "" {
+			index, _ = strconv.Atoi(replyThread)
+			if index == 0 {
+				return fmt.Errorf("thread index starts at 1")
+			}
+		}
+		thread, err = selectThread(threads, index, "Select thread to reply to:")
+	}
 	if err != nil {
 		return err
 	}
-	message, err := promptMessage(replyMessage, "Reply message:")
+
+	if messageFlag == "" && replyResolve {
+		messageFlag = "Done"
+	}
+	message, err := promptMessage(messageFlag, "Reply message:")
 	if err != nil {
 		return err
 	}
@@ -243,24 +282,40 @@ func runCommentsReply(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	comments := map[string][]gerrit.ReviewComment{
-		lastComment.File: {
-			{
-				InReplyTo: lastComment.ID,
-				Line:      lastComment.Line,
-				Message:   message,
-			},
-		},
+	reply := gerrit.ReviewComment{
+		InReplyTo: lastComment.ID,
+		Line:      lastComment.Line,
+		Message:   message,
+	}
+	if replyResolve {
+		reply.Unresolved = boolPtr(false)
 	}
+	comments := map[string][]gerrit.ReviewComment{lastComment.File: {reply}}
 
 	if err := client.PostReviewWithComments(changeID, revision, comments); err != nil {
 		return fmt.Errorf("failed to post reply: %w", err)
 	}
 
-	fmt.Printf("%s Reply posted to %s:%d\n", utils.Green("✓"), lastComment.File, lastComment.Line)
+	suffix := ""
+	if replyResolve {
+		suffix = " (resolved)"
+	}
+	fmt.Printf("%s Reply posted to %s:%d%s\n", utils.Green("✓"), lastComment.File, lastComment.Line, suffix)
 	return nil
 }
 
+// findThreadByCommentID returns the thread containing the comment with id.
+func findThreadByCommentID(threads [][]Comment, id string) ([]Comment, error) {
+	for _, thread := range threads {
+		for _, c := range thread {
+			if c.ID == id {
+				return thread, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no comment with ID %s", id)
+}
+
 func runCommentsAdd(cmd *cobra.Command, args []string) error {
 	changeID := args[0]
 	if err := utils.ValidateChangeID(changeID); err != nil {
@@ -306,7 +361,7 @@ func runCommentsAdd(cmd *cobra.Command, args []string) error {
 			Message: "Select file to comment on:",
 			Options: fileNames,
 		}
-		if err := survey.AskOne(prompt, &selected); err != nil {
+		if err := askOne(prompt, &selected); err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
 		filePath = fileNames[selected]
@@ -318,7 +373,7 @@ func runCommentsAdd(cmd *cobra.Command, args []string) error {
 		prompt := &survey.Input{
 			Message: "Line number:",
 		}
-		if err := survey.AskOne(prompt, &lineStr, survey.WithValidator(survey.Required)); err != nil {
+		if err := askOne(prompt, &lineStr, survey.WithValidator(survey.Required)); err != nil {
 			return fmt.Errorf("cancelled: %w", err)
 		}
 		line, err = strconv.Atoi(lineStr)
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFileLine(t *testing.T) {
+	tests := []struct {
+		raw      string
+		wantFile string
+		wantLine int
+		wantErr  bool
+	}{
+		{"src/app.go:42", "src/app.go", 42, false},
+		{"C:/src/app.go:7", "C:/src/app.go", 7, false},
+		{"app.go", "", 0, true},
+		{"app.go:0", "", 0, true},
+		{"app.go:x", "", 0, true},
+		{":12", "", 0, true},
+		{"rebase please", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			file, line, err := parseFileLine(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFileLine(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if file != tt.wantFile || line != tt.wantLine {
+				t.Errorf("parseFileLine(%q) = %q, %d, want %q, %d", tt.raw, file, line, tt.wantFile, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestStripCommentLines(t *testing.T) {
+	text := "Can this be nil?\n\n  # indented stays\n" + commentTemplate("12345", "app.go", 42)
+	want := "Can this be nil?\n\n  # indented stays"
+	if got := stripCommentLines(text); got != want {
+		t.Errorf("stripCommentLines() = %q, want %q", got, want)
+	}
+	if got := stripCommentLines(commentTemplate("12345", "", 0)); got != "" {
+		t.Errorf("stripCommentLines(template) = %q, want empty", got)
+	}
+}
+
+func TestCommentTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		fileFlag    string
+		lineFlag    int
+		wantFile    string
+		wantLine    int
+		wantMessage string
+		wantErr     bool
+	}{
+		{"message only", []string{"12345", "rebase please"}, "", 0, "", 0, "rebase please", false},
+		{"message with a time", []string{"12345", "failed at 10:30"}, "", 0, "", 0, "failed at 10:30", false},
+		{"lone location is a message", []string{"12345", "app.go:42"}, "", 0, "", 0, "app.go:42", false},
+		{"location and message", []string{"12345", "src/app.go:42", "Can this be nil?"}, "", 0, "src/app.go", 42, "Can this be nil?", false},
+		{"flags", []string{"12345"}, "src/app.go", 42, "src/app.go", 42, "", false},
+		{"flags and message", []string{"12345", "nit"}, "src/app.go", 42, "src/app.go", 42, "nit", false},
+		{"flags and location", []string{"12345", "app.go:1", "nit"}, "src/app.go", 42, "", 0, "", true},
+		{"file without line", []string{"12345"}, "src/app.go", 0, "", 0, "", true},
+		{"negative line", []string{"12345"}, "src/app.go", -1, "", 0, "", true},
+		{"bad location", []string{"12345", "app.go", "nit"}, "", 0, "", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, line, message, err := commentTarget(tt.args, tt.fileFlag, tt.lineFlag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commentTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if file != tt.wantFile || line != tt.wantLine || message != tt.wantMessage {
+				t.Errorf("commentTarget() = %q, %d, %q, want %q, %d, %q", file, line, message, tt.wantFile, tt.wantLine, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCommentRequiresMessageInCI(t *testing.T) {
+	stderr, err := runGerryCI(t, "", "comment", "12345")
+	if err == nil {
+		t.Fatal("gerry comment without a message succeeded in CI mode")
+	}
+	if !strings.Contains(stderr, "message required (--message or stdin)") {
+		t.Errorf("stderr = %q, want the missing message error", stderr)
+	}
+}
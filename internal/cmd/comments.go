@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
@@ -13,11 +14,14 @@ import (
 )
 
 var (
-	showAll bool
+	showAll                bool
+	commentsInteractive    bool
+	commentsPatchset       int
+	commentsMineUnresolved bool
 )
 
 var commentsCmd = &cobra.Command{
-	Use:   "comments <change-id>",
+	Use:   "comments [change-id]",
 	Short: "View and manage comments on a change",
 	Long: `View, reply to, add, resolve, or unresolve comments on a change.
 
@@ -27,13 +31,24 @@ Subcommands:
   resolve    Mark a comment thread as resolved
   unresolve  Mark a comment thread as unresolved
 
-When called without a subcommand, displays comments on the change.`,
+When called without a subcommand, displays comments on the change. With
+--interactive, walks the threads one at a time with the surrounding code,
+letting you reply, mark done, skip, or open the thread in the browser, and
+publishes all replies as one review at the end.
+
+With --mine-unresolved and no change, lists every unresolved thread across
+all your open changes, longest-waiting first. Otherwise, "." or no change-id
+means the change checked out in the current repository (see 'gerry details');
+without one, pick one of your open or incoming changes from a list.`,
 	Args: cobra.ArbitraryArgs,
 	RunE: runComments,
 }
 
 func init() {
 	commentsCmd.Flags().BoolVar(&showAll, "all", false, "Show all comments (default: unresolved only)")
+	commentsCmd.Flags().BoolVarP(&commentsInteractive, "interactive", "i", false, "Walk threads interactively and publish replies as one review")
+	commentsCmd.Flags().IntVar(&commentsPatchset, "patchset", 0, "Only show threads started on this patchset")
+	commentsCmd.Flags().BoolVar(&commentsMineUnresolved, "mine-unresolved", false, "List unresolved threads across all your open changes")
 	commentsCmd.AddCommand(commentsReplyCmd)
 	commentsCmd.AddCommand(commentsAddCmd)
 	commentsCmd.AddCommand(commentsResolveCmd)
@@ -41,10 +56,35 @@ func init() {
 }
 
 func runComments(cmd *cobra.Command, args []string) error {
-	if len(args) != 1 {
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	if structured && commentsInteractive {
+		return fmt.Errorf("--interactive cannot be combined with --output %s", outputFormat)
+	}
+
+	if commentsMineUnresolved {
+		if len(args) > 0 {
+			return fmt.Errorf("--mine-unresolved doesn't take a change ID")
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+		return runMineUnresolved(cfg, structured)
+	}
+
+	if len(args) > 1 {
 		cmd.Help()
 		return nil
 	}
+	if args, err = withCurrentChange(args); err != nil {
+		return err
+	}
 	changeID := args[0]
 	if err := utils.ValidateChangeID(changeID); err != nil {
 		return fmt.Errorf("invalid change ID: %w", err)
@@ -59,15 +99,32 @@ func runComments(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if commentsInteractive {
+		if commentsPatchset > 0 {
+			return fmt.Errorf("--patchset cannot be combined with --interactive")
+		}
+		return runCommentsInteractive(cfg, changeID)
+	}
+
 	utils.Debugf("Fetching comments for change %s", changeID)
 
 	threads, err := getOrderedThreads(cfg, changeID, showAll)
 	if err != nil {
 		return err
 	}
+	if commentsPatchset > 0 {
+		threads = filterThreadsByPatchset(threads, commentsPatchset)
+	}
+	if structured {
+		return renderOutput(os.Stdout, threadOutput(threads))
+	}
 
 	if len(threads) == 0 {
-		if showAll {
+		if commentsPatchset > 0 && showAll {
+			fmt.Printf("No comments found on patchset %d.\n", commentsPatchset)
+		} else if commentsPatchset > 0 {
+			fmt.Printf("No unresolved comment threads found on patchset %d. Use --all to show all comments.\n", commentsPatchset)
+		} else if showAll {
 			fmt.Println("No comments found on this change.")
 		} else {
 			fmt.Println("No unresolved comment threads found. Use --all to show all comments.")
@@ -81,15 +138,43 @@ func runComments(cmd *cobra.Command, args []string) error {
 
 // Comment is the display-layer representation of a comment, normalized across API sources.
 type Comment struct {
-	ID         string
-	PatchSet   int
-	File       string
-	Line       int
-	Author     string
-	Message    string
-	Updated    string
-	Unresolved bool
-	InReplyTo  string
+	ID         string `json:"id"`
+	PatchSet   int    `json:"patch_set"`
+	File       string `json:"file"`
+	Line       int    `json:"line,omitempty"`
+	Author     string `json:"author"`
+	Message    string `json:"message"`
+	Updated    string `json:"updated"`
+	Unresolved bool   `json:"unresolved"`
+	InReplyTo  string `json:"in_reply_to,omitempty"`
+}
+
+// commentThread is a thread as --output json or yaml writes it.
+type commentThread struct {
+	File       string    `json:"file"`
+	Line       int       `json:"line,omitempty"`
+	PatchSet   int       `json:"patch_set"`
+	Unresolved bool      `json:"unresolved"`
+	Comments   []Comment `json:"comments"`
+}
+
+// threadOutput converts ordered threads for --output json or yaml.
+func threadOutput(threads [][]Comment) []commentThread {
+	out := make([]commentThread, 0, len(threads))
+	for _, thread := range threads {
+		if len(thread) == 0 {
+			continue
+		}
+		first := thread[0]
+		out = append(out, commentThread{
+			File:       first.File,
+			Line:       first.Line,
+			PatchSet:   first.PatchSet,
+			Unresolved: first.Unresolved,
+			Comments:   thread,
+		})
+	}
+	return out
 }
 
 func getCommentsREST(cfg *config.Config, changeID string) ([]Comment, error) {
@@ -427,3 +512,15 @@ func markThreadResolution(threads [][]Comment) [][]Comment {
 
 	return threads
 }
+
+// filterThreadsByPatchset keeps the threads whose first comment was left on
+// the given patchset. SSH comments carry no patchset and are dropped.
+func filterThreadsByPatchset(threads [][]Comment, patchset int) [][]Comment {
+	var filtered [][]Comment
+	for _, thread := range threads {
+		if len(thread) > 0 && thread[0].PatchSet == patchset {
+			filtered = append(filtered, thread)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// commentContextRadius is how many lines around a comment are shown.
+const commentContextRadius = 3
+
+// pendingReply is a reply queued during an interactive session.
+type pendingReply struct {
+	Parent     Comment
+	Message    string
+	Unresolved bool
+}
+
+// contextLine is one line of file content around a comment.
+type contextLine struct {
+	Number    int
+	Text      string
+	Commented bool
+}
+
+// runCommentsInteractive walks unresolved threads one at a time, queueing
+// replies, and publishes them all as one review at the end.
+func runCommentsInteractive(cfg *config.Config, changeID string) error {
+	if utils.CIMode() {
+		return fmt.Errorf("%w: --interactive", utils.ErrInteractiveDisabled)
+	}
+	if cfg.HTTPPassword == "" {
+		return fmt.Errorf("this command requires REST API access; run 'gerry init' to configure HTTP credentials")
+	}
+	client := gerrit.NewRESTClient(cfg)
+
+	change, err := client.GetChange(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get change details: %w", err)
+	}
+
+	threads, err := getOrderedThreads(cfg, changeID, showAll)
+	if err != nil {
+		return err
+	}
+	if len(threads) == 0 {
+		fmt.Println("No unresolved comment threads found. Use --all to include resolved threads.")
+		return nil
+	}
+
+	contents := map[string][]string{}
+	fileLines := func(c Comment) []string {
+		key := fmt.Sprintf("%d:%s", c.PatchSet, c.File)
+		if lines, ok := contents[key]; ok {
+			return lines
+		}
+		var lines []string
+		if content, err := client.GetFileContent(changeID, strconv.Itoa(c.PatchSet), c.File); err != nil {
+			utils.Debugf("Failed to load %s: %v", c.File, err)
+		} else {
+			lines = strings.Split(content, "\n")
+		}
+		contents[key] = lines
+		return lines
+	}
+
+	var replies []pendingReply
+	rr := terminal.NewRuneReader(terminal.Stdio{In: os.Stdin, Out: os.Stdout, Err: os.Stderr})
+
+threadLoop:
+	for i, thread := range threads {
+		first := thread[0]
+		last := thread[len(thread)-1]
+
+		fmt.Println()
+		fmt.Println(utils.Gray(strings.Repeat("─", 60)))
+		status := utils.BoldRed("[UNRESOLVED]")
+		if !first.Unresolved {
+			status = utils.Green("[RESOLVED]")
+		}
+		location := first.File
+		if first.Line > 0 {
+			location = fmt.Sprintf("%s:%d", first.File, first.Line)
+		}
+		fmt.Printf("%s %s %s %s\n", utils.BoldWhite(fmt.Sprintf("[%d/%d]", i+1, len(threads))),
+			utils.BoldCyan(location), utils.Gray(fmt.Sprintf("(PS%d)", first.PatchSet)), status)
+
+		if first.Line > 0 && first.PatchSet > 0 {
+			fmt.Println()
+			for _, l := range commentContext(fileLines(first), first.Line, commentContextRadius) {
+				number := utils.Gray(fmt.Sprintf("%5d", l.Number))
+				if l.Commented {
+					fmt.Printf("%s %s %s\n", number, utils.BoldYellow("▶"), utils.BoldWhite(l.Text))
+				} else {
+					fmt.Printf("%s   %s\n", number, l.Text)
+				}
+			}
+		}
+
+		fmt.Println()
+		for _, comment := range thread {
+			fmt.Printf("  %s %s\n", utils.BoldBlue(comment.Author+":"), utils.FormatTimeAgo(comment.Updated))
+			for _, line := range strings.Split(strings.TrimSpace(comment.Message), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+
+		for {
+			fmt.Printf("\n%s ", utils.Cyan("[r]eply  [R]eply+resolve  [d]one  [s]kip  [o]pen  [q]uit >"))
+			key, err := readKey(rr)
+			fmt.Println()
+			if err != nil {
+				return err
+			}
+
+			switch key {
+			case 'r', 'R':
+				message, err := promptMessage("", "Reply:")
+				if err != nil {
+					fmt.Println(utils.Gray("Reply cancelled."))
+					continue
+				}
+				replies = append(replies, pendingReply{Parent: last, Message: message, Unresolved: key == 'r'})
+				fmt.Println(utils.Green("Reply queued."))
+			case 'd':
+				replies = append(replies, pendingReply{Parent: last, Message: "Done", Unresolved: false})
+				fmt.Println(utils.Green("Marked done."))
+			case 's', '\r', '\n':
+			case 'o':
+				url := fmt.Sprintf("%s/%d/%s", changeURL(cfg, *change), first.PatchSet, first.File)
+				if first.Line > 0 {
+					url += fmt.Sprintf("#%d", first.Line)
+				}
+				if err := utils.OpenBrowser(url); err != nil {
+					fmt.Println(utils.Red(err.Error()))
+				}
+				continue
+			case 'q', terminal.KeyInterrupt, terminal.KeyEscape:
+				break threadLoop
+			default:
+				continue
+			}
+			break
+		}
+	}
+
+	return publishPendingReplies(client, changeID, replies)
+}
+
+// readKey reads a single keystroke without waiting for Enter.
+func readKey(rr *terminal.RuneReader) (rune, error) {
+	if err := rr.SetTermMode(); err != nil {
+		return 0, fmt.Errorf("failed to read key: %w", err)
+	}
+	defer rr.RestoreTermMode()
+
+	key, _, err := rr.ReadRune()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read key: %w", err)
+	}
+	return key, nil
+}
+
+// publishPendingReplies saves each reply as a draft on the patchset of the
+// comment it answers, then publishes every draft as a single review.
+func publishPendingReplies(client *gerrit.RESTClient, changeID string, replies []pendingReply) error {
+	fmt.Println()
+	if len(replies) == 0 {
+		fmt.Println("No replies to publish.")
+		return nil
+	}
+
+	fmt.Printf("%s\n", utils.BoldWhite(fmt.Sprintf("%d queued reply(ies):", len(replies))))
+	for _, r := range replies {
+		state := utils.BoldRed("unresolved")
+		if !r.Unresolved {
+			state = utils.Green("resolved")
+		}
+		fmt.Printf("  %s:%d %s %s\n", r.Parent.File, r.Parent.Line, state, utils.TruncateString(r.Message, 50))
+	}
+
+	publish := true
+	if err := askOne(&survey.Confirm{Message: "Publish replies?", Default: true}, &publish); err != nil {
+		return fmt.Errorf("cancelled: %w", err)
+	}
+	if !publish {
+		fmt.Println("Discarded replies.")
+		return nil
+	}
+
+	for _, r := range replies {
+		if r.Parent.ID == "" {
+			return fmt.Errorf("cannot reply: comment ID not available (REST API required)")
+		}
+		draft := gerrit.ReviewComment{
+			Path:       r.Parent.File,
+			InReplyTo:  r.Parent.ID,
+			Line:       r.Parent.Line,
+			Message:    r.Message,
+			Unresolved: boolPtr(r.Unresolved),
+		}
+		if err := client.CreateDraft(changeID, strconv.Itoa(r.Parent.PatchSet), draft); err != nil {
+			return fmt.Errorf("failed to save draft on %s (earlier replies remain as drafts): %w", r.Parent.File, err)
+		}
+	}
+
+	if err := client.PublishDrafts(changeID, "current", ""); err != nil {
+		return fmt.Errorf("failed to publish drafts (they are saved and can be published from the web UI): %w", err)
+	}
+
+	fmt.Printf("%s Published %d reply(ies) in one review\n", utils.Green("✓"), len(replies))
+	return nil
+}
+
+// commentContext returns the lines within radius of line (1-based).
+func commentContext(lines []string, line, radius int) []contextLine {
+	var context []contextLine
+	for n := line - radius; n <= line+radius; n++ {
+		if n < 1 || n > len(lines) {
+			continue
+		}
+		context = append(context, contextLine{Number: n, Text: lines[n-1], Commented: n == line})
+	}
+	return context
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// unresolvedThread is one open thread found by `gerry comments
+// --mine-unresolved`, described by its latest comment.
+type unresolvedThread struct {
+	Change     int    `json:"change"`
+	File       string `json:"file"`
+	Line       int    `json:"line,omitempty"`
+	LastAuthor string `json:"last_author"`
+	Updated    string `json:"updated"`
+	Message    string `json:"message"`
+}
+
+// runMineUnresolved lists every unresolved thread on the user's open
+// changes, longest-waiting first. With structured set, they are written per
+// --output instead of as a table.
+func runMineUnresolved(cfg *config.Config, structured bool) error {
+	client := gerrit.NewRESTClient(cfg)
+
+	query := fmt.Sprintf("owner:%s status:open", cfg.User)
+	utils.Debugf("Query: %s", query)
+	spinner := utils.NewSpinner("Fetching your open changes...")
+	changes, err := client.ListChanges(url.QueryEscape(query), 500)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list your changes: %w", err)
+	}
+
+	var found []unresolvedThread
+	withThreads := 0
+	for _, change := range changes {
+		// REST reports the count on every change, so only changes that have
+		// unresolved threads need their comments fetched.
+		if change.UnresolvedCommentCount == 0 {
+			continue
+		}
+		spinner := utils.NewSpinner(fmt.Sprintf("Reading threads on %d...", change.ChangeNumber()))
+		threads, err := getOrderedThreads(cfg, change.ChangeNumberStr(), false)
+		spinner.Stop()
+		if err != nil {
+			utils.Warnf("Skipping %d: %v", change.ChangeNumber(), err)
+			continue
+		}
+		if len(threads) > 0 {
+			withThreads++
+		}
+		found = append(found, collectUnresolvedThreads(change.ChangeNumber(), threads)...)
+	}
+
+	sortUnresolvedThreads(found)
+	if structured {
+		if found == nil {
+			found = []unresolvedThread{}
+		}
+		return renderOutput(os.Stdout, found)
+	}
+
+	if len(found) == 0 {
+		fmt.Println("No unresolved comment threads on your open changes.")
+		return nil
+	}
+
+	headers := []string{"Change", "File", "Line", "Last Comment By", "Age", "Message"}
+	var rows [][]string
+	for _, t := range found {
+		line := ""
+		if t.Line > 0 {
+			line = strconv.Itoa(t.Line)
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(strconv.Itoa(t.Change)),
+			utils.TruncateString(t.File, 40),
+			line,
+			t.LastAuthor,
+			utils.FormatTimeAgo(t.Updated),
+			utils.TruncateString(firstLine(strings.TrimSpace(t.Message)), 50),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%s unresolved thread(s) on %d change(s)\n", utils.BoldRed(strconv.Itoa(len(found))), withThreads)
+	return nil
+}
+
+// collectUnresolvedThreads describes each unresolved thread of a change by
+// its latest comment.
+func collectUnresolvedThreads(change int, threads [][]Comment) []unresolvedThread {
+	var found []unresolvedThread
+	for _, thread := range threads {
+		if len(thread) == 0 || !thread[0].Unresolved {
+			continue
+		}
+		first, last := thread[0], thread[len(thread)-1]
+		found = append(found, unresolvedThread{
+			Change:     change,
+			File:       first.File,
+			Line:       first.Line,
+			LastAuthor: last.Author,
+			Updated:    last.Updated,
+			Message:    last.Message,
+		})
+	}
+	return found
+}
+
+// sortUnresolvedThreads orders threads by their latest comment, oldest (most
+// likely forgotten) first.
+func sortUnresolvedThreads(threads []unresolvedThread) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Updated != threads[j].Updated {
+			return threads[i].Updated < threads[j].Updated
+		}
+		return threads[i].Change < threads[j].Change
+	})
+}
@@ -1,6 +1,9 @@
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 // Two independent root threads anchored at the same (file, line), one of which
 // is resolved via a reply. They must surface as two distinct threads with
@@ -75,3 +78,92 @@ func TestBuildCommentThreadsSSHFallback(t *testing.T) {
 		t.Fatalf("expected 2 line-grouped threads, got %d", len(threads))
 	}
 }
+
+func TestCommentContext(t *testing.T) {
+	lines := []string{"one", "two", "three", "four", "five"}
+
+	got := commentContext(lines, 2, 2)
+	want := []contextLine{
+		{Number: 1, Text: "one"},
+		{Number: 2, Text: "two", Commented: true},
+		{Number: 3, Text: "three"},
+		{Number: 4, Text: "four"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commentContext() = %+v, want %+v", got, want)
+	}
+
+	if got := commentContext(nil, 3, 2); got != nil {
+		t.Errorf("commentContext(nil) = %+v, want nil", got)
+	}
+}
+
+func TestFilterThreadsByPatchset(t *testing.T) {
+	threads := [][]Comment{
+		{{ID: "a", PatchSet: 1}, {ID: "a2", PatchSet: 1}},
+		{{ID: "b", PatchSet: 2}},
+		{{ID: "c"}},
+	}
+	got := filterThreadsByPatchset(threads, 1)
+	if len(got) != 1 || got[0][0].ID != "a" || len(got[0]) != 2 {
+		t.Errorf("filterThreadsByPatchset(1) = %+v, want thread a", got)
+	}
+	if got := filterThreadsByPatchset(threads, 3); len(got) != 0 {
+		t.Errorf("filterThreadsByPatchset(3) = %+v, want none", got)
+	}
+}
+
+func TestCollectUnresolvedThreads(t *testing.T) {
+	threads := [][]Comment{
+		{
+			{File: "a.go", Line: 10, Author: "Bob", Updated: "2026-06-01 10:00:00", Unresolved: true, Message: "why?"},
+			{File: "a.go", Line: 10, Author: "Alice", Updated: "2026-06-02 10:00:00", Unresolved: true, Message: "because"},
+		},
+		{
+			{File: "b.go", Line: 3, Author: "Bob", Updated: "2026-05-01 10:00:00", Unresolved: false, Message: "nit"},
+		},
+	}
+
+	got := collectUnresolvedThreads(42, threads)
+	want := []unresolvedThread{{Change: 42, File: "a.go", Line: 10, LastAuthor: "Alice", Updated: "2026-06-02 10:00:00", Message: "because"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("collectUnresolvedThreads() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSortUnresolvedThreads(t *testing.T) {
+	threads := []unresolvedThread{
+		{Change: 2, Updated: "2026-06-03 10:00:00"},
+		{Change: 3, Updated: "2026-06-01 10:00:00"},
+		{Change: 1, Updated: "2026-06-03 10:00:00"},
+	}
+
+	sortUnresolvedThreads(threads)
+
+	var order []int
+	for _, th := range threads {
+		order = append(order, th.Change)
+	}
+	if !reflect.DeepEqual(order, []int{3, 1, 2}) {
+		t.Errorf("order = %v, want [3 1 2]", order)
+	}
+}
+
+func TestFindThreadByCommentID(t *testing.T) {
+	threads := [][]Comment{
+		{{ID: "a1", File: "x.go"}},
+		{{ID: "b1", File: "y.go"}, {ID: "b2", File: "y.go", InReplyTo: "b1"}},
+	}
+
+	thread, err := findThreadByCommentID(threads, "b2")
+	if err != nil {
+		t.Fatalf("findThreadByCommentID() error = %v", err)
+	}
+	if thread[0].ID != "b1" {
+		t.Errorf("found thread starting at %s, want b1", thread[0].ID)
+	}
+
+	if _, err := findThreadByCommentID(threads, "zz"); err == nil {
+		t.Error("findThreadByCommentID() found a thread for an unknown ID")
+	}
+}
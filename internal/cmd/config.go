@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var doctorFormat string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect gerry's configuration",
+}
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check which transports and server features work",
+	Long: `Test every transport and feature gerry relies on and print a capability
+table, so you know in advance which commands will fall back to another
+transport and which will fail:
+
+  ssh          SSH connection and server version
+  ssh-query    Change queries over SSH
+  rest-read    Authenticated REST reads (needs an HTTP password)
+  rest-write   Authenticated REST writes (a no-op preferences update)
+  stream       The Stream Events capability, needed by gerry watch
+  checks       Whether the checks plugin is installed
+  code-owners  Whether the code-owners plugin is installed
+
+Nothing on the server is changed. Exits non-zero when neither SSH nor REST
+works.
+
+Examples:
+  gerry config doctor
+  gerry config doctor --format json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDoctor,
+}
+
+func init() {
+	configDoctorCmd.Flags().StringVar(&doctorFormat, "format", "table", "Output format: table, json")
+	configCmd.AddCommand(configDoctorCmd)
+}
+
+// Check statuses.
+const (
+	checkOK      = "ok"
+	checkFailed  = "failed"
+	checkSkipped = "skipped"
+)
+
+// capabilityCheck is one row of the doctor's capability table.
+type capabilityCheck struct {
+	ID        string `json:"id"`
+	Feature   string `json:"feature"`
+	Transport string `json:"transport"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Affects   string `json:"affects"`
+}
+
+// doctorReport is the output of `gerry config doctor`.
+type doctorReport struct {
+	Checks []capabilityCheck `json:"checks"`
+	Notes  []string          `json:"notes"`
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	if doctorFormat != "table" && doctorFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", doctorFormat)
+	}
+	if doctorFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	spinner := utils.NewSpinner("Checking connectivity...")
+	checks := runDoctorChecks(cfg)
+	spinner.Stop()
+
+	report := doctorReport{Checks: checks, Notes: doctorNotes(checks)}
+
+	if doctorFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		displayDoctorReport(report)
+	}
+
+	if !checkPassed(checks, "ssh") && !checkPassed(checks, "rest-read") {
+		return fmt.Errorf("neither SSH nor REST works; check server, port, user, and credentials")
+	}
+	return nil
+}
+
+// runDoctorChecks runs every check in order, skipping the ones whose
+// transport has already failed.
+func runDoctorChecks(cfg *config.Config) []capabilityCheck {
+	var checks []capabilityCheck
+	add := func(c capabilityCheck, err error, detail string) {
+		switch {
+		case c.Status == checkSkipped:
+		case err != nil:
+			c.Status, c.Detail = checkFailed, err.Error()
+		default:
+			c.Status, c.Detail = checkOK, detail
+		}
+		checks = append(checks, c)
+	}
+
+	sshClient := gerrit.NewSSHClient(cfg)
+	version, err := sshClient.GetVersion()
+	add(capabilityCheck{ID: "ssh", Feature: "Connection", Transport: "SSH", Affects: "admin, show-queue, watch"},
+		err, strings.TrimSpace(version))
+
+	queryCheck := capabilityCheck{ID: "ssh-query", Feature: "Change queries", Transport: "SSH", Affects: "SSH fallback for list, search, details, fetch"}
+	if !checkPassed(checks, "ssh") {
+		queryCheck.Status, queryCheck.Detail = checkSkipped, "SSH connection failed"
+		add(queryCheck, nil, "")
+	} else {
+		_, err := sshClient.QueryChanges("limit:1")
+		add(queryCheck, err, "")
+	}
+
+	restClient := gerrit.NewRESTClient(cfg)
+	var serverInfo *gerrit.ServerInfo
+	var serverInfoErr error
+	pluginInstalled := func(name string) func() (string, error) {
+		return func() (string, error) {
+			if serverInfo == nil && serverInfoErr == nil {
+				serverInfo, serverInfoErr = restClient.GetServerInfo()
+			}
+			if serverInfoErr != nil {
+				return "", serverInfoErr
+			}
+			if !serverInfo.Plugin.HasPlugin(name) {
+				return "", fmt.Errorf("not installed")
+			}
+			return "installed", nil
+		}
+	}
+
+	restChecks := []struct {
+		check capabilityCheck
+		run   func() (string, error)
+	}{
+		{
+			capabilityCheck{ID: "rest-read", Feature: "Read", Transport: "REST", Affects: "most commands"},
+			func() (string, error) {
+				_, err := restClient.ListChanges(url.QueryEscape("status:open"), 1)
+				return "", err
+			},
+		},
+		{
+			capabilityCheck{ID: "rest-write", Feature: "Write", Transport: "REST", Affects: "vote, comment, submit, retrigger, files --mark-reviewed"},
+			func() (string, error) {
+				return "", restClient.UpdatePreferences(map[string]interface{}{})
+			},
+		},
+		{
+			capabilityCheck{ID: "stream", Feature: "Stream Events permission", Transport: "REST", Affects: "watch"},
+			func() (string, error) {
+				caps, err := restClient.GetCapabilities("streamEvents")
+				if err != nil {
+					return "", err
+				}
+				if _, ok := caps["streamEvents"]; !ok {
+					return "", fmt.Errorf("capability not granted")
+				}
+				return "granted", nil
+			},
+		},
+		{
+			capabilityCheck{ID: "checks", Feature: "checks plugin", Transport: "REST", Affects: "CI status beyond change messages"},
+			pluginInstalled("checks"),
+		},
+		{
+			capabilityCheck{ID: "code-owners", Feature: "code-owners plugin", Transport: "REST", Affects: "owners, suggest"},
+			pluginInstalled("code-owners"),
+		},
+	}
+
+	for _, rc := range restChecks {
+		c := rc.check
+		switch {
+		case cfg.HTTPPassword == "":
+			c.Status, c.Detail = checkSkipped, "no HTTP password configured"
+		case c.ID != "rest-read" && !checkPassed(checks, "rest-read"):
+			c.Status, c.Detail = checkSkipped, "REST read failed"
+		}
+		if c.Status == checkSkipped {
+			add(c, nil, "")
+			continue
+		}
+		detail, err := rc.run()
+		add(c, err, detail)
+	}
+	return checks
+}
+
+// checkPassed reports whether the check with the given ID ran and succeeded.
+func checkPassed(checks []capabilityCheck, id string) bool {
+	for _, c := range checks {
+		if c.ID == id {
+			return c.Status == checkOK
+		}
+	}
+	return false
+}
+
+// doctorNotes explains, in terms of commands, what the failed and skipped
+// checks mean.
+func doctorNotes(checks []capabilityCheck) []string {
+	notes := []string{}
+	sshOK, queryOK := checkPassed(checks, "ssh"), checkPassed(checks, "ssh-query")
+	restOK := checkPassed(checks, "rest-read")
+
+	switch {
+	case !restOK && queryOK:
+		notes = append(notes, "REST is unavailable: list, search, details, and fetch will fall back to SSH; REST-only commands (mine, vote, submit, files, activity) will fail")
+	case !restOK && sshOK:
+		notes = append(notes, "REST is unavailable and SSH queries fail: only SSH admin commands will work")
+	case restOK && !sshOK:
+		notes = append(notes, "SSH is unavailable: admin, show-queue, and watch will fail, and there is no fallback if REST calls fail")
+	case restOK && !queryOK:
+		notes = append(notes, "SSH queries fail: there is no fallback if REST calls fail")
+	}
+	if restOK && !checkPassed(checks, "rest-write") {
+		notes = append(notes, "REST writes fail: vote, comment, submit, retrigger, and files --mark-reviewed will fail or fall back to SSH")
+	}
+	if restOK && !checkPassed(checks, "stream") {
+		notes = append(notes, "Stream Events is not granted: gerry watch will fail")
+	}
+	if restOK && !checkPassed(checks, "code-owners") {
+		notes = append(notes, "code-owners plugin not found: gerry owners and code-owner suggestions will be unavailable")
+	}
+	return notes
+}
+
+func displayDoctorReport(report doctorReport) {
+	headers := []string{"Check", "Transport", "Status", "Detail", "Affects"}
+	var rows [][]string
+	for _, c := range report.Checks {
+		status := utils.Green("✓ " + c.Status)
+		switch c.Status {
+		case checkFailed:
+			status = utils.Red("✗ " + c.Status)
+		case checkSkipped:
+			status = utils.Gray("- " + c.Status)
+		}
+		rows = append(rows, []string{c.Feature, c.Transport, status, utils.TruncateString(c.Detail, 50), c.Affects})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+
+	if len(report.Notes) == 0 {
+		fmt.Printf("\n%s Everything gerry uses is available\n", utils.Green("✓"))
+		return
+	}
+	fmt.Println()
+	for _, note := range report.Notes {
+		fmt.Printf("%s %s\n", utils.Yellow("!"), note)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func doctorChecks(statuses map[string]string) []capabilityCheck {
+	var checks []capabilityCheck
+	for _, id := range []string{"ssh", "ssh-query", "rest-read", "rest-write", "stream", "checks", "code-owners"} {
+		status, ok := statuses[id]
+		if !ok {
+			status = checkOK
+		}
+		checks = append(checks, capabilityCheck{ID: id, Status: status})
+	}
+	return checks
+}
+
+func TestDoctorNotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses map[string]string
+		want     []string // substrings, one per expected note
+	}{
+		{"all good", nil, nil},
+		{"checks plugin missing is fine", map[string]string{"checks": checkFailed}, nil},
+		{
+			"no REST",
+			map[string]string{"rest-read": checkSkipped, "rest-write": checkSkipped, "stream": checkSkipped, "checks": checkSkipped, "code-owners": checkSkipped},
+			[]string{"fall back to SSH"},
+		},
+		{"no SSH", map[string]string{"ssh": checkFailed, "ssh-query": checkSkipped}, []string{"SSH is unavailable"}},
+		{
+			"read-only REST without stream events",
+			map[string]string{"rest-write": checkFailed, "stream": checkFailed},
+			[]string{"REST writes fail", "gerry watch will fail"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := doctorNotes(doctorChecks(tt.statuses))
+			if len(got) != len(tt.want) {
+				t.Fatalf("doctorNotes() = %q, want %d note(s)", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("note %d = %q, want it to mention %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
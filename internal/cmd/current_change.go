@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// currentChangeArg names the change checked out in the current repository
+// wherever a change ID is expected.
+const currentChangeArg = "."
+
+// changeRefPattern matches a change ref, e.g. refs/changes/45/12345/3.
+var changeRefPattern = regexp.MustCompile(`refs/changes/\d{2}/(\d+)/\d+`)
+
+// withCurrentChange resolves "." to the change checked out in the current
+// repository. Without a change ID it uses that change when there is one,
+// and otherwise lets the user pick one.
+func withCurrentChange(args []string) ([]string, error) {
+	if len(args) > 0 && args[0] != currentChangeArg {
+		return args, nil
+	}
+
+	changeID, err := currentChangeID()
+	if err != nil {
+		if len(args) > 0 {
+			return nil, err
+		}
+		utils.Debugf("No current change: %v", err)
+		return withPickedChange(args)
+	}
+	utils.Debugf("Using change %s from the current checkout", changeID)
+	if len(args) == 0 {
+		return []string{changeID}, nil
+	}
+	return append([]string{changeID}, args[1:]...), nil
+}
+
+// currentChangeID returns the change checked out in the current repository:
+// the number of the change ref HEAD was fetched from or points at, or else
+// the Change-Id footer of the HEAD commit.
+func currentChangeID() (string, error) {
+	head, err := gitOutput("", "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("cannot find the current change: not in a git repository")
+	}
+
+	if path, err := gitOutput("", "rev-parse", "--git-path", "FETCH_HEAD"); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			if number := fetchHeadChange(string(data), head); number != "" {
+				return number, nil
+			}
+		}
+	}
+
+	if refs, err := gitOutput("", "for-each-ref", "--points-at", "HEAD", "--format=%(refname)", "refs/changes/"); err == nil {
+		for _, ref := range strings.Fields(refs) {
+			if m := changeRefPattern.FindStringSubmatch(ref); m != nil {
+				return m[1], nil
+			}
+		}
+	}
+
+	message, err := gitOutput("", "log", "-1", "--format=%B", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read the HEAD commit: %w", err)
+	}
+	if changeID := parseChangeIDFooter(message); changeID != "" {
+		return changeID, nil
+	}
+	return "", fmt.Errorf("cannot find the current change: HEAD was not fetched from a change ref and has no Change-Id footer")
+}
+
+// fetchHeadChange returns the change number of the FETCH_HEAD entry for
+// commit head when it was fetched from a change ref, as 'gerry fetch' does.
+func fetchHeadChange(fetchHead, head string) string {
+	scanner := bufio.NewScanner(strings.NewReader(fetchHead))
+	for scanner.Scan() {
+		sha, rest, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok || sha != head {
+			continue
+		}
+		if m := changeRefPattern.FindStringSubmatch(rest); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import "testing"
+
+func TestFetchHeadChange(t *testing.T) {
+	head := "3f9c2a1e8b7d6c5f4e3d2c1b0a9f8e7d6c5b4a39"
+	other := "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name      string
+		fetchHead string
+		want      string
+	}{
+		{
+			name:      "change ref",
+			fetchHead: head + "\t\t'refs/changes/45/12345/3' of ssh://alice@gerrit.example.com:29418/proj\n",
+			want:      "12345",
+		},
+		{
+			name:      "fetched change is not checked out",
+			fetchHead: other + "\t\t'refs/changes/45/12345/3' of ssh://alice@gerrit.example.com:29418/proj\n",
+		},
+		{
+			name:      "branch",
+			fetchHead: head + "\t\tbranch 'master' of ssh://alice@gerrit.example.com:29418/proj\n",
+		},
+		{
+			name: "several entries",
+			fetchHead: other + "\t\t'refs/changes/01/201/1' of ssh://gerrit/proj\n" +
+				head + "\t\t'refs/changes/02/202/4' of ssh://gerrit/proj\n",
+			want: "202",
+		},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fetchHeadChange(tt.fetchHead, head); got != tt.want {
+				t.Errorf("fetchHeadChange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var dashboardLimit int
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show your incoming and outgoing reviews in one view",
+	Long: `Show the sections of the Gerrit web dashboard, each from its own query,
+fetched at once:
+
+  Outgoing reviews   your open changes
+  Incoming reviews   open changes you are a reviewer on
+  CC'd on            open changes you are CC'd on
+  Recently closed    changes you own or reviewed, closed in the last 4 weeks
+
+Work-in-progress and ignored changes are left out, except your own in
+Recently closed. A section that fails to load is reported in place; the
+others are still shown.
+
+Examples:
+  gerry dashboard
+  gerry dashboard --limit 5
+  gerry dashboard --output json`,
+	Args: cobra.NoArgs,
+	RunE: runDashboard,
+}
+
+func init() {
+	dashboardCmd.Flags().IntVarP(&dashboardLimit, "limit", "n", 10, "Maximum number of changes per section")
+}
+
+// dashboardSection is one section of the dashboard and the query behind it.
+type dashboardSection struct {
+	Title string
+	Query string
+}
+
+// dashboardSections mirrors the default dashboard of the Gerrit web UI.
+func dashboardSections(user string) []dashboardSection {
+	return []dashboardSection{
+		{"Outgoing reviews", fmt.Sprintf("is:open owner:%s -is:wip -is:ignored", user)},
+		{"Incoming reviews", fmt.Sprintf("is:open -owner:%s -is:wip -is:ignored reviewer:%s", user, user)},
+		{"CC'd on", fmt.Sprintf("is:open -is:wip -is:ignored cc:%s", user)},
+		{"Recently closed", fmt.Sprintf("is:closed -is:ignored (-is:wip OR owner:%s) (owner:%s OR reviewer:%s OR cc:%s) -age:4w", user, user, user, user)},
+	}
+}
+
+// dashboardResult is a loaded section, as --output json or yaml writes it.
+type dashboardResult struct {
+	Title   string          `json:"title"`
+	Query   string          `json:"query"`
+	Changes []gerrit.Change `json:"changes"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	if dashboardLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	spinner := utils.NewSpinner("Loading dashboard...")
+	results := loadDashboard(dashboardSections(cfg.User), func(query string) ([]gerrit.Change, error) {
+		return listChangesQuietly(cfg, query, dashboardLimit)
+	})
+	spinner.Stop()
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed == len(results) {
+		return fmt.Errorf("failed to load dashboard: %s", results[0].Error)
+	}
+
+	if structured {
+		return renderOutput(os.Stdout, results)
+	}
+	displayDashboard(results, changeTableSpec(cfg.User, 45, teamTableDefaults...))
+	return nil
+}
+
+// loadDashboard runs every section's query concurrently and returns the
+// results in section order.
+func loadDashboard(sections []dashboardSection, fetch func(query string) ([]gerrit.Change, error)) []dashboardResult {
+	results := make([]dashboardResult, len(sections))
+	var wg sync.WaitGroup
+	for i, section := range sections {
+		wg.Add(1)
+		go func(i int, section dashboardSection) {
+			defer wg.Done()
+			utils.Debugf("Query (%s): %s", section.Title, section.Query)
+			result := dashboardResult{Title: section.Title, Query: section.Query, Changes: []gerrit.Change{}}
+			changes, err := fetch(section.Query)
+			if err != nil {
+				result.Error = err.Error()
+			} else if changes != nil {
+				result.Changes = changes
+			}
+			results[i] = result
+		}(i, section)
+	}
+	wg.Wait()
+	return results
+}
+
+func displayDashboard(results []dashboardResult, table utils.TableSpec) {
+	columns, _ := table.ParseColumns("")
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		header := utils.BoldCyan(r.Title)
+		if r.Error == "" {
+			count := fmt.Sprintf("(%d)", len(r.Changes))
+			if n := len(r.Changes); n > 0 && r.Changes[n-1].MoreChanges {
+				count = fmt.Sprintf("(%d+)", n)
+			}
+			header += " " + utils.Gray(count)
+		}
+		fmt.Println(header)
+
+		switch {
+		case r.Error != "":
+			fmt.Printf("  %s\n", utils.Red("Failed to load: "+r.Error))
+		case len(r.Changes) == 0:
+			fmt.Printf("  %s\n", utils.Gray("No changes"))
+		default:
+			displayChangeTable(table, columns, r.Changes)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestDashboardSections(t *testing.T) {
+	sections := dashboardSections("alice")
+	var titles []string
+	for _, s := range sections {
+		titles = append(titles, s.Title)
+		if !strings.Contains(s.Query, "alice") {
+			t.Errorf("%s query %q doesn't mention the user", s.Title, s.Query)
+		}
+	}
+	if got := strings.Join(titles, ", "); got != "Outgoing reviews, Incoming reviews, CC'd on, Recently closed" {
+		t.Errorf("sections = %s", got)
+	}
+}
+
+func TestLoadDashboard(t *testing.T) {
+	sections := []dashboardSection{{"One", "q1"}, {"Two", "q2"}, {"Three", "q3"}}
+	results := loadDashboard(sections, func(query string) ([]gerrit.Change, error) {
+		switch query {
+		case "q1":
+			return []gerrit.Change{{Number: 1}}, nil
+		case "q2":
+			return nil, fmt.Errorf("connection refused")
+		}
+		return nil, nil
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, s := range sections {
+		if results[i].Title != s.Title || results[i].Query != s.Query {
+			t.Errorf("result %d is %s (%s), want %s (%s)", i, results[i].Title, results[i].Query, s.Title, s.Query)
+		}
+	}
+	if len(results[0].Changes) != 1 || results[0].Error != "" {
+		t.Errorf("One = %+v, want one change", results[0])
+	}
+	if results[1].Error != "connection refused" {
+		t.Errorf("Two error = %q", results[1].Error)
+	}
+	if results[2].Changes == nil || len(results[2].Changes) != 0 {
+		t.Errorf("Three changes = %#v, want an empty slice", results[2].Changes)
+	}
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
@@ -12,22 +14,36 @@ import (
 )
 
 var (
-	showFiles bool
+	showFiles       bool
+	detailsPatchset int
 )
 
 var detailsCmd = &cobra.Command{
-	Use:   "details <change-id>",
+	Use:   "details [change-id]",
 	Short: "Show change details",
-	Long:  `Show comprehensive information about a change including files, reviewers, and scores.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Show comprehensive information about a change including files, reviewers, and scores.
+
+With --patchset, the description, file list, and review progress are those of
+an older patchset; labels and reviewers always reflect the current one.
+
+"." or no change-id means the change checked out in the current repository:
+the change ref HEAD was fetched from (as by 'gerry fetch'), or else the
+Change-Id footer of the HEAD commit. Without a checked-out change and
+change-id, pick one of your open or incoming changes from a list.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runDetails,
 }
 
 func init() {
 	detailsCmd.Flags().BoolVar(&showFiles, "files", false, "Show list of changed files")
+	detailsCmd.Flags().IntVar(&detailsPatchset, "patchset", 0, "Show this patchset instead of the current one (requires REST)")
 }
 
 func runDetails(cmd *cobra.Command, args []string) error {
+	args, err := withCurrentChange(args)
+	if err != nil {
+		return err
+	}
 	changeID := args[0]
 	if err := utils.ValidateChangeID(changeID); err != nil {
 		return fmt.Errorf("invalid change ID: %w", err)
@@ -41,9 +57,39 @@ func runDetails(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
 
 	utils.Debugf("Fetching details for change %s", changeID)
 
+	if detailsPatchset > 0 {
+		client := gerrit.NewRESTClient(cfg)
+		change, err := client.GetChangeWithOptions(changeID, "ALL_REVISIONS", "ALL_COMMITS")
+		if err != nil {
+			return fmt.Errorf("failed to get change details: %w", err)
+		}
+		latest := change.CurrentPatchSetNumber()
+		rev, err := viewPatchset(change, detailsPatchset)
+		if err != nil {
+			return err
+		}
+		if structured {
+			return renderChangeDetails(cfg, changeID, change)
+		}
+
+		displayChangeDetails(change)
+		fmt.Printf("\n%s patch set %d of %d, uploaded by %s %s\n", utils.Yellow("Viewing"), detailsPatchset, latest,
+			rev.Uploader.DisplayName(), utils.FormatTimeAgo(rev.Created))
+		displayReviewProgress(cfg, changeID, change)
+		if showFiles {
+			fmt.Println()
+			displayChangeFiles(cfg, changeID, change)
+		}
+		return nil
+	}
+
 	change, err := getChangeDetailsREST(cfg, changeID)
 	if err != nil {
 		utils.Warnf("REST API failed: %v", err)
@@ -53,8 +99,12 @@ func runDetails(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get change details: %w", err)
 		}
 	}
+	if structured {
+		return renderChangeDetails(cfg, changeID, change)
+	}
 
 	displayChangeDetails(change)
+	displayReviewProgress(cfg, changeID, change)
 
 	if showFiles {
 		fmt.Println()
@@ -63,6 +113,24 @@ func runDetails(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// renderChangeDetails writes the change for --output json or yaml, with the
+// viewed patchset's files when --files is given.
+func renderChangeDetails(cfg *config.Config, changeID string, change *gerrit.Change) error {
+	if showFiles && change.CurrentRevision != "" {
+		files, err := gerrit.NewRESTClient(cfg).GetChangeFiles(changeID, change.CurrentRevision)
+		if err != nil {
+			return fmt.Errorf("failed to get changed files: %w", err)
+		}
+		if change.Revisions == nil {
+			change.Revisions = make(map[string]gerrit.RevisionInfo)
+		}
+		rev := change.Revisions[change.CurrentRevision]
+		rev.Files = files
+		change.Revisions[change.CurrentRevision] = rev
+	}
+	return renderOutput(os.Stdout, change)
+}
+
 func getChangeDetailsREST(cfg *config.Config, changeID string) (*gerrit.Change, error) {
 	client := gerrit.NewRESTClient(cfg)
 	return client.GetChange(changeID)
@@ -263,3 +331,42 @@ func displayChangeFiles(cfg *config.Config, changeID string, change *gerrit.Chan
 		fmt.Printf("  %s%s%s\n", statusIcon, fileName, changes)
 	}
 }
+
+// displayReviewProgress shows how many files of the current patchset you have
+// marked reviewed (see 'gerry files'). Skipped when files cannot be listed.
+func displayReviewProgress(cfg *config.Config, changeID string, change *gerrit.Change) {
+	patchset := change.CurrentPatchSetNumber()
+	if patchset == 0 {
+		return
+	}
+
+	client := gerrit.NewRESTClient(cfg)
+	files, err := client.GetChangeFiles(changeID, strconv.Itoa(patchset))
+	if err != nil {
+		utils.Debugf("Failed to get files for review progress: %v", err)
+		return
+	}
+	paths := reviewablePaths(files)
+	if len(paths) == 0 {
+		return
+	}
+
+	done, remaining := countReviewed(paths, newReviewedTracker(cfg, changeID, patchset).reviewed())
+	status := utils.Yellow(fmt.Sprintf("%d unreviewed", remaining))
+	if remaining == 0 {
+		status = utils.Green("all reviewed")
+	}
+	fmt.Printf("\n%s %d of %d files reviewed (%s)\n", utils.BoldCyan("Your Review:"), done, len(paths), status)
+}
+
+// viewPatchset points the change's current revision at an older patchset so
+// the revision-based views (description, files, review progress) show it.
+// The change must have been fetched with ALL_REVISIONS.
+func viewPatchset(change *gerrit.Change, patchset int) (gerrit.RevisionInfo, error) {
+	sha, rev, ok := change.RevisionByNumber(patchset)
+	if !ok {
+		return gerrit.RevisionInfo{}, fmt.Errorf("patchset %d not found (change has %d)", patchset, change.CurrentPatchSetNumber())
+	}
+	change.CurrentRevision = sha
+	return rev, nil
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFile    string
+	diffNoPager bool
+	diffFrom    int
+	diffTo      int
+)
+
+// diffContext is the number of unchanged lines around each hunk of a
+// patchset-to-patchset diff, as in git diff.
+const diffContext = 3
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <change-id> [patchset]",
+	Short: "Show the diff of a change",
+	Long: `Show a patchset of a change as a colorized unified diff, preceded by its
+commit message. Without a patchset the current one is shown.
+
+With --from, show what changed between two patchsets of the change instead:
+from patchset --from to --to (default: the current one). Use it to re-review
+a change after the author uploads a new patchset.
+
+When writing to a terminal the output is piped through diff_pager from the
+config, if set, e.g. "less -R" or "delta". --no-pager prints it directly.
+
+Examples:
+  gerry diff 12345
+  gerry diff 12345 3
+  gerry diff 12345 --file src/app.go
+  gerry diff 12345 --from 2 --to 5
+  gerry diff 12345 --from 4
+  gerry diff 12345 --no-pager`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFile, "file", "f", "", "Only show the diff of this file")
+	diffCmd.Flags().BoolVar(&diffNoPager, "no-pager", false, "Do not pipe the output through diff_pager")
+	diffCmd.Flags().IntVar(&diffFrom, "from", 0, "Show the changes since this patchset")
+	diffCmd.Flags().IntVar(&diffTo, "to", 0, "Patchset to compare --from against (default: current)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	revision := "current"
+	if len(args) > 1 {
+		if !isDigits(args[1]) {
+			return fmt.Errorf("invalid patchset: %s", args[1])
+		}
+		revision = args[1]
+	}
+
+	fromSet, toSet := cmd.Flags().Changed("from"), cmd.Flags().Changed("to")
+	if toSet && !fromSet {
+		return fmt.Errorf("--to requires --from; to show a single patchset, pass it as an argument")
+	}
+	if fromSet {
+		if len(args) > 1 {
+			return fmt.Errorf("use --to instead of a patchset argument with --from")
+		}
+		if diffFrom < 1 || (toSet && diffTo < 1) {
+			return fmt.Errorf("patchset numbers start at 1")
+		}
+		if toSet {
+			if diffTo == diffFrom {
+				return fmt.Errorf("--from and --to are the same patchset")
+			}
+			revision = strconv.Itoa(diffTo)
+		}
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	var output string
+	if fromSet {
+		output, err = patchsetDiff(client, changeID, strconv.Itoa(diffFrom), revision)
+	} else {
+		output, err = patchDiff(client, changeID, revision)
+	}
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		return nil
+	}
+
+	output = colorizeDiff(output)
+	if cfg.DiffPager == "" || diffNoPager || !utils.IsTerminal(os.Stdout) {
+		fmt.Print(output)
+		return nil
+	}
+
+	pager := shellCommand(cfg.DiffPager)
+	pager.Stdin = strings.NewReader(output)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if err := pager.Run(); err != nil {
+		return fmt.Errorf("pager %q failed: %w", cfg.DiffPager, err)
+	}
+	return nil
+}
+
+// patchDiff returns a patchset as a format-patch.
+func patchDiff(client *gerrit.RESTClient, changeID, revision string) (string, error) {
+	spinner := utils.NewSpinner(fmt.Sprintf("Fetching diff of %s...", changeID))
+	patch, err := client.GetPatch(changeID, revision, diffFile)
+	spinner.Stop()
+	if err != nil {
+		return "", fmt.Errorf("failed to get patch: %w", err)
+	}
+	if diffFile != "" && !strings.Contains(patch, "\ndiff --git ") {
+		return "", fmt.Errorf("%s is not modified in patchset %s of %s", diffFile, revision, changeID)
+	}
+	return patch, nil
+}
+
+// patchsetDiff returns the unified diff of every file that differs between
+// patchset base and revision, or "" if none does.
+func patchsetDiff(client *gerrit.RESTClient, changeID, base, revision string) (string, error) {
+	spinner := utils.NewSpinner(fmt.Sprintf("Comparing patchsets of %s...", changeID))
+	files, err := client.GetChangeFilesAgainst(changeID, revision, base)
+	if err != nil {
+		spinner.Stop()
+		return "", fmt.Errorf("failed to list files: %w", err)
+	}
+	paths := reviewablePaths(files)
+	if diffFile != "" {
+		if _, ok := files[diffFile]; !ok {
+			spinner.Stop()
+			return "", fmt.Errorf("%s did not change between patchset %s and %s", diffFile, base, revision)
+		}
+		paths = []string{diffFile}
+	}
+
+	var b strings.Builder
+	for _, path := range paths {
+		diff, err := client.GetFileDiff(changeID, revision, path, base, diffContext)
+		if err != nil {
+			spinner.Stop()
+			return "", fmt.Errorf("failed to get diff of %s: %w", path, err)
+		}
+		b.WriteString(renderFileDiff(path, diff))
+	}
+	spinner.Stop()
+
+	if b.Len() == 0 {
+		utils.Infof("No files changed between patchset %s and %s", base, revision)
+	}
+	return b.String(), nil
+}
+
+// renderFileDiff writes a Gerrit file diff as a git-style unified diff.
+func renderFileDiff(path string, diff *gerrit.DiffInfo) string {
+	oldPath, newPath := path, path
+	if diff.MetaA != nil {
+		oldPath = diff.MetaA.Name
+	}
+	if diff.MetaB != nil {
+		newPath = diff.MetaB.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", oldPath, newPath)
+	if diff.ChangeType == "RENAMED" || diff.ChangeType == "COPIED" {
+		verb := "rename"
+		if diff.ChangeType == "COPIED" {
+			verb = "copy"
+		}
+		fmt.Fprintf(&b, "%s from %s\n%s to %s\n", verb, oldPath, verb, newPath)
+	}
+	if diff.Binary {
+		fmt.Fprintf(&b, "Binary files a/%s and b/%s differ\n", oldPath, newPath)
+		return b.String()
+	}
+
+	from, to := "a/"+oldPath, "b/"+newPath
+	if diff.MetaA == nil {
+		from = "/dev/null"
+	}
+	if diff.MetaB == nil {
+		to = "/dev/null"
+	}
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", from, to)
+
+	// Hunks are separated by skipped common lines.
+	var hunk []string
+	lineA, lineB := 1, 1
+	startA, startB, countA, countB := 0, 0, 0, 0
+	changed := false
+	flush := func() {
+		if changed {
+			fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(startA, countA), hunkRange(startB, countB))
+			for _, line := range hunk {
+				b.WriteString(line + "\n")
+			}
+		}
+		hunk, countA, countB, changed = nil, 0, 0, false
+	}
+	for _, chunk := range diff.Content {
+		if chunk.Skip > 0 {
+			flush()
+			lineA += chunk.Skip
+			lineB += chunk.Skip
+			continue
+		}
+		if len(hunk) == 0 {
+			startA, startB = lineA, lineB
+		}
+		for _, line := range chunk.AB {
+			hunk = append(hunk, " "+line)
+		}
+		for _, line := range chunk.A {
+			hunk = append(hunk, "-"+line)
+		}
+		for _, line := range chunk.B {
+			hunk = append(hunk, "+"+line)
+		}
+		countA += len(chunk.AB) + len(chunk.A)
+		countB += len(chunk.AB) + len(chunk.B)
+		lineA += len(chunk.AB) + len(chunk.A)
+		lineB += len(chunk.AB) + len(chunk.B)
+		if len(chunk.A) > 0 || len(chunk.B) > 0 {
+			changed = true
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// hunkRange formats one side of a hunk header. An empty side names the line
+// before it, as in git diff.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// colorizeDiff colors a format-patch the way git diff does: file headers
+// bold, hunk headers cyan, additions green, and deletions red. The commit
+// message before the first file is left as is.
+func colorizeDiff(patch string) string {
+	var b strings.Builder
+	inDiff := false
+	for _, line := range strings.SplitAfter(patch, "\n") {
+		text := strings.TrimRight(line, "\n")
+		newline := line[len(text):]
+		if strings.HasPrefix(text, "diff --git ") {
+			inDiff = true
+		}
+
+		switch {
+		case !inDiff || text == "":
+			b.WriteString(line)
+			continue
+		case strings.HasPrefix(text, "diff --git "),
+			strings.HasPrefix(text, "index "),
+			strings.HasPrefix(text, "--- "),
+			strings.HasPrefix(text, "+++ "),
+			strings.HasPrefix(text, "new file mode"),
+			strings.HasPrefix(text, "deleted file mode"),
+			strings.HasPrefix(text, "rename "),
+			strings.HasPrefix(text, "similarity index"):
+			text = utils.BoldWhite(text)
+		case strings.HasPrefix(text, "@@"):
+			text = utils.Cyan(text)
+		case strings.HasPrefix(text, "+"):
+			text = utils.Green(text)
+		case strings.HasPrefix(text, "-"):
+			text = utils.Red(text)
+		}
+		b.WriteString(text + newline)
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/fatih/color"
+)
+
+func TestColorizeDiff(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	patch := "From abc Mon Sep 17 00:00:00 2001\n" +
+		"Subject: [PATCH] Fix it\n" +
+		"\n" +
+		"- not a deletion\n" +
+		"---\n" +
+		"diff --git a/x.go b/x.go\n" +
+		"--- a/x.go\n" +
+		"+++ b/x.go\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" keep\n" +
+		"-old\n" +
+		"+new\n"
+
+	want := "From abc Mon Sep 17 00:00:00 2001\n" +
+		"Subject: [PATCH] Fix it\n" +
+		"\n" +
+		"- not a deletion\n" +
+		"---\n" +
+		utils.BoldWhite("diff --git a/x.go b/x.go") + "\n" +
+		utils.BoldWhite("--- a/x.go") + "\n" +
+		utils.BoldWhite("+++ b/x.go") + "\n" +
+		utils.Cyan("@@ -1,2 +1,2 @@") + "\n" +
+		" keep\n" +
+		utils.Red("-old") + "\n" +
+		utils.Green("+new") + "\n"
+
+	if got := colorizeDiff(patch); got != want {
+		t.Errorf("colorizeDiff() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderFileDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff gerrit.DiffInfo
+		want string
+	}{
+		{
+			name: "hunks split by skipped lines",
+			diff: gerrit.DiffInfo{
+				MetaA:      &gerrit.DiffFileMeta{Name: "x.go"},
+				MetaB:      &gerrit.DiffFileMeta{Name: "x.go"},
+				ChangeType: "MODIFIED",
+				Content: []gerrit.DiffContent{
+					{AB: []string{"one"}},
+					{A: []string{"two"}, B: []string{"2", "2b"}},
+					{AB: []string{"three"}},
+					{Skip: 10},
+					{AB: []string{"fourteen"}},
+					{B: []string{"new"}},
+				},
+			},
+			want: "diff --git a/x.go b/x.go\n--- a/x.go\n+++ b/x.go\n" +
+				"@@ -1,3 +1,4 @@\n one\n-two\n+2\n+2b\n three\n" +
+				"@@ -14,1 +15,2 @@\n fourteen\n+new\n",
+		},
+		{
+			name: "added file",
+			diff: gerrit.DiffInfo{
+				MetaB:      &gerrit.DiffFileMeta{Name: "new.go"},
+				ChangeType: "ADDED",
+				Content:    []gerrit.DiffContent{{B: []string{"package x"}}},
+			},
+			want: "diff --git a/new.go b/new.go\n--- /dev/null\n+++ b/new.go\n@@ -0,0 +1,1 @@\n+package x\n",
+		},
+		{
+			name: "renamed binary",
+			diff: gerrit.DiffInfo{
+				MetaA:      &gerrit.DiffFileMeta{Name: "old.png"},
+				MetaB:      &gerrit.DiffFileMeta{Name: "new.png"},
+				ChangeType: "RENAMED",
+				Binary:     true,
+			},
+			want: "diff --git a/old.png b/new.png\nrename from old.png\nrename to new.png\nBinary files a/old.png and b/new.png differ\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := tt.diff
+			if got := renderFileDiff(diff.MetaB.Name, &diff); got != tt.want {
+				t.Errorf("renderFileDiff() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffstatTopic  string
+	diffstatQuery  string
+	diffstatFormat string
+	diffstatMax    int
+)
+
+var diffstatCmd = &cobra.Command{
+	Use:   "diffstat",
+	Short: "Total the lines changed across a topic or query",
+	Long: `Sum the files and lines changed by the current patchset of every change in a
+topic or matching a query, broken down by project, to report the size of a
+multi-change effort. The commit message is not counted, and a file touched by
+several changes in a project counts once towards its file total.
+
+--topic leaves out abandoned changes; --query is used as given.
+
+Examples:
+  gerry diffstat --topic big-feature
+  gerry diffstat --query "owner:self status:merged after:2025-01-01"
+  gerry diffstat --topic big-feature --format json`,
+	Args: cobra.NoArgs,
+	RunE: runDiffstat,
+}
+
+func init() {
+	diffstatCmd.Flags().StringVarP(&diffstatTopic, "topic", "t", "", "Topic to total")
+	diffstatCmd.Flags().StringVarP(&diffstatQuery, "query", "q", "", "Gerrit search query selecting the changes to total")
+	diffstatCmd.Flags().StringVar(&diffstatFormat, "format", "table", "Output format: table, json")
+	diffstatCmd.Flags().IntVar(&diffstatMax, "max-changes", 1000, "Maximum number of changes to total")
+}
+
+// projectDiffstat is the diffstat of one project's changes, or of all of
+// them for the total.
+type projectDiffstat struct {
+	Project    string `json:"project,omitempty"`
+	Changes    int    `json:"changes"`
+	Files      int    `json:"files"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+
+	paths map[string]bool
+}
+
+// diffstatReport is the output of `gerry diffstat`.
+type diffstatReport struct {
+	Query    string            `json:"query"`
+	Projects []projectDiffstat `json:"projects"`
+	Total    projectDiffstat   `json:"total"`
+}
+
+func runDiffstat(cmd *cobra.Command, args []string) error {
+	if (diffstatTopic == "") == (diffstatQuery == "") {
+		return fmt.Errorf("exactly one of --topic or --query is required")
+	}
+	if diffstatFormat != "table" && diffstatFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", diffstatFormat)
+	}
+	if diffstatFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	query := diffstatQuery
+	if diffstatTopic != "" {
+		query = fmt.Sprintf("topic:%q -status:abandoned", diffstatTopic)
+	}
+
+	var changes []gerrit.Change
+	total, err := paginateChanges(client, query, []string{"CURRENT_REVISION", "CURRENT_FILES"}, 100, diffstatMax, func(page []gerrit.Change) error {
+		changes = append(changes, page...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list changes: %w", err)
+	}
+	if total >= diffstatMax {
+		utils.Warnf("Stopped after %d changes; raise --max-changes to total them all", diffstatMax)
+	}
+
+	report := buildDiffstat(changes)
+	report.Query = query
+
+	if diffstatFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if report.Total.Changes == 0 {
+		utils.Infof("No changes match %s", query)
+		return nil
+	}
+
+	headers := []string{"Project", "Changes", "Files", "Insertions", "Deletions"}
+	var rows [][]string
+	for _, p := range report.Projects {
+		rows = append(rows, diffstatRow(p.Project, p))
+	}
+	if len(report.Projects) > 1 {
+		rows = append(rows, diffstatRow(utils.BoldWhite("Total"), report.Total))
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	return nil
+}
+
+func diffstatRow(name string, d projectDiffstat) []string {
+	return []string{
+		name,
+		strconv.Itoa(d.Changes),
+		strconv.Itoa(d.Files),
+		utils.Green("+" + strconv.Itoa(d.Insertions)),
+		utils.Red("-" + strconv.Itoa(d.Deletions)),
+	}
+}
+
+// buildDiffstat totals the current-revision files of changes per project,
+// largest project first.
+func buildDiffstat(changes []gerrit.Change) diffstatReport {
+	byProject := make(map[string]*projectDiffstat)
+	report := diffstatReport{Projects: []projectDiffstat{}}
+
+	for _, change := range changes {
+		p := byProject[change.Project]
+		if p == nil {
+			p = &projectDiffstat{Project: change.Project, paths: make(map[string]bool)}
+			byProject[change.Project] = p
+		}
+		p.Changes++
+		report.Total.Changes++
+
+		files := change.Revisions[change.CurrentRevision].Files
+		for _, path := range reviewablePaths(files) {
+			fi := files[path]
+			p.paths[path] = true
+			p.Insertions += fi.LinesInserted
+			p.Deletions += fi.LinesDeleted
+		}
+	}
+
+	for _, p := range byProject {
+		p.Files = len(p.paths)
+		report.Total.Files += p.Files
+		report.Total.Insertions += p.Insertions
+		report.Total.Deletions += p.Deletions
+		report.Projects = append(report.Projects, *p)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		a, b := report.Projects[i], report.Projects[j]
+		if a.Insertions+a.Deletions != b.Insertions+b.Deletions {
+			return a.Insertions+a.Deletions > b.Insertions+b.Deletions
+		}
+		return a.Project < b.Project
+	})
+	return report
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func changeWithFiles(number int, project string, files map[string]gerrit.FileInfo) gerrit.Change {
+	return gerrit.Change{
+		Number:          number,
+		Project:         project,
+		CurrentRevision: "rev",
+		Revisions:       map[string]gerrit.RevisionInfo{"rev": {Number: 1, Files: files}},
+	}
+}
+
+func TestBuildDiffstat(t *testing.T) {
+	changes := []gerrit.Change{
+		changeWithFiles(1, "web", map[string]gerrit.FileInfo{
+			"/COMMIT_MSG": {LinesInserted: 10},
+			"app.js":      {LinesInserted: 5, LinesDeleted: 2},
+		}),
+		changeWithFiles(2, "web", map[string]gerrit.FileInfo{
+			"app.js":  {LinesInserted: 1},
+			"app.css": {LinesDeleted: 4},
+		}),
+		changeWithFiles(3, "api", map[string]gerrit.FileInfo{
+			"server.go": {LinesInserted: 100, LinesDeleted: 20},
+		}),
+	}
+
+	r := buildDiffstat(changes)
+
+	if len(r.Projects) != 2 || r.Projects[0].Project != "api" {
+		t.Fatalf("Projects = %+v, want api first", r.Projects)
+	}
+	web := r.Projects[1]
+	if web.Changes != 2 || web.Files != 2 || web.Insertions != 6 || web.Deletions != 6 {
+		t.Errorf("web = %+v, want 2 changes, 2 files, +6 -6", web)
+	}
+	if r.Total.Changes != 3 || r.Total.Files != 3 || r.Total.Insertions != 106 || r.Total.Deletions != 26 {
+		t.Errorf("Total = %+v, want 3 changes, 3 files, +106 -26", r.Total)
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	draftMessage    string
+	draftPatchset   int
+	draftUnresolved bool
+	draftEdit       bool
+	draftDeleteAll  bool
+
+	draftCodeReview int
+	draftVerified   int
+	draftLabels     []string
+)
+
+var draftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Collect draft comments and publish them as one review",
+	Long: `Build up inline comments as drafts on the server, the way the web UI does,
+then publish them all at once with a review message and votes. Drafts are the
+same ones the web UI shows, so you can mix the two.
+
+Examples:
+  gerry draft add 12345 src/app.go:42 "Can this be nil?"
+  gerry draft add 12345 src/util.go:7 --unresolved --edit
+  gerry draft list 12345
+  gerry draft delete 12345 8f3a1c2e_5b7d9f01
+  gerry draft publish 12345 -m "A few questions" --code-review -1`,
+}
+
+var draftAddCmd = &cobra.Command{
+	Use:   "add <change-id> <file>:<line> [message]",
+	Short: "Save a draft inline comment",
+	Long: `Save a draft comment on a line of a file. The message is the last argument
+or --message; "-" reads it from stdin, and with --edit, or no message at all,
+$VISUAL or $EDITOR is opened to write it.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runDraftAdd,
+}
+
+var draftListCmd = &cobra.Command{
+	Use:   "list <change-id>",
+	Short: "List your draft comments on a change",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDraftList,
+}
+
+var draftDeleteCmd = &cobra.Command{
+	Use:   "delete <change-id> [draft-id...]",
+	Short: "Delete draft comments",
+	Long: `Delete draft comments by ID (from gerry draft list), or all of your drafts
+on the change with --all.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDraftDelete,
+}
+
+var draftPublishCmd = &cobra.Command{
+	Use:   "publish <change-id> [shorthand...]",
+	Short: "Publish all drafts as one review",
+	Long: `Publish all of your drafts on the change, across patchsets, as a single
+review on the current patchset, with an optional message and votes. Votes take
+the same shorthand arguments as gerry vote (+2, v+1, -v after --).`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDraftPublish,
+}
+
+func init() {
+	draftAddCmd.Flags().StringVarP(&draftMessage, "message", "m", "", "Comment message (\"-\" reads stdin)")
+	draftAddCmd.Flags().IntVarP(&draftPatchset, "patchset", "p", 0, "Patchset to comment on (default: current)")
+	draftAddCmd.Flags().BoolVar(&draftUnresolved, "unresolved", false, "Start an unresolved thread")
+	draftAddCmd.Flags().BoolVarP(&draftEdit, "edit", "e", false, "Write the message in $EDITOR")
+
+	draftDeleteCmd.Flags().BoolVar(&draftDeleteAll, "all", false, "Delete all of your drafts on the change")
+
+	draftPublishCmd.Flags().StringVarP(&draftMessage, "message", "m", "", "Review message")
+	draftPublishCmd.Flags().IntVar(&draftCodeReview, "code-review", 0, "Code-Review vote (-2..+2)")
+	draftPublishCmd.Flags().IntVar(&draftVerified, "verified", 0, "Verified vote (-1..+1)")
+	draftPublishCmd.Flags().StringArrayVarP(&draftLabels, "label", "l", nil, "Arbitrary label vote NAME=VALUE (repeatable)")
+
+	draftCmd.AddCommand(draftAddCmd)
+	draftCmd.AddCommand(draftListCmd)
+	draftCmd.AddCommand(draftDeleteCmd)
+	draftCmd.AddCommand(draftPublishCmd)
+}
+
+// draftEntry is one of the caller's draft comments.
+type draftEntry struct {
+	ID         string
+	PatchSet   int
+	File       string
+	Line       int
+	Message    string
+	Unresolved bool
+}
+
+func runDraftAdd(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	file, line, err := parseFileLine(args[1])
+	if err != nil {
+		return err
+	}
+	if draftPatchset < 0 {
+		return fmt.Errorf("invalid patchset: %d", draftPatchset)
+	}
+
+	message := draftMessage
+	if len(args) > 2 {
+		if draftMessage != "" {
+			return fmt.Errorf("give the message either as an argument or with --message, not both")
+		}
+		message = args[2]
+	}
+	switch {
+	case message == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read message from stdin: %w", err)
+		}
+		message = string(data)
+	case draftEdit || message == "":
+		if message, err = editMessage(message, commentTemplate(changeID, file, line)); err != nil {
+			return err
+		}
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("message cannot be empty")
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	revision := "current"
+	if draftPatchset > 0 {
+		revision = strconv.Itoa(draftPatchset)
+	}
+	draft := gerrit.ReviewComment{
+		Path:       file,
+		Line:       line,
+		Message:    message,
+		Unresolved: boolPtr(draftUnresolved),
+	}
+	if err := client.CreateDraft(changeID, revision, draft); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	fmt.Printf("%s Draft saved on %s:%d; publish with 'gerry draft publish %s'\n", utils.Green("✓"), file, line, changeID)
+	return nil
+}
+
+func runDraftList(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	drafts, err := client.ListDrafts(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to list drafts: %w", err)
+	}
+	entries := flattenDrafts(drafts)
+	if len(entries) == 0 {
+		fmt.Printf("No drafts on change %s.\n", changeID)
+		return nil
+	}
+
+	headers := []string{"ID", "PS", "File", "Line", "Resolved", "Message"}
+	var rows [][]string
+	for _, d := range entries {
+		line := ""
+		if d.Line > 0 {
+			line = strconv.Itoa(d.Line)
+		}
+		resolved := utils.Green("yes")
+		if d.Unresolved {
+			resolved = utils.Yellow("no")
+		}
+		rows = append(rows, []string{
+			d.ID,
+			strconv.Itoa(d.PatchSet),
+			utils.TruncateString(d.File, 40),
+			line,
+			resolved,
+			utils.TruncateString(firstLine(strings.TrimSpace(d.Message)), 50),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d draft(s); publish with 'gerry draft publish %s'\n", len(entries), changeID)
+	return nil
+}
+
+func runDraftDelete(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	ids := args[1:]
+	if draftDeleteAll == (len(ids) > 0) {
+		return fmt.Errorf("give draft IDs to delete, or --all")
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	// Deleting needs the draft's patchset, so look the IDs up first.
+	drafts, err := client.ListDrafts(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to list drafts: %w", err)
+	}
+	byID := make(map[string]draftEntry)
+	for _, d := range flattenDrafts(drafts) {
+		byID[d.ID] = d
+	}
+	if draftDeleteAll {
+		for id := range byID {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			return fmt.Errorf("no draft with ID %s on change %s", id, changeID)
+		}
+	}
+	if len(ids) == 0 {
+		fmt.Printf("No drafts on change %s.\n", changeID)
+		return nil
+	}
+
+	for _, id := range ids {
+		d := byID[id]
+		if err := client.DeleteDraft(changeID, strconv.Itoa(d.PatchSet), id); err != nil {
+			return fmt.Errorf("failed to delete draft %s: %w", id, err)
+		}
+		fmt.Printf("%s Deleted draft on %s:%d\n", utils.Green("✓"), d.File, d.Line)
+	}
+	return nil
+}
+
+func runDraftPublish(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+	aliases := labelAliases(cfg)
+
+	input := gerrit.ReviewInput{
+		Message: draftMessage,
+		Labels:  map[string]int{},
+		Drafts:  "PUBLISH_ALL_REVISIONS",
+	}
+	if cmd.Flags().Changed("code-review") {
+		input.Labels[aliases["cr"]] = draftCodeReview
+	}
+	if cmd.Flags().Changed("verified") {
+		input.Labels[aliases["v"]] = draftVerified
+	}
+	for _, raw := range draftLabels {
+		name, n, err := parseLabelAssignment(raw)
+		if err != nil {
+			return err
+		}
+		input.Labels[name] = n
+	}
+	for _, raw := range args[1:] {
+		name, n, err := parseShorthandVote(raw, aliases)
+		if err != nil {
+			return err
+		}
+		input.Labels[name] = n
+	}
+
+	drafts, err := client.ListDrafts(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to list drafts: %w", err)
+	}
+	count := len(flattenDrafts(drafts))
+	if count == 0 && len(input.Labels) == 0 && strings.TrimSpace(input.Message) == "" {
+		return fmt.Errorf("no drafts to publish on change %s", changeID)
+	}
+
+	if err := client.PostReview(changeID, "current", input); err != nil {
+		return fmt.Errorf("failed to publish drafts: %w", err)
+	}
+
+	summary := fmt.Sprintf("%d draft(s)", count)
+	if len(input.Labels) > 0 {
+		summary += " with " + formatVotes(input.Labels)
+	}
+	fmt.Printf("%s Published %s on change %s\n", utils.Green("✓"), summary, changeID)
+	return nil
+}
+
+// flattenDrafts lists drafts by file, line, and patchset.
+func flattenDrafts(drafts map[string][]gerrit.CommentInfo) []draftEntry {
+	var entries []draftEntry
+	for file, comments := range drafts {
+		for _, c := range comments {
+			entries = append(entries, draftEntry{
+				ID:         c.ID,
+				PatchSet:   c.PatchSet,
+				File:       file,
+				Line:       c.Line,
+				Message:    c.Message,
+				Unresolved: c.Unresolved,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.PatchSet < b.PatchSet
+	})
+	return entries
+}
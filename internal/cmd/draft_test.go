@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestFlattenDrafts(t *testing.T) {
+	drafts := map[string][]gerrit.CommentInfo{
+		"b.go": {{ID: "d3", PatchSet: 2, Line: 5, Message: "three"}},
+		"a.go": {
+			{ID: "d2", PatchSet: 3, Line: 20, Message: "two", Unresolved: true},
+			{ID: "d1", PatchSet: 3, Line: 4, Message: "one"},
+		},
+	}
+
+	want := []draftEntry{
+		{ID: "d1", PatchSet: 3, File: "a.go", Line: 4, Message: "one"},
+		{ID: "d2", PatchSet: 3, File: "a.go", Line: 20, Message: "two", Unresolved: true},
+		{ID: "d3", PatchSet: 2, File: "b.go", Line: 5, Message: "three"},
+	}
+	if got := flattenDrafts(drafts); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenDrafts() = %+v, want %+v", got, want)
+	}
+	if got := flattenDrafts(nil); len(got) != 0 {
+		t.Errorf("flattenDrafts(nil) = %+v, want none", got)
+	}
+}
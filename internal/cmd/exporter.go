@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exporterListen   string
+	exporterInterval string
+	exporterLimit    int
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve review-health metrics for Prometheus",
+	Long: `Run a long-lived HTTP server exposing review-health metrics in the Prometheus
+text format at /metrics. Each configured query is evaluated on an interval and
+reported with a query="<name>" label:
+
+  gerry_changes                        changes matching the query
+  gerry_changes_awaiting_review        changes waiting on someone other than the owner
+  gerry_changes_unverified             changes without a positive Verified vote
+  gerry_review_wait_median_seconds     median wait of changes awaiting review
+  gerry_review_wait_p90_seconds        90th percentile wait
+  gerry_review_wait_oldest_seconds     longest wait
+  gerry_query_up                       1 if the last evaluation succeeded
+
+Awaiting review uses the same rule as gerry sla and gerry snapshot. Configure
+the queries in ~/.gerry/config.json; without any, the open review queue
+("status:open -is:wip") is reported as "open":
+
+  "exporter": {
+    "interval": "5m",
+    "queries": [
+      {"name": "canvas", "query": "project:canvas-lms status:open -is:wip"},
+      {"name": "mine", "query": "owner:self status:open"}
+    ]
+  }
+
+Examples:
+  gerry exporter
+  gerry exporter --listen :9123 --interval 1m`,
+	Args: cobra.NoArgs,
+	RunE: runExporter,
+}
+
+func init() {
+	exporterCmd.Flags().StringVar(&exporterListen, "listen", ":9123", "Address to serve metrics on")
+	exporterCmd.Flags().StringVar(&exporterInterval, "interval", "", "How often to evaluate the queries (default: exporter.interval, or 5m)")
+	exporterCmd.Flags().IntVarP(&exporterLimit, "limit", "n", 500, "Maximum number of changes to measure per query")
+}
+
+// exporterResult is the latest measurement of one query.
+type exporterResult struct {
+	Snapshot   queueSnapshot
+	Unverified int
+	Up         bool
+}
+
+// exporterState holds the metrics page, rebuilt after every evaluation.
+type exporterState struct {
+	mu      sync.RWMutex
+	results map[string]exporterResult
+	page    string
+}
+
+func runExporter(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	queries := []config.ExporterQuery{{Name: "open", Query: defaultQueueQuery}}
+	interval := "5m"
+	if cfg.Exporter != nil {
+		if len(cfg.Exporter.Queries) > 0 {
+			queries = cfg.Exporter.Queries
+		}
+		if cfg.Exporter.Interval != "" {
+			interval = cfg.Exporter.Interval
+		}
+	}
+	for _, q := range queries {
+		if q.Name == "" || q.Query == "" {
+			return fmt.Errorf("invalid configuration: every exporter query needs a name and a query")
+		}
+	}
+	if exporterInterval != "" {
+		interval = exporterInterval
+	}
+	every, err := utils.ParseDuration(interval)
+	if err != nil {
+		return err
+	}
+	if every < time.Minute {
+		return fmt.Errorf("interval %s is too short; use at least 1m to spare the server", interval)
+	}
+
+	state := &exporterState{results: make(map[string]exporterResult)}
+	// Every evaluation must see the server's current state, and Ctrl+C
+	// shuts the exporter down.
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+	client = client.WithoutCache().WithContext(ctx)
+
+	// The first evaluation runs in the foreground with progress, so the
+	// first scrape already has values; later ones run quietly.
+	state.refresh(client, queries, time.Now().UTC())
+	if ctx.Err() != nil {
+		return nil
+	}
+	utils.DisableProgress()
+	go func() {
+		for sleepContext(ctx, every) == nil {
+			state.refresh(client, queries, time.Now().UTC())
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		state.mu.RLock()
+		page := state.page
+		state.mu.RUnlock()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><a href="/metrics">metrics</a></body></html>`)
+	})
+
+	server := &http.Server{Addr: exporterListen, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	utils.Infof("Serving metrics on %s/metrics (%d queries, refreshed every %s)", exporterListen, len(queries), interval)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// refresh evaluates every query and rebuilds the page. A failed query keeps
+// its previous values and reports gerry_query_up 0.
+func (s *exporterState) refresh(client *gerrit.RESTClient, queries []config.ExporterQuery, now time.Time) {
+	bar := utils.NewProgressBar("Evaluating queries", len(queries))
+	defer bar.Stop()
+	for i, q := range queries {
+		bar.Set(i + 1)
+		result := measureExporterQuery(client, q, now)
+		s.mu.Lock()
+		if !result.Up {
+			result = s.results[q.Name]
+			result.Up = false
+		}
+		s.results[q.Name] = result
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	s.page = renderExporterMetrics(queries, s.results, now)
+	s.mu.Unlock()
+}
+
+func measureExporterQuery(client *gerrit.RESTClient, q config.ExporterQuery, now time.Time) exporterResult {
+	utils.Debugf("Query %s: %s", q.Name, q.Query)
+	changes, err := client.ListChangesWithOptions(url.QueryEscape(q.Query), exporterLimit, "MESSAGES")
+	if err != nil {
+		utils.Warnf("Query %s failed: %v", q.Name, err)
+		return exporterResult{}
+	}
+	if len(changes) > 0 && changes[len(changes)-1].MoreChanges {
+		utils.Warnf("Query %s matches more than %d changes; raise --limit to measure them all", q.Name, exporterLimit)
+	}
+
+	result := exporterResult{Snapshot: measureQueue(changes, now), Up: true}
+	for _, change := range changes {
+		if score, present := getLabelScore(change, "Verified"); present && score <= 0 {
+			result.Unverified++
+		}
+	}
+	return result
+}
+
+// renderExporterMetrics writes the results in the Prometheus text exposition
+// format, in query order.
+func renderExporterMetrics(queries []config.ExporterQuery, results map[string]exporterResult, now time.Time) string {
+	metrics := []struct {
+		name  string
+		help  string
+		value func(exporterResult) float64
+	}{
+		{"gerry_changes", "Changes matching the query.", func(r exporterResult) float64 { return float64(r.Snapshot.Open) }},
+		{"gerry_changes_awaiting_review", "Changes waiting on someone other than the owner.", func(r exporterResult) float64 { return float64(r.Snapshot.Awaiting) }},
+		{"gerry_changes_unverified", "Changes without a positive Verified vote.", func(r exporterResult) float64 { return float64(r.Unverified) }},
+		{"gerry_review_wait_median_seconds", "Median wait of changes awaiting review.", func(r exporterResult) float64 { return r.Snapshot.MedianWaitHours * 3600 }},
+		{"gerry_review_wait_p90_seconds", "90th percentile wait of changes awaiting review.", func(r exporterResult) float64 { return r.Snapshot.P90WaitHours * 3600 }},
+		{"gerry_review_wait_oldest_seconds", "Longest wait of a change awaiting review.", func(r exporterResult) float64 { return r.Snapshot.MaxWaitHours * 3600 }},
+		{"gerry_query_up", "Whether the last evaluation of the query succeeded.", func(r exporterResult) float64 {
+			if r.Up {
+				return 1
+			}
+			return 0
+		}},
+	}
+
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", m.name, m.help, m.name)
+		for _, q := range queries {
+			fmt.Fprintf(&b, "%s{query=\"%s\"} %g\n", m.name, escapeLabelValue(q.Name), m.value(results[q.Name]))
+		}
+	}
+	fmt.Fprintf(&b, "# HELP gerry_last_refresh_timestamp_seconds When the queries were last evaluated.\n# TYPE gerry_last_refresh_timestamp_seconds gauge\ngerry_last_refresh_timestamp_seconds %d\n", now.Unix())
+	return b.String()
+}
+
+// escapeLabelValue escapes a Prometheus label value.
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
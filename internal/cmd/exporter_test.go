@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestRenderExporterMetrics(t *testing.T) {
+	queries := []config.ExporterQuery{
+		{Name: "open", Query: "status:open"},
+		{Name: `team "a"`, Query: "project:a"},
+	}
+	results := map[string]exporterResult{
+		"open": {Snapshot: queueSnapshot{Open: 12, Awaiting: 5, MedianWaitHours: 1.5, MaxWaitHours: 48}, Unverified: 2, Up: true},
+	}
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	got := renderExporterMetrics(queries, results, now)
+	for _, want := range []string{
+		"# TYPE gerry_changes gauge\n",
+		"gerry_changes{query=\"open\"} 12\n",
+		"gerry_changes_awaiting_review{query=\"open\"} 5\n",
+		"gerry_changes_unverified{query=\"open\"} 2\n",
+		"gerry_review_wait_median_seconds{query=\"open\"} 5400\n",
+		"gerry_review_wait_oldest_seconds{query=\"open\"} 172800\n",
+		"gerry_query_up{query=\"open\"} 1\n",
+		"gerry_query_up{query=\"team \\\"a\\\"\"} 0\n",
+		"gerry_last_refresh_timestamp_seconds 1705276800\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderExporterMetrics() missing %q in:\n%s", want, got)
+		}
+	}
+}
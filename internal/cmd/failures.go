@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
@@ -11,15 +15,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var failuresHistory bool
+
 var failuresCmd = &cobra.Command{
-	Use:   "failures <change-id>",
+	Use:   "failures [change-id]",
 	Short: "Get the most recent build failure link",
-	Long:  `Retrieves the most recent build failure link from Service Cloud Jenkins for a change.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runFailures,
+	Long: `Retrieves the most recent build failure link from Service Cloud Jenkins for a change.
+
+With --history, lists every failure on the change, oldest first, with its
+patchset, time, and failing stages. Each failed job is marked new or with how
+many earlier failures it also appeared in, to tell recurring failures from new
+ones.
+
+When test_reports formats are configured, the latest structured test summary
+posted by a CI bot is shown too: failed/passed/skipped counts, total duration,
+and each failed test with its duration.
+
+"." or no change-id means the change checked out in the current repository
+(see 'gerry details').`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFailures,
+}
+
+func init() {
+	failuresCmd.Flags().BoolVar(&failuresHistory, "history", false, "List every failure on the change, not just the most recent")
 }
 
 func runFailures(cmd *cobra.Command, args []string) error {
+	args, err := withCurrentChange(args)
+	if err != nil {
+		return err
+	}
 	changeID := args[0]
 	if err := utils.ValidateChangeID(changeID); err != nil {
 		return fmt.Errorf("invalid change ID: %w", err)
@@ -34,6 +60,11 @@ func runFailures(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+
 	utils.Debugf("Fetching failure links for change %s", changeID)
 
 	client := gerrit.NewRESTClient(cfg)
@@ -42,13 +73,50 @@ func runFailures(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get change messages: %w", err)
 	}
 
+	if failuresHistory {
+		history := findFailureHistory(messages)
+		if structured {
+			if history == nil {
+				history = []failureRecord{}
+			}
+			return renderOutput(os.Stdout, history)
+		}
+		if len(history) == 0 {
+			utils.Info("No build failure links found from Service Cloud Jenkins")
+			return nil
+		}
+		printFailureHistory(history)
+		return nil
+	}
+
+	parsers, err := compileTestReportFormats(cfg.TestReports)
+	if err != nil {
+		return err
+	}
+	report, hasReport := findLatestTestReport(messages, parsers)
+
 	result := findMostRecentFailure(messages)
-	if result.SummaryLink == "" {
+	if structured {
+		out := failuresOutput{SummaryLink: result.SummaryLink, Sections: result.Sections}
+		if hasReport {
+			out.TestReport = &report
+		}
+		return renderOutput(os.Stdout, out)
+	}
+	if result.SummaryLink == "" && !hasReport {
 		utils.Info("No build failure links found from Service Cloud Jenkins")
 		return nil
 	}
 
-	printFailures(result)
+	if result.SummaryLink != "" {
+		printFailures(result)
+	}
+	if hasReport {
+		if result.SummaryLink != "" {
+			fmt.Println()
+		}
+		printTestReport(report)
+	}
 	return nil
 }
 
@@ -71,20 +139,28 @@ func printFailures(result failureResult) {
 
 // buildFailure is a single failed job/test linked in a failure section.
 type buildFailure struct {
-	Name string
-	Link string
+	Name string `json:"name"`
+	Link string `json:"link"`
 }
 
 // failureSection is a named group of failures, e.g. "Test failures" or "Build failures".
 type failureSection struct {
-	Title    string
-	Failures []buildFailure
+	Title    string         `json:"title"`
+	Failures []buildFailure `json:"failures"`
 }
 
 // failureResult holds the build summary link and all failure sections.
 type failureResult struct {
-	SummaryLink string
-	Sections    []failureSection
+	SummaryLink string           `json:"summary_link"`
+	Sections    []failureSection `json:"sections"`
+}
+
+// failuresOutput is what --output json or yaml writes without --history:
+// the most recent failure and the latest test report, if any.
+type failuresOutput struct {
+	SummaryLink string           `json:"summary_link,omitempty"`
+	Sections    []failureSection `json:"sections,omitempty"`
+	TestReport  *testReport      `json:"test_report,omitempty"`
 }
 
 var (
@@ -97,29 +173,136 @@ var (
 
 func findMostRecentFailure(messages []gerrit.ChangeMessageInfo) failureResult {
 	for i := len(messages) - 1; i >= 0; i-- {
-		msg := messages[i]
+		if result, ok := parseFailureMessage(messages[i]); ok {
+			return result
+		}
+	}
+
+	return failureResult{}
+}
+
+// parseFailureMessage extracts the failure from a Jenkins Verified-1 message.
+func parseFailureMessage(msg gerrit.ChangeMessageInfo) (failureResult, bool) {
+	if !isJenkinsMessage(msg) {
+		return failureResult{}, false
+	}
+
+	if !strings.Contains(msg.Message, "Verified-1") {
+		return failureResult{}, false
+	}
+
+	summary := summaryLinkPattern.FindString(msg.Message)
+	if summary == "" {
+		return failureResult{}, false
+	}
+
+	return failureResult{
+		SummaryLink: summary,
+		Sections:    parseFailureSections(msg.Message),
+	}, true
+}
+
+// isJenkinsMessage reports whether the CI bot posted msg.
+func isJenkinsMessage(msg gerrit.ChangeMessageInfo) bool {
+	return strings.Contains(strings.ToLower(msg.Author.DisplayName()), "service cloud jenkins")
+}
 
-		author := msg.Author.DisplayName()
-		if !strings.Contains(strings.ToLower(author), "service cloud jenkins") {
+// failureRecord is one failure in the history of a change.
+type failureRecord struct {
+	Patchset int           `json:"patchset"`
+	Date     string        `json:"date"`
+	Result   failureResult `json:"result"`
+	// SeenBefore counts, per failed job name, the earlier failures it was in.
+	SeenBefore map[string]int `json:"seen_before"`
+}
+
+var patchSetPrefixPattern = regexp.MustCompile(`^Patch Set (\d+)`)
+
+// findFailureHistory returns every failure on the change, oldest first.
+func findFailureHistory(messages []gerrit.ChangeMessageInfo) []failureRecord {
+	var history []failureRecord
+	seen := map[string]int{}
+
+	for _, msg := range messages {
+		result, ok := parseFailureMessage(msg)
+		if !ok {
 			continue
 		}
 
-		if !strings.Contains(msg.Message, "Verified-1") {
-			continue
+		record := failureRecord{
+			Patchset:   msg.RevisionNumber,
+			Date:       msg.Date,
+			Result:     result,
+			SeenBefore: map[string]int{},
+		}
+		if record.Patchset == 0 {
+			if m := patchSetPrefixPattern.FindStringSubmatch(msg.Message); m != nil {
+				record.Patchset, _ = strconv.Atoi(m[1])
+			}
 		}
 
-		summary := summaryLinkPattern.FindString(msg.Message)
-		if summary == "" {
-			continue
+		// Count each job once per failure even if listed in several sections.
+		names := map[string]bool{}
+		for _, section := range result.Sections {
+			for _, f := range section.Failures {
+				names[f.Name] = true
+			}
+		}
+		for name := range names {
+			record.SeenBefore[name] = seen[name]
+			seen[name]++
 		}
 
-		return failureResult{
-			SummaryLink: summary,
-			Sections:    parseFailureSections(msg.Message),
+		history = append(history, record)
+	}
+
+	return history
+}
+
+// failingStages returns the names of failed jobs outside the test sections,
+// i.e. the pipeline stages that broke.
+func failingStages(result failureResult) []string {
+	var stages []string
+	for _, section := range result.Sections {
+		if strings.HasPrefix(strings.ToLower(section.Title), "test") {
+			continue
+		}
+		for _, f := range section.Failures {
+			stages = append(stages, f.Name)
 		}
 	}
+	return stages
+}
 
-	return failureResult{}
+func printFailureHistory(history []failureRecord) {
+	fmt.Printf("%s\n", utils.BoldWhite(fmt.Sprintf("Failure history (%d)", len(history))))
+
+	for _, record := range history {
+		fmt.Println()
+		header := "Patch Set ?"
+		if record.Patchset > 0 {
+			header = fmt.Sprintf("Patch Set %d", record.Patchset)
+		}
+		if record.Date != "" {
+			header += " · " + utils.FormatTimeAgo(record.Date)
+		}
+		fmt.Printf("%s\n", utils.BoldRed(header))
+		fmt.Printf("  %s\n", utils.Cyan(record.Result.SummaryLink))
+		if stages := failingStages(record.Result); len(stages) > 0 {
+			fmt.Printf("  %s %s\n", utils.BoldWhite("Stages:"), strings.Join(stages, ", "))
+		}
+
+		for _, s := range record.Result.Sections {
+			for _, f := range s.Failures {
+				tag := utils.Yellow("new")
+				if n := record.SeenBefore[f.Name]; n > 0 {
+					tag = utils.Red(fmt.Sprintf("recurring, %d earlier", n))
+				}
+				fmt.Printf("  %s %s %s\n", utils.BoldRed("✗"), f.Name, utils.Dim("("+tag+")"))
+				fmt.Printf("    %s\n", utils.Dim(f.Link))
+			}
+		}
+	}
 }
 
 // parseFailureSections walks the message line by line, grouping markdown-link
@@ -146,3 +329,206 @@ func parseFailureSections(message string) []failureSection {
 
 	return sections
 }
+
+// testResult is one test from a CI bot's structured report.
+type testResult struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"` // passed, failed, or skipped
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// testReport is the structured test summary found in one change message.
+type testReport struct {
+	Source   string       `json:"source,omitempty"`
+	Patchset int          `json:"patchset,omitempty"`
+	Date     string       `json:"date,omitempty"`
+	Tests    []testResult `json:"tests"`
+}
+
+// counts tallies the report's tests by status.
+func (r testReport) counts() (passed, failed, skipped int) {
+	for _, t := range r.Tests {
+		switch t.Status {
+		case "passed":
+			passed++
+		case "skipped":
+			skipped++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// testReportParser is a configured test report format, ready to apply.
+type testReportParser struct {
+	format  config.TestReportFormat
+	pattern *regexp.Regexp
+}
+
+func compileTestReportFormats(formats []config.TestReportFormat) ([]testReportParser, error) {
+	var parsers []testReportParser
+	for i, f := range formats {
+		if err := f.Validate(); err != nil {
+			name := f.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return nil, fmt.Errorf("invalid test report format %s: %w", name, err)
+		}
+		p := testReportParser{format: f}
+		if f.Pattern != "" {
+			p.pattern = regexp.MustCompile(f.Pattern)
+		}
+		parsers = append(parsers, p)
+	}
+	return parsers, nil
+}
+
+// findLatestTestReport returns the newest message any parser finds tests in.
+func findLatestTestReport(messages []gerrit.ChangeMessageInfo, parsers []testReportParser) (testReport, bool) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		for _, p := range parsers {
+			if p.format.Author != "" && !strings.Contains(strings.ToLower(msg.Author.DisplayName()), strings.ToLower(p.format.Author)) {
+				continue
+			}
+			tests := p.parse(msg.Message)
+			if len(tests) == 0 {
+				continue
+			}
+			return testReport{
+				Source:   p.format.Name,
+				Patchset: messagePatchset(msg),
+				Date:     msg.Date,
+				Tests:    tests,
+			}, true
+		}
+	}
+	return testReport{}, false
+}
+
+func (p testReportParser) parse(message string) []testResult {
+	if p.pattern == nil {
+		return parseJSONTestReport(message, p.format.JSONMarker)
+	}
+
+	var tests []testResult
+	for _, line := range strings.Split(message, "\n") {
+		m := p.pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		t := testResult{Name: strings.TrimSpace(m[p.pattern.SubexpIndex("name")]), Status: "failed"}
+		if i := p.pattern.SubexpIndex("status"); i >= 0 {
+			t.Status = normalizeTestStatus(m[i])
+		}
+		if i := p.pattern.SubexpIndex("duration"); i >= 0 {
+			t.Duration = parseTestDuration(m[i])
+		}
+		if t.Name != "" {
+			tests = append(tests, t)
+		}
+	}
+	return tests
+}
+
+// parseJSONTestReport decodes the JSON value following the marker line.
+func parseJSONTestReport(message, marker string) []testResult {
+	i := strings.Index(message, marker)
+	if i < 0 {
+		return nil
+	}
+	rest := strings.TrimLeft(message[i+len(marker):], " \t\r\n`")
+	rest = strings.TrimPrefix(rest, "json")
+
+	var block struct {
+		Tests []struct {
+			Name     string  `json:"name"`
+			Status   string  `json:"status"`
+			Duration float64 `json:"duration"`
+		} `json:"tests"`
+	}
+	if err := json.NewDecoder(strings.NewReader(rest)).Decode(&block); err != nil {
+		utils.Debugf("Failed to parse JSON test report: %v", err)
+		return nil
+	}
+
+	var tests []testResult
+	for _, t := range block.Tests {
+		if t.Name == "" {
+			continue
+		}
+		tests = append(tests, testResult{
+			Name:     t.Name,
+			Status:   normalizeTestStatus(t.Status),
+			Duration: time.Duration(t.Duration * float64(time.Second)),
+		})
+	}
+	return tests
+}
+
+// normalizeTestStatus maps the many spellings of a result onto passed,
+// failed, or skipped. Anything unrecognized, including no status, is a
+// failure, since reports that list only one kind of result list failures.
+func normalizeTestStatus(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pass", "passed", "ok", "success", "succeeded":
+		return "passed"
+	case "skip", "skipped", "pending", "ignored", "disabled":
+		return "skipped"
+	}
+	return "failed"
+}
+
+// parseTestDuration accepts Go durations ("1.5s", "2m3s") or bare seconds.
+func parseTestDuration(s string) time.Duration {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d
+	}
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(secs * float64(time.Second))
+	}
+	return 0
+}
+
+func printTestReport(report testReport) {
+	passed, failed, skipped := report.counts()
+	var total time.Duration
+	for _, t := range report.Tests {
+		total += t.Duration
+	}
+
+	header := "Test results"
+	if report.Source != "" {
+		header += " (" + report.Source + ")"
+	}
+	fmt.Printf("%s\n", utils.BoldWhite(header))
+
+	summary := fmt.Sprintf("%s, %s, %s",
+		utils.Red(fmt.Sprintf("%d failed", failed)),
+		utils.Green(fmt.Sprintf("%d passed", passed)),
+		utils.Yellow(fmt.Sprintf("%d skipped", skipped)))
+	if total > 0 {
+		summary += " · " + total.Round(time.Millisecond).String()
+	}
+	if report.Patchset > 0 {
+		summary += " · " + fmt.Sprintf("Patch Set %d", report.Patchset)
+	}
+	if report.Date != "" {
+		summary += " · " + utils.FormatTimeAgo(report.Date)
+	}
+	fmt.Printf("  %s\n", summary)
+
+	for _, t := range report.Tests {
+		if t.Status != "failed" {
+			continue
+		}
+		line := fmt.Sprintf("  %s %s", utils.BoldRed("✗"), t.Name)
+		if t.Duration > 0 {
+			line += " " + utils.Dim("("+t.Duration.Round(time.Millisecond).String()+")")
+		}
+		fmt.Println(line)
+	}
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
 )
 
@@ -114,3 +116,108 @@ func TestFindMostRecentFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestFindFailureHistory(t *testing.T) {
+	failure := func(ps, build string, jobs ...string) gerrit.ChangeMessageInfo {
+		body := "Patch Set " + ps + ": Verified-1\n" +
+			"[Build summary report](https://jenkins.inst-ci.net/job/Canvas/job/main/" + build + "/build-summary-report/)\n\n" +
+			"Build failures:\n"
+		for _, job := range jobs {
+			body += "- [" + job + "](https://jenkins.inst-ci.net/job/Canvas/job/main/" + build + "/x)\n"
+		}
+		return jenkinsMsg(body)
+	}
+
+	messages := []gerrit.ChangeMessageInfo{
+		failure("1", "100", "Linters"),
+		jenkinsMsg("Patch Set 1: Verified+1"),
+		failure("2", "200", "Linters", "Rspec"),
+		{Author: gerrit.Account{Name: "Drake Harper"}, Message: "Patch Set 2: Verified-1"},
+	}
+	messages[2].RevisionNumber = 2
+
+	history := findFailureHistory(messages)
+	if len(history) != 2 {
+		t.Fatalf("findFailureHistory() returned %d records, want 2", len(history))
+	}
+	if history[0].Patchset != 1 || history[1].Patchset != 2 {
+		t.Errorf("patchsets = %d, %d; want 1, 2", history[0].Patchset, history[1].Patchset)
+	}
+	wantSeen := map[string]int{"Linters": 1, "Rspec": 0}
+	if !reflect.DeepEqual(history[1].SeenBefore, wantSeen) {
+		t.Errorf("SeenBefore = %v, want %v", history[1].SeenBefore, wantSeen)
+	}
+	if got := failingStages(history[1].Result); !reflect.DeepEqual(got, []string{"Linters", "Rspec"}) {
+		t.Errorf("failingStages() = %v", got)
+	}
+}
+
+func TestFindLatestTestReport(t *testing.T) {
+	parsers, err := compileTestReportFormats([]config.TestReportFormat{
+		{Name: "rspec", Author: "jenkins", Pattern: `^(?P<status>PASS|FAIL|SKIP) (?P<name>\S+) \((?P<duration>[\d.]+)s\)$`},
+		{Name: "junit", JSONMarker: "Test report:"},
+	})
+	if err != nil {
+		t.Fatalf("compileTestReportFormats() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		messages []gerrit.ChangeMessageInfo
+		want     []testResult
+	}{
+		{
+			name: "regex lines",
+			messages: []gerrit.ChangeMessageInfo{jenkinsMsg(
+				"Patch Set 2: Verified-1\n\nFAIL spec/user_spec.rb:12 (1.5s)\nPASS spec/course_spec.rb:3 (0.25s)\nSKIP spec/flaky_spec.rb:9 (0s)")},
+			want: []testResult{
+				{Name: "spec/user_spec.rb:12", Status: "failed", Duration: 1500 * time.Millisecond},
+				{Name: "spec/course_spec.rb:3", Status: "passed", Duration: 250 * time.Millisecond},
+				{Name: "spec/flaky_spec.rb:9", Status: "skipped"},
+			},
+		},
+		{
+			name: "regex ignores other authors",
+			messages: []gerrit.ChangeMessageInfo{
+				{Author: gerrit.Account{Name: "Drake Harper"}, Message: "FAIL spec/user_spec.rb:12 (1.5s)"},
+			},
+		},
+		{
+			name: "json block, newest message wins",
+			messages: []gerrit.ChangeMessageInfo{
+				jenkinsMsg("FAIL spec/old_spec.rb:1 (1s)"),
+				{Message: "Test report:\n```json\n{\"tests\": [{\"name\": \"TestLogin\", \"status\": \"error\", \"duration\": 2}]}\n```"},
+			},
+			want: []testResult{{Name: "TestLogin", Status: "failed", Duration: 2 * time.Second}},
+		},
+		{
+			name:     "malformed json",
+			messages: []gerrit.ChangeMessageInfo{{Message: "Test report: {not json"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, ok := findLatestTestReport(tt.messages, parsers)
+			if ok != (tt.want != nil) {
+				t.Fatalf("findLatestTestReport() ok = %v, want %v", ok, tt.want != nil)
+			}
+			if !reflect.DeepEqual(report.Tests, tt.want) {
+				t.Errorf("Tests = %+v, want %+v", report.Tests, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileTestReportFormatsInvalid(t *testing.T) {
+	for _, f := range []config.TestReportFormat{
+		{Name: "none"},
+		{Name: "both", Pattern: `(?P<name>.+)`, JSONMarker: "Tests:"},
+		{Name: "no name group", Pattern: `FAIL (.+)`},
+		{Name: "bad regexp", Pattern: `(?P<name>`},
+	} {
+		if _, err := compileTestReportFormats([]config.TestReportFormat{f}); err == nil {
+			t.Errorf("compileTestReportFormats(%s) succeeded, want error", f.Name)
+		}
+	}
+}
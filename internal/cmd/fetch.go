@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
@@ -15,24 +16,73 @@ import (
 )
 
 var (
-	checkoutFetch bool
-	noVerify      bool
+	checkoutFetch    bool
+	noVerify         bool
+	fetchWithParents bool
+	fetchBranch      string
+	fetchRemoteFlag  string
+	fetchVia         string
+	fetchAutostash   bool
 )
 
+// defaultFetchBranch is the value of a bare --branch, standing for
+// review/<number>/<patchset>.
+const defaultFetchBranch = "review/<number>/<patchset>"
+
 var fetchCmd = &cobra.Command{
-	Use:   "fetch <change-id> [patchset]",
+	Use:   "fetch [change-id] [patchset]",
 	Short: "Fetch a change",
-	Long:  `Fetch a change and checkout to FETCH_HEAD. If patchset is not specified, fetches the current patch set.`,
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `Fetch a change and checkout to FETCH_HEAD. If patchset is not specified, fetches the current patch set.
+Without a change-id, pick one of your open or incoming changes from a list.
+
+With --with-parents, the open changes the patch set depends on are fetched
+too, each at its latest patch set, and stacked oldest first: a change is
+checked out as is when it already sits on the one before, and cherry-picked
+onto it otherwise. HEAD ends up detached at the requested change, or on the
+--branch branch. Requires
+REST API access.
+
+The change is fetched from the git remote pointing at the configured server,
+else over SSH from the project named by the repository's .gitreview or origin
+remote, else from the configured project; --remote overrides this. With
+--via http (or "fetch_protocol": "http" in the config), git fetches from the
+authenticated HTTP(S) URL instead of SSH, using ~/.gitcookies or the HTTP
+password, for networks that block the SSH port.
+
+With --autostash, local modifications to tracked files are stashed before
+the checkout and re-applied afterwards, as 'git rebase --autostash' does. If
+they conflict with the change, they stay in the stash.
+
+With --branch, the change is checked out on a local branch instead of a
+detached HEAD, so it survives later fetches and shows up in 'git branch'. The
+branch is named review/<number>/<patchset> unless a name is given with
+--branch=<name>. An existing branch is reused if it already points at the
+patch set, and never moved otherwise.
+
+Examples:
+  gerry fetch 12345
+  gerry fetch 12345 2 --branch
+  gerry fetch 12345 --branch=fix-grading`,
+	Args:  cobra.MaximumNArgs(2),
 	RunE: runFetch,
 }
 
 func init() {
 	fetchCmd.Flags().BoolVarP(&checkoutFetch, "checkout", "c", true, "Checkout to FETCH_HEAD after fetching")
 	fetchCmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip git hooks during checkout")
+	fetchCmd.Flags().BoolVar(&fetchWithParents, "with-parents", false, "Also fetch the open parent changes at their latest patch sets and stack them")
+	fetchCmd.Flags().StringVar(&fetchBranch, "branch", "", "Create a local branch for the change, e.g. --branch or --branch=<name> (default review/<number>/<patchset>)")
+	fetchCmd.Flags().Lookup("branch").NoOptDefVal = defaultFetchBranch
+	fetchCmd.Flags().StringVar(&fetchRemoteFlag, "remote", "", "Git remote or URL to fetch from (default: detected)")
+	fetchCmd.Flags().BoolVar(&fetchAutostash, "autostash", false, "Stash local changes before checking out and re-apply them afterwards")
+	fetchCmd.Flags().StringVar(&fetchVia, "via", "", "Fetch over ssh or http (default: fetch_protocol from the config, else ssh)")
 }
 
 func runFetch(cmd *cobra.Command, args []string) error {
+	args, err := withPickedChange(args)
+	if err != nil {
+		return err
+	}
 	changeID := args[0]
 	// Validate change ID
 	if err := utils.ValidateChangeID(changeID); err != nil {
@@ -56,11 +106,20 @@ func runFetch(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	if err := applyFetchVia(cfg, fetchVia); err != nil {
+		return err
+	}
 
 	// Check if we're in a git repository
 	if !isGitRepository() {
 		return fmt.Errorf("not in a git repository")
 	}
+	if fetchWithParents && !checkoutFetch {
+		return fmt.Errorf("--with-parents builds the chain in the checkout and cannot be combined with --checkout=false")
+	}
+	if fetchAutostash && !checkoutFetch {
+		return fmt.Errorf("--autostash only applies when checking out and cannot be combined with --checkout=false")
+	}
 
 	utils.Debugf("Fetching change %s patchset %s", changeID, patchset)
 
@@ -79,6 +138,17 @@ func runFetch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	branch := fetchBranchName(fetchBranch, change.ChangeNumberStr(), patchsetNum)
+	if branch != "" {
+		if _, err := gitOutput("", "check-ref-format", "--branch", branch); err != nil {
+			return fmt.Errorf("invalid branch name: %s", branch)
+		}
+	}
+
+	if fetchWithParents {
+		return fetchChangeChain(cfg, change, patchsetNum, branch, fetchRemote(cfg, fetchRemoteFlag))
+	}
+
 	// Build the refs path
 	refsPath := fmt.Sprintf("refs/changes/%s/%s/%s",
 		getChangePrefix(changeID),
@@ -87,8 +157,7 @@ func runFetch(cmd *cobra.Command, args []string) error {
 
 	utils.Debugf("Fetching from refs: %s", refsPath)
 
-	// Get git remote URL for the server
-	remoteURL := buildRemoteURL(cfg)
+	remoteURL := fetchRemote(cfg, fetchRemoteFlag)
 
 	fmt.Printf("Fetching change %s (patchset %s) from %s...\n",
 		utils.BoldCyan(changeID),
@@ -96,14 +165,32 @@ func runFetch(cmd *cobra.Command, args []string) error {
 		cfg.Server)
 
 	// Execute git fetch
-	if err := gitFetch(remoteURL, refsPath); err != nil {
+	if err := gitFetch(cfg, remoteURL, refsPath); err != nil {
 		return fmt.Errorf("git fetch failed: %w", err)
 	}
 
 	fmt.Printf("%s Successfully fetched change\n", color.GreenString("✓"))
 
-	// Checkout to FETCH_HEAD if requested
-	if checkoutFetch {
+	if fetchAutostash {
+		stashed, err := autostash()
+		if err != nil {
+			return err
+		}
+		if stashed {
+			defer popAutostash()
+		}
+	}
+
+	if branch != "" {
+		commit, err := gitOutput("", "rev-parse", "FETCH_HEAD")
+		if err != nil {
+			return fmt.Errorf("failed to read FETCH_HEAD: %w", err)
+		}
+		if err := createFetchBranch(branch, commit, checkoutFetch); err != nil {
+			return err
+		}
+	} else if checkoutFetch {
+		// Checkout to FETCH_HEAD if requested
 		fmt.Print("Checking out to FETCH_HEAD... ")
 		if err := gitCheckout("FETCH_HEAD", noVerify); err != nil {
 			fmt.Println(color.RedString("FAILED"))
@@ -122,8 +209,110 @@ func runFetch(cmd *cobra.Command, args []string) error {
 		utils.BoldCyan(changeID))
 
 	if !checkoutFetch {
-		fmt.Println("Use 'git checkout FETCH_HEAD' to switch to the fetched change")
+		if branch != "" {
+			fmt.Printf("Use 'git checkout %s' to switch to the fetched change\n", branch)
+		} else {
+			fmt.Println("Use 'git checkout FETCH_HEAD' to switch to the fetched change")
+		}
+	}
+	return nil
+}
+
+// fetchBranchName returns the local branch --branch asks for, or "" when
+// the flag was not given.
+func fetchBranchName(flag, number, patchset string) string {
+	if flag == defaultFetchBranch {
+		return fmt.Sprintf("review/%s/%s", number, patchset)
+	}
+	return flag
+}
+
+// createFetchBranch creates branch at commit and checks it out if checkout
+// is set. An existing branch is only reused if it already points at commit.
+func createFetchBranch(branch, commit string, checkout bool) error {
+	if existing, err := gitOutput("", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		if existing != commit {
+			return fmt.Errorf("branch %s already exists at %s; delete it or choose another name with --branch=<name>", branch, shortSHA(existing))
+		}
+		if checkout {
+			if _, err := gitOutput("", "checkout", "--quiet", branch); err != nil {
+				return fmt.Errorf("checkout failed: %s", gitErrorMessage(err))
+			}
+		}
+		fmt.Printf("%s Branch %s is already at the change\n", color.GreenString("✓"), utils.BoldCyan(branch))
+		return nil
+	}
+
+	args := []string{"branch", branch, commit}
+	if checkout {
+		args = []string{"checkout", "--quiet", "-b", branch, commit}
+	}
+	if _, err := gitOutput("", args...); err != nil {
+		return fmt.Errorf("failed to create branch %s: %s", branch, gitErrorMessage(err))
+	}
+	fmt.Printf("%s Created branch %s\n", color.GreenString("✓"), utils.BoldCyan(branch))
+	return nil
+}
+
+// fetchChangeChain fetches a change with its open parents and stacks them
+// in the current checkout.
+func fetchChangeChain(cfg *config.Config, change *gerrit.Change, patchset, branch, remoteURL string) error {
+	number, err := strconv.Atoi(patchset)
+	if err != nil {
+		return fmt.Errorf("invalid patchset number: %s", patchset)
+	}
+	chain, err := changeChain(cfg, change, number)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Fetching change %s with %d parent change(s) from %s...\n",
+		utils.BoldCyan(change.ChangeNumberStr()), len(chain)-1, cfg.Server)
+	// Stash only once everything is fetched, right before the checkout.
+	stashed := false
+	err = buildChain(cfg, remoteURL, chain, func(commit string) (string, error) {
+		if fetchAutostash {
+			var err error
+			if stashed, err = autostash(); err != nil {
+				return "", err
+			}
+		}
+		if err := gitCheckout(commit, noVerify); err != nil {
+			if stashed {
+				popAutostash()
+				stashed = false
+			}
+			return "", fmt.Errorf("checkout failed: %w", err)
+		}
+		return "", nil
+	})
+	if err != nil {
+		if stashed {
+			keepAutostash()
+		}
+		return err
 	}
+	if branch != "" {
+		head, err := gitOutput("", "rev-parse", "HEAD")
+		if err != nil {
+			err = fmt.Errorf("failed to read HEAD: %w", err)
+		} else {
+			err = createFetchBranch(branch, head, true)
+		}
+		if err != nil {
+			if stashed {
+				popAutostash()
+			}
+			return err
+		}
+	}
+	if stashed {
+		popAutostash()
+	}
+
+	fmt.Printf("\n%s Change %s is ready for review on top of its parents\n",
+		color.GreenString("🎉"),
+		utils.BoldCyan(change.ChangeNumberStr()))
 	return nil
 }
 
@@ -158,6 +347,9 @@ func getChangePrefix(changeID string) string {
 }
 
 func buildRemoteURL(cfg *config.Config) string {
+	if cfg.FetchesOverHTTP() {
+		return cfg.GetRESTURL(cfg.Project)
+	}
 	// Prefer SSH for git operations (more reliable with SSH keys)
 	if cfg.Project != "" {
 		return fmt.Sprintf("ssh://%s@%s:%d/%s", cfg.User, cfg.Server, cfg.Port, cfg.Project)
@@ -165,6 +357,55 @@ func buildRemoteURL(cfg *config.Config) string {
 	return fmt.Sprintf("ssh://%s@%s:%d", cfg.User, cfg.Server, cfg.Port)
 }
 
+// applyFetchVia overrides the configured fetch protocol with a --via flag.
+func applyFetchVia(cfg *config.Config, via string) error {
+	if via == "" {
+		return nil
+	}
+	if err := config.ValidateFetchProtocol(via); err != nil {
+		return fmt.Errorf("invalid --via: %w", err)
+	}
+	cfg.FetchProtocol = via
+	return nil
+}
+
+// fetchRemote returns the git remote or URL to fetch changes from in the
+// current repository: override if set, else the remote pointing at the
+// configured server, else the server's URL for the project named by the
+// checkout's .gitreview or origin remote, else the configured project. When
+// fetching over HTTP, only HTTP(S) remotes are considered.
+func fetchRemote(cfg *config.Config, override string) string {
+	if override != "" {
+		return override
+	}
+	root, err := getGitRepoRoot()
+	if err != nil {
+		return buildRemoteURL(cfg)
+	}
+	remotes := gitRemoteURLs(root)
+	if cfg.FetchesOverHTTP() {
+		for name, remote := range remotes {
+			if !isHTTPRemote(remote) {
+				delete(remotes, name)
+			}
+		}
+	}
+	if name := pickGerritRemote(remotes, cfg.Server); name != "" {
+		utils.Debugf("Fetching from remote %s (%s)", name, remotes[name])
+		return name
+	}
+	if project, err := detectCheckoutProject(root); err == nil {
+		utils.Debugf("Fetching from project %s detected in %s", project, root)
+		return buildProjectRemoteURL(cfg, project)
+	}
+	return buildRemoteURL(cfg)
+}
+
+// isHTTPRemote reports whether a remote URL is fetched over HTTP(S).
+func isHTTPRemote(remote string) bool {
+	return strings.HasPrefix(remote, "http://") || strings.HasPrefix(remote, "https://")
+}
+
 func isGitRepository() bool {
 	_, err := os.Stat(".git")
 	if err == nil {
@@ -176,8 +417,9 @@ func isGitRepository() bool {
 	return cmd.Run() == nil
 }
 
-func gitFetch(remoteURL, refsPath string) error {
+func gitFetch(cfg *config.Config, remoteURL, refsPath string) error {
 	cmd := exec.Command("git", "fetch", remoteURL, refsPath)
+	cmd.Env = gitRemoteEnv(cfg, "", remoteURL)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/fatih/color"
+)
+
+// chainLink is one change of a relation chain to fetch, at the patchset to
+// use for it.
+type chainLink struct {
+	Number   int
+	Patchset int
+	Subject  string
+	Status   string
+}
+
+// changeChain resolves the open ancestors of a change's patchset from its
+// relation chain and returns them oldest first, followed by the change
+// itself. Ancestors are taken at their latest patchset, even when the
+// change was uploaded on top of an older one. Requires REST.
+func changeChain(cfg *config.Config, change *gerrit.Change, patchset int) ([]chainLink, error) {
+	client := gerrit.NewRESTClient(cfg)
+	related, err := client.GetRelatedChanges(change.ChangeNumberStr(), strconv.Itoa(patchset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related changes (--with-parents needs REST access): %w", err)
+	}
+	ancestors := chainAncestors(related, change.ChangeNumber(), patchset)
+	for _, link := range ancestors {
+		if link.Status == "ABANDONED" {
+			utils.Warnf("Parent change %d is abandoned", link.Number)
+		}
+	}
+	return append(ancestors, chainLink{
+		Number:   change.ChangeNumber(),
+		Patchset: patchset,
+		Subject:  change.Subject,
+		Status:   change.Status,
+	}), nil
+}
+
+// chainAncestors follows the first parents of the given patchset through
+// related, stopping at a merged change or at a commit outside the chain,
+// and returns the ancestors oldest first.
+func chainAncestors(related []gerrit.RelatedChangeAndCommitInfo, number, patchset int) []chainLink {
+	byCommit := make(map[string]gerrit.RelatedChangeAndCommitInfo, len(related))
+	var current *gerrit.RelatedChangeAndCommitInfo
+	for i, r := range related {
+		byCommit[r.Commit.Commit] = r
+		if r.Number == number && r.RevisionNumber == patchset {
+			current = &related[i]
+		}
+	}
+	if current == nil {
+		return nil
+	}
+
+	var ancestors []chainLink
+	commit := *current
+	// Bounded by len(related) in case the chain is malformed.
+	for range related {
+		if len(commit.Commit.Parents) == 0 {
+			break
+		}
+		parent, ok := byCommit[commit.Commit.Parents[0].Commit]
+		if !ok || parent.Status == "MERGED" || parent.Number == number {
+			break
+		}
+		patchset := parent.CurrentRevisionNumber
+		if patchset == 0 {
+			patchset = parent.RevisionNumber
+		}
+		ancestors = append([]chainLink{{
+			Number:   parent.Number,
+			Patchset: patchset,
+			Subject:  parent.Commit.Subject,
+			Status:   parent.Status,
+		}}, ancestors...)
+		commit = parent
+	}
+	return ancestors
+}
+
+// buildChain fetches every change of chain and stacks them, oldest first:
+// start checks out the first one and returns the directory to build the
+// rest in, and each later change is checked out when it already sits on
+// the one before, or cherry-picked onto it otherwise.
+func buildChain(cfg *config.Config, remoteURL string, chain []chainLink, start func(commit string) (dir string, err error)) error {
+	commits := make([]string, len(chain))
+	env := gitRemoteEnv(cfg, "", remoteURL)
+	for i, link := range chain {
+		number := strconv.Itoa(link.Number)
+		refsPath := fmt.Sprintf("refs/changes/%s/%s/%d", getChangePrefix(number), number, link.Patchset)
+		utils.Debugf("Fetching from refs: %s", refsPath)
+		if _, err := gitOutputEnv("", env, "fetch", "--quiet", remoteURL, refsPath); err != nil {
+			return fmt.Errorf("failed to fetch change %d: %s", link.Number, gitErrorMessage(err))
+		}
+		commit, err := gitOutput("", "rev-parse", "FETCH_HEAD")
+		if err != nil {
+			return fmt.Errorf("failed to read FETCH_HEAD: %w", err)
+		}
+		commits[i] = commit
+	}
+
+	dir, err := start(commits[0])
+	if err != nil {
+		return err
+	}
+	printChainLink(chain[0], "checked out")
+
+	for i := 1; i < len(chain); i++ {
+		link := chain[i]
+		head, err := gitOutput(dir, "rev-parse", "HEAD")
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD: %w", err)
+		}
+		parent, _ := gitOutput(dir, "rev-parse", commits[i]+"^")
+		if parent == head {
+			if _, err := gitOutput(dir, "checkout", "--quiet", "--detach", commits[i]); err != nil {
+				return fmt.Errorf("failed to check out change %d: %s", link.Number, gitErrorMessage(err))
+			}
+			printChainLink(link, "checked out")
+			continue
+		}
+		if _, err := gitOutput(dir, "cherry-pick", commits[i]); err != nil {
+			fmt.Printf("  %s %d  %s\n", color.RedString("✗"), link.Number, link.Subject)
+			msg := fmt.Sprintf("cherry-picking change %d onto its parent failed: %s\n"+
+				"If it stopped on a conflict, resolve it and run 'git cherry-pick --continue'", link.Number, gitErrorMessage(err))
+			if rest := chain[i+1:]; len(rest) > 0 {
+				numbers := make([]string, len(rest))
+				for j, r := range rest {
+					numbers[j] = strconv.Itoa(r.Number)
+				}
+				msg += "; not yet applied: " + strings.Join(numbers, ", ")
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		printChainLink(link, "cherry-picked")
+	}
+	return nil
+}
+
+func printChainLink(link chainLink, action string) {
+	fmt.Printf("  %s %s  %s %s\n", color.GreenString("✓"), utils.BoldCyan(strconv.Itoa(link.Number)),
+		utils.TruncateString(link.Subject, 60), utils.Gray(fmt.Sprintf("(patchset %d, %s)", link.Patchset, action)))
+}
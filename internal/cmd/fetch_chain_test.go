@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func relatedChange(number, patchset, current int, commit, parent, status string) gerrit.RelatedChangeAndCommitInfo {
+	return gerrit.RelatedChangeAndCommitInfo{
+		Number:                number,
+		RevisionNumber:        patchset,
+		CurrentRevisionNumber: current,
+		Status:                status,
+		Commit: gerrit.CommitInfo{
+			Commit:  commit,
+			Subject: "Change " + commit,
+			Parents: []gerrit.CommitInfo{{Commit: parent}},
+		},
+	}
+}
+
+func TestChainAncestors(t *testing.T) {
+	// Newest first, as Gerrit returns them: 104 on top of 103 on top of
+	// 102 (whose patchset 3 is outdated) on top of merged 101.
+	related := []gerrit.RelatedChangeAndCommitInfo{
+		relatedChange(104, 1, 1, "d1", "c2", "NEW"),
+		relatedChange(103, 2, 2, "c2", "b2", "NEW"),
+		relatedChange(102, 2, 3, "b2", "a1", "NEW"),
+		relatedChange(101, 1, 1, "a1", "base", "MERGED"),
+	}
+
+	tests := []struct {
+		name     string
+		number   int
+		patchset int
+		want     []chainLink
+	}{
+		{
+			name: "top of the chain", number: 104, patchset: 1,
+			want: []chainLink{
+				{Number: 102, Patchset: 3, Subject: "Change b2", Status: "NEW"},
+				{Number: 103, Patchset: 2, Subject: "Change c2", Status: "NEW"},
+			},
+		},
+		{
+			name: "middle of the chain", number: 103, patchset: 2,
+			want: []chainLink{{Number: 102, Patchset: 3, Subject: "Change b2", Status: "NEW"}},
+		},
+		{name: "bottom of the chain", number: 102, patchset: 2},
+		{name: "patchset not in the chain", number: 104, patchset: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chainAncestors(related, tt.number, tt.patchset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chainAncestors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if got := chainAncestors(nil, 104, 1); got != nil {
+		t.Errorf("chainAncestors(nil) = %+v, want nil", got)
+	}
+}
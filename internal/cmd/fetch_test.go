@@ -0,0 +1,19 @@
+package cmd
+
+import "testing"
+
+func TestFetchBranchName(t *testing.T) {
+	tests := []struct {
+		flag string
+		want string
+	}{
+		{"", ""},
+		{defaultFetchBranch, "review/12345/3"},
+		{"fix-grading", "fix-grading"},
+	}
+	for _, tt := range tests {
+		if got := fetchBranchName(tt.flag, "12345", "3"); got != tt.want {
+			t.Errorf("fetchBranchName(%q) = %q, want %q", tt.flag, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filesPatchset       int
+	filesMarkReviewed   []string
+	filesUnmarkReviewed []string
+)
+
+// reviewedFileName is the local reviewed-state store inside the config dir.
+const reviewedFileName = "reviewed.json"
+
+var filesCmd = &cobra.Command{
+	Use:   "files <change-id>",
+	Short: "List a change's files and track which ones you have reviewed",
+	Long: `List the files of a patchset with your reviewed state, or mark files as
+reviewed so a large change can be reviewed over several sittings.
+
+Reviewed state is per patchset. It is stored on the server (the same flag the
+web UI's checkboxes set) when REST access is configured, and in
+~/.gerry/reviewed.json otherwise or when the server call fails.
+
+Examples:
+  gerry files 12345
+  gerry files 12345 --mark-reviewed src/main.go --mark-reviewed README.md
+  gerry files 12345 --patchset 3 --unmark-reviewed src/main.go`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFiles,
+}
+
+func init() {
+	filesCmd.Flags().IntVar(&filesPatchset, "patchset", 0, "Patchset number (default: current)")
+	filesCmd.Flags().StringArrayVar(&filesMarkReviewed, "mark-reviewed", nil, "Mark a file as reviewed (repeatable)")
+	filesCmd.Flags().StringArrayVar(&filesUnmarkReviewed, "unmark-reviewed", nil, "Clear a file's reviewed mark (repeatable)")
+}
+
+func runFiles(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	patchset := filesPatchset
+	if patchset == 0 {
+		change, err := getChangeForFetch(cfg, changeID)
+		if err != nil {
+			return fmt.Errorf("failed to get change details: %w", err)
+		}
+		patchset = change.CurrentPatchSetNumber()
+		if patchset == 0 {
+			return fmt.Errorf("could not determine current patchset")
+		}
+	}
+
+	tracker := newReviewedTracker(cfg, changeID, patchset)
+
+	if len(filesMarkReviewed) > 0 || len(filesUnmarkReviewed) > 0 {
+		for _, path := range filesMarkReviewed {
+			if err := tracker.set(path, true); err != nil {
+				return fmt.Errorf("failed to mark %s as reviewed: %w", path, err)
+			}
+			fmt.Printf("%s Marked %s as reviewed (patchset %d)\n", utils.Green("✓"), path, patchset)
+		}
+		for _, path := range filesUnmarkReviewed {
+			if err := tracker.set(path, false); err != nil {
+				return fmt.Errorf("failed to unmark %s: %w", path, err)
+			}
+			fmt.Printf("%s Cleared reviewed mark on %s (patchset %d)\n", utils.Green("✓"), path, patchset)
+		}
+		return nil
+	}
+
+	client := gerrit.NewRESTClient(cfg)
+	files, err := client.GetChangeFiles(changeID, strconv.Itoa(patchset))
+	if err != nil {
+		return fmt.Errorf("failed to get files: %w", err)
+	}
+	reviewed := tracker.reviewed()
+
+	paths := reviewablePaths(files)
+	fmt.Printf("%s %s, patchset %d\n", utils.BoldCyan("Change"), utils.BoldWhite(changeID), patchset)
+	for _, path := range paths {
+		mark := utils.Gray("·")
+		if reviewed[path] {
+			mark = utils.Green("✓")
+		}
+		fi := files[path]
+		fmt.Printf("  %s %s %s\n", mark, path, utils.Gray(fmt.Sprintf("(+%d -%d)", fi.LinesInserted, fi.LinesDeleted)))
+	}
+
+	done, remaining := countReviewed(paths, reviewed)
+	fmt.Printf("\n%d of %d files reviewed, %d remaining\n", done, len(paths), remaining)
+	return nil
+}
+
+// reviewablePaths returns the sorted file paths of a revision, without
+// Gerrit's magic /COMMIT_MSG and /MERGE_LIST entries.
+func reviewablePaths(files map[string]gerrit.FileInfo) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		if path == "/COMMIT_MSG" || path == "/MERGE_LIST" {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// countReviewed splits paths into reviewed and remaining counts.
+func countReviewed(paths []string, reviewed map[string]bool) (done, remaining int) {
+	for _, path := range paths {
+		if reviewed[path] {
+			done++
+		} else {
+			remaining++
+		}
+	}
+	return done, remaining
+}
+
+// reviewedTracker reads and writes per-file reviewed state, using the
+// server's reviewed flag over REST and switching to the local store for the
+// rest of the run once REST is unavailable or has failed.
+type reviewedTracker struct {
+	rest     *gerrit.RESTClient
+	useLocal bool
+	changeID string
+	revision string
+	key      string
+}
+
+func newReviewedTracker(cfg *config.Config, changeID string, patchset int) *reviewedTracker {
+	t := &reviewedTracker{
+		rest:     gerrit.NewRESTClient(cfg),
+		useLocal: cfg.HTTPPassword == "",
+		changeID: changeID,
+		revision: strconv.Itoa(patchset),
+		key:      reviewedKey(cfg.Server, changeID, patchset),
+	}
+	if t.useLocal {
+		utils.Debugf("No HTTP password configured, using local reviewed state")
+	}
+	return t
+}
+
+func (t *reviewedTracker) set(path string, reviewed bool) error {
+	if !t.useLocal {
+		err := t.rest.SetReviewed(t.changeID, t.revision, path, reviewed)
+		if err == nil {
+			return nil
+		}
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to local reviewed state...")
+		t.useLocal = true
+	}
+	return updateLocalReviewed(t.key, path, reviewed)
+}
+
+// reviewed returns the set of reviewed paths from the server and the local
+// store combined; either source failing only loses its half.
+func (t *reviewedTracker) reviewed() map[string]bool {
+	set := make(map[string]bool)
+	if !t.useLocal {
+		paths, err := t.rest.GetReviewedFiles(t.changeID, t.revision)
+		if err != nil {
+			utils.Debugf("Failed to get reviewed files from server: %v", err)
+		}
+		for _, path := range paths {
+			set[path] = true
+		}
+	}
+
+	store, err := loadLocalReviewed()
+	if err != nil {
+		utils.Debugf("Failed to load local reviewed state: %v", err)
+	}
+	for _, path := range store[t.key] {
+		set[path] = true
+	}
+	return set
+}
+
+// reviewedKey identifies a patchset in the local store.
+func reviewedKey(server, changeID string, patchset int) string {
+	return fmt.Sprintf("%s/%s/%d", server, changeID, patchset)
+}
+
+func localReviewedPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, reviewedFileName), nil
+}
+
+// loadLocalReviewed reads the local store; a missing file is an empty store.
+func loadLocalReviewed() (map[string][]string, error) {
+	path, err := localReviewedPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	store := map[string][]string{}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func updateLocalReviewed(key, path string, reviewed bool) error {
+	store, err := loadLocalReviewed()
+	if err != nil {
+		return err
+	}
+	store[key] = setReviewedPath(store[key], path, reviewed)
+	if len(store[key]) == 0 {
+		delete(store, key)
+	}
+
+	file, err := localReviewedPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0600)
+}
+
+// setReviewedPath adds or removes path from a sorted, duplicate-free list.
+func setReviewedPath(paths []string, path string, reviewed bool) []string {
+	var out []string
+	for _, p := range paths {
+		if p != path {
+			out = append(out, p)
+		}
+	}
+	if reviewed {
+		out = append(out, path)
+		sort.Strings(out)
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestReviewablePaths(t *testing.T) {
+	files := map[string]gerrit.FileInfo{
+		"/COMMIT_MSG": {},
+		"/MERGE_LIST": {},
+		"b.go":        {},
+		"a/x.go":      {},
+	}
+	want := []string{"a/x.go", "b.go"}
+	if got := reviewablePaths(files); !reflect.DeepEqual(got, want) {
+		t.Errorf("reviewablePaths() = %v, want %v", got, want)
+	}
+}
+
+func TestCountReviewed(t *testing.T) {
+	paths := []string{"a.go", "b.go", "c.go"}
+	done, remaining := countReviewed(paths, map[string]bool{"b.go": true, "gone.go": true})
+	if done != 1 || remaining != 2 {
+		t.Errorf("countReviewed() = %d, %d; want 1, 2", done, remaining)
+	}
+}
+
+func TestSetReviewedPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		path     string
+		reviewed bool
+		want     []string
+	}{
+		{"add keeps sorted", []string{"c.go"}, "a.go", true, []string{"a.go", "c.go"}},
+		{"add is idempotent", []string{"a.go"}, "a.go", true, []string{"a.go"}},
+		{"remove", []string{"a.go", "c.go"}, "a.go", false, []string{"c.go"}},
+		{"remove last", []string{"a.go"}, "a.go", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setReviewedPath(tt.paths, tt.path, tt.reviewed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setReviewedPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalReviewedStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	key := reviewedKey("gerrit.example.com", "12345", 2)
+	if err := updateLocalReviewed(key, "a.go", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateLocalReviewed(key, "b.go", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateLocalReviewed(key, "a.go", false); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := loadLocalReviewed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b.go"}; !reflect.DeepEqual(store[key], want) {
+		t.Errorf("store[%q] = %v, want %v", key, store[key], want)
+	}
+}
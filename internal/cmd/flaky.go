@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flakyQuery  string
+	flakySince  string
+	flakyLimit  int
+	flakyFormat string
+)
+
+var flakyCmd = &cobra.Command{
+	Use:   "flaky",
+	Short: "Rank CI jobs by how often they fail flakily",
+	Long: `Find flaky builds: a Jenkins Verified-1 on a patchset that is later followed
+by a Verified+1 on the same patchset, i.e. the build passed on retry without
+any new code. The jobs listed in each such failure are ranked by how often
+they flaked, to help prioritize CI fixes.
+
+Examples:
+  gerry flaky --query "project:canvas-lms"
+  gerry flaky --query "project:canvas-lms branch:main" --since 90d --format json`,
+	Args: cobra.NoArgs,
+	RunE: runFlaky,
+}
+
+func init() {
+	flakyCmd.Flags().StringVarP(&flakyQuery, "query", "q", "", "Gerrit search query selecting the changes to analyze")
+	flakyCmd.Flags().StringVar(&flakySince, "since", "30d", "Only count failures newer than this (e.g. 14d, 4w)")
+	flakyCmd.Flags().IntVarP(&flakyLimit, "limit", "n", 500, "Maximum number of changes to analyze")
+	flakyCmd.Flags().StringVar(&flakyFormat, "format", "table", "Output format: table, json")
+}
+
+// flakeEvent is one failure that later passed on the same patchset.
+type flakeEvent struct {
+	Change   int
+	Patchset int
+	Date     time.Time
+	Jobs     []string
+}
+
+// flakyJob aggregates flakes per failed job.
+type flakyJob struct {
+	Job      string `json:"job"`
+	Flakes   int    `json:"flakes"`
+	Changes  int    `json:"changes"`
+	LastSeen string `json:"last_seen"`
+
+	last time.Time
+}
+
+// flakyReport is the output of `gerry flaky`.
+type flakyReport struct {
+	Since          string     `json:"since"`
+	ChangesScanned int        `json:"changes_scanned"`
+	FlakyBuilds    int        `json:"flaky_builds"`
+	FlakyChanges   int        `json:"flaky_changes"`
+	Jobs           []flakyJob `json:"jobs"`
+}
+
+func runFlaky(cmd *cobra.Command, args []string) error {
+	window, err := utils.ParseDuration(flakySince)
+	if err != nil {
+		return err
+	}
+	if flakyFormat != "table" && flakyFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", flakyFormat)
+	}
+	if flakyFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("-age:%dh", int(math.Ceil(window.Hours())))
+	if flakyQuery != "" {
+		query = flakyQuery + " " + query
+	}
+
+	spinner := utils.NewSpinner("Analyzing build history...")
+	utils.Debugf("Query: %s", query)
+	changes, err := client.ListChangesWithOptions(url.QueryEscape(query), flakyLimit, "MESSAGES")
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list changes: %w", err)
+	}
+
+	since := time.Now().UTC().Add(-window)
+	var events []flakeEvent
+	for _, change := range changes {
+		events = append(events, findFlakes(change, since)...)
+	}
+	report := rankFlakyJobs(events)
+	report.Since = since.Format(time.RFC3339)
+	report.ChangesScanned = len(changes)
+
+	if flakyFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if report.FlakyBuilds == 0 {
+		fmt.Printf("%s No flaky builds found in %d change(s)\n", utils.Green("✓"), report.ChangesScanned)
+		return nil
+	}
+
+	headers := []string{"Job", "Flakes", "Changes", "Last Seen"}
+	var rows [][]string
+	for _, j := range report.Jobs {
+		rows = append(rows, []string{
+			utils.TruncateString(j.Job, 60),
+			utils.Red(strconv.Itoa(j.Flakes)),
+			strconv.Itoa(j.Changes),
+			utils.FormatTimeAgo(j.last),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d flaky build(s) across %d of %d change(s)\n", report.FlakyBuilds, report.FlakyChanges, report.ChangesScanned)
+	return nil
+}
+
+// findFlakes returns the Jenkins failures on change, newer than since, that
+// were followed by a Jenkins pass on the same patchset.
+func findFlakes(change gerrit.Change, since time.Time) []flakeEvent {
+	var events []flakeEvent
+	pending := map[int][]flakeEvent{}
+
+	for _, msg := range change.Messages {
+		if !isJenkinsMessage(msg) {
+			continue
+		}
+		ps := messagePatchset(msg)
+		v := verdictPattern.FindStringSubmatch(msg.Message)
+		if ps == 0 || v == nil || v[1] == "+0" {
+			continue
+		}
+
+		if strings.HasPrefix(v[1], "-") {
+			at, err := utils.ParseGerritTime(msg.Date)
+			if err != nil || at.Before(since) {
+				continue
+			}
+			var jobs []string
+			for _, section := range parseFailureSections(msg.Message) {
+				for _, f := range section.Failures {
+					jobs = append(jobs, f.Name)
+				}
+			}
+			pending[ps] = append(pending[ps], flakeEvent{Change: change.ChangeNumber(), Patchset: ps, Date: at, Jobs: jobs})
+			continue
+		}
+
+		events = append(events, pending[ps]...)
+		delete(pending, ps)
+	}
+	return events
+}
+
+// unknownFlakyJob groups flaky failures whose message lists no jobs.
+const unknownFlakyJob = "(no job listed)"
+
+// rankFlakyJobs counts flakes per job, most frequent first.
+func rankFlakyJobs(events []flakeEvent) flakyReport {
+	report := flakyReport{FlakyBuilds: len(events), Jobs: []flakyJob{}}
+	byJob := map[string]*flakyJob{}
+	jobChanges := map[string]map[int]bool{}
+	flakyChanges := map[int]bool{}
+
+	for _, e := range events {
+		flakyChanges[e.Change] = true
+		jobs := e.Jobs
+		if len(jobs) == 0 {
+			jobs = []string{unknownFlakyJob}
+		}
+		for _, name := range jobs {
+			j, ok := byJob[name]
+			if !ok {
+				j = &flakyJob{Job: name}
+				byJob[name] = j
+				jobChanges[name] = map[int]bool{}
+			}
+			j.Flakes++
+			jobChanges[name][e.Change] = true
+			if e.Date.After(j.last) {
+				j.last = e.Date
+			}
+		}
+	}
+
+	for name, j := range byJob {
+		j.Changes = len(jobChanges[name])
+		j.LastSeen = j.last.Format(time.RFC3339)
+		report.Jobs = append(report.Jobs, *j)
+	}
+	sort.Slice(report.Jobs, func(i, k int) bool {
+		a, b := report.Jobs[i], report.Jobs[k]
+		if a.Flakes != b.Flakes {
+			return a.Flakes > b.Flakes
+		}
+		if a.Changes != b.Changes {
+			return a.Changes > b.Changes
+		}
+		return a.Job < b.Job
+	})
+	report.FlakyChanges = len(flakyChanges)
+	return report
+}
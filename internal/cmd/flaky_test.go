@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func datedJenkinsMsg(date, body string) gerrit.ChangeMessageInfo {
+	msg := jenkinsMsg(body)
+	msg.Date = date
+	return msg
+}
+
+func TestFindFlakes(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failure := "Patch Set 2: Verified-1\n\nBuild Failed\n\nTest failures:\n\n[rspec](https://jenkins.example.com/1)\n"
+
+	tests := []struct {
+		name     string
+		messages []gerrit.ChangeMessageInfo
+		want     [][]string
+	}{
+		{
+			name: "fail then pass on same patchset",
+			messages: []gerrit.ChangeMessageInfo{
+				datedJenkinsMsg("2024-01-02 10:00:00.000000000", failure),
+				datedJenkinsMsg("2024-01-02 11:00:00.000000000", "Patch Set 2: Verified+1"),
+			},
+			want: [][]string{{"rspec"}},
+		},
+		{
+			name: "fixed by new patchset",
+			messages: []gerrit.ChangeMessageInfo{
+				datedJenkinsMsg("2024-01-02 10:00:00.000000000", failure),
+				{Message: "Uploaded patch set 3."},
+				datedJenkinsMsg("2024-01-02 11:00:00.000000000", "Patch Set 3: Verified+1"),
+			},
+		},
+		{
+			name: "failure before window",
+			messages: []gerrit.ChangeMessageInfo{
+				datedJenkinsMsg("2023-12-30 10:00:00.000000000", failure),
+				datedJenkinsMsg("2024-01-02 11:00:00.000000000", "Patch Set 2: Verified+1"),
+			},
+		},
+		{
+			name: "two failures then pass, no jobs listed",
+			messages: []gerrit.ChangeMessageInfo{
+				datedJenkinsMsg("2024-01-02 10:00:00.000000000", "Patch Set 2: Verified-1\n\nBuild Failed"),
+				datedJenkinsMsg("2024-01-02 11:00:00.000000000", "Patch Set 2: Verified-1\n\nBuild Failed"),
+				datedJenkinsMsg("2024-01-02 12:00:00.000000000", "Patch Set 2: Verified+1"),
+			},
+			want: [][]string{nil, nil},
+		},
+		{
+			name: "still failing",
+			messages: []gerrit.ChangeMessageInfo{
+				datedJenkinsMsg("2024-01-02 10:00:00.000000000", failure),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, e := range findFlakes(gerrit.Change{Number: 100, Messages: tt.messages}, since) {
+				got = append(got, e.Jobs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findFlakes() jobs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankFlakyJobs(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	events := []flakeEvent{
+		{Change: 1, Date: day(2), Jobs: []string{"rspec", "selenium"}},
+		{Change: 1, Date: day(3), Jobs: []string{"selenium"}},
+		{Change: 2, Date: day(4), Jobs: []string{"selenium"}},
+		{Change: 3, Date: day(5)},
+	}
+
+	report := rankFlakyJobs(events)
+	if report.FlakyBuilds != 4 || report.FlakyChanges != 3 {
+		t.Errorf("got %d builds across %d changes, want 4 across 3", report.FlakyBuilds, report.FlakyChanges)
+	}
+
+	var got []string
+	for _, j := range report.Jobs {
+		got = append(got, j.Job)
+	}
+	want := []string{"selenium", "(no job listed)", "rspec"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("job order = %v, want %v", got, want)
+	}
+	if s := report.Jobs[0]; s.Flakes != 3 || s.Changes != 2 || s.LastSeen != "2024-01-04T00:00:00Z" {
+		t.Errorf("selenium = %+v", s)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+// gitCredentialHelper answers git's credential requests for the server with
+// the HTTP password, passed through the environment so that it never shows
+// up on a command line.
+const gitCredentialHelper = `!f() { test "$1" = get && echo "username=$GERRY_GIT_USER" && echo "password=$GERRY_GIT_PASSWORD"; }; f`
+
+// gitConfigEntry is a git setting passed through GIT_CONFIG_KEY_<n> and
+// GIT_CONFIG_VALUE_<n>.
+type gitConfigEntry struct {
+	Key   string
+	Value string
+}
+
+// gitRemoteEnv returns the environment for a git fetch or push from remote,
+// a URL or the name of a remote of the checkout at dir. When remote is the
+// server over HTTP(S), it is gerry's environment plus what authenticates
+// git to the server (see gitHTTPEnv); otherwise it is nil, so git inherits
+// gerry's environment unchanged. The credentials never reach any other
+// process.
+func gitRemoteEnv(cfg *config.Config, dir, remote string) []string {
+	remoteURL := remote
+	if !strings.Contains(remote, ":") {
+		if u, err := gitOutput(dir, "remote", "get-url", remote); err == nil {
+			remoteURL = u
+		}
+	}
+	if !isHTTPRemote(remoteURL) || !strings.EqualFold(remoteURLHost(remoteURL), cfg.Server) {
+		return nil
+	}
+
+	cookieFile := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		if _, err := os.Stat(filepath.Join(home, ".gitcookies")); err == nil {
+			cookieFile = filepath.Join(home, ".gitcookies")
+		}
+	}
+	return gitHTTPEnv(os.Environ(), cfg.GetHTTPBaseURL(), cfg.User, cfg.HTTPPassword, cookieFile)
+}
+
+// gitHTTPEnv adds to environ the git settings that authenticate requests to
+// baseURL with cookieFile, if set, and with user and password through
+// gitCredentialHelper, if a password is set. The settings are scoped to
+// baseURL and come after the user's own, so their credential helpers are
+// asked first. It returns nil when there is nothing to add.
+func gitHTTPEnv(environ []string, baseURL, user, password, cookieFile string) []string {
+	var entries []gitConfigEntry
+	if cookieFile != "" {
+		entries = append(entries, gitConfigEntry{"http." + baseURL + ".cookieFile", cookieFile})
+	}
+	if password != "" {
+		entries = append(entries, gitConfigEntry{"credential." + baseURL + ".helper", gitCredentialHelper})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	count := 0
+	env := make([]string, 0, len(environ)+2*len(entries)+3)
+	for _, kv := range environ {
+		if value, ok := strings.CutPrefix(kv, "GIT_CONFIG_COUNT="); ok {
+			count, _ = strconv.Atoi(value)
+			continue
+		}
+		env = append(env, kv)
+	}
+	for i, e := range entries {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", count+i, e.Key),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count+i, e.Value))
+	}
+	env = append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(count+len(entries)))
+	if password != "" {
+		env = append(env, "GERRY_GIT_USER="+user, "GERRY_GIT_PASSWORD="+password)
+	}
+	return env
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestGitHTTPEnv(t *testing.T) {
+	if got := gitHTTPEnv([]string{"PATH=/bin"}, "https://review.example.com", "alice", "", ""); got != nil {
+		t.Errorf("gitHTTPEnv() without credentials = %v, want nil", got)
+	}
+
+	environ := []string{"PATH=/bin", "GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=core.pager", "GIT_CONFIG_VALUE_0=cat"}
+	got := gitHTTPEnv(environ, "https://review.example.com", "alice", "s3cret", "/home/alice/.gitcookies")
+	want := []string{
+		"PATH=/bin",
+		"GIT_CONFIG_KEY_0=core.pager",
+		"GIT_CONFIG_VALUE_0=cat",
+		"GIT_CONFIG_KEY_1=http.https://review.example.com.cookieFile",
+		"GIT_CONFIG_VALUE_1=/home/alice/.gitcookies",
+		"GIT_CONFIG_KEY_2=credential.https://review.example.com.helper",
+		"GIT_CONFIG_VALUE_2=" + gitCredentialHelper,
+		"GIT_CONFIG_COUNT=3",
+		"GERRY_GIT_USER=alice",
+		"GERRY_GIT_PASSWORD=s3cret",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("gitHTTPEnv() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGitRemoteEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := &config.Config{Server: "review.example.com", Port: 29418, User: "alice", HTTPPassword: "s3cret"}
+
+	for _, remote := range []string{
+		"ssh://alice@review.example.com:29418/app",
+		"https://mirror.example.com/app",
+	} {
+		if env := gitRemoteEnv(cfg, "", remote); env != nil {
+			t.Errorf("gitRemoteEnv(%q) = %v, want nil", remote, env)
+		}
+	}
+	env := gitRemoteEnv(cfg, "", "https://review.example.com/a/app")
+	if !containsString(env, "GERRY_GIT_PASSWORD=s3cret") {
+		t.Errorf("gitRemoteEnv(https) is missing the password: %v", env)
+	}
+}
+
+// The credentials are only ever set on the git commands that need them,
+// never in gerry's own environment.
+func TestHTTPPasswordStaysOutOfEnvironment(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GERRIT_SERVER", "review.example.com")
+	t.Setenv("GERRIT_USER", "alice")
+	t.Setenv("GERRIT_HTTP_PASSWORD", "s3cret")
+	t.Setenv("GERRIT_FETCH_PROTOCOL", "http")
+
+	rootCmd.SetArgs([]string{"version"})
+	defer rootCmd.SetArgs(nil)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("gerry version: %v", err)
+	}
+	gitRemoteEnv(&config.Config{Server: "review.example.com", User: "alice", HTTPPassword: "s3cret"}, "", "https://review.example.com/a/app")
+
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "GERRY_GIT_") || strings.HasPrefix(kv, "GIT_CONFIG_") {
+			t.Errorf("gerry's environment contains %s", kv)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var groupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "Manage Gerrit group membership",
+	Long: `Manage the members of Gerrit groups, e.g. reviewer groups, without the admin
+UI. Requires REST API access and ownership of the group (or administrator
+rights).`,
+}
+
+var groupsAddMemberCmd = &cobra.Command{
+	Use:   "add-member <group> <user>...",
+	Short: "Add users to a group",
+	Long: `Add one or more users to a group. The group can be given by name or UUID;
+users by username, email, or account ID.
+
+Examples:
+  gerry groups add-member frontend-reviewers alice
+  gerry groups add-member frontend-reviewers alice bob@example.com`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runGroupsAddMember,
+}
+
+var groupsRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <group> <user>...",
+	Short: "Remove users from a group",
+	Long: `Remove one or more users from a group.
+
+Examples:
+  gerry groups remove-member frontend-reviewers alice`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runGroupsRemoveMember,
+}
+
+func init() {
+	groupsCmd.AddCommand(groupsAddMemberCmd)
+	groupsCmd.AddCommand(groupsRemoveMemberCmd)
+}
+
+func runGroupsAddMember(cmd *cobra.Command, args []string) error {
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	group := args[0]
+	for _, user := range args[1:] {
+		member, err := client.AddGroupMember(group, user)
+		if err != nil {
+			return groupMemberError("add", user, group, err)
+		}
+		fmt.Printf("%s Added %s to %s\n", utils.Green("✓"), member.DisplayName(), utils.BoldCyan(group))
+	}
+	return nil
+}
+
+func runGroupsRemoveMember(cmd *cobra.Command, args []string) error {
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	group := args[0]
+	for _, user := range args[1:] {
+		if err := client.RemoveGroupMember(group, user); err != nil {
+			return groupMemberError("remove", user, group, err)
+		}
+		fmt.Printf("%s Removed %s from %s\n", utils.Green("✓"), user, utils.BoldCyan(group))
+	}
+	return nil
+}
+
+// groupMemberError explains the failures a team lead is likely to hit.
+func groupMemberError(action, user, group string, err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "403"):
+		return fmt.Errorf("not permitted to %s members of %s: only the group's owners and administrators can change its membership (%w)", action, group, err)
+	case strings.Contains(msg, "404"):
+		return fmt.Errorf("group %s or user %s not found, or not visible to you: %w", group, user, err)
+	case strings.Contains(msg, "405"):
+		return fmt.Errorf("group %s is not an internal Gerrit group, so its members cannot be changed here: %w", group, err)
+	case strings.Contains(msg, "422"):
+		return fmt.Errorf("user %s not found or ambiguous: %w", user, err)
+	}
+	return fmt.Errorf("failed to %s %s: %w", action, user, err)
+}
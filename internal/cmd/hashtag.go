@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var hashtagCmd = &cobra.Command{
+	Use:   "hashtag",
+	Short: "Add, remove, and list change hashtags",
+	Long: `Manage the hashtags on a change, e.g. for sprint tracking. A leading "#" on a
+tag is ignored. Use 'gerry list --hashtag <tag>' to find changes by hashtag.
+
+Examples:
+  gerry hashtag add 12345 sprint-42 needs-docs
+  gerry hashtag remove 12345 needs-docs
+  gerry hashtag list 12345`,
+}
+
+var hashtagAddCmd = &cobra.Command{
+	Use:   "add <change-id> <tag...>",
+	Short: "Add hashtags to a change",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runHashtagAdd,
+}
+
+var hashtagRemoveCmd = &cobra.Command{
+	Use:   "remove <change-id> <tag...>",
+	Short: "Remove hashtags from a change",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runHashtagRemove,
+}
+
+var hashtagListCmd = &cobra.Command{
+	Use:   "list <change-id>",
+	Short: "List the hashtags of a change",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runHashtagList,
+}
+
+func init() {
+	hashtagCmd.AddCommand(hashtagAddCmd)
+	hashtagCmd.AddCommand(hashtagRemoveCmd)
+	hashtagCmd.AddCommand(hashtagListCmd)
+}
+
+func runHashtagAdd(cmd *cobra.Command, args []string) error {
+	return updateHashtags(args[0], args[1:], true)
+}
+
+func runHashtagRemove(cmd *cobra.Command, args []string) error {
+	return updateHashtags(args[0], args[1:], false)
+}
+
+func updateHashtags(changeID string, raw []string, add bool) error {
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	tags, err := normalizeHashtags(raw)
+	if err != nil {
+		return err
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	var current []string
+	if add {
+		current, err = client.SetHashtags(changeID, tags, nil)
+	} else {
+		current, err = client.SetHashtags(changeID, nil, tags)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update hashtags: %w", err)
+	}
+
+	verb := "Added"
+	if !add {
+		verb = "Removed"
+	}
+	fmt.Printf("%s %s %s on %s\n", utils.Green("✓"), verb, formatHashtags(tags), changeID)
+	if len(current) > 0 {
+		fmt.Printf("  Hashtags: %s\n", formatHashtags(current))
+	}
+	return nil
+}
+
+func runHashtagList(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	tags, err := client.GetHashtags(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get hashtags: %w", err)
+	}
+	if len(tags) == 0 {
+		fmt.Printf("Change %s has no hashtags.\n", changeID)
+		return nil
+	}
+	for _, tag := range tags {
+		fmt.Println(tag)
+	}
+	return nil
+}
+
+// normalizeHashtags strips a leading "#" from each tag and rejects empty
+// tags and tags with whitespace, which Gerrit does not allow.
+func normalizeHashtags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	for _, r := range raw {
+		tag := strings.TrimPrefix(strings.TrimSpace(r), "#")
+		if tag == "" || strings.ContainsAny(tag, " \t\n") {
+			return nil, fmt.Errorf("invalid hashtag %q", r)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// formatHashtags renders tags as "#a, #b".
+func formatHashtags(tags []string) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = utils.BoldCyan("#" + tag)
+	}
+	return strings.Join(out, ", ")
+}
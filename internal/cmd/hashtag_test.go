@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeHashtags(t *testing.T) {
+	got, err := normalizeHashtags([]string{"sprint-42", "#needs-docs", " spaced "})
+	if err != nil {
+		t.Fatalf("normalizeHashtags() error = %v", err)
+	}
+	if want := []string{"sprint-42", "needs-docs", "spaced"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeHashtags() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"", "#", "two words"} {
+		if _, err := normalizeHashtags([]string{bad}); err == nil {
+			t.Errorf("normalizeHashtags(%q) accepted an invalid tag", bad)
+		}
+	}
+}
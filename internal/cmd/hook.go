@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// hookMarker identifies hook scripts written by gerry, so they can be
+// replaced or removed without clobbering user-written hooks.
+const hookMarker = "# Installed by gerry"
+
+// prePushCommitLimit caps how many commits per pushed ref are checked.
+const prePushCommitLimit = 50
+
+var (
+	hookForce    bool
+	hookWarnOnly bool
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Install git hooks that guard review hygiene",
+	Long: `Install git hooks backed by gerry.
+
+Supported hooks:
+  pre-push  Before pushing to refs/for/*, check whether the changes being
+            updated have unresolved comment threads or a failing Verified
+            vote, and block the push (or just warn with --warn-only).
+
+Bypass a hook for a single push with 'git push --no-verify'.`,
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:   "install <hook>",
+	Short: "Install a gerry git hook in the current repository",
+	Long: `Install a gerry git hook in the current repository. An existing hook that
+was not written by gerry is left alone unless --force is given.
+
+Examples:
+  gerry hook install pre-push
+  gerry hook install pre-push --warn-only`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHookInstall,
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:   "uninstall <hook>",
+	Short: "Remove a gerry git hook from the current repository",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runHookUninstall,
+}
+
+var hookRunCmd = &cobra.Command{
+	Use:    "run <hook> [args...]",
+	Short:  "Run a gerry git hook (called by the installed hook script)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	RunE:   runHookRun,
+}
+
+func init() {
+	hookInstallCmd.Flags().BoolVarP(&hookForce, "force", "f", false, "Overwrite an existing hook not installed by gerry")
+	hookInstallCmd.Flags().BoolVar(&hookWarnOnly, "warn-only", false, "Warn about problems instead of blocking the push")
+	hookRunCmd.Flags().BoolVar(&hookWarnOnly, "warn-only", false, "Warn about problems instead of blocking the push")
+
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookUninstallCmd)
+	hookCmd.AddCommand(hookRunCmd)
+}
+
+// prePushRef is one line of the pre-push hook's stdin.
+type prePushRef struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+func validateHookName(name string) error {
+	if name != "pre-push" {
+		return fmt.Errorf("unsupported hook %q (supported: pre-push)", name)
+	}
+	return nil
+}
+
+// hookPath returns where git looks for the named hook, honoring
+// core.hooksPath and linked worktrees.
+func hookPath(name string) (string, error) {
+	if !isGitRepository() {
+		return "", fmt.Errorf("not in a git repository")
+	}
+	dir, err := gitOutput("", "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("failed to locate hooks directory: %w", err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+func isGerryHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), hookMarker)
+}
+
+func runHookInstall(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := validateHookName(name); err != nil {
+		return err
+	}
+
+	path, err := hookPath(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil && !isGerryHook(path) && !hookForce {
+		return fmt.Errorf("%s already exists and was not installed by gerry; use --force to overwrite", path)
+	}
+
+	runArgs := name
+	if hookWarnOnly {
+		runArgs += " --warn-only"
+	}
+	script := fmt.Sprintf(`#!/bin/sh
+%s (gerry hook install %s). Bypass with: git push --no-verify
+command -v gerry >/dev/null 2>&1 || exit 0
+exec gerry hook run %s "$@"
+`, hookMarker, name, runArgs)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+
+	fmt.Printf("%s Installed %s hook at %s\n", utils.Green("✓"), name, path)
+	return nil
+}
+
+func runHookUninstall(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := validateHookName(name); err != nil {
+		return err
+	}
+
+	path, err := hookPath(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Printf("No %s hook installed.\n", name)
+		return nil
+	}
+	if !isGerryHook(path) {
+		return fmt.Errorf("%s was not installed by gerry; remove it manually", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove hook: %w", err)
+	}
+
+	fmt.Printf("%s Removed %s hook\n", utils.Green("✓"), name)
+	return nil
+}
+
+func runHookRun(cmd *cobra.Command, args []string) error {
+	if err := validateHookName(args[0]); err != nil {
+		return err
+	}
+
+	// The hook must never be the reason a push fails because gerry itself is
+	// misconfigured or the server is down, so those cases only warn.
+	cfg, err := config.Load()
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gerry pre-push: skipping checks: %v\n", err)
+		return nil
+	}
+
+	refs := parsePrePushInput(os.Stdin)
+	problems := checkPrePush(cfg, refs)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, utils.BoldYellow("gerry pre-push: the changes you are updating need attention:"))
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  %s\n", p)
+	}
+	if hookWarnOnly {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Push blocked. Address the above, or bypass with: git push --no-verify")
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("pre-push checks failed")
+}
+
+// parsePrePushInput reads "<local ref> <local sha> <remote ref> <remote sha>"
+// lines and keeps pushes of commits to refs/for/*.
+func parsePrePushInput(r io.Reader) []prePushRef {
+	var refs []prePushRef
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		ref := prePushRef{LocalRef: fields[0], LocalSHA: fields[1], RemoteRef: fields[2], RemoteSHA: fields[3]}
+		if !strings.HasPrefix(ref.RemoteRef, "refs/for/") || isUncommitted(ref.LocalSHA) {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// prePushBranch extracts the target branch from refs/for/<branch>%options.
+func prePushBranch(remoteRef string) string {
+	branch := strings.TrimPrefix(remoteRef, "refs/for/")
+	branch, _, _ = strings.Cut(branch, "%")
+	return branch
+}
+
+// checkPrePush looks up the open changes updated by each pushed ref and
+// describes any that have unresolved threads or a failing Verified vote.
+func checkPrePush(cfg *config.Config, refs []prePushRef) []string {
+	var problems []string
+	for _, ref := range refs {
+		out, err := exec.Command("git", "rev-list", fmt.Sprintf("--max-count=%d", prePushCommitLimit),
+			ref.LocalSHA, "--not", "--remotes").Output()
+		if err != nil {
+			utils.Debugf("rev-list failed: %v", err)
+			continue
+		}
+		commits := strings.Fields(string(out))
+		if len(commits) == 0 {
+			commits = []string{ref.LocalSHA}
+		}
+
+		changeIDs, err := commitChangeIDs(commits)
+		if err != nil || len(changeIDs) == 0 {
+			continue
+		}
+
+		var terms []string
+		for _, key := range changeIDs {
+			terms = append(terms, "change:"+key)
+		}
+		query := fmt.Sprintf("(%s) branch:%s status:open", strings.Join(terms, " OR "), prePushBranch(ref.RemoteRef))
+
+		changes, err := listChangesREST(cfg, query, len(terms))
+		if err != nil {
+			utils.Debugf("REST API failed: %v", err)
+			changes, err = listChangesSSH(cfg, query, len(terms))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gerry pre-push: skipping checks: %v\n", err)
+				continue
+			}
+		}
+
+		for _, change := range changes {
+			if reasons := prePushProblems(change); len(reasons) > 0 {
+				problems = append(problems, fmt.Sprintf("%s %s: %s",
+					change.ChangeNumberStr(), utils.TruncateString(change.Subject, 50), strings.Join(reasons, ", ")))
+			}
+		}
+	}
+	return problems
+}
+
+// prePushProblems lists the review-hygiene issues of a change.
+func prePushProblems(change gerrit.Change) []string {
+	var reasons []string
+	if change.UnresolvedCommentCount > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d unresolved comment thread(s)", change.UnresolvedCommentCount))
+	}
+	if score, ok := getLabelScore(change, "Verified"); ok && score < 0 {
+		reasons = append(reasons, fmt.Sprintf("Verified %d", score))
+	}
+	return reasons
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestParsePrePushInput(t *testing.T) {
+	input := strings.Join([]string{
+		"HEAD " + shaA + " refs/for/main 0000000000000000000000000000000000000000",
+		"refs/heads/topic " + shaB + " refs/heads/topic " + shaA,
+		"(delete) 0000000000000000000000000000000000000000 refs/for/main " + shaA,
+		"garbage",
+	}, "\n")
+
+	got := parsePrePushInput(strings.NewReader(input))
+	want := []prePushRef{{
+		LocalRef:  "HEAD",
+		LocalSHA:  shaA,
+		RemoteRef: "refs/for/main",
+		RemoteSHA: "0000000000000000000000000000000000000000",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePrePushInput() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPrePushBranch(t *testing.T) {
+	tests := map[string]string{
+		"refs/for/main":                 "main",
+		"refs/for/release/1.0":          "release/1.0",
+		"refs/for/main%topic=x,r=alice": "main",
+	}
+	for ref, want := range tests {
+		if got := prePushBranch(ref); got != want {
+			t.Errorf("prePushBranch(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestPrePushProblems(t *testing.T) {
+	clean := gerrit.Change{Labels: map[string]interface{}{"Verified": labelVotes(1)}}
+	if got := prePushProblems(clean); got != nil {
+		t.Errorf("prePushProblems(clean) = %v, want nil", got)
+	}
+
+	dirty := gerrit.Change{
+		UnresolvedCommentCount: 2,
+		Labels:                 map[string]interface{}{"Verified": labelVotes(1, -1)},
+	}
+	want := []string{"2 unresolved comment thread(s)", "Verified -1"}
+	if got := prePushProblems(dirty); !reflect.DeepEqual(got, want) {
+		t.Errorf("prePushProblems(dirty) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hostkeyPort        int
+	hostkeyFingerprint string
+	hostkeyYes         bool
+)
+
+var hostkeyCmd = &cobra.Command{
+	Use:   "hostkey",
+	Short: "Manage the Gerrit server's SSH host key",
+	Long: `Show, trust, or forget the SSH host key of a Gerrit server in
+~/.ssh/known_hosts, the file gerry's SSH commands check.
+
+The server defaults to the configured one, and the port to the configured SSH
+port (29418 without a configuration). Use 'trust --fingerprint' in automation
+to pin the expected key instead of accepting whatever the server presents.`,
+}
+
+var hostkeyShowCmd = &cobra.Command{
+	Use:   "show [server]",
+	Short: "Show the server's host key fingerprints and whether they are trusted",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHostkeyShow,
+}
+
+var hostkeyTrustCmd = &cobra.Command{
+	Use:   "trust [server]",
+	Short: "Add the server's current host key to known_hosts",
+	Long: `Fetch the server's host keys, show their fingerprints, and after
+confirmation replace any known_hosts entries for the server with them.
+
+Examples:
+  gerry hostkey trust
+  gerry hostkey trust gerrit.example.com --fingerprint SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHostkeyTrust,
+}
+
+var hostkeyRemoveCmd = &cobra.Command{
+	Use:   "remove [server]",
+	Short: "Remove the server's entries from known_hosts",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHostkeyRemove,
+}
+
+func init() {
+	hostkeyCmd.PersistentFlags().IntVar(&hostkeyPort, "port", 0, "SSH port (default: configured port, or 29418)")
+	hostkeyTrustCmd.Flags().StringVar(&hostkeyFingerprint, "fingerprint", "", "Only trust the key with this SHA256 fingerprint (no prompt)")
+	hostkeyTrustCmd.Flags().BoolVarP(&hostkeyYes, "yes", "y", false, "Trust the presented keys without prompting")
+	hostkeyCmd.AddCommand(hostkeyShowCmd)
+	hostkeyCmd.AddCommand(hostkeyTrustCmd)
+	hostkeyCmd.AddCommand(hostkeyRemoveCmd)
+}
+
+// hostKey is one public key, as a known_hosts or ssh-keyscan line.
+type hostKey struct {
+	Type string
+	Key  string // base64 blob
+}
+
+// Fingerprint returns the key's SHA256 fingerprint as printed by ssh-keygen.
+func (k hostKey) Fingerprint() string {
+	blob, err := base64.StdEncoding.DecodeString(k.Key)
+	if err != nil {
+		return "(invalid key)"
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// hostkeyTarget is the server whose key is managed, and the jump host the
+// server is reached through, if any.
+type hostkeyTarget struct {
+	server string
+	port   int
+	jump   string
+}
+
+// resolveHostkeyTarget combines the argument, flags, and configuration. A
+// server given as an argument doesn't need a configuration.
+func resolveHostkeyTarget(args []string) (hostkeyTarget, error) {
+	t := hostkeyTarget{port: 29418}
+	cfg, cfgErr := config.Load()
+	if cfgErr == nil {
+		t = hostkeyTarget{server: cfg.Server, port: cfg.Port, jump: cfg.SSHProxyJump}
+	}
+	if len(args) == 1 {
+		t.server = args[0]
+	} else if cfgErr != nil {
+		return t, fmt.Errorf("failed to load configuration: %w", cfgErr)
+	}
+	if hostkeyPort != 0 {
+		t.port = hostkeyPort
+	}
+	if err := utils.ValidateServerURL(t.server); err != nil {
+		return t, fmt.Errorf("invalid server: %w", err)
+	}
+	return t, nil
+}
+
+// name is how OpenSSH names the host in known_hosts: bare for port 22,
+// "[host]:port" otherwise.
+func (t hostkeyTarget) name() string {
+	if t.port == 22 {
+		return t.server
+	}
+	return fmt.Sprintf("[%s]:%d", t.server, t.port)
+}
+
+// keyscanCommand returns the command line that fetches the server's keys.
+// Behind a jump host the scan runs on the last hop, since ssh-keyscan can't
+// tunnel; earlier hops are passed to ssh as -J.
+func (t hostkeyTarget) keyscanCommand() []string {
+	scan := []string{"ssh-keyscan", "-p", strconv.Itoa(t.port), t.server}
+	if t.jump == "" {
+		return scan
+	}
+	hops := strings.Split(t.jump, ",")
+	last := hops[len(hops)-1]
+	cmd := []string{"ssh"}
+	if len(hops) > 1 {
+		cmd = append(cmd, "-J", strings.Join(hops[:len(hops)-1], ","))
+	}
+	if !strings.HasPrefix(last, "ssh://") {
+		last = "ssh://" + last
+	}
+	return append(append(cmd, last), scan...)
+}
+
+func knownHostsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".ssh", "known_hosts"), nil
+}
+
+// parseHostKeys reads keys from known_hosts-format lines, skipping comments
+// and @revoked entries. The host field is ignored.
+func parseHostKeys(output string) []hostKey {
+	var keys []hostKey
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "@") {
+			if fields[0] == "@revoked" {
+				continue
+			}
+			fields = fields[1:]
+		}
+		if len(fields) < 3 {
+			continue
+		}
+		keys = append(keys, hostKey{Type: fields[1], Key: fields[2]})
+	}
+	return keys
+}
+
+// scanHostKeys fetches the keys the server presents.
+func scanHostKeys(t hostkeyTarget) ([]hostKey, error) {
+	args := t.keyscanCommand()
+	cmd := exec.Command(args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// ssh-keyscan exits non-zero when any key type fails, so only the keys
+	// it printed matter.
+	err := cmd.Run()
+	keys := parseHostKeys(stdout.String())
+	if len(keys) == 0 {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%s not found; install OpenSSH", args[0])
+		}
+		return nil, fmt.Errorf("no host keys received from %s:%d (is the server reachable?)%s", t.server, t.port, strings.TrimRight("\n"+stderr.String(), "\n"))
+	}
+	return keys, nil
+}
+
+// knownHostKeys returns the keys known_hosts has for name, including hashed
+// entries.
+func knownHostKeys(name string) ([]hostKey, error) {
+	path, err := knownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	out, err := exec.Command("ssh-keygen", "-F", name, "-f", path).Output()
+	if err != nil {
+		// ssh-keygen -F exits 1 when the host isn't found.
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseHostKeys(string(out)), nil
+}
+
+// hostKeyStatus compares a presented key with the known keys of the host.
+func hostKeyStatus(key hostKey, known []hostKey) string {
+	mismatch := false
+	for _, k := range known {
+		if k.Type != key.Type {
+			continue
+		}
+		if k.Key == key.Key {
+			return "trusted"
+		}
+		mismatch = true
+	}
+	if mismatch {
+		return "changed"
+	}
+	return "unknown"
+}
+
+func runHostkeyShow(cmd *cobra.Command, args []string) error {
+	target, err := resolveHostkeyTarget(args)
+	if err != nil {
+		return err
+	}
+	name := target.name()
+
+	keys, err := scanHostKeys(target)
+	if err != nil {
+		return err
+	}
+	known, err := knownHostKeys(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s\n", utils.BoldCyan("Host:"), utils.BoldWhite(name))
+	var rows [][]string
+	for _, key := range keys {
+		status := hostKeyStatus(key, known)
+		switch status {
+		case "trusted":
+			status = utils.Green("✓ trusted")
+		case "changed":
+			status = utils.BoldRed("✗ CHANGED")
+		default:
+			status = utils.Yellow("not trusted")
+		}
+		rows = append(rows, []string{key.Type, key.Fingerprint(), status})
+	}
+	fmt.Print(utils.FormatTable([]string{"Type", "Fingerprint", "Status"}, rows, 2))
+	return nil
+}
+
+func runHostkeyTrust(cmd *cobra.Command, args []string) error {
+	target, err := resolveHostkeyTarget(args)
+	if err != nil {
+		return err
+	}
+	name := target.name()
+
+	keys, err := scanHostKeys(target)
+	if err != nil {
+		return err
+	}
+
+	if hostkeyFingerprint != "" {
+		var pinned []hostKey
+		for _, key := range keys {
+			if key.Fingerprint() == hostkeyFingerprint {
+				pinned = append(pinned, key)
+			}
+		}
+		if len(pinned) == 0 {
+			return fmt.Errorf("%s presented no key with fingerprint %s; nothing trusted", name, hostkeyFingerprint)
+		}
+		keys = pinned
+	}
+
+	fmt.Printf("%s %s\n", utils.BoldCyan("Host:"), utils.BoldWhite(name))
+	for _, key := range keys {
+		fmt.Printf("  %s %s\n", key.Type, key.Fingerprint())
+	}
+
+	if hostkeyFingerprint == "" && !hostkeyYes {
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("Trust these keys for %s?", name),
+		}
+		if err := askOne(prompt, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if err := removeKnownHost(name); err != nil {
+		return err
+	}
+	if err := appendKnownHosts(name, keys); err != nil {
+		return err
+	}
+	fmt.Printf("%s Trusted %d key(s) for %s\n", utils.Green("✓"), len(keys), name)
+	return nil
+}
+
+func runHostkeyRemove(cmd *cobra.Command, args []string) error {
+	target, err := resolveHostkeyTarget(args)
+	if err != nil {
+		return err
+	}
+	name := target.name()
+
+	known, err := knownHostKeys(name)
+	if err != nil {
+		return err
+	}
+	if len(known) == 0 {
+		utils.Infof("No known_hosts entries for %s", name)
+		return nil
+	}
+	if err := removeKnownHost(name); err != nil {
+		return err
+	}
+	fmt.Printf("%s Removed %d key(s) for %s\n", utils.Green("✓"), len(known), name)
+	return nil
+}
+
+// removeKnownHost deletes every known_hosts entry for name.
+func removeKnownHost(name string) error {
+	path, err := knownHostsPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	if out, err := exec.Command("ssh-keygen", "-R", name, "-f", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove %s from %s: %w\n%s", name, path, err, out)
+	}
+	// ssh-keygen -R leaves the previous contents behind as known_hosts.old.
+	os.Remove(path + ".old")
+	return nil
+}
+
+func appendKnownHosts(name string, keys []hostKey) error {
+	path, err := knownHostsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(f, "%s %s %s\n", name, key.Type, key.Key); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHostKeys(t *testing.T) {
+	output := `# gerrit.example.com:29418 SSH-2.0-GerritCodeReview_3.9.1
+[gerrit.example.com]:29418 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
+|1|F1E1KeoE/eEWhi10WpGv4OdiO6Y=|3988QV0VE8wmZL7suNrYQLITLCg= ecdsa-sha2-nistp256 AAAAE2VjZHNh
+@revoked [gerrit.example.com]:29418 ssh-rsa AAAAB3NzaC1yc2E
+@cert-authority *.example.com ssh-rsa AAAAB3NzaC1yc2EC
+
+garbage
+`
+	want := []hostKey{
+		{Type: "ssh-ed25519", Key: "AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"},
+		{Type: "ecdsa-sha2-nistp256", Key: "AAAAE2VjZHNh"},
+		{Type: "ssh-rsa", Key: "AAAAB3NzaC1yc2EC"},
+	}
+	if got := parseHostKeys(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseHostKeys() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHostKeyFingerprint(t *testing.T) {
+	// ssh-keygen -lf prints this fingerprint for the key.
+	key := hostKey{Type: "ssh-ed25519", Key: "AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"}
+	if got, want := key.Fingerprint(), "SHA256:+DiY3wvvV6TuJJhbpZisF/zLDA0zPMSvHdkr4UvCOqU"; got != want {
+		t.Errorf("Fingerprint() = %q, want %q", got, want)
+	}
+}
+
+func TestHostKeyStatus(t *testing.T) {
+	ed := hostKey{Type: "ssh-ed25519", Key: "AAAA1"}
+	tests := []struct {
+		name  string
+		known []hostKey
+		want  string
+	}{
+		{"no entries", nil, "unknown"},
+		{"same key", []hostKey{{Type: "ssh-rsa", Key: "BBBB"}, ed}, "trusted"},
+		{"different key of same type", []hostKey{{Type: "ssh-ed25519", Key: "AAAA2"}}, "changed"},
+		{"only other types", []hostKey{{Type: "ssh-rsa", Key: "BBBB"}}, "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hostKeyStatus(ed, tt.known); got != tt.want {
+				t.Errorf("hostKeyStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostkeyTargetName(t *testing.T) {
+	if got := (hostkeyTarget{server: "gerrit.example.com", port: 22}).name(); got != "gerrit.example.com" {
+		t.Errorf("port 22: got %q", got)
+	}
+	if got := (hostkeyTarget{server: "gerrit.example.com", port: 29418}).name(); got != "[gerrit.example.com]:29418" {
+		t.Errorf("port 29418: got %q", got)
+	}
+}
+
+func TestHostkeyTargetKeyscanCommand(t *testing.T) {
+	tests := []struct {
+		jump string
+		want []string
+	}{
+		{"", []string{"ssh-keyscan", "-p", "29418", "gerrit"}},
+		{"me@bastion:2222", []string{"ssh", "ssh://me@bastion:2222", "ssh-keyscan", "-p", "29418", "gerrit"}},
+		{"outer,inner", []string{"ssh", "-J", "outer", "ssh://inner", "ssh-keyscan", "-p", "29418", "gerrit"}},
+	}
+	for _, tt := range tests {
+		target := hostkeyTarget{server: "gerrit", port: 29418, jump: tt.jump}
+		if got := target.keyscanCommand(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("keyscanCommand() with jump %q = %q, want %q", tt.jump, got, tt.want)
+		}
+	}
+}
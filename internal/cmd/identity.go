@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+// identityResolver applies the author_aliases and bot_patterns config. A
+// nil resolver leaves accounts alone and treats none as bots.
+type identityResolver struct {
+	aliases map[string]string
+	bots    []string
+}
+
+// newIdentityResolver returns the resolver for cfg, or nil if it configures
+// neither aliases nor bots.
+func newIdentityResolver(cfg *config.Config) *identityResolver {
+	if len(cfg.AuthorAliases) == 0 && len(cfg.BotPatterns) == 0 {
+		return nil
+	}
+	r := &identityResolver{aliases: make(map[string]string)}
+	for from, to := range cfg.AuthorAliases {
+		r.aliases[strings.ToLower(strings.TrimSpace(from))] = strings.TrimSpace(to)
+	}
+	for _, pattern := range cfg.BotPatterns {
+		r.bots = append(r.bots, strings.ToLower(pattern))
+	}
+	return r
+}
+
+// accountKeys returns the identifiers of an account that aliases and bot
+// patterns are matched against, most specific first.
+func accountKeys(a gerrit.Account) []string {
+	var keys []string
+	for _, key := range []string{a.Email, a.Username, a.Name} {
+		if key != "" {
+			keys = append(keys, strings.ToLower(key))
+		}
+	}
+	return keys
+}
+
+// isBot reports whether the account matches a bot pattern.
+func (r *identityResolver) isBot(a gerrit.Account) bool {
+	if r == nil {
+		return false
+	}
+	for _, key := range accountKeys(a) {
+		for _, pattern := range r.bots {
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolve returns the account with its name replaced by its alias, if it
+// has one.
+func (r *identityResolver) resolve(a gerrit.Account) gerrit.Account {
+	if r == nil {
+		return a
+	}
+	for _, key := range accountKeys(a) {
+		if name, ok := r.aliases[key]; ok {
+			a.Name = name
+			return a
+		}
+	}
+	return a
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestIdentityResolver(t *testing.T) {
+	r := newIdentityResolver(&config.Config{
+		AuthorAliases: map[string]string{
+			"JDoe@Old-Corp.com": "Jane Doe",
+			"jdoe2":             "Jane Doe",
+		},
+		BotPatterns: []string{"*jenkins*", "svc-*"},
+	})
+
+	aliases := []struct {
+		account gerrit.Account
+		want    string
+	}{
+		{gerrit.Account{Name: "J. Doe", Email: "jdoe@old-corp.com"}, "Jane Doe"},
+		{gerrit.Account{Username: "JDOE2"}, "Jane Doe"},
+		{gerrit.Account{Name: "Bob", Email: "bob@example.com"}, "Bob"},
+	}
+	for _, tt := range aliases {
+		if got := r.resolve(tt.account).DisplayName(); got != tt.want {
+			t.Errorf("resolve(%+v) = %q, want %q", tt.account, got, tt.want)
+		}
+	}
+
+	bots := []struct {
+		account gerrit.Account
+		want    bool
+	}{
+		{gerrit.Account{Name: "Service Cloud Jenkins"}, true},
+		{gerrit.Account{Name: "Deploy", Username: "svc-deploy"}, true},
+		{gerrit.Account{Name: "Jane Doe", Email: "jane@example.com"}, false},
+	}
+	for _, tt := range bots {
+		if got := r.isBot(tt.account); got != tt.want {
+			t.Errorf("isBot(%+v) = %v, want %v", tt.account, got, tt.want)
+		}
+	}
+
+	var none *identityResolver
+	if none.isBot(gerrit.Account{Name: "Jenkins"}) || none.resolve(gerrit.Account{Name: "x"}).Name != "x" {
+		t.Error("nil resolver changed an account")
+	}
+	if newIdentityResolver(&config.Config{}) != nil {
+		t.Error("newIdentityResolver() without config is not nil")
+	}
+}
+
+func TestBotMessagesAreNotReviews(t *testing.T) {
+	r := newIdentityResolver(&config.Config{BotPatterns: []string{"*jenkins*"}})
+	change := gerrit.Change{
+		Owner:   gerrit.Account{AccountID: 1},
+		Created: "2025-01-06 09:00:00.000000000",
+		Messages: []gerrit.ChangeMessageInfo{
+			{Author: gerrit.Account{AccountID: 2, Name: "Jenkins"}, Message: "Patch Set 1: Verified+1", Date: "2025-01-06 09:10:00.000000000"},
+			{Author: gerrit.Account{AccountID: 3, Name: "Bob"}, Message: "Patch Set 1:\n\nLGTM", Date: "2025-01-06 11:00:00.000000000"},
+		},
+	}
+	if got := measureReviewLatency(change, r).FirstReview.Minutes(); got != 120 {
+		t.Errorf("first review after %v minutes, want 120", got)
+	}
+	if got := measureReviewLatency(change, nil).FirstReview.Minutes(); got != 10 {
+		t.Errorf("without bot patterns, first review after %v minutes, want 10", got)
+	}
+}
@@ -1,34 +1,88 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initServer         string
+	initPort           int
+	initUser           string
+	initHTTPPort       int
+	initHTTPPassword   string
+	initProject        string
+	initNonInteractive bool
+	initSkipVerify     bool
+	initForce          bool
+	initFromGitReview  bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize gerry configuration",
-	Long:  `Interactive setup wizard to configure your Gerrit connection.`,
+	Long: `Interactive setup wizard to configure your Gerrit connection.
+
+Re-running init pre-fills every prompt with the current configuration, so you
+only need to change what is different. Values passed as flags pre-fill the
+prompts too.
+
+With --from-gitreview the server, SSH port, project, and default branch are
+taken from the repository's .gitreview file (as used by git-review), so teams
+coming from that tool only need to add their username and HTTP password.
+Flags still take precedence.
+
+With --non-interactive no prompts are shown: the existing configuration (if
+any) is updated with the values given as flags, connectivity is tested, and
+the result is saved. This is intended for provisioning scripts. Prefer the
+GERRIT_HTTP_PASSWORD environment variable over --http-password so the
+password does not end up in shell history.
+
+Examples:
+  gerry init
+  gerry init --server gerrit.example.com --user jdoe --http-port 8443 --non-interactive
+  gerry init --from-gitreview
+  GERRIT_HTTP_PASSWORD=secret gerry init --server gerrit.example.com --user jdoe --non-interactive`,
 	RunE: runInit,
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
-	fmt.Println(color.YellowString("Welcome to gerry setup wizard!"))
-	fmt.Println("This will guide you through setting up your Gerrit connection.")
-
 	cfg := &config.Config{}
 
 	// Detect an existing configuration and pre-fill prompts with its values.
-	existing, _ := config.Load()
+	var existing *config.Config
+	if !initForce {
+		existing, _ = config.Load()
+	}
 	if existing != nil {
 		*cfg = *existing
+	}
+	if initFromGitReview {
+		if err := applyGitReview(cfg); err != nil {
+			return err
+		}
+	}
+	applyInitFlags(cmd, cfg)
+
+	if initNonInteractive || utils.CIMode() {
+		return runInitNonInteractive(cfg)
+	}
+
+	fmt.Println(color.YellowString("Welcome to gerry setup wizard!"))
+	fmt.Println("This will guide you through setting up your Gerrit connection.")
+
+	if existing != nil {
 		if configPath, err := config.GetConfigPath(); err == nil {
 			fmt.Printf("\nExisting configuration detected at %s.\n", configPath)
 		}
@@ -52,7 +106,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		Default: cfg.Server,
 		Help:    "Example: gerrit.example.com",
 	}
-	if err := survey.AskOne(serverPrompt, &cfg.Server, survey.WithValidator(survey.Required)); err != nil {
+	if err := askOne(serverPrompt, &cfg.Server, survey.WithValidator(survey.Required)); err != nil {
 		return err
 	}
 
@@ -63,7 +117,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		Help:    "Default Gerrit SSH port is 29418",
 	}
 	var portStr string
-	if err := survey.AskOne(portPrompt, &portStr); err != nil {
+	if err := askOne(portPrompt, &portStr); err != nil {
 		return err
 	}
 	fmt.Sscanf(portStr, "%d", &cfg.Port)
@@ -73,7 +127,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		Message: "Your Gerrit username:",
 		Default: userDefault,
 	}
-	if err := survey.AskOne(userPrompt, &cfg.User, survey.WithValidator(survey.Required)); err != nil {
+	if err := askOne(userPrompt, &cfg.User, survey.WithValidator(survey.Required)); err != nil {
 		return err
 	}
 
@@ -103,7 +157,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		Message: "Do you want to configure REST API access? (recommended for full functionality)",
 		Default: true,
 	}
-	if err := survey.AskOne(restPrompt, &useREST); err != nil {
+	if err := askOne(restPrompt, &useREST); err != nil {
 		return err
 	}
 
@@ -115,7 +169,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 			Help:    "Only set this if your Gerrit uses a non-standard port. Common ports: 443 (HTTPS), 8080 (HTTP), 8443 (HTTPS alternate)",
 		}
 		var httpPortStr string
-		if err := survey.AskOne(httpPortPrompt, &httpPortStr); err != nil {
+		if err := askOne(httpPortPrompt, &httpPortStr); err != nil {
 			return err
 		}
 		if httpPortStr != "" {
@@ -131,7 +185,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 			Help:    "Found in Gerrit Settings → HTTP Password",
 		}
 		var httpPassword string
-		if err := survey.AskOne(httpPasswordPrompt, &httpPassword); err != nil {
+		if err := askOne(httpPasswordPrompt, &httpPassword); err != nil {
 			return err
 		}
 		// Keep the existing password when the user leaves the prompt blank.
@@ -173,9 +227,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Default project (optional)
 	projectPrompt := &survey.Input{
 		Message: "Default project (optional):",
+		Default: cfg.Project,
 		Help:    "You can specify a default project to use with commands",
 	}
-	if err := survey.AskOne(projectPrompt, &cfg.Project); err != nil {
+	if err := askOne(projectPrompt, &cfg.Project); err != nil {
 		return err
 	}
 
@@ -190,7 +245,161 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// applyInitFlags overlays explicitly passed flags onto cfg.
+func applyInitFlags(cmd *cobra.Command, cfg *config.Config) {
+	flags := cmd.Flags()
+	if flags.Changed("server") {
+		cfg.Server = initServer
+	}
+	if flags.Changed("port") {
+		cfg.Port = initPort
+	}
+	if flags.Changed("user") {
+		cfg.User = initUser
+	}
+	if flags.Changed("http-port") {
+		cfg.HTTPPort = initHTTPPort
+	}
+	if flags.Changed("http-password") {
+		cfg.HTTPPassword = initHTTPPassword
+	} else if password := os.Getenv("GERRIT_HTTP_PASSWORD"); password != "" {
+		cfg.HTTPPassword = password
+	}
+	if flags.Changed("project") {
+		cfg.Project = initProject
+	}
+}
+
+// gitReview holds the [gerrit] section of a .gitreview file.
+type gitReview struct {
+	Host          string
+	Port          int
+	Project       string
+	DefaultBranch string
+}
+
+// applyGitReview overlays the current repository's .gitreview onto cfg.
+func applyGitReview(cfg *config.Config) error {
+	root, err := getGitRepoRoot()
+	if err != nil {
+		return fmt.Errorf("--from-gitreview must be run inside a git repository")
+	}
+	path := filepath.Join(root, ".gitreview")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read .gitreview: %w", err)
+	}
+	review, err := parseGitReview(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	cfg.Server = review.Host
+	if review.Port != 0 {
+		cfg.Port = review.Port
+	}
+	if review.Project != "" {
+		cfg.Project = review.Project
+	}
+	if review.DefaultBranch != "" {
+		cfg.DefaultBranch = review.DefaultBranch
+	}
+	fmt.Printf("Using %s: server %s, project %s\n", path, cfg.Server, cfg.Project)
+	return nil
+}
+
+// parseGitReview reads the INI-style .gitreview format. Only the [gerrit]
+// section matters; keys are case-insensitive and a project's ".git" suffix
+// is dropped, as git-review does.
+func parseGitReview(data string) (gitReview, error) {
+	var review gitReview
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+		if section != "gerrit" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "host":
+			review.Host = value
+		case "port":
+			port, err := strconv.Atoi(value)
+			if err != nil {
+				return gitReview{}, fmt.Errorf("invalid port %q", value)
+			}
+			review.Port = port
+		case "project":
+			review.Project = strings.TrimSuffix(value, ".git")
+		case "defaultbranch":
+			review.DefaultBranch = value
+		}
+	}
+	if review.Host == "" {
+		return gitReview{}, fmt.Errorf("no host in [gerrit] section")
+	}
+	return review, nil
+}
+
+// runInitNonInteractive validates, tests, and saves cfg without prompting.
+// Unlike the wizard, a failed REST test is an error rather than silently
+// disabling REST access, so provisioning scripts notice the problem.
+func runInitNonInteractive(cfg *config.Config) error {
+	if cfg.Port == 0 {
+		cfg.Port = 29418
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if !initSkipVerify {
+		fmt.Print("Testing SSH connection... ")
+		if err := gerrit.NewSSHClient(cfg).TestConnection(); err != nil {
+			fmt.Println(color.RedString("FAILED"))
+			return fmt.Errorf("SSH connection failed: %w", err)
+		}
+		fmt.Println(color.GreenString("SUCCESS"))
+
+		if cfg.HTTPPassword != "" {
+			fmt.Print("Testing REST API connection... ")
+			if err := gerrit.NewRESTClient(cfg).TestConnection(); err != nil {
+				fmt.Println(color.RedString("FAILED"))
+				return fmt.Errorf("REST API connection failed: %w", err)
+			}
+			fmt.Println(color.GreenString("SUCCESS"))
+		}
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	configPath, _ := config.GetConfigPath()
+	fmt.Printf("%s Configuration saved to: %s\n", color.GreenString("✓"), configPath)
+	return nil
+}
+
 func init() {
-	// Remove unnecessary flags for init command
-	initCmd.Flags().BoolP("force", "f", false, "Force overwrite existing configuration")
+	initCmd.Flags().StringVar(&initServer, "server", "", "Gerrit server hostname")
+	initCmd.Flags().IntVar(&initPort, "port", 0, "SSH port (default 29418)")
+	initCmd.Flags().StringVar(&initUser, "user", "", "Gerrit username")
+	initCmd.Flags().IntVar(&initHTTPPort, "http-port", 0, "HTTP/HTTPS port for the REST API")
+	initCmd.Flags().StringVar(&initHTTPPassword, "http-password", "", "HTTP password (prefer GERRIT_HTTP_PASSWORD)")
+	initCmd.Flags().StringVar(&initProject, "project", "", "Default project")
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Do not prompt; configure from flags and the existing config")
+	initCmd.Flags().BoolVar(&initSkipVerify, "skip-verify", false, "Skip SSH/REST connectivity tests (with --non-interactive)")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Ignore the existing configuration instead of pre-filling from it")
+	initCmd.Flags().BoolVar(&initFromGitReview, "from-gitreview", false, "Take server, port, project, and default branch from the repository's .gitreview")
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import "testing"
+
+func TestParseGitReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    gitReview
+		wantErr bool
+	}{
+		{
+			name: "full",
+			data: "[gerrit]\nhost=review.example.com\nport=29418\nproject=department/project.git\ndefaultbranch=master\n",
+			want: gitReview{Host: "review.example.com", Port: 29418, Project: "department/project", DefaultBranch: "master"},
+		},
+		{
+			name: "spacing, case, comments, and other sections",
+			data: "# comment\n[remote]\nhost = other\n[Gerrit]\n; comment\nHost = review.example.com\nproject = tools\n",
+			want: gitReview{Host: "review.example.com", Project: "tools"},
+		},
+		{
+			name:    "no host",
+			data:    "[gerrit]\nproject=tools\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad port",
+			data:    "[gerrit]\nhost=review.example.com\nport=ssh\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGitReview(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGitReview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseGitReview() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
@@ -11,24 +17,64 @@ import (
 )
 
 var (
-	detailed   bool
-	reviewer   bool
-	listLimit  int
-	listStatus string
+	detailed      bool
+	reviewer      bool
+	listLimit     int
+	listStatus    string
+	listWorkspace bool
+	listHashtags  []string
+	listAttention bool
+	listProject   string
+	listBranch    string
+	listTopic     string
+	listLabels    []string
+	listAge       string
+	listQuery     string
+	listColumns   string
+	listSort      string
+	listWatch     string
+	listAll       bool
+	listOwnerTeam string
+)
+
+var (
+	// listLabelPattern matches a label predicate as Gerrit's label: operator
+	// takes it, e.g. Code-Review=-1, Verified>=1, Code-Review+2.
+	listLabelPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(=|<=|>=|<|>)?([+-]?\d+|MAX|MIN|ANY)$`)
+	// listAgePattern matches a Gerrit age, e.g. 2d, 36h, 1week.
+	listAgePattern = regexp.MustCompile(`^\d+(s|sec|secs|second|seconds|m|min|mins|minute|minutes|h|hr|hrs|hour|hours|d|day|days|w|week|weeks|mon|month|months|y|year|years)$`)
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List changes",
-	Long:  `List your open changes or changes that need your review.`,
+	Long: `List your open changes or changes that need your review.
+
+With --workspace the list covers the projects in your workspace manifest
+(see 'gerry workspace'), grouped by project.`,
 	RunE: runList,
 }
 
 func init() {
 	listCmd.Flags().BoolVar(&detailed, "detailed", false, "Show detailed information")
 	listCmd.Flags().BoolVar(&reviewer, "reviewer", false, "Show changes that need your review")
-	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 25, "Maximum number of changes to show")
+	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 25, "Maximum number of changes to show (0 for all)")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Show every matching change, fetched page by page (same as --limit 0)")
 	listCmd.Flags().StringVar(&listStatus, "status", "open", "Filter by status (open, merged, abandoned)")
+	listCmd.Flags().BoolVar(&listWorkspace, "workspace", false, "List changes across the workspace projects, grouped by project")
+	listCmd.Flags().StringArrayVar(&listHashtags, "hashtag", nil, "Only list changes with this hashtag (repeatable; all must match)")
+	listCmd.Flags().BoolVar(&listAttention, "needs-attention", false, "Only list changes with a Code-Review -1/-2 or a failing Verified vote")
+	listCmd.Flags().StringVar(&listProject, "project", "", "Only list changes in this project")
+	listCmd.Flags().StringVar(&listBranch, "branch", "", "Only list changes targeting this branch")
+	listCmd.Flags().StringVar(&listTopic, "topic", "", "Only list changes with this topic")
+	listCmd.Flags().StringArrayVar(&listLabels, "label", nil, "Only list changes matching a label vote, e.g. Code-Review=-1 or Verified>=1 (repeatable)")
+	listCmd.Flags().StringVar(&listAge, "age", "", "Only list changes not updated for at least this long, e.g. 2d or 1w")
+	listCmd.Flags().StringVar(&listQuery, "query", "", "Additional Gerrit search terms")
+	listCmd.Flags().StringVar(&listColumns, "columns", "", "Comma-separated table columns: change, attention, subject, owner, project, branch, status, cr, qr, lr, v, unresolved, mergeable, updated")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort changes by updated, number, project, or attention (your turn first) (default: Gerrit's order)")
+	listCmd.Flags().StringVar(&listWatch, "watch", "", "Refresh the table every interval, e.g. --watch or --watch=60 (default 30s)")
+	listCmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval
+	listCmd.Flags().StringVar(&listOwnerTeam, "owner-team", "", "List the changes owned by the members of this configured team, grouped by member")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -40,56 +86,321 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	limit, err := changeLimit(cmd, listLimit, listAll)
+	if err != nil {
+		return err
+	}
+	if listColumns != "" && (structured || detailed) {
+		return fmt.Errorf("--columns only applies to the table view")
+	}
+	table := changeTableSpec(cfg.User, 60, listTableDefaults...)
+	columns, err := table.ParseColumns(listColumns)
+	if err != nil {
+		return err
+	}
+	less, err := table.SortOrder(listSort)
+	if err != nil {
+		return err
+	}
+	var interval time.Duration
+	if listWatch != "" {
+		if structured || detailed || listWorkspace {
+			return fmt.Errorf("--watch only applies to the table view, without --detailed or --workspace")
+		}
+		if interval, err = parseWatchInterval(listWatch); err != nil {
+			return err
+		}
+	}
+
+	var members []string
+	if listOwnerTeam != "" {
+		if reviewer || listWorkspace {
+			return fmt.Errorf("--owner-team cannot be combined with --reviewer or --workspace")
+		}
+		if members, err = namedTeam(cfg, listOwnerTeam); err != nil {
+			return err
+		}
+	}
 
 	// Build query based on flags
 	var query string
-	if reviewer {
+	if len(members) > 0 {
+		query = fmt.Sprintf("%s status:%s", memberQuery([]string{"owner"}, members), listStatus)
+	} else if reviewer {
 		query = fmt.Sprintf("reviewer:%s status:%s", cfg.User, listStatus)
 	} else {
 		query = fmt.Sprintf("owner:%s status:%s", cfg.User, listStatus)
 	}
+	filter := listFilter{
+		Project:        listProject,
+		Branch:         listBranch,
+		Topic:          listTopic,
+		Hashtags:       listHashtags,
+		Labels:         listLabels,
+		Age:            listAge,
+		NeedsAttention: listAttention,
+		Query:          listQuery,
+	}
+	filters, err := filter.queryTerms()
+	if err != nil {
+		return err
+	}
+	if len(filters) > 0 {
+		query += " " + strings.Join(filters, " ")
+	}
+
+	var repos []config.WorkspaceRepo
+	if listWorkspace {
+		repos, err = loadWorkspace(cfg)
+		if err != nil {
+			return err
+		}
+		query += " " + workspaceQuery(repos)
+	}
 
 	utils.Debugf("Query: %s", query)
 
-	// Try REST API first, fall back to SSH if needed
-	changes, err := listChangesREST(cfg, query, listLimit)
-	if err != nil {
-		utils.Warnf("REST API failed: %v", err)
-		utils.Info("Falling back to SSH...")
-		changes, err = listChangesSSH(cfg, query, listLimit)
+	// Each --watch refresh must see the server's current state, and Ctrl+C
+	// stops watching.
+	ctx := context.Background()
+	client := gerrit.NewRESTClient(cfg)
+	if interval > 0 {
+		var stop context.CancelFunc
+		ctx, stop = interruptContext(cmd)
+		defer stop()
+		client = client.WithoutCache().WithContext(ctx)
+	}
+	fetch := func() ([]gerrit.Change, error) {
+		// Try REST API first, fall back to SSH if needed
+		changes, err := listChangesPaged(client, query, limit)
+		if ctx.Err() != nil {
+			// Interrupted; there is nothing to fall back for.
+			return nil, ctx.Err()
+		}
 		if err != nil {
-			return fmt.Errorf("failed to list changes: %w", err)
+			utils.Warnf("REST API failed: %v", err)
+			utils.Info("Falling back to SSH...")
+			changes, err = listChangesSSH(cfg, query, limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list changes: %w", err)
+			}
 		}
+		sortChanges(changes, less)
+		return changes, nil
 	}
 
-	if len(changes) == 0 {
-		if reviewer {
-			fmt.Println("No changes found that need your review.")
-		} else {
-			fmt.Println("No changes found.")
+	emptyMessage := "No changes found."
+	if len(members) > 0 {
+		emptyMessage = fmt.Sprintf("No changes found for team %s.", listOwnerTeam)
+	} else if listAttention {
+		emptyMessage = "No changes need attention."
+	} else if reviewer {
+		emptyMessage = "No changes found that need your review."
+	}
+
+	if interval > 0 {
+		w := &changeTableWatcher{
+			title:    "gerry list",
+			interval: interval,
+			fetch:    fetch,
+			format: func(changes []gerrit.Change) string {
+				return formatChangeTable(table, columns, changes)
+			},
+			empty: emptyMessage,
+			out:   os.Stdout,
+			clear: utils.IsTerminal(os.Stdout),
 		}
+		return w.run(ctx)
+	}
+
+	changes, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	if structured {
+		if changes == nil {
+			changes = []gerrit.Change{}
+		}
+		return renderOutput(os.Stdout, changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println(emptyMessage)
+		return nil
+	}
+
+	if listWorkspace {
+		displayWorkspaceChanges(repos, changes, table, columns)
 		return nil
 	}
 
 	// Display results
-	if detailed {
+	if len(members) > 0 {
+		displayMemberChanges(groupByMember(members, changes, false), detailed, table, columns)
+	} else if detailed {
 		displayDetailedChanges(changes)
 	} else {
-		displaySimpleChanges(changes)
+		displayChangeTable(table, columns, changes)
 	}
 	return nil
 }
 
+// listFilter holds the gerry list filter flags.
+type listFilter struct {
+	Project        string
+	Branch         string
+	Topic          string
+	Hashtags       []string
+	Labels         []string
+	Age            string
+	NeedsAttention bool
+	Query          string
+}
+
+// queryTerms validates the filters and returns them as Gerrit search terms.
+func (f listFilter) queryTerms() ([]string, error) {
+	var terms []string
+	if f.Project != "" {
+		terms = append(terms, "project:"+queryValue(f.Project))
+	}
+	if f.Branch != "" {
+		terms = append(terms, "branch:"+queryValue(f.Branch))
+	}
+	if f.Topic != "" {
+		terms = append(terms, "topic:"+queryValue(f.Topic))
+	}
+	if len(f.Hashtags) > 0 {
+		tags, err := normalizeHashtags(f.Hashtags)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			terms = append(terms, fmt.Sprintf("hashtag:%q", tag))
+		}
+	}
+	for _, label := range f.Labels {
+		if !listLabelPattern.MatchString(label) {
+			return nil, fmt.Errorf("invalid --label %q (use NAME=VALUE, e.g. Code-Review=-1 or Verified>=1)", label)
+		}
+		terms = append(terms, "label:"+label)
+	}
+	if f.Age != "" {
+		if !listAgePattern.MatchString(f.Age) {
+			return nil, fmt.Errorf("invalid --age %q (use a number and unit, e.g. 2d, 36h, or 1w)", f.Age)
+		}
+		terms = append(terms, "age:"+f.Age)
+	}
+	if f.NeedsAttention {
+		terms = append(terms, needsAttentionQuery)
+	}
+	if f.Query != "" {
+		terms = append(terms, "("+f.Query+")")
+	}
+	return terms, nil
+}
+
+// displayWorkspaceChanges prints one table per workspace project, in
+// manifest order, skipping projects without changes.
+func displayWorkspaceChanges(repos []config.WorkspaceRepo, changes []gerrit.Change, table utils.TableSpec, columns []utils.TableColumn) {
+	byProject := make(map[string][]gerrit.Change)
+	for _, change := range changes {
+		byProject[change.Project] = append(byProject[change.Project], change)
+	}
+
+	first := true
+	for _, r := range repos {
+		projectChanges := byProject[r.Project]
+		if len(projectChanges) == 0 {
+			continue
+		}
+		delete(byProject, r.Project)
+		if !first {
+			fmt.Println()
+		}
+		first = false
+		fmt.Printf("%s %s\n", utils.BoldCyan(r.Project), utils.Gray("("+r.Path+")"))
+		if detailed {
+			displayDetailedChanges(projectChanges)
+		} else {
+			displayChangeTable(table, columns, projectChanges)
+		}
+	}
+}
+
 func listChangesREST(cfg *config.Config, query string, limit int) ([]gerrit.Change, error) {
 	client := gerrit.NewRESTClient(cfg)
 	encodedQuery := url.QueryEscape(query)
 	return client.ListChanges(encodedQuery, limit)
 }
 
+// changeLimit resolves the --limit and --all flags of list and team to a
+// change limit, 0 meaning no limit.
+func changeLimit(cmd *cobra.Command, limit int, all bool) (int, error) {
+	if all {
+		if cmd.Flags().Changed("limit") {
+			return 0, fmt.Errorf("--all cannot be combined with --limit")
+		}
+		return 0, nil
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("--limit must be 0 (no limit) or more")
+	}
+	return limit, nil
+}
+
+// listChangesQuietly runs query over REST, falling back to SSH with only a
+// debug message, for callers that draw their own output around it.
+func listChangesQuietly(cfg *config.Config, query string, limit int) ([]gerrit.Change, error) {
+	changes, err := listChangesREST(cfg, query, limit)
+	if err == nil {
+		return changes, nil
+	}
+	utils.Debugf("REST API failed, falling back to SSH: %v", err)
+	return listChangesSSH(cfg, query, limit)
+}
+
+// listPageSize is the page size listChangesPaged requests.
+const listPageSize = 200
+
+// listChangesPaged fetches up to limit changes over REST, page by page, or
+// every change when limit is 0.
+func listChangesPaged(client *gerrit.RESTClient, query string, limit int) ([]gerrit.Change, error) {
+	pageSize, maxChanges := listPageSize, limit
+	if limit <= 0 {
+		maxChanges = math.MaxInt
+	} else if limit < pageSize {
+		pageSize = limit
+	}
+
+	var changes []gerrit.Change
+	_, err := paginateChanges(client, query, gerrit.DefaultChangeOptions, pageSize, maxChanges, func(page []gerrit.Change) error {
+		changes = append(changes, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// listChangesSSH runs query over SSH, returning up to limit changes, or as
+// many as the server allows when limit is 0.
 func listChangesSSH(cfg *config.Config, query string, limit int) ([]gerrit.Change, error) {
 	client := gerrit.NewSSHClient(cfg)
 
-	output, err := client.ExecuteCommandArgs("query", "--format=JSON", "--current-patch-set", fmt.Sprintf("limit:%d", limit), query)
+	args := []string{"query", "--format=JSON", "--current-patch-set"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("limit:%d", limit))
+	}
+	output, err := client.ExecuteCommandArgs(append(args, query)...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,22 +408,10 @@ func listChangesSSH(cfg *config.Config, query string, limit int) ([]gerrit.Chang
 	return parseSSHChanges(output), nil
 }
 
-func displaySimpleChanges(changes []gerrit.Change) {
-	headers := []string{"Change", "Subject", "CR", "QR", "LR", "V", "M", "Updated"}
-	var rows [][]string
-
-	for _, change := range changes {
-		rows = append(rows, []string{
-			utils.BoldCyan(change.ChangeNumberStr()),
-			utils.TruncateString(change.Subject, 60),
-			getLabelStatus(change, "Code-Review"),
-			getLabelStatus(change, "QA-Review"),
-			getLabelStatus(change, "Lint-Review"),
-			getLabelStatus(change, "Verified"),
-			getMergeableStatus(change),
-			utils.FormatTimeAgoShort(change.UpdatedTime()),
-		})
-	}
-
-	fmt.Print(utils.FormatTable(headers, rows, 2))
+// displaySimpleChanges renders changes in the default list table; the @
+// column marks the ones whose attention set includes user.
+func displaySimpleChanges(changes []gerrit.Change, user string) {
+	table := changeTableSpec(user, 60, listTableDefaults...)
+	columns, _ := table.ParseColumns("")
+	displayChangeTable(table, columns, changes)
 }
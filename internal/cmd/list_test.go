@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/spf13/cobra"
+)
+
+func TestListFilterQueryTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  listFilter
+		want    []string
+		wantErr string
+	}{
+		{"none", listFilter{}, nil, ""},
+		{
+			"all",
+			listFilter{
+				Project:        "canvas-lms",
+				Branch:         "release/2025-06",
+				Topic:          "dark mode",
+				Hashtags:       []string{"#ui"},
+				Labels:         []string{"Code-Review=-1", "Verified>=1"},
+				Age:            "2d",
+				NeedsAttention: true,
+				Query:          "file:^app/.* OR file:^lib/.*",
+			},
+			[]string{
+				"project:canvas-lms",
+				"branch:release/2025-06",
+				`topic:"dark mode"`,
+				`hashtag:"ui"`,
+				"label:Code-Review=-1",
+				"label:Verified>=1",
+				"age:2d",
+				needsAttentionQuery,
+				"(file:^app/.* OR file:^lib/.*)",
+			},
+			"",
+		},
+		{"label without operator", listFilter{Labels: []string{"Code-Review+2"}}, []string{"label:Code-Review+2"}, ""},
+		{"label keyword", listFilter{Labels: []string{"Verified=MAX"}}, []string{"label:Verified=MAX"}, ""},
+		{"label without value", listFilter{Labels: []string{"Code-Review"}}, nil, "invalid --label"},
+		{"label with space", listFilter{Labels: []string{"Code-Review = 1"}}, nil, "invalid --label"},
+		{"age in weeks", listFilter{Age: "1week"}, []string{"age:1week"}, ""},
+		{"age without unit", listFilter{Age: "2"}, nil, "invalid --age"},
+		{"bad hashtag", listFilter{Hashtags: []string{"two words"}}, nil, "invalid hashtag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.queryTerms()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("queryTerms() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryTerms(): %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("queryTerms() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeTableSort(t *testing.T) {
+	aliceTurn := map[string]gerrit.AttentionSetInfo{"1000": {Account: gerrit.Account{Username: "alice"}}}
+	changes := []gerrit.Change{
+		{Number: 2, Project: "b", Updated: "2025-01-02 00:00:00.000000000", UnresolvedCommentCount: 1},
+		{Number: 3, Project: "a", Updated: "2025-01-01 00:00:00.000000000", AttentionSet: aliceTurn},
+		{Number: 1, Project: "b", Updated: "2025-01-03 00:00:00.000000000", UnresolvedCommentCount: 4},
+	}
+	tests := []struct {
+		sort string
+		want []int
+	}{
+		{"", []int{2, 3, 1}},
+		{"updated", []int{1, 2, 3}},
+		{"number", []int{3, 2, 1}},
+		{"project", []int{3, 2, 1}},
+		{"attention", []int{3, 1, 2}},
+	}
+	table := changeTableSpec("alice", 60, listTableDefaults...)
+	for _, tt := range tests {
+		less, err := table.SortOrder(tt.sort)
+		if err != nil {
+			t.Fatalf("SortOrder(%q): %v", tt.sort, err)
+		}
+		sorted := append([]gerrit.Change(nil), changes...)
+		sortChanges(sorted, less)
+		var got []int
+		for _, change := range sorted {
+			got = append(got, change.Number)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("--sort %q = %v, want %v", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestChangeLimit(t *testing.T) {
+	tests := []struct {
+		args    []string
+		all     bool
+		want    int
+		wantErr string
+	}{
+		{nil, false, 25, ""},
+		{[]string{"--limit", "0"}, false, 0, ""},
+		{[]string{"--limit", "500"}, false, 500, ""},
+		{nil, true, 0, ""},
+		{[]string{"--limit", "10"}, true, 0, "--all cannot be combined with --limit"},
+		{[]string{"--limit", "-1"}, false, 0, "--limit must be 0"},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		limit := cmd.Flags().IntP("limit", "n", 25, "")
+		if err := cmd.Flags().Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := changeLimit(cmd, *limit, tt.all)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("changeLimit(%v, all=%v) error = %v, want %q", tt.args, tt.all, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("changeLimit(%v, all=%v) = %d, %v; want %d", tt.args, tt.all, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var messageEditFile string
+
+var messageCmd = &cobra.Command{
+	Use:   "message",
+	Short: "Show or edit a change's commit message",
+	Long: `Show or edit the commit message of a change without a local checkout.
+
+Examples:
+  gerry message show 12345
+  gerry message edit 12345
+  gerry message edit 12345 --file new-message.txt`,
+}
+
+var messageShowCmd = &cobra.Command{
+	Use:   "show <change-id>",
+	Short: "Print the commit message of the current patchset",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMessageShow,
+}
+
+var messageEditCmd = &cobra.Command{
+	Use:   "edit <change-id>",
+	Short: "Edit the commit message, uploading a new patchset",
+	Long: `Open the commit message of the current patchset in $VISUAL or $EDITOR and
+upload the edited message as a new patchset. Lines starting with '#' are
+ignored and an empty or unchanged message aborts. The Change-Id footer must
+be kept.
+
+With --file the new message is read from a file ("-" for stdin) instead, as
+is: '#' lines are kept. In CI mode --file is required.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMessageEdit,
+}
+
+var changeIDFooterRe = regexp.MustCompile(`(?m)^Change-Id: (I[0-9a-f]{40})\s*$`)
+
+func init() {
+	messageEditCmd.Flags().StringVarP(&messageEditFile, "file", "F", "", "Read the new message from a file (\"-\" reads stdin)")
+
+	messageCmd.AddCommand(messageShowCmd)
+	messageCmd.AddCommand(messageEditCmd)
+}
+
+func runMessageShow(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	message, err := client.GetCommitMessage(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get commit message: %w", err)
+	}
+	fmt.Print(message)
+	return nil
+}
+
+func runMessageEdit(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	if messageEditFile == "" && utils.CIMode() {
+		return fmt.Errorf("message required (--file or stdin)")
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetCommitMessage(changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get commit message: %w", err)
+	}
+
+	var edited string
+	if messageEditFile == "" {
+		template := fmt.Sprintf("\n# Editing the commit message of change %s.\n# Lines starting with '#' are ignored; an empty message aborts.\n", changeID)
+		if edited, err = editMessage(current, template); err != nil {
+			return err
+		}
+	} else if edited, err = readMessageFile(messageEditFile); err != nil {
+		return err
+	}
+
+	if edited == "" {
+		return fmt.Errorf("aborting: empty commit message")
+	}
+	if edited == strings.TrimSpace(current) {
+		fmt.Println("Commit message unchanged; nothing to upload.")
+		return nil
+	}
+	if err := checkChangeIDFooter(current, edited); err != nil {
+		return err
+	}
+
+	if err := client.SetCommitMessage(changeID, edited+"\n"); err != nil {
+		return fmt.Errorf("failed to update commit message: %w", err)
+	}
+
+	fmt.Printf("%s Uploaded a new patchset of %s with the edited message\n", utils.Green("✓"), changeID)
+	return nil
+}
+
+// readMessageFile reads a commit message from path, or stdin for "-". Unlike
+// editor output it is taken as is, so '#' lines such as Markdown headings
+// stay.
+func readMessageFile(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read message from stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read message file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// checkChangeIDFooter makes sure an edited message keeps the original's
+// Change-Id footer, which Gerrit needs to match the new patchset to the
+// change.
+func checkChangeIDFooter(original, edited string) error {
+	m := changeIDFooterRe.FindStringSubmatch(original)
+	if m == nil {
+		return nil
+	}
+	for _, found := range changeIDFooterRe.FindAllStringSubmatch(edited, -1) {
+		if found[1] == m[1] {
+			return nil
+		}
+	}
+	return fmt.Errorf("aborting: the edited message must keep the footer %q", m[0])
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckChangeIDFooter(t *testing.T) {
+	const footer = "Change-Id: I0123456789abcdef0123456789abcdef01234567"
+	original := "Fix teh typo\n\nLonger body.\n\n" + footer + "\n"
+
+	tests := []struct {
+		name    string
+		edited  string
+		wantErr bool
+	}{
+		{"footer kept", "Fix the typo\n\nLonger body.\n\n" + footer, false},
+		{"footer moved", "Fix the typo\n\n" + footer + "\nBug: 42", false},
+		{"footer dropped", "Fix the typo\n\nLonger body.", true},
+		{"footer changed", "Fix the typo\n\nChange-Id: Iffffffffffffffffffffffffffffffffffffffff", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkChangeIDFooter(original, tt.edited); (err != nil) != tt.wantErr {
+				t.Errorf("checkChangeIDFooter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := checkChangeIDFooter("No footer", "Still none"); err != nil {
+		t.Errorf("checkChangeIDFooter() without a footer error = %v", err)
+	}
+}
+
+func TestReadMessageFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.txt")
+	text := "Fix the typo\n\n# Steps\n\n#123 is the bug.\n"
+	if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readMessageFile(path)
+	if err != nil {
+		t.Fatalf("readMessageFile: %v", err)
+	}
+	if want := strings.TrimSpace(text); got != want {
+		t.Errorf("readMessageFile() = %q, want %q", got, want)
+	}
+
+	if _, err := readMessageFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readMessageFile() of a missing file succeeded")
+	}
+}
+
+func TestMessageEditRequiresMessageInCI(t *testing.T) {
+	stderr, err := runGerryCI(t, "", "message", "edit", "12345")
+	if err == nil {
+		t.Fatal("gerry message edit without --file succeeded in CI mode")
+	}
+	if !strings.Contains(stderr, "message required (--file or stdin)") {
+		t.Errorf("stderr = %q, want the missing message error", stderr)
+	}
+}
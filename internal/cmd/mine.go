@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const mineQueryLimit = 100
+
+var mineCmd = &cobra.Command{
+	Use:   "mine",
+	Short: "Show a one-screen summary of your review workload",
+	Long: `Show a compact summary of your open changes grouped by state, the
+number of changes waiting on your review, and unresolved comment threads on
+your own changes. Runs two REST queries, so it is fast enough for a shell prompt.
+
+Each open change is counted in exactly one state, checked in this order:
+  submittable       all submit requirements are met
+  verified failing  Verified is negative
+  needs my action   WIP, a negative Code-Review, or unresolved comments
+  needs review      everything else (waiting on reviewers)
+
+Examples:
+  gerry mine`,
+	Args: cobra.NoArgs,
+	RunE: runMine,
+}
+
+// mineSummary holds the counts rendered by `gerry mine`.
+type mineSummary struct {
+	Open              int
+	NeedsReview       int
+	NeedsAction       int
+	VerifiedFailing   int
+	Submittable       int
+	PendingReviews    int
+	PendingMore       bool
+	UnresolvedThreads int
+	ChangesWithThread int
+}
+
+func runMine(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	spinner := utils.NewSpinner("Loading your changes...")
+
+	ownQuery := fmt.Sprintf("owner:%s status:open", cfg.User)
+	utils.Debugf("Query: %s", ownQuery)
+	own, err := client.ListChangesWithOptions(url.QueryEscape(ownQuery), mineQueryLimit, "SUBMITTABLE")
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to list your changes: %w", err)
+	}
+
+	reviewQuery := fmt.Sprintf("reviewer:%s -owner:%s status:open -is:wip", cfg.User, cfg.User)
+	utils.Debugf("Query: %s", reviewQuery)
+	pending, err := client.ListChanges(url.QueryEscape(reviewQuery), mineQueryLimit)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list pending reviews: %w", err)
+	}
+
+	summary := summarizeMine(own)
+	summary.PendingReviews = len(pending)
+	if len(pending) > 0 && pending[len(pending)-1].MoreChanges {
+		summary.PendingMore = true
+	}
+
+	displayMineSummary(summary)
+	return nil
+}
+
+// summarizeMine buckets the user's own open changes by state.
+func summarizeMine(changes []gerrit.Change) mineSummary {
+	var s mineSummary
+	for _, change := range changes {
+		s.Open++
+		switch classifyMineChange(change) {
+		case "submittable":
+			s.Submittable++
+		case "verified-failing":
+			s.VerifiedFailing++
+		case "needs-action":
+			s.NeedsAction++
+		default:
+			s.NeedsReview++
+		}
+		if change.UnresolvedCommentCount > 0 {
+			s.UnresolvedThreads += change.UnresolvedCommentCount
+			s.ChangesWithThread++
+		}
+	}
+	return s
+}
+
+// classifyMineChange returns the single state a change is counted under.
+func classifyMineChange(change gerrit.Change) string {
+	if change.Submittable {
+		return "submittable"
+	}
+	if score, ok := getLabelScore(change, "Verified"); ok && score < 0 {
+		return "verified-failing"
+	}
+	if change.WorkInProgress || change.UnresolvedCommentCount > 0 {
+		return "needs-action"
+	}
+	if score, ok := getLabelScore(change, "Code-Review"); ok && score < 0 {
+		return "needs-action"
+	}
+	return "needs-review"
+}
+
+func displayMineSummary(s mineSummary) {
+	count := func(n int, colorize func(a ...interface{}) string) string {
+		if n == 0 {
+			return utils.Gray("0")
+		}
+		return colorize(fmt.Sprintf("%d", n))
+	}
+
+	fmt.Printf("%s %s\n", utils.BoldCyan("My open changes:"), utils.BoldWhite(fmt.Sprintf("%d", s.Open)))
+	fmt.Printf("  %s %s\n", utils.PadString("Needs review:", 18), count(s.NeedsReview, utils.Yellow))
+	fmt.Printf("  %s %s\n", utils.PadString("Needs my action:", 18), count(s.NeedsAction, utils.BoldYellow))
+	fmt.Printf("  %s %s\n", utils.PadString("Verified failing:", 18), count(s.VerifiedFailing, utils.BoldRed))
+	fmt.Printf("  %s %s\n", utils.PadString("Submittable:", 18), count(s.Submittable, utils.BoldGreen))
+
+	pending := count(s.PendingReviews, utils.BoldCyan)
+	if s.PendingMore {
+		pending += utils.BoldCyan("+")
+	}
+	fmt.Printf("%s %s\n", utils.BoldCyan("Pending reviews:"), pending)
+
+	threads := count(s.UnresolvedThreads, utils.BoldRed)
+	if s.ChangesWithThread > 0 {
+		threads += utils.Gray(fmt.Sprintf(" (on %d change(s))", s.ChangesWithThread))
+	}
+	fmt.Printf("%s %s\n", utils.BoldCyan("Unresolved threads:"), threads)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func labelVotes(values ...float64) map[string]interface{} {
+	all := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		all = append(all, map[string]interface{}{"value": v})
+	}
+	return map[string]interface{}{"all": all}
+}
+
+func TestClassifyMineChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change gerrit.Change
+		want   string
+	}{
+		{
+			name:   "submittable wins over everything",
+			change: gerrit.Change{Submittable: true, UnresolvedCommentCount: 2},
+			want:   "submittable",
+		},
+		{
+			name:   "verified -1",
+			change: gerrit.Change{Labels: map[string]interface{}{"Verified": labelVotes(-1)}},
+			want:   "verified-failing",
+		},
+		{
+			name:   "negative code review",
+			change: gerrit.Change{Labels: map[string]interface{}{"Code-Review": labelVotes(1, -1)}},
+			want:   "needs-action",
+		},
+		{
+			name:   "unresolved comments",
+			change: gerrit.Change{UnresolvedCommentCount: 1},
+			want:   "needs-action",
+		},
+		{
+			name:   "work in progress",
+			change: gerrit.Change{WorkInProgress: true},
+			want:   "needs-action",
+		},
+		{
+			name:   "waiting on reviewers",
+			change: gerrit.Change{Labels: map[string]interface{}{"Code-Review": labelVotes(1), "Verified": labelVotes(1)}},
+			want:   "needs-review",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := classifyMineChange(tt.change); got != tt.want {
+			t.Errorf("%s: classifyMineChange() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeMine(t *testing.T) {
+	changes := []gerrit.Change{
+		{Submittable: true},
+		{UnresolvedCommentCount: 3},
+		{UnresolvedCommentCount: 1, Labels: map[string]interface{}{"Verified": labelVotes(-1)}},
+		{},
+	}
+
+	got := summarizeMine(changes)
+	if got.Open != 4 || got.Submittable != 1 || got.NeedsAction != 1 || got.VerifiedFailing != 1 || got.NeedsReview != 1 {
+		t.Errorf("unexpected state counts: %+v", got)
+	}
+	if got.UnresolvedThreads != 4 || got.ChangesWithThread != 2 {
+		t.Errorf("unresolved = %d on %d changes, want 4 on 2", got.UnresolvedThreads, got.ChangesWithThread)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var urlCmd = &cobra.Command{
+	Use:   "url [change-id]",
+	Short: "Print the web URL of a change",
+	Long: `Print the web URL of a change.
+
+A change number is turned into a URL from the configured server alone; a
+Change-Id is looked up first. "." or no argument means the change checked
+out in the current repository (see 'gerry details').
+
+Examples:
+  gerry url 12345
+  gerry url I8473b95934b5732ac55d26311a706c9c2bde9940
+  gerry url .`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runURL,
+}
+
+var openCmd = &cobra.Command{
+	Use:   "open [change-id]",
+	Short: "Open a change in the browser",
+	Long: `Open a change in the default browser.
+
+Accepts the same arguments as 'gerry url'; without one, opens the change
+checked out in the current repository.
+
+Examples:
+  gerry open 12345
+  gerry open`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOpen,
+}
+
+func runURL(cmd *cobra.Command, args []string) error {
+	url, err := resolveChangeURL(args)
+	if err != nil {
+		return err
+	}
+	fmt.Println(url)
+	return nil
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	url, err := resolveChangeURL(args)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Opening %s\n", url)
+	return utils.OpenBrowser(url)
+}
+
+// resolveChangeURL returns the web URL of the change named by args, or of
+// the current change when args is empty or ".".
+func resolveChangeURL(args []string) (string, error) {
+	args, err := withCurrentChange(args)
+	if err != nil {
+		return "", err
+	}
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return "", fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if number, err := strconv.Atoi(changeID); err == nil {
+		return changeNumberURL(cfg, number), nil
+	}
+
+	utils.Debugf("Looking up %s", changeID)
+	change, ok := lookupChangesByKey(cfg, "", map[string]string{"HEAD": changeID})[changeID]
+	if !ok {
+		return "", fmt.Errorf("change %s not found", changeID)
+	}
+	return changeURL(cfg, change), nil
+}
+
+// changeNumberURL returns Gerrit's short URL for a change number, which the
+// server redirects to the change page. It needs no lookup.
+func changeNumberURL(cfg *config.Config, number int) string {
+	return fmt.Sprintf("%s/%d", cfg.GetHTTPBaseURL(), number)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestChangeNumberURL(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{"default ports", config.Config{Server: "gerrit.example.com", Port: 29418}, "https://gerrit.example.com/12345"},
+		{"http port", config.Config{Server: "gerrit.example.com", Port: 29418, HTTPPort: 8080}, "http://gerrit.example.com:8080/12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changeNumberURL(&tt.cfg, 12345); got != tt.want {
+				t.Errorf("changeNumberURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat is the global --output flag: "table" for the usual colored
+// view, or "json" or "yaml" for scripts. list, team, dashboard, details,
+// status, stack list and status, comments, trees, and failures honor it.
+var outputFormat string
+
+// structuredOutput reports whether --output asks for json or yaml, and if so
+// silences progress output and moves log messages to stderr so only the
+// document reaches stdout.
+func structuredOutput() (bool, error) {
+	switch outputFormat {
+	case "", "table":
+		return false, nil
+	case "json", "yaml":
+		utils.DisableProgress()
+		utils.SetLogOutput(os.Stderr)
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown output format: %s (supported: table, json, yaml)", outputFormat)
+}
+
+// rejectStructuredOutput fails on --output json or yaml for the commands
+// that choose their report format with --format instead, rather than
+// silently ignoring it.
+func rejectStructuredOutput(cmd *cobra.Command) error {
+	if outputFormat == "" || outputFormat == "table" {
+		return nil
+	}
+	return fmt.Errorf("%s does not support --output %s; choose its report format with --format (and write it to a file with -o/--output-file)", cmd.CommandPath(), outputFormat)
+}
+
+// renderOutput writes v as JSON or YAML, per --output. YAML is converted
+// from the JSON encoding so both use the same field names and order.
+func renderOutput(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	if outputFormat != "yaml" {
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	blockStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the JSON flow and quoting styles from a parsed document
+// so it is written as ordinary block YAML.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
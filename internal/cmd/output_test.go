@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderOutput(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+
+	v := []unresolvedThread{{Change: 12345, File: "app/models/user.rb", LastAuthor: "Jane Doe", Message: "why: this?"}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"json", `[
+  {
+    "change": 12345,
+    "file": "app/models/user.rb",
+    "last_author": "Jane Doe",
+    "updated": "",
+    "message": "why: this?"
+  }
+]
+`},
+		{"yaml", `- change: 12345
+  file: app/models/user.rb
+  last_author: Jane Doe
+  updated: ""
+  message: 'why: this?'
+`},
+	}
+	for _, tt := range tests {
+		outputFormat = tt.format
+		var buf bytes.Buffer
+		if err := renderOutput(&buf, v); err != nil {
+			t.Fatalf("renderOutput(%s): %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("renderOutput(%s) =\n%s\nwant\n%s", tt.format, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	defer func(format string) { outputFormat = format }(outputFormat)
+
+	for format, want := range map[string]bool{"": false, "table": false} {
+		outputFormat = format
+		if got, err := structuredOutput(); err != nil || got != want {
+			t.Errorf("structuredOutput() for %q = %v, %v; want %v", format, got, err, want)
+		}
+	}
+	outputFormat = "xml"
+	if _, err := structuredOutput(); err == nil || !strings.Contains(err.Error(), "unknown output format") {
+		t.Errorf("structuredOutput() for xml error = %v", err)
+	}
+}
+
+func TestAnalyzeOutputFlags(t *testing.T) {
+	defer func(format, file string) { outputFormat, analyzeOutput = format, file }(outputFormat, analyzeOutput)
+
+	// The global --output picks the format; -o/--output-file names the file.
+	if err := analyzeCmd.ParseFlags([]string{"--output", "json", "-o", "report.md"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if outputFormat != "json" || analyzeOutput != "report.md" {
+		t.Errorf("--output = %q, --output-file = %q; want json, report.md", outputFormat, analyzeOutput)
+	}
+	if analyzeCmd.Flag("output") != rootCmd.PersistentFlags().Lookup("output") {
+		t.Error("analyze --output does not resolve to the global flag")
+	}
+	if err := rejectStructuredOutput(analyzeCmd); err == nil {
+		t.Error("rejectStructuredOutput() accepted --output json on analyze")
+	}
+
+	outputFormat = "table"
+	if err := rejectStructuredOutput(analyzeCmd); err != nil {
+		t.Errorf("rejectStructuredOutput() error = %v for --output table", err)
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// ownerSuggestLimit is how many code owners are suggested per file.
+const ownerSuggestLimit = 5
+
+var (
+	ownersSuggest bool
+	ownersPending bool
+	ownersFormat  string
+)
+
+var ownersCmd = &cobra.Command{
+	Use:   "owners <change-id>",
+	Short: "Show required code owner approvals per file",
+	Long: `Show the code owner approval status of every file in a change, using the
+code-owners plugin. Each file is one of:
+  approved                a code owner approved the file
+  pending                 a code owner is a reviewer but has not approved yet
+  insufficient reviewers  no code owner of the file is a reviewer
+
+With --suggest, code owners are suggested for every file that is not yet
+approved, so you know whom to add.
+
+Examples:
+  gerry owners 12345
+  gerry owners 12345 --pending --suggest
+  gerry owners 12345 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOwners,
+}
+
+func init() {
+	ownersCmd.Flags().BoolVar(&ownersSuggest, "suggest", false, "Suggest code owners for files that are not approved")
+	ownersCmd.Flags().BoolVar(&ownersPending, "pending", false, "Only show files that are not approved")
+	ownersCmd.Flags().StringVar(&ownersFormat, "format", "table", "Output format: table, json")
+}
+
+// ownerFileStatus is the flattened code owner status of one file.
+type ownerFileStatus struct {
+	Path      string   `json:"path"`
+	OldPath   string   `json:"old_path,omitempty"`
+	Status    string   `json:"status"`
+	Reasons   []string `json:"reasons,omitempty"`
+	Suggested []string `json:"suggested,omitempty"`
+}
+
+func runOwners(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	if ownersFormat != "table" && ownersFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", ownersFormat)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetCodeOwnersStatus(changeID)
+	if err != nil {
+		return codeOwnersError(err)
+	}
+
+	files := flattenOwnerStatuses(status.FileCodeOwnerStatuses)
+	if ownersPending {
+		var pending []ownerFileStatus
+		for _, f := range files {
+			if f.Status != "APPROVED" {
+				pending = append(pending, f)
+			}
+		}
+		files = pending
+	}
+
+	if ownersSuggest {
+		var paths []string
+		for _, f := range files {
+			if f.Status != "APPROVED" {
+				paths = append(paths, f.Path)
+			}
+		}
+		suggestions := suggestCodeOwners(client, changeID, paths, ownerSuggestLimit)
+		for i := range files {
+			for _, account := range suggestions[files[i].Path] {
+				files[i].Suggested = append(files[i].Suggested, account.DisplayName())
+			}
+		}
+	}
+
+	if ownersFormat == "json" {
+		data, err := json.MarshalIndent(files, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No files need code owner approval.")
+		return nil
+	}
+
+	headers := []string{"File", "Status"}
+	if ownersSuggest {
+		headers = append(headers, "Suggested owners")
+	}
+	var rows [][]string
+	approved := 0
+	for _, f := range files {
+		if f.Status == "APPROVED" {
+			approved++
+		}
+		path := f.Path
+		if f.OldPath != "" && f.OldPath != f.Path {
+			path = fmt.Sprintf("%s → %s", f.OldPath, f.Path)
+		}
+		row := []string{path, formatOwnerStatus(f.Status)}
+		if ownersSuggest {
+			row = append(row, strings.Join(f.Suggested, ", "))
+		}
+		rows = append(rows, row)
+	}
+
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d of %d file(s) approved by code owners (patchset %d)\n", approved, len(files), status.PatchSetNumber)
+	return nil
+}
+
+// codeOwnersError turns a 404 into a hint that the plugin is missing.
+func codeOwnersError(err error) error {
+	if strings.Contains(err.Error(), "404") {
+		return fmt.Errorf("code owners are not available (is the code-owners plugin installed and enabled for this project?): %w", err)
+	}
+	return fmt.Errorf("failed to get code owners status: %w", err)
+}
+
+// suggestCodeOwners asks the code-owners plugin for owners of each path.
+// Paths whose lookup fails are logged and omitted.
+func suggestCodeOwners(client *gerrit.RESTClient, changeID string, paths []string, limit int) map[string][]gerrit.Account {
+	suggestions := map[string][]gerrit.Account{}
+	for _, path := range paths {
+		owners, err := client.SuggestCodeOwners(changeID, "current", path, limit)
+		if err != nil {
+			utils.Debugf("Failed to suggest code owners for %s: %v", path, err)
+			continue
+		}
+		for _, owner := range owners.CodeOwners {
+			suggestions[path] = append(suggestions[path], owner.Account)
+		}
+	}
+	return suggestions
+}
+
+// flattenOwnerStatuses reduces each file to one status. For renames the
+// less satisfied of the old and new path wins.
+func flattenOwnerStatuses(statuses []gerrit.FileCodeOwnerStatusInfo) []ownerFileStatus {
+	var files []ownerFileStatus
+	for _, s := range statuses {
+		var f ownerFileStatus
+		for _, ps := range []*gerrit.PathCodeOwnerStatus{s.OldPathStatus, s.NewPathStatus} {
+			if ps == nil {
+				continue
+			}
+			if f.Status == "" || ownerStatusRank(ps.Status) < ownerStatusRank(f.Status) {
+				f.Status = ps.Status
+			}
+			f.Reasons = append(f.Reasons, ps.Reasons...)
+		}
+		switch {
+		case s.NewPathStatus != nil:
+			f.Path = s.NewPathStatus.Path
+			if s.OldPathStatus != nil {
+				f.OldPath = s.OldPathStatus.Path
+			}
+		case s.OldPathStatus != nil:
+			f.Path = s.OldPathStatus.Path
+		default:
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+func ownerStatusRank(status string) int {
+	switch status {
+	case "INSUFFICIENT_REVIEWERS":
+		return 0
+	case "PENDING":
+		return 1
+	case "APPROVED":
+		return 2
+	default:
+		return -1
+	}
+}
+
+func formatOwnerStatus(status string) string {
+	switch status {
+	case "APPROVED":
+		return utils.Green("approved")
+	case "PENDING":
+		return utils.Yellow("pending")
+	case "INSUFFICIENT_REVIEWERS":
+		return utils.Red("insufficient reviewers")
+	default:
+		return strings.ToLower(status)
+	}
+}
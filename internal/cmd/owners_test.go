@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestFlattenOwnerStatuses(t *testing.T) {
+	statuses := []gerrit.FileCodeOwnerStatusInfo{
+		{
+			ChangeType:    "MODIFIED",
+			NewPathStatus: &gerrit.PathCodeOwnerStatus{Path: "a.go", Status: "APPROVED"},
+		},
+		{
+			ChangeType:    "DELETED",
+			OldPathStatus: &gerrit.PathCodeOwnerStatus{Path: "b.go", Status: "PENDING"},
+		},
+		{
+			ChangeType:    "RENAMED",
+			OldPathStatus: &gerrit.PathCodeOwnerStatus{Path: "old/c.go", Status: "APPROVED"},
+			NewPathStatus: &gerrit.PathCodeOwnerStatus{Path: "new/c.go", Status: "INSUFFICIENT_REVIEWERS", Reasons: []string{"no owner"}},
+		},
+		{ChangeType: "MODIFIED"},
+	}
+
+	got := flattenOwnerStatuses(statuses)
+	want := []ownerFileStatus{
+		{Path: "a.go", Status: "APPROVED"},
+		{Path: "b.go", Status: "PENDING"},
+		{Path: "new/c.go", OldPath: "old/c.go", Status: "INSUFFICIENT_REVIEWERS", Reasons: []string{"no owner"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenOwnerStatuses() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// pickChangeLimit caps the changes the picker offers.
+const pickChangeLimit = 50
+
+// withPickedChange returns args unchanged when a change ID was given, and
+// otherwise lets the user pick one of their open or incoming changes.
+func withPickedChange(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	changeID, err := pickChange()
+	if err != nil {
+		return nil, err
+	}
+	return []string{changeID}, nil
+}
+
+// pickChange shows a fuzzy-searchable list of the user's open changes and
+// the ones awaiting their review, and returns the chosen change number.
+func pickChange() (string, error) {
+	if utils.CIMode() || !utils.IsTerminal(os.Stdin) {
+		return "", fmt.Errorf("a change ID is required (no terminal to pick one from)")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	query := fmt.Sprintf("is:open (owner:%s OR reviewer:%s) -is:ignored", cfg.User, cfg.User)
+	utils.Debugf("Query: %s", query)
+	spinner := utils.NewSpinner("Loading your changes...")
+	changes, err := listChangesQuietly(cfg, query, pickChangeLimit)
+	spinner.Stop()
+	if err != nil {
+		return "", fmt.Errorf("failed to list changes: %w", err)
+	}
+	if len(changes) == 0 {
+		return "", fmt.Errorf("no open changes to pick from; pass a change ID")
+	}
+
+	options := make([]string, len(changes))
+	for i, change := range changes {
+		options[i] = pickOption(change)
+	}
+
+	var selected int
+	prompt := &survey.Select{
+		Message:  "Change:",
+		Options:  options,
+		PageSize: 15,
+	}
+	if err := askOne(prompt, &selected, survey.WithFilter(func(filter, value string, _ int) bool {
+		return fuzzyMatch(filter, value)
+	})); err != nil {
+		return "", fmt.Errorf("cancelled: %w", err)
+	}
+	return changes[selected].ChangeNumberStr(), nil
+}
+
+// pickOption is a change as the picker lists it, e.g.
+// "384465  canvas-lms  Fix login redirect (Jane Doe)".
+func pickOption(change gerrit.Change) string {
+	return fmt.Sprintf("%s  %s  %s (%s)", change.ChangeNumberStr(), change.Project,
+		utils.TruncateString(change.Subject, 60), change.Owner.DisplayName())
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in s in
+// order, ignoring case and spaces, so "cnvlogin" matches
+// "384465  canvas-lms  Fix login redirect".
+func fuzzyMatch(pattern, s string) bool {
+	target := []rune(strings.ToLower(s))
+	i := 0
+	for _, r := range strings.ToLower(pattern) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for i < len(target) && target[i] != r {
+			i++
+		}
+		if i == len(target) {
+			return false
+		}
+		i++
+	}
+	return true
+}
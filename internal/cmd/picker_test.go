@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	option := "384465  canvas-lms  Fix login redirect (Jane Doe)"
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"", true},
+		{"384465", true},
+		{"cnvlogin", true},
+		{"FIX LOGIN", true},
+		{"jane", true},
+		{"login canvas", false},
+		{"384466", false},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.pattern, option); got != tt.want {
+			t.Errorf("fuzzyMatch(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestPickOption(t *testing.T) {
+	change := gerrit.Change{
+		Number:  384465,
+		Project: "canvas-lms",
+		Subject: "Fix login redirect",
+		Owner:   gerrit.Account{Name: "Jane Doe"},
+	}
+	if got, want := pickOption(change), "384465  canvas-lms  Fix login redirect (Jane Doe)"; got != want {
+		t.Errorf("pickOption() = %q, want %q", got, want)
+	}
+}
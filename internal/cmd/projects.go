@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var projectsInfoFormat string
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Inspect Gerrit projects",
+}
+
+var projectsInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show a project's settings and where they come from",
+	Long: `Show a project's description, parent, state, default branch, submit type,
+and the effective value of its inheritable settings, marking which are set on
+the project itself and which are inherited from a parent. Useful when submits
+behave differently across repositories.
+
+Examples:
+  gerry projects info canvas-lms
+  gerry projects info tools/gerry --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectsInfo,
+}
+
+func init() {
+	projectsInfoCmd.Flags().StringVar(&projectsInfoFormat, "format", "table", "Output format: table, json")
+	projectsCmd.AddCommand(projectsInfoCmd)
+}
+
+// projectReport is the combined view rendered by `gerry projects info`.
+type projectReport struct {
+	Name          string             `json:"name"`
+	Description   string             `json:"description,omitempty"`
+	Parent        string             `json:"parent,omitempty"`
+	State         string             `json:"state,omitempty"`
+	DefaultBranch string             `json:"default_branch,omitempty"`
+	SubmitType    projectSetting     `json:"submit_type"`
+	Settings      []projectSetting   `json:"settings"`
+	Config        *gerrit.ConfigInfo `json:"config,omitempty"`
+}
+
+// projectSetting is one effective setting and whether it is inherited.
+type projectSetting struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Inherited bool   `json:"inherited"`
+}
+
+func runProjectsInfo(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if projectsInfoFormat != "table" && projectsInfoFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", projectsInfoFormat)
+	}
+	if projectsInfoFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	spinner := utils.NewSpinner("Loading project...")
+	project, err := client.GetProject(name)
+	if err != nil {
+		spinner.Stop()
+		if strings.Contains(err.Error(), "404") {
+			return fmt.Errorf("project %s not found or not visible to you", name)
+		}
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	projectCfg, err := client.GetProjectConfig(name)
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to get project config: %w", err)
+	}
+	head, err := client.GetProjectHead(name)
+	spinner.Stop()
+	if err != nil {
+		utils.Debugf("Failed to get HEAD: %v", err)
+	}
+
+	report := buildProjectReport(project, projectCfg, head)
+
+	if projectsInfoFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayProjectReport(report)
+	return nil
+}
+
+// buildProjectReport merges the project, its config, and HEAD into one view.
+func buildProjectReport(project *gerrit.ProjectInfo, cfg *gerrit.ConfigInfo, head string) projectReport {
+	r := projectReport{
+		Name:          project.Name,
+		Description:   project.Description,
+		Parent:        project.Parent,
+		State:         project.State,
+		DefaultBranch: strings.TrimPrefix(head, "refs/heads/"),
+		Settings:      []projectSetting{},
+		Config:        cfg,
+	}
+	if r.Name == "" {
+		r.Name = project.ID
+	}
+	if r.State == "" {
+		r.State = cfg.State
+	}
+
+	r.SubmitType = projectSetting{Name: "Submit type", Value: cfg.SubmitType}
+	if st := cfg.DefaultSubmitType; st != nil {
+		r.SubmitType.Value = st.Value
+		r.SubmitType.Inherited = st.ConfiguredValue == "INHERIT"
+	}
+
+	booleans := []struct {
+		name  string
+		value *gerrit.InheritedBooleanInfo
+	}{
+		{"Use content merge", cfg.UseContentMerge},
+		{"Require Change-Id", cfg.RequireChangeID},
+		{"Require Signed-off-by", cfg.UseSignedOffBy},
+		{"Require contributor agreement", cfg.UseContributorAgreements},
+		{"New change for every commit not in target", cfg.CreateNewChangeForAllNotInTarget},
+		{"Reject implicit merges", cfg.RejectImplicitMerges},
+		{"Private by default", cfg.PrivateByDefault},
+		{"Work-in-progress by default", cfg.WorkInProgressByDefault},
+	}
+	for _, b := range booleans {
+		if b.value == nil {
+			continue
+		}
+		r.Settings = append(r.Settings, projectSetting{
+			Name:      b.name,
+			Value:     fmt.Sprintf("%t", b.value.Value),
+			Inherited: b.value.ConfiguredValue == "INHERIT",
+		})
+	}
+	if limit := cfg.MaxObjectSizeLimit; limit != nil && limit.Value != "" {
+		r.Settings = append(r.Settings, projectSetting{
+			Name:      "Max object size",
+			Value:     limit.Value,
+			Inherited: limit.ConfiguredValue == "",
+		})
+	}
+	return r
+}
+
+func displayProjectReport(r projectReport) {
+	fmt.Printf("%s %s\n", utils.BoldCyan("Project:"), utils.BoldWhite(r.Name))
+	if r.Description != "" {
+		fmt.Printf("%s %s\n", utils.BoldCyan("Description:"), r.Description)
+	}
+	if r.Parent != "" {
+		fmt.Printf("%s %s\n", utils.BoldCyan("Parent:"), r.Parent)
+	}
+	if r.State != "" {
+		fmt.Printf("%s %s\n", utils.BoldCyan("State:"), r.State)
+	}
+	if r.DefaultBranch != "" {
+		fmt.Printf("%s %s\n", utils.BoldCyan("Default Branch:"), r.DefaultBranch)
+	}
+	if r.SubmitType.Value != "" {
+		fmt.Printf("%s %s %s\n", utils.BoldCyan("Submit Type:"), r.SubmitType.Value, settingSource(r.SubmitType))
+	}
+
+	if len(r.Settings) == 0 {
+		return
+	}
+	fmt.Printf("\n%s\n", utils.BoldCyan("Settings:"))
+	var rows [][]string
+	for _, s := range r.Settings {
+		rows = append(rows, []string{s.Name, s.Value, settingSource(s)})
+	}
+	fmt.Print(utils.FormatTable([]string{"Setting", "Value", "Source"}, rows, 2))
+}
+
+func settingSource(s projectSetting) string {
+	if s.Inherited {
+		return utils.Gray("(inherited)")
+	}
+	return utils.Yellow("(set on project)")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestBuildProjectReport(t *testing.T) {
+	inherited := true
+	project := &gerrit.ProjectInfo{ID: "tools%2Fgerry", Name: "tools/gerry", Parent: "All-Projects", State: "ACTIVE"}
+	cfg := &gerrit.ConfigInfo{
+		DefaultSubmitType: &gerrit.SubmitTypeInfo{Value: "REBASE_IF_NECESSARY", ConfiguredValue: "INHERIT", InheritedValue: "REBASE_IF_NECESSARY"},
+		UseContentMerge:   &gerrit.InheritedBooleanInfo{Value: true, ConfiguredValue: "INHERIT", InheritedValue: &inherited},
+		RequireChangeID:   &gerrit.InheritedBooleanInfo{Value: false, ConfiguredValue: "FALSE"},
+	}
+
+	r := buildProjectReport(project, cfg, "refs/heads/main")
+
+	if r.DefaultBranch != "main" {
+		t.Errorf("DefaultBranch = %q, want main", r.DefaultBranch)
+	}
+	if r.SubmitType.Value != "REBASE_IF_NECESSARY" || !r.SubmitType.Inherited {
+		t.Errorf("SubmitType = %+v, want inherited REBASE_IF_NECESSARY", r.SubmitType)
+	}
+	want := []projectSetting{
+		{Name: "Use content merge", Value: "true", Inherited: true},
+		{Name: "Require Change-Id", Value: "false", Inherited: false},
+	}
+	if len(r.Settings) != len(want) {
+		t.Fatalf("Settings = %+v, want %+v", r.Settings, want)
+	}
+	for i := range want {
+		if r.Settings[i] != want[i] {
+			t.Errorf("Settings[%d] = %+v, want %+v", i, r.Settings[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushBranch    string
+	pushTopic     string
+	pushWIP       bool
+	pushReady     bool
+	pushReviewers []string
+	pushCCs       []string
+	pushHashtags  []string
+	pushRemote    string
+	pushDryRun    bool
+)
+
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload HEAD for review",
+	Long: `Push HEAD to refs/for/<branch> with the topic, reviewers, hashtags, and WIP
+state given as flags, encoded as Gerrit push options on the refspec.
+
+The target branch is --branch, else the branch the current one tracks, else
+default_branch from the config, else main. The push goes to the git remote
+pointing at the configured Gerrit server (preferring one named "gerrit", then
+"origin"), or, if there is none, to the server's SSH URL for the checkout's
+project.
+
+Examples:
+  gerry push
+  gerry push --branch release/3.1 --topic grading-fix
+  gerry push --wip -r alice -r bob --hashtag flaky-tests
+  gerry push --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runPush,
+}
+
+func init() {
+	pushCmd.Flags().StringVarP(&pushBranch, "branch", "b", "", "Target branch (default: the tracked branch, default_branch, or main)")
+	pushCmd.Flags().StringVarP(&pushTopic, "topic", "t", "", "Set the topic")
+	pushCmd.Flags().BoolVar(&pushWIP, "wip", false, "Upload as work in progress")
+	pushCmd.Flags().BoolVar(&pushReady, "ready", false, "Mark the change ready for review")
+	pushCmd.Flags().StringArrayVarP(&pushReviewers, "reviewer", "r", nil, "Add a reviewer (repeatable)")
+	pushCmd.Flags().StringArrayVar(&pushCCs, "cc", nil, "Add a CC (repeatable)")
+	pushCmd.Flags().StringArrayVar(&pushHashtags, "hashtag", nil, "Add a hashtag (repeatable)")
+	pushCmd.Flags().StringVar(&pushRemote, "remote", "", "Git remote or URL to push to (default: detected)")
+	pushCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "Print the git push command without running it")
+}
+
+// pushOptions are the Gerrit options appended to a refs/for/ refspec.
+type pushOptions struct {
+	Topic     string
+	WIP       bool
+	Ready     bool
+	Reviewers []string
+	CCs       []string
+	Hashtags  []string
+}
+
+func runPush(cmd *cobra.Command, args []string) error {
+	if pushWIP && pushReady {
+		return fmt.Errorf("--wip and --ready cannot be combined")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	root, err := getGitRepoRoot()
+	if err != nil {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	branch := pushBranch
+	if branch == "" {
+		branch = defaultPushBranch(cfg, root)
+	}
+
+	refspec, err := buildPushRefspec(branch, pushOptions{
+		Topic:     pushTopic,
+		WIP:       pushWIP,
+		Ready:     pushReady,
+		Reviewers: pushReviewers,
+		CCs:       pushCCs,
+		Hashtags:  pushHashtags,
+	})
+	if err != nil {
+		return err
+	}
+
+	remote := pushRemote
+	if remote == "" {
+		remote, err = detectPushRemote(cfg, root)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pushDryRun {
+		fmt.Printf("git push %s %s\n", remote, refspec)
+		return nil
+	}
+
+	fmt.Printf("Pushing HEAD to %s...\n", utils.BoldCyan("refs/for/"+branch))
+	if err := gitRunEnv(root, gitRemoteEnv(cfg, root, remote), "push", remote, refspec); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+	return nil
+}
+
+// defaultPushBranch is the branch the current one tracks, the configured
+// default branch, or main.
+func defaultPushBranch(cfg *config.Config, root string) string {
+	if upstream, err := gitOutput(root, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		if _, branch, ok := strings.Cut(upstream, "/"); ok && branch != "" {
+			return branch
+		}
+	}
+	if cfg.DefaultBranch != "" {
+		return cfg.DefaultBranch
+	}
+	return "main"
+}
+
+// buildPushRefspec returns HEAD:refs/for/<branch> with opts as %-options,
+// e.g. HEAD:refs/for/main%topic=foo,wip,r=alice.
+func buildPushRefspec(branch string, opts pushOptions) (string, error) {
+	var options []string
+	add := func(key, value string) error {
+		if value == "" || strings.ContainsAny(value, ", \t\n%") {
+			return fmt.Errorf("invalid %s %q: push option values cannot be empty or contain commas, spaces, or %%", key, value)
+		}
+		options = append(options, key+"="+value)
+		return nil
+	}
+
+	if opts.Topic != "" {
+		if err := add("topic", opts.Topic); err != nil {
+			return "", err
+		}
+	}
+	if opts.WIP {
+		options = append(options, "wip")
+	}
+	if opts.Ready {
+		options = append(options, "ready")
+	}
+	for _, r := range opts.Reviewers {
+		if err := add("r", r); err != nil {
+			return "", err
+		}
+	}
+	for _, cc := range opts.CCs {
+		if err := add("cc", cc); err != nil {
+			return "", err
+		}
+	}
+	for _, tag := range opts.Hashtags {
+		if err := add("hashtag", tag); err != nil {
+			return "", err
+		}
+	}
+
+	refspec := "HEAD:refs/for/" + branch
+	if len(options) > 0 {
+		refspec += "%" + strings.Join(options, ",")
+	}
+	return refspec, nil
+}
+
+// detectPushRemote returns the git remote pointing at the configured server,
+// or the server's SSH URL for the checkout's project.
+func detectPushRemote(cfg *config.Config, root string) (string, error) {
+	remotes := gitRemoteURLs(root)
+	if name := pickGerritRemote(remotes, cfg.Server); name != "" {
+		utils.Debugf("Pushing to remote %s (%s)", name, remotes[name])
+		return name, nil
+	}
+
+	project, err := checkoutProject(cfg, root)
+	if err != nil {
+		return "", fmt.Errorf("no git remote points at %s and %w", cfg.Server, err)
+	}
+	return buildProjectRemoteURL(cfg, project), nil
+}
+
+// gitRemoteURLs returns the URL of each git remote of the checkout at root.
+func gitRemoteURLs(root string) map[string]string {
+	output, err := gitOutput(root, "config", "--get-regexp", `^remote\..*\.url$`)
+	if err != nil {
+		return map[string]string{}
+	}
+	return parseRemoteURLs(output)
+}
+
+// parseRemoteURLs parses 'git config --get-regexp ^remote\..*\.url$' output
+// into a map of remote names to URLs.
+func parseRemoteURLs(output string) map[string]string {
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		remotes[name] = value
+	}
+	return remotes
+}
+
+// pickGerritRemote returns the remote whose URL is on server, preferring
+// "gerrit", then "origin", then the first by name.
+func pickGerritRemote(remotes map[string]string, server string) string {
+	var names []string
+	for name, remote := range remotes {
+		if strings.EqualFold(remoteURLHost(remote), server) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Slice(names, func(i, j int) bool {
+		rank := func(name string) int {
+			switch name {
+			case "gerrit":
+				return 0
+			case "origin":
+				return 1
+			}
+			return 2
+		}
+		if rank(names[i]) != rank(names[j]) {
+			return rank(names[i]) < rank(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names[0]
+}
+
+// remoteURLHost returns the host of a remote URL such as
+// ssh://user@host:29418/project or user@host:project.
+func remoteURLHost(remote string) string {
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		return u.Hostname()
+	}
+	if i := strings.Index(remote, ":"); i > 0 && !strings.Contains(remote[:i], "/") {
+		host := remote[:i]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		return host
+	}
+	return ""
+}
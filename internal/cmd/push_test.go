@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestBuildPushRefspec(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		opts    pushOptions
+		want    string
+		wantErr bool
+	}{
+		{"plain", "main", pushOptions{}, "HEAD:refs/for/main", false},
+		{
+			"all options",
+			"release/3.1",
+			pushOptions{Topic: "grading", WIP: true, Reviewers: []string{"alice", "bob"}, CCs: []string{"team"}, Hashtags: []string{"flaky"}},
+			"HEAD:refs/for/release/3.1%topic=grading,wip,r=alice,r=bob,cc=team,hashtag=flaky",
+			false,
+		},
+		{"ready", "main", pushOptions{Ready: true}, "HEAD:refs/for/main%ready", false},
+		{"comma in topic", "main", pushOptions{Topic: "a,b"}, "", true},
+		{"space in hashtag", "main", pushOptions{Hashtags: []string{"two words"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildPushRefspec(tt.branch, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildPushRefspec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("buildPushRefspec() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRemoteURLs(t *testing.T) {
+	output := "remote.origin.url ssh://me@review.example.com:29418/app\n" +
+		"remote.my.fork.url git@github.com:me/app.git\n" +
+		"malformed"
+	got := parseRemoteURLs(output)
+	want := map[string]string{
+		"origin":  "ssh://me@review.example.com:29418/app",
+		"my.fork": "git@github.com:me/app.git",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseRemoteURLs() = %v, want %v", got, want)
+	}
+	for name, remote := range want {
+		if got[name] != remote {
+			t.Errorf("parseRemoteURLs()[%q] = %q, want %q", name, got[name], remote)
+		}
+	}
+}
+
+func TestPickGerritRemote(t *testing.T) {
+	tests := []struct {
+		name    string
+		remotes map[string]string
+		want    string
+	}{
+		{"prefers gerrit", map[string]string{"origin": "ssh://me@review.example.com:29418/app", "gerrit": "https://review.example.com/app"}, "gerrit"},
+		{"then origin", map[string]string{"origin": "me@review.example.com:app.git", "mirror": "ssh://review.example.com/app"}, "origin"},
+		{"ignores other hosts", map[string]string{"origin": "git@github.com:me/app.git"}, ""},
+		{"any matching name", map[string]string{"upstream": "ssh://review.example.com:29418/app"}, "upstream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickGerritRemote(tt.remotes, "review.example.com"); got != tt.want {
+				t.Errorf("pickGerritRemote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectPushRemoteProject(t *testing.T) {
+	cfg := &config.Config{Server: "review.example.com", Port: 29418, User: "alice", Project: "canvas-lms"}
+
+	// Without a remote on the server, the checkout's own project wins over
+	// the configured default.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitreview"), []byte("[gerrit]\nhost=review.example.com\nproject=outcomes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := detectPushRemote(cfg, root)
+	if err != nil {
+		t.Fatalf("detectPushRemote() error = %v", err)
+	}
+	if want := buildProjectRemoteURL(cfg, "outcomes"); got != want {
+		t.Errorf("detectPushRemote() = %q, want %q", got, want)
+	}
+
+	got, err = detectPushRemote(cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("detectPushRemote() without .gitreview error = %v", err)
+	}
+	if want := buildProjectRemoteURL(cfg, "canvas-lms"); got != want {
+		t.Errorf("detectPushRemote() without .gitreview = %q, want %q", got, want)
+	}
+}
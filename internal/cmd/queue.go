@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	queueAll    bool
+	queueFormat string
+)
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Show where your changes are in the submit queue",
+	Long: `Show your changes waiting in a Zuul-style submit queue (merge train): the
+pipeline and queue each one is in, its position, and its estimated state:
+
+  running  jobs are running and nothing has failed yet
+  queued   enqueued, no job has started
+  waiting  waiting for changes ahead of it (not yet active)
+  failing  a job failed or a change ahead failed; it will be dequeued or
+           re-tested
+
+Configure the status endpoint in ~/.gerry/config.json:
+
+  "submit_queue": {
+    "status_url": "https://zuul.example.com/api/tenant/main/status",
+    "pipelines": ["gate"]
+  }
+
+Examples:
+  gerry queue
+  gerry queue --all
+  gerry queue --format json`,
+	Args: cobra.NoArgs,
+	RunE: runQueue,
+}
+
+func init() {
+	queueCmd.Flags().BoolVar(&queueAll, "all", false, "Show every entry in the queue, not just your changes")
+	queueCmd.Flags().StringVar(&queueFormat, "format", "table", "Output format: table, json")
+}
+
+// zuulStatus is the subset of Zuul's tenant status JSON gerry reads.
+type zuulStatus struct {
+	Pipelines []struct {
+		Name         string `json:"name"`
+		ChangeQueues []struct {
+			Name  string       `json:"name"`
+			Heads [][]zuulItem `json:"heads"`
+		} `json:"change_queues"`
+	} `json:"pipelines"`
+}
+
+// zuulItem is one queue entry. Zuul before 10 reports the change on the item
+// itself; later versions list it under refs.
+type zuulItem struct {
+	ID             string    `json:"id"`
+	Project        string    `json:"project"`
+	Refs           []zuulRef `json:"refs"`
+	Live           *bool     `json:"live"`
+	Active         bool      `json:"active"`
+	EnqueueTime    int64     `json:"enqueue_time"`
+	RemainingTime  *int64    `json:"remaining_time"`
+	FailingReasons []string  `json:"failing_reasons"`
+	Jobs           []zuulJob `json:"jobs"`
+}
+
+type zuulRef struct {
+	ID      string `json:"id"`
+	Project string `json:"project"`
+}
+
+type zuulJob struct {
+	Name      string   `json:"name"`
+	Result    *string  `json:"result"`
+	StartTime *float64 `json:"start_time"`
+}
+
+// queueEntry is one change in the submit queue as `gerry queue` reports it.
+type queueEntry struct {
+	Change     int      `json:"change"`
+	Patchset   int      `json:"patchset"`
+	Project    string   `json:"project"`
+	Pipeline   string   `json:"pipeline"`
+	Queue      string   `json:"queue"`
+	Position   int      `json:"position"`
+	Length     int      `json:"length"`
+	State      string   `json:"state"`
+	Enqueued   string   `json:"enqueued,omitempty"`
+	ETA        string   `json:"eta,omitempty"`
+	FailedJobs []string `json:"failed_jobs,omitempty"`
+	Mine       bool     `json:"mine"`
+
+	enqueued  time.Time
+	remaining time.Duration
+}
+
+func runQueue(cmd *cobra.Command, args []string) error {
+	if queueFormat != "table" && queueFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", queueFormat)
+	}
+	if queueFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+	if cfg.SubmitQueue == nil || cfg.SubmitQueue.StatusURL == "" {
+		return fmt.Errorf("no submit queue configured; set submit_queue.status_url in ~/.gerry/config.json")
+	}
+	pipelines := cfg.SubmitQueue.Pipelines
+	if len(pipelines) == 0 {
+		pipelines = []string{"gate"}
+	}
+
+	spinner := utils.NewSpinner("Reading the submit queue...")
+	status, err := fetchZuulStatus(cfg.SubmitQueue.StatusURL)
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to read submit queue status: %w", err)
+	}
+
+	ownQuery := fmt.Sprintf("owner:%s status:open", cfg.User)
+	utils.Debugf("Query: %s", ownQuery)
+	own, err := client.ListChanges(url.QueryEscape(ownQuery), 500)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list your changes: %w", err)
+	}
+	mine := make(map[int]bool)
+	for _, change := range own {
+		mine[change.ChangeNumber()] = true
+	}
+
+	entries := queueEntries(status, pipelines, mine, time.Now())
+	if !queueAll {
+		var filtered []queueEntry
+		for _, e := range entries {
+			if e.Mine {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+
+	if queueFormat == "json" {
+		if entries == nil {
+			entries = []queueEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if queueAll {
+			utils.Infof("The submit queue (%s) is empty", strings.Join(pipelines, ", "))
+		} else {
+			utils.Info("None of your changes are in the submit queue")
+		}
+		return nil
+	}
+	displayQueueEntries(entries)
+	return nil
+}
+
+func fetchZuulStatus(statusURL string) (*zuulStatus, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Get(statusURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, statusURL)
+	}
+
+	var status zuulStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse status: %w", err)
+	}
+	return &status, nil
+}
+
+// queueEntries flattens the live Gerrit changes in the given pipelines into
+// entries, marking those in mine. Position counts from the head of each
+// queue, 1 being next to merge.
+func queueEntries(status *zuulStatus, pipelines []string, mine map[int]bool, now time.Time) []queueEntry {
+	wanted := make(map[string]bool)
+	for _, p := range pipelines {
+		wanted[p] = true
+	}
+
+	var entries []queueEntry
+	for _, pipeline := range status.Pipelines {
+		if !wanted[pipeline.Name] {
+			continue
+		}
+		for _, queue := range pipeline.ChangeQueues {
+			for _, head := range queue.Heads {
+				var live []queueEntry
+				for _, item := range head {
+					if item.Live != nil && !*item.Live {
+						continue
+					}
+					change, patchset, ok := item.change()
+					if !ok {
+						continue
+					}
+					e := queueEntry{
+						Change:   change,
+						Patchset: patchset,
+						Project:  item.project(),
+						Pipeline: pipeline.Name,
+						Queue:    queue.Name,
+						State:    zuulItemState(item),
+						Mine:     mine[change],
+					}
+					for _, job := range item.Jobs {
+						if job.Result != nil && *job.Result == "FAILURE" {
+							e.FailedJobs = append(e.FailedJobs, job.Name)
+						}
+					}
+					if item.EnqueueTime > 0 {
+						e.enqueued = time.UnixMilli(item.EnqueueTime)
+						e.Enqueued = e.enqueued.UTC().Format(time.RFC3339)
+					}
+					if item.RemainingTime != nil {
+						e.remaining = time.Duration(*item.RemainingTime) * time.Millisecond
+						e.ETA = now.Add(e.remaining).UTC().Format(time.RFC3339)
+					}
+					live = append(live, e)
+				}
+				for i := range live {
+					live[i].Position = i + 1
+					live[i].Length = len(live)
+				}
+				entries = append(entries, live...)
+			}
+		}
+	}
+	return entries
+}
+
+// change parses the item's "12345,2" ID into change and patchset numbers;
+// ok is false for items that aren't Gerrit changes (e.g. branch heads).
+func (item zuulItem) change() (change, patchset int, ok bool) {
+	id := item.ID
+	if id == "" && len(item.Refs) > 0 {
+		id = item.Refs[0].ID
+	}
+	num, ps, found := strings.Cut(id, ",")
+	if !found {
+		return 0, 0, false
+	}
+	change, err := strconv.Atoi(num)
+	if err != nil {
+		return 0, 0, false
+	}
+	patchset, _ = strconv.Atoi(ps)
+	return change, patchset, true
+}
+
+func (item zuulItem) project() string {
+	if item.Project == "" && len(item.Refs) > 0 {
+		return item.Refs[0].Project
+	}
+	return item.Project
+}
+
+// zuulItemState estimates where an item stands from its failures, whether
+// it is active, and how far its jobs have got.
+func zuulItemState(item zuulItem) string {
+	if len(item.FailingReasons) > 0 {
+		return "failing"
+	}
+	for _, job := range item.Jobs {
+		if job.Result != nil && *job.Result == "FAILURE" {
+			return "failing"
+		}
+	}
+	if !item.Active {
+		return "waiting"
+	}
+	for _, job := range item.Jobs {
+		if job.StartTime != nil || job.Result != nil {
+			return "running"
+		}
+	}
+	return "queued"
+}
+
+func displayQueueEntries(entries []queueEntry) {
+	headers := []string{"Change", "Project", "Pipeline", "Position", "State", "Enqueued", "ETA"}
+	var rows [][]string
+	for _, e := range entries {
+		number := fmt.Sprintf("%d,%d", e.Change, e.Patchset)
+		if e.Mine {
+			number = utils.BoldCyan(number)
+		}
+		state := e.State
+		switch e.State {
+		case "failing":
+			state = utils.Red(state)
+			if len(e.FailedJobs) > 0 {
+				state += utils.Gray(" (" + utils.TruncateString(strings.Join(e.FailedJobs, ", "), 40) + ")")
+			}
+		case "running":
+			state = utils.Green(state)
+		case "waiting":
+			state = utils.Yellow(state)
+		}
+		enqueued, eta := "", ""
+		if !e.enqueued.IsZero() {
+			enqueued = utils.FormatTimeAgo(e.enqueued)
+		}
+		if e.ETA != "" {
+			eta = utils.FormatDuration(e.remaining)
+		}
+		pipeline := e.Pipeline
+		if e.Queue != "" {
+			pipeline += "/" + e.Queue
+		}
+		rows = append(rows, []string{
+			number,
+			e.Project,
+			pipeline,
+			fmt.Sprintf("%d of %d", e.Position, e.Length),
+			state,
+			enqueued,
+			eta,
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const zuulStatusFixture = `{
+  "pipelines": [
+    {"name": "check", "change_queues": [{"name": "", "heads": [[{"id": "900,1", "project": "canvas-lms", "live": true, "active": true}]]}]},
+    {"name": "gate", "change_queues": [{"name": "integrated", "heads": [[
+      {"id": "100,3", "project": "canvas-lms", "live": true, "active": true, "enqueue_time": 1700000000000, "remaining_time": 600000,
+       "jobs": [{"name": "unit", "result": null, "start_time": 1700000001}]},
+      {"id": "101,1", "project": "canvas-lms", "live": false, "active": true},
+      {"refs": [{"id": "102,2", "project": "quizzes"}], "live": true, "active": true,
+       "jobs": [{"name": "lint", "result": "FAILURE"}, {"name": "unit", "result": null}]},
+      {"id": "103,1", "project": "canvas-lms", "live": true, "active": false, "failing_reasons": []},
+      {"id": "refs/heads/main", "project": "canvas-lms", "live": true, "active": true}
+    ]]}]}
+  ]
+}`
+
+func TestQueueEntries(t *testing.T) {
+	var status zuulStatus
+	if err := json.Unmarshal([]byte(zuulStatusFixture), &status); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := queueEntries(&status, []string{"gate"}, map[int]bool{102: true}, now)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	want := []struct {
+		change, patchset, position int
+		project, state             string
+		mine                       bool
+	}{
+		{100, 3, 1, "canvas-lms", "running", false},
+		{102, 2, 2, "quizzes", "failing", true},
+		{103, 1, 3, "canvas-lms", "waiting", false},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Change != w.change || e.Patchset != w.patchset || e.Position != w.position || e.Length != 3 ||
+			e.Project != w.project || e.State != w.state || e.Mine != w.mine || e.Queue != "integrated" {
+			t.Errorf("entries[%d] = %+v, want %+v", i, e, w)
+		}
+	}
+	if entries[0].ETA != "2024-01-01T00:10:00Z" || entries[0].Enqueued != "2023-11-14T22:13:20Z" {
+		t.Errorf("entries[0] times = enqueued %s, eta %s", entries[0].Enqueued, entries[0].ETA)
+	}
+	if len(entries[1].FailedJobs) != 1 || entries[1].FailedJobs[0] != "lint" {
+		t.Errorf("entries[1].FailedJobs = %v, want [lint]", entries[1].FailedJobs)
+	}
+}
+
+func TestZuulItemState(t *testing.T) {
+	failure := "FAILURE"
+	started := 1.0
+	tests := []struct {
+		name string
+		item zuulItem
+		want string
+	}{
+		{"failing reasons", zuulItem{Active: true, FailingReasons: []string{"a change ahead failed"}}, "failing"},
+		{"failed job", zuulItem{Active: true, Jobs: []zuulJob{{Result: &failure}}}, "failing"},
+		{"inactive", zuulItem{Jobs: []zuulJob{{}}}, "waiting"},
+		{"nothing started", zuulItem{Active: true, Jobs: []zuulJob{{}}}, "queued"},
+		{"started", zuulItem{Active: true, Jobs: []zuulJob{{}, {StartTime: &started}}}, "running"},
+	}
+	for _, tt := range tests {
+		if got := zuulItemState(tt.item); got != tt.want {
+			t.Errorf("%s: zuulItemState() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
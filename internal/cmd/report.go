@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportWeekly bool
+	reportSince  string
+	reportLimit  int
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a markdown status report of your work",
+	Long: `Print a markdown summary ready to paste into a status update:
+
+  Merged             your changes merged in the period
+  In review          your open changes and where each one stands
+  Reviews completed  other people's changes you voted on in the period
+  Blockers           your open changes with a failing Verified vote or
+                     unresolved comment threads
+
+--weekly covers the last 7 days; --since picks another window.
+
+Examples:
+  gerry report --weekly
+  gerry report --since 14d > status.md`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportWeekly, "weekly", false, "Report on the last 7 days (the default)")
+	reportCmd.Flags().StringVar(&reportSince, "since", "", "Report on a custom window instead (e.g. 3d, 2w)")
+	reportCmd.Flags().IntVarP(&reportLimit, "limit", "n", 200, "Maximum number of changes to scan per query")
+}
+
+// reportItem is one change line in a report section.
+type reportItem struct {
+	Change gerrit.Change
+	Detail string
+}
+
+// statusReport holds the sections of `gerry report`.
+type statusReport struct {
+	Since    time.Time
+	Until    time.Time
+	Merged   []reportItem
+	InReview []reportItem
+	Reviewed []reportItem
+	Blockers []reportItem
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	if reportWeekly && reportSince != "" {
+		return fmt.Errorf("--weekly and --since are mutually exclusive")
+	}
+	period := reportSince
+	if period == "" {
+		period = "7d"
+	}
+	window, err := utils.ParseDuration(period)
+	if err != nil {
+		return err
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	age := fmt.Sprintf("-age:%dh", int(math.Ceil(window.Hours())))
+	queries := []struct {
+		query   string
+		options []string
+	}{
+		{fmt.Sprintf("owner:%s status:merged %s", cfg.User, age), nil},
+		{fmt.Sprintf("owner:%s status:open", cfg.User), []string{"DETAILED_LABELS", "SUBMITTABLE"}},
+		{fmt.Sprintf("reviewedby:%s -owner:%s %s", cfg.User, cfg.User, age), []string{"MESSAGES"}},
+	}
+
+	spinner := utils.NewSpinner("Collecting your week...")
+	results := make([][]gerrit.Change, len(queries))
+	for i, q := range queries {
+		utils.Debugf("Query: %s", q.query)
+		changes, err := client.ListChangesWithOptions(url.QueryEscape(q.query), reportLimit, q.options...)
+		if err != nil {
+			spinner.Stop()
+			return fmt.Errorf("failed to list changes: %w", err)
+		}
+		results[i] = changes
+	}
+	spinner.Stop()
+
+	now := time.Now()
+	report := buildStatusReport(results[0], results[1], results[2], gerrit.Account{Username: cfg.User}, now.Add(-window))
+	report.Until = now
+	fmt.Print(renderStatusReport(cfg, report))
+	return nil
+}
+
+// buildStatusReport sorts the query results into report sections. merged
+// and open are the user's own changes, reviewed the changes of others they
+// reviewed; only activity after since counts.
+func buildStatusReport(merged, open, reviewed []gerrit.Change, me gerrit.Account, since time.Time) statusReport {
+	report := statusReport{Since: since}
+
+	for _, change := range merged {
+		if at, err := utils.ParseGerritTime(change.Submitted); err == nil && at.Before(since) {
+			continue
+		}
+		report.Merged = append(report.Merged, reportItem{Change: change})
+	}
+
+	for _, change := range open {
+		report.InReview = append(report.InReview, reportItem{Change: change, Detail: reviewStateDetail(change)})
+		var blockers []string
+		if score, ok := getLabelScore(change, "Verified"); ok && score < 0 {
+			blockers = append(blockers, "Verified failing")
+		}
+		if n := change.UnresolvedCommentCount; n > 0 {
+			blockers = append(blockers, fmt.Sprintf("%d unresolved thread(s)", n))
+		}
+		if len(blockers) > 0 {
+			report.Blockers = append(report.Blockers, reportItem{Change: change, Detail: strings.Join(blockers, ", ")})
+		}
+	}
+
+	for _, change := range reviewed {
+		var votes []string
+		for _, msg := range change.Messages {
+			if !isSameAccount(msg.Author, me) {
+				continue
+			}
+			if at, err := utils.ParseGerritTime(msg.Date); err != nil || at.Before(since) {
+				continue
+			}
+			if entry := classifyActivityMessage(msg); entry.Kind == "reviewed" {
+				votes = append(votes, reviewVoteDetail(entry.Detail))
+			}
+		}
+		if len(votes) > 0 {
+			// The latest vote is the one that stands.
+			report.Reviewed = append(report.Reviewed, reportItem{Change: change, Detail: votes[len(votes)-1]})
+		}
+	}
+
+	for _, items := range [][]reportItem{report.Merged, report.InReview, report.Reviewed, report.Blockers} {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Change.ChangeNumber() < items[j].Change.ChangeNumber()
+		})
+	}
+	return report
+}
+
+// reviewStateDetail describes where an open change stands, in the terms
+// `gerry mine` counts it under.
+func reviewStateDetail(change gerrit.Change) string {
+	switch classifyMineChange(change) {
+	case "submittable":
+		return "ready to submit"
+	case "verified-failing":
+		return "Verified failing"
+	case "needs-action":
+		if change.WorkInProgress {
+			return "work in progress"
+		}
+		return "needs my action"
+	default:
+		return "waiting on reviewers"
+	}
+}
+
+// reviewVoteDetail strips the "Patch Set N: " prefix from a vote message's
+// first line, leaving the votes.
+func reviewVoteDetail(line string) string {
+	if i := strings.Index(line, ": "); i >= 0 && strings.HasPrefix(line, "Patch Set ") {
+		return line[i+2:]
+	}
+	return line
+}
+
+func renderStatusReport(cfg *config.Config, r statusReport) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Status report: %s to %s\n", r.Since.Format("Jan 2"), r.Until.Format("Jan 2, 2006")))
+
+	sections := []struct {
+		title string
+		items []reportItem
+	}{
+		{"Merged", r.Merged},
+		{"In review", r.InReview},
+		{"Reviews completed", r.Reviewed},
+		{"Blockers", r.Blockers},
+	}
+	for _, section := range sections {
+		sb.WriteString(fmt.Sprintf("\n## %s (%d)\n\n", section.title, len(section.items)))
+		if len(section.items) == 0 {
+			sb.WriteString("- None\n")
+			continue
+		}
+		for _, item := range section.items {
+			c := item.Change
+			sb.WriteString(fmt.Sprintf("- [%d](%s) %s: %s", c.ChangeNumber(), changeURL(cfg, c), c.Project, c.Subject))
+			if item.Detail != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", item.Detail))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestBuildStatusReport(t *testing.T) {
+	me := gerrit.Account{Username: "alice"}
+	bob := gerrit.Account{Username: "bob"}
+	since := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	merged := []gerrit.Change{
+		{Number: 3, Subject: "New", Submitted: "2024-01-12 10:00:00.000000000"},
+		{Number: 1, Subject: "Old", Submitted: "2024-01-02 10:00:00.000000000"},
+	}
+	open := []gerrit.Change{
+		{Number: 5, Subject: "Failing", Labels: map[string]interface{}{"Verified": labelVotes(-1)}, UnresolvedCommentCount: 2},
+		{Number: 4, Subject: "Ready", Submittable: true},
+		{Number: 6, Subject: "Waiting"},
+	}
+	reviewed := []gerrit.Change{
+		{Number: 7, Owner: bob, Messages: []gerrit.ChangeMessageInfo{
+			{Author: me, Date: "2024-01-11 09:00:00.000000000", Message: "Patch Set 1: Code-Review-1\n\n(2 comments)"},
+			{Author: me, Date: "2024-01-12 09:00:00.000000000", Message: "Patch Set 2: Code-Review+2"},
+		}},
+		{Number: 8, Owner: bob, Messages: []gerrit.ChangeMessageInfo{
+			{Author: me, Date: "2024-01-02 09:00:00.000000000", Message: "Patch Set 1: Code-Review+1"},
+		}},
+		{Number: 9, Owner: bob, Messages: []gerrit.ChangeMessageInfo{
+			{Author: me, Date: "2024-01-11 09:00:00.000000000", Message: "Patch Set 1:\n\n(1 comment)"},
+		}},
+	}
+
+	r := buildStatusReport(merged, open, reviewed, me, since)
+
+	if len(r.Merged) != 1 || r.Merged[0].Change.Number != 3 {
+		t.Errorf("Merged = %+v, want only change 3", r.Merged)
+	}
+	wantInReview := []string{"ready to submit", "Verified failing", "waiting on reviewers"}
+	if len(r.InReview) != len(wantInReview) {
+		t.Fatalf("InReview = %+v, want %d items", r.InReview, len(wantInReview))
+	}
+	for i, want := range wantInReview {
+		if r.InReview[i].Detail != want {
+			t.Errorf("InReview[%d].Detail = %q, want %q", i, r.InReview[i].Detail, want)
+		}
+	}
+	if len(r.Reviewed) != 1 || r.Reviewed[0].Change.Number != 7 || r.Reviewed[0].Detail != "Code-Review+2" {
+		t.Errorf("Reviewed = %+v, want change 7 with Code-Review+2", r.Reviewed)
+	}
+	if len(r.Blockers) != 1 || r.Blockers[0].Detail != "Verified failing, 2 unresolved thread(s)" {
+		t.Errorf("Blockers = %+v, want change 5 with both blockers", r.Blockers)
+	}
+}
+
+func TestRenderStatusReport(t *testing.T) {
+	cfg := &config.Config{Server: "gerrit.example.com", Port: 29418}
+	r := statusReport{
+		Since:  time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+		Merged: []reportItem{{Change: gerrit.Change{Number: 3, Project: "canvas-lms", Subject: "Fix grading"}}},
+	}
+
+	got := renderStatusReport(cfg, r)
+	for _, want := range []string{
+		"# Status report: Jan 10 to Jan 17, 2024\n",
+		"## Merged (1)\n\n- [3](https://gerrit.example.com/c/canvas-lms/+/3) canvas-lms: Fix grading\n",
+		"## Blockers (0)\n\n- None\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderStatusReport() missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
@@ -9,14 +14,35 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	retriggerWait     bool
+	retriggerInterval time.Duration
+	retriggerTimeout  time.Duration
+)
+
 var retriggerCmd = &cobra.Command{
 	Use:   "retrigger <change-id>",
 	Short: "Retrigger Canvas LMS build for a change",
-	Long:  `Posts a comment with __TRIGGER_CANVAS_LMS__ to retrigger the build pipeline.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Posts a comment with __TRIGGER_CANVAS_LMS__ to retrigger the build pipeline.
+
+With --wait, keeps polling the change until Service Cloud Jenkins votes
+Verified on the current patchset, then reports the result with the failure
+links. Exits non-zero if the build fails, the wait times out, or a new
+patchset is uploaded meanwhile.
+
+Examples:
+  gerry retrigger 12345
+  gerry retrigger 12345 --wait --timeout 90m`,
+	Args: cobra.ExactArgs(1),
 	RunE: runRetrigger,
 }
 
+func init() {
+	retriggerCmd.Flags().BoolVar(&retriggerWait, "wait", false, "Wait for the build result and exit non-zero on failure")
+	retriggerCmd.Flags().DurationVar(&retriggerInterval, "interval", 30*time.Second, "Polling interval with --wait")
+	retriggerCmd.Flags().DurationVar(&retriggerTimeout, "timeout", 2*time.Hour, "Give up waiting after this long")
+}
+
 func runRetrigger(cmd *cobra.Command, args []string) error {
 	changeID := args[0]
 
@@ -38,11 +64,124 @@ func runRetrigger(cmd *cobra.Command, args []string) error {
 
 	client := gerrit.NewRESTClient(cfg)
 
+	// Note where the history ends so only verdicts after the trigger count.
+	var patchset, seen int
+	if retriggerWait {
+		change, err := client.GetChange(changeID)
+		if err != nil {
+			return fmt.Errorf("failed to get change: %w", err)
+		}
+		patchset = change.CurrentPatchSetNumber()
+		messages, err := client.GetChangeMessages(changeID)
+		if err != nil {
+			return fmt.Errorf("failed to get change messages: %w", err)
+		}
+		seen = len(messages)
+	}
+
 	// Post the trigger comment
-	if err := client.PostReview(changeID, "current", "__TRIGGER_CANVAS_LMS__"); err != nil {
+	if err := client.PostReview(changeID, "current", gerrit.ReviewInput{Message: "__TRIGGER_CANVAS_LMS__"}); err != nil {
 		return fmt.Errorf("failed to post retrigger comment: %w", err)
 	}
 
 	utils.Info("Build retrigger comment posted successfully")
-	return nil
+	if !retriggerWait {
+		return nil
+	}
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+	return waitForBuild(ctx, client.WithoutCache().WithContext(ctx), changeID, patchset, seen)
+}
+
+// waitForBuild polls the change messages until Jenkins posts a Verified vote
+// on patchset after the first seen messages, or ctx is cancelled.
+func waitForBuild(ctx context.Context, client *gerrit.RESTClient, changeID string, patchset, seen int) error {
+	deadline := time.Now().Add(retriggerTimeout)
+	spinner := utils.NewSpinner(fmt.Sprintf("Waiting for build on patch set %d...", patchset))
+
+	for {
+		if sleepContext(ctx, retriggerInterval) != nil {
+			spinner.Stop()
+			return fmt.Errorf("interrupted; the build on patch set %d keeps running", patchset)
+		}
+
+		messages, err := client.GetChangeMessages(changeID)
+		if err != nil {
+			utils.Debugf("Failed to poll change messages: %v", err)
+		} else {
+			newer := messages
+			if seen <= len(messages) {
+				newer = messages[seen:]
+			}
+			verdict, msg, superseded := findBuildVerdict(newer, patchset)
+			switch {
+			case superseded:
+				spinner.Stop()
+				return fmt.Errorf("patch set %d was superseded by a new upload; stopped waiting", patchset)
+			case verdict == "pass":
+				spinner.Stop()
+				fmt.Printf("%s Build passed on patch set %d\n", utils.Green("✓"), patchset)
+				return nil
+			case verdict == "fail":
+				spinner.Stop()
+				if result, ok := parseFailureMessage(msg); ok {
+					printFailures(result)
+				}
+				return fmt.Errorf("build failed on patch set %d", patchset)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			spinner.Stop()
+			return fmt.Errorf("timed out after %s waiting for a build result", retriggerTimeout)
+		}
+	}
+}
+
+var (
+	verdictPattern  = regexp.MustCompile(`Verified([+-]\d)`)
+	uploadedPattern = regexp.MustCompile(`^Uploaded patch set (\d+)`)
+)
+
+// findBuildVerdict scans messages (oldest first) for the first Jenkins
+// Verified vote on patchset, returning "pass" or "fail" and the message.
+// superseded is set when a newer patchset is uploaded before any verdict.
+func findBuildVerdict(messages []gerrit.ChangeMessageInfo, patchset int) (verdict string, msg gerrit.ChangeMessageInfo, superseded bool) {
+	for _, m := range messages {
+		if u := uploadedPattern.FindStringSubmatch(m.Message); u != nil {
+			if n, _ := strconv.Atoi(u[1]); n > patchset {
+				return "", gerrit.ChangeMessageInfo{}, true
+			}
+			continue
+		}
+		if !isJenkinsMessage(m) {
+			continue
+		}
+		if ps := messagePatchset(m); ps != 0 && ps != patchset {
+			continue
+		}
+		v := verdictPattern.FindStringSubmatch(m.Message)
+		if v == nil {
+			continue
+		}
+		if strings.HasPrefix(v[1], "-") {
+			return "fail", m, false
+		}
+		if v[1] != "+0" {
+			return "pass", m, false
+		}
+	}
+	return "", gerrit.ChangeMessageInfo{}, false
+}
+
+// messagePatchset returns the patchset a message was posted on, or 0.
+func messagePatchset(m gerrit.ChangeMessageInfo) int {
+	if m.RevisionNumber != 0 {
+		return m.RevisionNumber
+	}
+	if p := patchSetPrefixPattern.FindStringSubmatch(m.Message); p != nil {
+		n, _ := strconv.Atoi(p[1])
+		return n
+	}
+	return 0
 }
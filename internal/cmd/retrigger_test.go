@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestFindBuildVerdict(t *testing.T) {
+	tests := []struct {
+		name           string
+		messages       []gerrit.ChangeMessageInfo
+		wantVerdict    string
+		wantSuperseded bool
+	}{
+		{"no verdict yet", []gerrit.ChangeMessageInfo{jenkinsMsg("Patch Set 3:\n\nBuild Started")}, "", false},
+		{"pass", []gerrit.ChangeMessageInfo{jenkinsMsg("Patch Set 3: Verified+1\n\nBuild Successful")}, "pass", false},
+		{"fail", []gerrit.ChangeMessageInfo{jenkinsMsg("Patch Set 3: Verified-1\n\nBuild Failed")}, "fail", false},
+		{"vote on another patchset", []gerrit.ChangeMessageInfo{jenkinsMsg("Patch Set 2: Verified-1")}, "", false},
+		{"human vote ignored", []gerrit.ChangeMessageInfo{{Author: gerrit.Account{Name: "Drake Harper"}, Message: "Patch Set 3: Verified-1"}}, "", false},
+		{"superseded", []gerrit.ChangeMessageInfo{{Message: "Uploaded patch set 4."}, jenkinsMsg("Patch Set 4: Verified+1")}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, _, superseded := findBuildVerdict(tt.messages, 3)
+			if verdict != tt.wantVerdict || superseded != tt.wantSuperseded {
+				t.Errorf("findBuildVerdict() = %q, %v; want %q, %v", verdict, superseded, tt.wantVerdict, tt.wantSuperseded)
+			}
+		})
+	}
+}
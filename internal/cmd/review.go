@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewCodeReview int
+	reviewVerified   int
+	reviewLabels     []string
+	reviewMessage    string
+	reviewReady      bool
+	reviewWIP        bool
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review <change-id> [shorthand...]",
+	Short: "Post a review: votes, a message, and ready/WIP state",
+	Long: `Post a review on the current patchset of a change in one go: label votes, a
+review message, and optionally mark the change ready for review or work in
+progress.
+
+Votes take the same shorthand arguments as gerry vote (+2, v+1, -v after --),
+resolved through label_aliases.
+
+Examples:
+  gerry review 12345 --code-review +2 --message "LGTM"
+  gerry review 12345 --code-review +2 --verified +1
+  gerry review 12345 +2 v+1 -m "Ship it"
+  gerry review 12345 -l QA-Review=+1
+  gerry review 12345 --wip -m "Parking this until the API lands"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReview,
+}
+
+func init() {
+	reviewCmd.Flags().IntVar(&reviewCodeReview, "code-review", 0, "Code-Review vote (-2..+2)")
+	reviewCmd.Flags().IntVar(&reviewVerified, "verified", 0, "Verified vote (-1..+1)")
+	reviewCmd.Flags().StringArrayVarP(&reviewLabels, "label", "l", nil, "Arbitrary label vote NAME=VALUE (repeatable)")
+	reviewCmd.Flags().StringVarP(&reviewMessage, "message", "m", "", "Review message")
+	reviewCmd.Flags().BoolVar(&reviewReady, "ready", false, "Mark the change ready for review")
+	reviewCmd.Flags().BoolVar(&reviewWIP, "wip", false, "Mark the change work in progress")
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	if reviewReady && reviewWIP {
+		return fmt.Errorf("--ready and --wip cannot be combined")
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+	aliases := labelAliases(cfg)
+
+	input := gerrit.ReviewInput{
+		Message:        reviewMessage,
+		Labels:         map[string]int{},
+		Ready:          reviewReady,
+		WorkInProgress: reviewWIP,
+	}
+	if cmd.Flags().Changed("code-review") {
+		input.Labels[aliases["cr"]] = reviewCodeReview
+	}
+	if cmd.Flags().Changed("verified") {
+		input.Labels[aliases["v"]] = reviewVerified
+	}
+	for _, raw := range reviewLabels {
+		name, n, err := parseLabelAssignment(raw)
+		if err != nil {
+			return err
+		}
+		input.Labels[name] = n
+	}
+	for _, raw := range args[1:] {
+		name, n, err := parseShorthandVote(raw, aliases)
+		if err != nil {
+			return err
+		}
+		input.Labels[name] = n
+	}
+
+	if len(input.Labels) == 0 && strings.TrimSpace(input.Message) == "" && !input.Ready && !input.WorkInProgress {
+		return fmt.Errorf("nothing to post: give a vote, --message, --ready, or --wip")
+	}
+
+	revision, err := getCurrentRevision(client, changeID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.PostReview(changeID, revision, input); err != nil {
+		return fmt.Errorf("failed to post review: %w", err)
+	}
+
+	fmt.Printf("%s Reviewed %s%s\n", utils.Green("✓"), changeID, reviewSummary(input))
+	return nil
+}
+
+// reviewSummary describes what a posted review did, e.g.
+// ": Code-Review+2, Verified+1; marked ready; with message".
+func reviewSummary(input gerrit.ReviewInput) string {
+	var parts []string
+	if len(input.Labels) > 0 {
+		parts = append(parts, formatVotes(input.Labels))
+	}
+	if input.Ready {
+		parts = append(parts, "marked ready")
+	}
+	if input.WorkInProgress {
+		parts = append(parts, "marked work in progress")
+	}
+	if strings.TrimSpace(input.Message) != "" {
+		parts = append(parts, "with message")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestReviewSummary(t *testing.T) {
+	tests := []struct {
+		input gerrit.ReviewInput
+		want  string
+	}{
+		{gerrit.ReviewInput{Labels: map[string]int{"Verified": 1, "Code-Review": 2}, Message: "LGTM"}, ": Code-Review+2, Verified+1; with message"},
+		{gerrit.ReviewInput{WorkInProgress: true, Message: " "}, ": marked work in progress"},
+		{gerrit.ReviewInput{Ready: true}, ": marked ready"},
+		{gerrit.ReviewInput{}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := reviewSummary(tt.input); got != tt.want {
+			t.Errorf("reviewSummary(%+v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,6 +15,8 @@ import (
 var (
 	cfgFile   string
 	verbose   bool
+	ciMode    bool
+	noCache   bool
 	version   string
 	buildTime string
 )
@@ -26,13 +31,88 @@ and manage your code review workflow without leaving your terminal.`,
 		if verbose {
 			utils.SetLogLevel(utils.DebugLevel)
 		}
+		if noCache {
+			gerrit.DisableCache()
+		}
+		if ciMode || utils.CIModeFromEnv() {
+			enableCIMode(cmd.Root())
+		}
+		configureGitSSH()
 	},
 }
 
 func Execute(ver, build string) error {
 	version = ver
 	buildTime = build
-	return rootCmd.Execute()
+
+	// Check the environment up front so that even flag errors are reported
+	// in the machine-readable format.
+	if utils.CIModeFromEnv() {
+		enableCIMode(rootCmd)
+	}
+
+	err := rootCmd.Execute()
+	if err != nil && utils.CIMode() {
+		utils.WriteJSONError(os.Stderr, err)
+	}
+	return err
+}
+
+// enableCIMode turns on strict non-interactive mode and replaces cobra's
+// human-oriented error and usage output with a JSON error line.
+func enableCIMode(root *cobra.Command) {
+	utils.EnableCIMode()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+}
+
+// configureGitSSH routes the ssh that git spawns for fetch and push through
+// the configured jump host. A GIT_SSH_COMMAND set by the user is left alone.
+func configureGitSSH() {
+	cfg, err := config.Load()
+	if err != nil || cfg.SSHProxyJump == "" || cfg.Validate() != nil {
+		return
+	}
+	os.Setenv("GIT_SSH_COMMAND", gitSSHCommand(os.Getenv("GIT_SSH_COMMAND"), cfg.SSHProxyJump))
+}
+
+// gitSSHCommand adds the jump host to GIT_SSH_COMMAND unless current is a
+// command gerry didn't set.
+func gitSSHCommand(current, jump string) string {
+	switch current {
+	case "":
+		return "ssh -J " + jump
+	case utils.CIGitSSHCommand:
+		return current + " -J " + jump
+	}
+	return current
+}
+
+// askOne wraps survey.AskOne, failing instead of prompting in CI mode.
+func askOne(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	if utils.CIMode() {
+		return fmt.Errorf("%w: %s", utils.ErrInteractiveDisabled, promptLabel(p))
+	}
+	return survey.AskOne(p, response, opts...)
+}
+
+func promptLabel(p survey.Prompt) string {
+	switch prompt := p.(type) {
+	case *survey.Input:
+		return prompt.Message
+	case *survey.Password:
+		return prompt.Message
+	case *survey.Confirm:
+		return prompt.Message
+	case *survey.Select:
+		return prompt.Message
+	case *survey.Multiline:
+		return prompt.Message
+	case *survey.Editor:
+		return prompt.Message
+	default:
+		return "prompt"
+	}
 }
 
 func init() {
@@ -40,6 +120,9 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gerry/config.json)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "bypass the response cache (see cache_ttl)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "output format for list, team, dashboard, details, status, stack, comments, trees, and failures: table, json, yaml")
+	rootCmd.PersistentFlags().BoolVar(&ciMode, "ci", false, "strict non-interactive mode: no prompts or colors, JSON errors (also GERRY_NONINTERACTIVE=1)")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
@@ -48,8 +131,12 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(teamCmd)
+	rootCmd.AddCommand(dashboardCmd)
 	rootCmd.AddCommand(commentsCmd)
 	rootCmd.AddCommand(detailsCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(urlCmd)
+	rootCmd.AddCommand(openCmd)
 	rootCmd.AddCommand(fetchCmd)
 	rootCmd.AddCommand(cherryPickCmd)
 	rootCmd.AddCommand(treeCmd)
@@ -60,6 +147,51 @@ func init() {
 	rootCmd.AddCommand(shareCmd)
 	rootCmd.AddCommand(rebaseCmd)
 	rootCmd.AddCommand(voteCmd)
+	rootCmd.AddCommand(mineCmd)
+	rootCmd.AddCommand(showQueueCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(submitCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(blameCmd)
+	rootCmd.AddCommand(ownersCmd)
+	rootCmd.AddCommand(slaCmd)
+	rootCmd.AddCommand(suggestCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(badgeCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(filesCmd)
+	rootCmd.AddCommand(activityCmd)
+	rootCmd.AddCommand(groupsCmd)
+	rootCmd.AddCommand(projectsCmd)
+	rootCmd.AddCommand(flakyCmd)
+	rootCmd.AddCommand(autosubmitCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(hostkeyCmd)
+	rootCmd.AddCommand(sizeCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(trendCmd)
+	rootCmd.AddCommand(queueCmd)
+	rootCmd.AddCommand(workspaceCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(backportCmd)
+	rootCmd.AddCommand(diffstatCmd)
+	rootCmd.AddCommand(exporterCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(pushCmd)
+	rootCmd.AddCommand(stackCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(draftCmd)
+	rootCmd.AddCommand(attentionCmd)
+	rootCmd.AddCommand(topicCmd)
+	rootCmd.AddCommand(hashtagCmd)
+	rootCmd.AddCommand(wipCmd)
+	rootCmd.AddCommand(readyCmd)
+	rootCmd.AddCommand(messageCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
 
 func initConfig() {
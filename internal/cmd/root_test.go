@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// gerryArgsEnv, when set, makes the test binary run gerry with these
+// newline-separated arguments instead of the tests; see runGerryCI.
+const gerryArgsEnv = "GERRY_TEST_ARGS"
+
+func TestMain(m *testing.M) {
+	if args := os.Getenv(gerryArgsEnv); args != "" {
+		rootCmd.SetArgs(strings.Split(args, "\n"))
+		if err := Execute("test", ""); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runGerryCI runs gerry with args in CI mode, in a separate process because
+// CI mode cannot be turned off again, and returns its stderr and error.
+func runGerryCI(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(),
+		gerryArgsEnv+"="+strings.Join(args, "\n"),
+		"GERRY_NONINTERACTIVE=1",
+		"HOME="+t.TempDir(),
+		"GERRIT_SERVER=review.example.com",
+		"GERRIT_USER=alice",
+		"EDITOR=false",
+		"VISUAL=")
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stderr.String(), err
+}
+
+// captureStdout returns what fn writes to stdout.
+func captureStdout(t *testing.T, fn func() error) ([]byte, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	captured := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		captured <- data
+	}()
+	err = fn()
+	w.Close()
+	return <-captured, err
+}
+
+func TestGitSSHCommand(t *testing.T) {
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{"", "ssh -J bastion"},
+		{utils.CIGitSSHCommand, utils.CIGitSSHCommand + " -J bastion"},
+		{"ssh -i ~/.ssh/ci_key", "ssh -i ~/.ssh/ci_key"},
+	}
+	for _, tt := range tests {
+		if got := gitSSHCommand(tt.current, "bastion"); got != tt.want {
+			t.Errorf("gitSSHCommand(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
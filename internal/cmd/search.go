@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -12,27 +16,83 @@ import (
 var (
 	searchLimit    int
 	searchDetailed bool
+	searchFormat   string
+	searchSaved    string
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search QUERY",
-	Short: "Search changes with a raw Gerrit query",
+	Use:     "search QUERY",
+	Aliases: []string{"query"},
+	Short:   "Search changes with a raw Gerrit query",
 	Long: `Search changes using a raw Gerrit search query.
 
 The query is passed through to Gerrit unchanged, so any Gerrit search
-operator is supported.
+operator is supported. Uses the REST API, falling back to SSH. --format json
+prints the matching changes as Gerrit returns them, for scripts.
+
+Frequently used queries can be saved under a name, like bookmarks in the web
+UI, and run with --saved (or 'gerry search run'); any query terms given as
+well narrow the saved query. Saved searches live under "searches" in
+~/.gerry/config.json, so they can be shared by copying that block.
 
 Examples:
   gerry search "owner:ashafovaloff@instructure.com status:open i18next migrate label:Verified+1"
   gerry search "project:canvas-lms status:merged"
-  gerry search 'message:"fix flaky" status:open'`,
-	Args: cobra.MinimumNArgs(1),
+  gerry search 'message:"fix flaky" status:open'
+  gerry query "project:canvas-lms label:Code-Review=-1 age:2d" --format json --limit 100
+  gerry search save needs-v "status:open owner:self label:Verified=0"
+  gerry search run needs-v
+  gerry query --saved needs-v project:canvas-lms`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runSearch,
 }
 
+var searchSaveCmd = &cobra.Command{
+	Use:   "save <name> <query>",
+	Short: "Save a named search query",
+	Long: `Save a Gerrit search query under a name. Saving an existing name replaces
+its query.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runSearchSave,
+}
+
+var searchRunCmd = &cobra.Command{
+	Use:   "run <name> [extra terms...]",
+	Short: "Run a saved search",
+	Long: `Run a saved search. Extra terms are appended to the saved query, e.g.
+'gerry search run needs-v project:canvas-lms'.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearchRun,
+}
+
+var searchListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved searches",
+	Args:    cobra.NoArgs,
+	RunE:    runSearchList,
+}
+
+var searchDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved search",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runSearchDelete,
+}
+
+var searchNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
 func init() {
-	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 25, "Maximum number of changes to show")
-	searchCmd.Flags().BoolVar(&searchDetailed, "detailed", false, "Show detailed information")
+	searchCmd.PersistentFlags().IntVarP(&searchLimit, "limit", "n", 25, "Maximum number of changes to show")
+	searchCmd.PersistentFlags().BoolVar(&searchDetailed, "detailed", false, "Show detailed information")
+	searchCmd.PersistentFlags().StringVar(&searchFormat, "format", "table", "Output format: table, json")
+	searchCmd.Flags().StringVar(&searchSaved, "saved", "", "Run the saved search with this name")
+
+	searchCmd.AddCommand(searchSaveCmd)
+	searchCmd.AddCommand(searchRunCmd)
+	searchCmd.AddCommand(searchListCmd)
+	searchCmd.AddCommand(searchDeleteCmd)
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -45,7 +105,39 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	query := strings.Join(args, " ")
+	if searchSaved != "" {
+		query, err := savedSearchQuery(cfg, searchSaved, args)
+		if err != nil {
+			return err
+		}
+		return searchChanges(cfg, query)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("give a query, or --saved <name>")
+	}
+	return searchChanges(cfg, strings.Join(args, " "))
+}
+
+// savedSearchQuery returns the saved search name, narrowed by extra terms.
+func savedSearchQuery(cfg *config.Config, name string, extra []string) (string, error) {
+	query, ok := cfg.Searches[name]
+	if !ok {
+		return "", fmt.Errorf("no saved search named %q (see 'gerry search list')", name)
+	}
+	if len(extra) > 0 {
+		query += " " + strings.Join(extra, " ")
+	}
+	return query, nil
+}
+
+// searchChanges runs a query and displays the matching changes.
+func searchChanges(cfg *config.Config, query string) error {
+	if searchFormat != "table" && searchFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", searchFormat)
+	}
+	if searchFormat == "json" {
+		utils.DisableProgress()
+	}
 	utils.Debugf("Query: %s", query)
 
 	// Try REST API first, fall back to SSH if needed
@@ -59,6 +151,18 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if searchFormat == "json" {
+		if changes == nil {
+			changes = []gerrit.Change{}
+		}
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
 	if len(changes) == 0 {
 		fmt.Println("No changes found.")
 		return nil
@@ -67,7 +171,106 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	if searchDetailed {
 		displayDetailedChanges(changes)
 	} else {
-		displaySimpleChanges(changes)
+		displaySimpleChanges(changes, cfg.User)
+	}
+	return nil
+}
+
+func runSearchSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	query := strings.Join(args[1:], " ")
+	if err := validateSearchName(name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("query cannot be empty")
+	}
+
+	replaced := false
+	err := config.Update(func(c *config.Config) error {
+		if c.Searches == nil {
+			c.Searches = make(map[string]string)
+		}
+		_, replaced = c.Searches[name]
+		c.Searches[name] = query
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save search: %w", err)
 	}
+
+	verb := "Saved"
+	if replaced {
+		verb = "Updated"
+	}
+	fmt.Printf("%s %s search %s: %s\n", utils.Green("✓"), verb, utils.BoldCyan(name), query)
+	return nil
+}
+
+// validateSearchName checks that a saved search name starts with a letter or
+// digit and uses only letters, digits, '.', '_' and '-'.
+func validateSearchName(name string) error {
+	if !searchNameRe.MatchString(name) {
+		return fmt.Errorf("invalid search name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+func runSearchRun(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	query, err := savedSearchQuery(cfg, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	return searchChanges(cfg, query)
+}
+
+func runSearchList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if len(cfg.Searches) == 0 {
+		fmt.Println("No saved searches. Create one with 'gerry search save <name> <query>'.")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Searches))
+	for name := range cfg.Searches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows [][]string
+	for _, name := range names {
+		rows = append(rows, []string{utils.BoldCyan(name), cfg.Searches[name]})
+	}
+	fmt.Print(utils.FormatTable([]string{"Name", "Query"}, rows, 2))
+	return nil
+}
+
+func runSearchDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	err := config.Update(func(c *config.Config) error {
+		if _, ok := c.Searches[name]; !ok {
+			return fmt.Errorf("no saved search named %q", name)
+		}
+		delete(c.Searches, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Deleted search %s\n", utils.Green("✓"), utils.BoldCyan(name))
 	return nil
 }
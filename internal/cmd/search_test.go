@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestSavedSearchQuery(t *testing.T) {
+	cfg := &config.Config{Searches: map[string]string{"hotfixes": "branch:stable/* status:open"}}
+
+	got, err := savedSearchQuery(cfg, "hotfixes", nil)
+	if err != nil || got != "branch:stable/* status:open" {
+		t.Errorf("savedSearchQuery() = %q, %v", got, err)
+	}
+
+	got, err = savedSearchQuery(cfg, "hotfixes", []string{"project:canvas-lms", "-is:wip"})
+	if want := "branch:stable/* status:open project:canvas-lms -is:wip"; err != nil || got != want {
+		t.Errorf("savedSearchQuery() = %q, %v, want %q", got, err, want)
+	}
+
+	if _, err := savedSearchQuery(cfg, "missing", nil); err == nil {
+		t.Error("savedSearchQuery() found an unknown search")
+	}
+}
+
+func TestValidateSearchName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"hotfixes", false},
+		{"my-reviews", false},
+		{"stable_2.x", false},
+		{"2024.q3", false},
+		{"", true},
+		{"-hotfixes", true},
+		{".hidden", true},
+		{"_private", true},
+		{"my reviews", true},
+		{"team/backend", true},
+		{"owner:self", true},
+	}
+	for _, tt := range tests {
+		if err := validateSearchName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("validateSearchName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
@@ -12,17 +14,32 @@ import (
 var (
 	shareReviewers []string
 	shareCCs       []string
+	shareRemove    []string
+	shareSets      []string
 )
 
 var shareCmd = &cobra.Command{
 	Use:   "share <change-id>",
 	Short: "Add reviewers or CCs to a change",
-	Long: `Add reviewers or CCs to a Gerrit change.
+	Long: `Add reviewers or CCs to a Gerrit change, or remove them.
+
+Uses the REST API when an HTTP password is configured and falls back to the
+SSH 'gerrit set-reviewers' command otherwise (or when REST fails). SSH has no
+notion of CCs, so --cc always requires REST access.
+
+--set adds every member of a named reviewer set from ~/.gerry/config.json,
+one at a time, and summarizes which were added:
+
+  "reviewer_sets": {
+    "frontend": ["alice", "bob", "ui-group"]
+  }
 
 Examples:
   gerry share 12345 -r john.doe
   gerry share 12345 --cc learning-experience
-  gerry share 12345 -r alice -r bob --cc my-team`,
+  gerry share 12345 -r alice -r bob --cc my-team
+  gerry share 12345 --remove carol
+  gerry share 12345 --set frontend`,
 	Args: cobra.ExactArgs(1),
 	RunE: runShare,
 }
@@ -30,13 +47,15 @@ Examples:
 func init() {
 	shareCmd.Flags().StringArrayVarP(&shareReviewers, "reviewer", "r", nil, "Add reviewer (can be user or group, repeatable)")
 	shareCmd.Flags().StringArrayVar(&shareCCs, "cc", nil, "Add CC (can be user or group, repeatable)")
+	shareCmd.Flags().StringArrayVar(&shareRemove, "remove", nil, "Remove reviewer or CC (repeatable)")
+	shareCmd.Flags().StringArrayVarP(&shareSets, "set", "s", nil, "Add every reviewer in a named reviewer set (repeatable)")
 }
 
 func runShare(cmd *cobra.Command, args []string) error {
 	changeID := args[0]
 
-	if len(shareReviewers) == 0 && len(shareCCs) == 0 {
-		return fmt.Errorf("at least one --reviewer (-r) or --cc is required")
+	if len(shareReviewers) == 0 && len(shareCCs) == 0 && len(shareRemove) == 0 && len(shareSets) == 0 {
+		return fmt.Errorf("at least one --reviewer (-r), --set (-s), --cc, or --remove is required")
 	}
 
 	if err := utils.ValidateChangeID(changeID); err != nil {
@@ -52,24 +71,149 @@ func runShare(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	client := gerrit.NewRESTClient(cfg)
+	setMembers, err := expandReviewerSets(cfg.ReviewerSets, shareSets, shareReviewers)
+	if err != nil {
+		return err
+	}
+
+	sharer := newReviewerSharer(cfg)
 
 	// Add reviewers
 	for _, reviewer := range shareReviewers {
 		utils.Debugf("Adding reviewer %s to change %s", reviewer, changeID)
-		if err := client.AddReviewer(changeID, reviewer, "REVIEWER"); err != nil {
+		if err := sharer.add(changeID, reviewer, "REVIEWER"); err != nil {
 			return fmt.Errorf("failed to add reviewer %s: %w", reviewer, err)
 		}
 		utils.Infof("Added reviewer: %s", reviewer)
 	}
 
+	// Add reviewer sets, carrying on past failures so one bad entry doesn't
+	// leave the rest of the set off the change
+	if len(setMembers) > 0 {
+		var failed []string
+		for _, reviewer := range setMembers {
+			utils.Debugf("Adding reviewer %s to change %s", reviewer, changeID)
+			if err := sharer.add(changeID, reviewer, "REVIEWER"); err != nil {
+				utils.Warnf("Failed to add reviewer %s: %v", reviewer, err)
+				failed = append(failed, reviewer)
+				continue
+			}
+			utils.Infof("Added reviewer: %s", reviewer)
+		}
+		added := len(setMembers) - len(failed)
+		if len(failed) > 0 {
+			return fmt.Errorf("added %d of %d reviewer(s) from %s; failed: %s",
+				added, len(setMembers), strings.Join(shareSets, ", "), strings.Join(failed, ", "))
+		}
+		fmt.Printf("%s Added %d reviewer(s) from %s\n", utils.Green("✓"), added, strings.Join(shareSets, ", "))
+	}
+
 	// Add CCs
 	for _, cc := range shareCCs {
 		utils.Debugf("Adding CC %s to change %s", cc, changeID)
-		if err := client.AddReviewer(changeID, cc, "CC"); err != nil {
+		if err := sharer.add(changeID, cc, "CC"); err != nil {
 			return fmt.Errorf("failed to add CC %s: %w", cc, err)
 		}
 		utils.Infof("Added CC: %s", cc)
 	}
+
+	// Remove reviewers/CCs
+	for _, account := range shareRemove {
+		utils.Debugf("Removing %s from change %s", account, changeID)
+		if err := sharer.remove(changeID, account); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", account, err)
+		}
+		utils.Infof("Removed: %s", account)
+	}
 	return nil
 }
+
+// expandReviewerSets returns the members of the named sets in order, without
+// duplicates or accounts already being added individually.
+func expandReviewerSets(sets map[string][]string, names, individual []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, account := range individual {
+		seen[account] = true
+	}
+
+	var members []string
+	for _, name := range names {
+		set, ok := sets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown reviewer set %q (%s)", name, reviewerSetNames(sets))
+		}
+		for _, account := range set {
+			account = strings.TrimSpace(account)
+			if account == "" || seen[account] {
+				continue
+			}
+			seen[account] = true
+			members = append(members, account)
+		}
+	}
+	return members, nil
+}
+
+func reviewerSetNames(sets map[string][]string) string {
+	if len(sets) == 0 {
+		return "none defined; add reviewer_sets to ~/.gerry/config.json"
+	}
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "available: " + strings.Join(names, ", ")
+}
+
+// reviewerSharer adds and removes reviewers over REST, switching to SSH for
+// the rest of the run once REST is unavailable or has failed.
+type reviewerSharer struct {
+	rest   *gerrit.RESTClient
+	ssh    *gerrit.SSHClient
+	useSSH bool
+}
+
+func newReviewerSharer(cfg *config.Config) *reviewerSharer {
+	s := &reviewerSharer{
+		rest:   gerrit.NewRESTClient(cfg),
+		ssh:    gerrit.NewSSHClient(cfg),
+		useSSH: cfg.HTTPPassword == "",
+	}
+	if s.useSSH {
+		utils.Debugf("No HTTP password configured, using SSH")
+	}
+	return s
+}
+
+func (s *reviewerSharer) add(changeID, account, state string) error {
+	if !s.useSSH {
+		err := s.rest.AddReviewer(changeID, account, state)
+		if err == nil {
+			return nil
+		}
+		if state == "CC" {
+			return err
+		}
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to SSH...")
+		s.useSSH = true
+	}
+	if state == "CC" {
+		return fmt.Errorf("adding CCs requires REST API access; run 'gerry init' to configure HTTP credentials")
+	}
+	return s.ssh.SetReviewers(changeID, []string{account}, nil)
+}
+
+func (s *reviewerSharer) remove(changeID, account string) error {
+	if !s.useSSH {
+		err := s.rest.RemoveReviewer(changeID, account)
+		if err == nil {
+			return nil
+		}
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to SSH...")
+		s.useSSH = true
+	}
+	return s.ssh.SetReviewers(changeID, nil, []string{account})
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestExpandReviewerSets(t *testing.T) {
+	sets := map[string][]string{
+		"frontend": {"alice", "bob", " ui-group "},
+		"backend":  {"bob", "carol", ""},
+	}
+
+	tests := []struct {
+		name       string
+		names      []string
+		individual []string
+		want       []string
+		wantErr    string
+	}{
+		{"one set", []string{"frontend"}, nil, []string{"alice", "bob", "ui-group"}, ""},
+		{"overlapping sets", []string{"frontend", "backend"}, nil, []string{"alice", "bob", "ui-group", "carol"}, ""},
+		{"individual reviewers skipped", []string{"backend"}, []string{"carol"}, []string{"bob"}, ""},
+		{"unknown set", []string{"mobile"}, nil, nil, `unknown reviewer set "mobile" (available: backend, frontend)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandReviewerSets(sets, tt.names, tt.individual)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expandReviewerSets() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandReviewerSets() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandReviewerSets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := expandReviewerSets(nil, []string{"frontend"}, nil); err == nil || !strings.Contains(err.Error(), "none defined") {
+		t.Errorf("expandReviewerSets() without sets error = %v, want a hint to define them", err)
+	}
+}
+
+// fakeSSH puts an ssh on PATH that records the gerrit command it is asked
+// to run, one argument per line, and returns the log file.
+func fakeSSH(t *testing.T) string {
+	t.Helper()
+	bin := t.TempDir()
+	log := filepath.Join(bin, "ssh.log")
+	script := "#!/bin/sh\nwhile [ \"$1\" != gerrit ]; do shift; done\nshift\nprintf '%s\\n' \"$@\" >> '" + log + "'\n"
+	if err := os.WriteFile(filepath.Join(bin, "ssh"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+// closedPort returns a local port nothing listens on.
+func closedPort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	return port
+}
+
+func TestReviewerSharer(t *testing.T) {
+	t.Run("ssh without an HTTP password", func(t *testing.T) {
+		log := fakeSSH(t)
+		sharer := newReviewerSharer(&config.Config{Server: "127.0.0.1", Port: 29418, User: "alice"})
+		if err := sharer.add("12345", "bob", "REVIEWER"); err != nil {
+			t.Fatalf("add() error = %v", err)
+		}
+		if err := sharer.remove("12345", "carol"); err != nil {
+			t.Fatalf("remove() error = %v", err)
+		}
+		data, _ := os.ReadFile(log)
+		want := "set-reviewers\n--add\nbob\n12345\nset-reviewers\n--remove\ncarol\n12345\n"
+		if string(data) != want {
+			t.Errorf("ssh ran %q, want %q", data, want)
+		}
+	})
+
+	t.Run("falls back to ssh when REST fails", func(t *testing.T) {
+		log := fakeSSH(t)
+		sharer := newReviewerSharer(&config.Config{Server: "127.0.0.1", Port: 29418, HTTPPort: closedPort(t), User: "alice", HTTPPassword: "secret"})
+		if err := sharer.add("12345", "bob", "REVIEWER"); err != nil {
+			t.Fatalf("add() error = %v", err)
+		}
+		if !sharer.useSSH {
+			t.Error("sharer keeps using REST after it failed")
+		}
+		data, _ := os.ReadFile(log)
+		if want := "set-reviewers\n--add\nbob\n12345\n"; string(data) != want {
+			t.Errorf("ssh ran %q, want %q", data, want)
+		}
+	})
+
+	t.Run("CCs need REST", func(t *testing.T) {
+		log := fakeSSH(t)
+		sharer := newReviewerSharer(&config.Config{Server: "127.0.0.1", Port: 29418, User: "alice"})
+		err := sharer.add("12345", "team", "CC")
+		if err == nil || !strings.Contains(err.Error(), "requires REST API access") {
+			t.Errorf("add(CC) error = %v, want a REST access error", err)
+		}
+		if _, err := os.Stat(log); !os.IsNotExist(err) {
+			t.Error("a CC was sent over ssh")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	showQueueSort    string
+	showQueueReverse bool
+	showQueueFilter  string
+)
+
+var showQueueCmd = &cobra.Command{
+	Use:   "show-queue",
+	Short: "Show the server's background task queue (admin)",
+	Long: `Show Gerrit's background work queue (replication, indexing, GC, ...)
+by wrapping the SSH 'gerrit show-queue -w' command, parsed into a sortable table.
+
+Waiting tasks show when they are scheduled to run and how long remains until
+then. Requires the View Queue or Administrate Server capability.
+
+Examples:
+  gerry show-queue
+  gerry show-queue --sort start
+  gerry show-queue --filter replication`,
+	Args: cobra.NoArgs,
+	RunE: runShowQueue,
+}
+
+func init() {
+	showQueueCmd.Flags().StringVar(&showQueueSort, "sort", "start", "Sort by: task, state, start, remaining, command")
+	showQueueCmd.Flags().BoolVar(&showQueueReverse, "reverse", false, "Reverse the sort order")
+	showQueueCmd.Flags().StringVarP(&showQueueFilter, "filter", "f", "", "Only show tasks whose command contains this text")
+}
+
+// queueTask is one parsed row of `gerrit show-queue` output.
+type queueTask struct {
+	ID      string
+	State   string
+	Start   string
+	Command string
+
+	// Scheduled is set when State is a time (a waiting task) rather than a word.
+	Scheduled time.Time
+	StartTime time.Time
+}
+
+var (
+	queueTimePattern = `(?:\d{2}:\d{2}:\d{2}\.\d{3}|[A-Z][a-z]{2}-\d{2} \d{2}:\d{2})`
+	queueLinePattern = regexp.MustCompile(`^([0-9a-f]{8})\s+(` + queueTimePattern + `|[a-z]+(?: \.+)?)\s+(` + queueTimePattern + `)\s+(.*)$`)
+	queueTimeRegex   = regexp.MustCompile(`^` + queueTimePattern + `$`)
+)
+
+func runShowQueue(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	output, err := gerrit.NewSSHClient(cfg).ShowQueue()
+	if err != nil {
+		return fmt.Errorf("failed to show queue: %w", err)
+	}
+
+	now := time.Now()
+	tasks := parseShowQueue(output, now)
+
+	if showQueueFilter != "" {
+		var filtered []queueTask
+		for _, t := range tasks {
+			if strings.Contains(strings.ToLower(t.Command), strings.ToLower(showQueueFilter)) {
+				filtered = append(filtered, t)
+			}
+		}
+		tasks = filtered
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks in the queue.")
+		return nil
+	}
+
+	if err := sortQueueTasks(tasks, showQueueSort, showQueueReverse); err != nil {
+		return err
+	}
+
+	headers := []string{"Task", "State", "Start", "Remaining", "Command"}
+	var rows [][]string
+	for _, t := range tasks {
+		state := t.State
+		remaining := utils.Gray("-")
+		if !t.Scheduled.IsZero() {
+			state = utils.Yellow("waiting")
+			remaining = formatQueueRemaining(t.Scheduled.Sub(now))
+		} else if t.State == "running" {
+			state = utils.Green(t.State)
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(t.ID),
+			state,
+			t.Start,
+			remaining,
+			t.Command,
+		})
+	}
+
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d task(s)\n", len(tasks))
+	return nil
+}
+
+// parseShowQueue extracts task rows from `gerrit show-queue -w` output,
+// skipping headers, separators, queue titles, and the trailing count line.
+func parseShowQueue(output string, now time.Time) []queueTask {
+	var tasks []queueTask
+	for _, line := range strings.Split(output, "\n") {
+		m := queueLinePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		t := queueTask{
+			ID:      m[1],
+			State:   strings.TrimSpace(m[2]),
+			Start:   m[3],
+			Command: strings.TrimSpace(m[4]),
+		}
+		if queueTimeRegex.MatchString(t.State) {
+			t.Scheduled = parseQueueTime(t.State, now)
+		}
+		t.StartTime = parseQueueTime(t.Start, now)
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// parseQueueTime interprets show-queue timestamps, which are either a time of
+// day ("14:31:15.435", today) or a date and time ("Apr-16 14:31", this year).
+func parseQueueTime(value string, now time.Time) time.Time {
+	if t, err := time.ParseInLocation("15:04:05.000", value, now.Location()); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), now.Location())
+	}
+	if t, err := time.ParseInLocation("Jan-02 15:04", value, now.Location()); err == nil {
+		return time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	}
+	return time.Time{}
+}
+
+func formatQueueRemaining(d time.Duration) string {
+	if d <= 0 {
+		return utils.BoldRed("overdue")
+	}
+	return d.Round(time.Second).String()
+}
+
+func sortQueueTasks(tasks []queueTask, key string, reverse bool) error {
+	var less func(a, b queueTask) bool
+	switch key {
+	case "task":
+		less = func(a, b queueTask) bool { return a.ID < b.ID }
+	case "state":
+		less = func(a, b queueTask) bool { return a.State < b.State }
+	case "start":
+		less = func(a, b queueTask) bool { return a.StartTime.Before(b.StartTime) }
+	case "remaining":
+		// Tasks without a schedule (running, done) sort before waiting ones.
+		less = func(a, b queueTask) bool { return a.Scheduled.Before(b.Scheduled) }
+	case "command":
+		less = func(a, b queueTask) bool { return a.Command < b.Command }
+	default:
+		return fmt.Errorf("unknown sort key %q (supported: task, state, start, remaining, command)", key)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if reverse {
+			return less(tasks[j], tasks[i])
+		}
+		return less(tasks[i], tasks[j])
+	})
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseShowQueue(t *testing.T) {
+	output := `Task     State        StartTime         Command
+------------------------------------------------------------------------------
+7aae09b2 14:31:15.435 14:31:13.435      mirror mirror1.example.com:/git/project.git
+2b89a5a5 running      14:30:01.000      git-upload-pack '/All-Projects' (admin)
+0ff8ed7b waiting ...  Apr-15 23:59      Index-Batch[change 1234]
+------------------------------------------------------------------------------
+  3 tasks
+`
+	now := time.Date(2026, 4, 16, 14, 31, 14, 0, time.Local)
+
+	tasks := parseShowQueue(output, now)
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+
+	waiting := tasks[0]
+	if waiting.ID != "7aae09b2" || waiting.Command != "mirror mirror1.example.com:/git/project.git" {
+		t.Errorf("unexpected first task: %+v", waiting)
+	}
+	if waiting.Scheduled.IsZero() {
+		t.Fatal("scheduled time should be parsed for a waiting task")
+	}
+	if got := waiting.Scheduled.Sub(now); got != 1435*time.Millisecond {
+		t.Errorf("remaining = %v, want 1.435s", got)
+	}
+
+	running := tasks[1]
+	if running.State != "running" || !running.Scheduled.IsZero() {
+		t.Errorf("unexpected running task: %+v", running)
+	}
+
+	old := tasks[2]
+	if old.State != "waiting ..." || old.StartTime.Day() != 15 {
+		t.Errorf("unexpected dated task: %+v", old)
+	}
+}
+
+func TestSortQueueTasks(t *testing.T) {
+	tasks := []queueTask{{ID: "b", Command: "z"}, {ID: "a", Command: "y"}}
+
+	if err := sortQueueTasks(tasks, "task", false); err != nil {
+		t.Fatal(err)
+	}
+	if tasks[0].ID != "a" {
+		t.Errorf("sort by task: got %s first", tasks[0].ID)
+	}
+
+	if err := sortQueueTasks(tasks, "command", true); err != nil {
+		t.Fatal(err)
+	}
+	if tasks[0].Command != "z" {
+		t.Errorf("reverse sort by command: got %s first", tasks[0].Command)
+	}
+
+	if err := sortQueueTasks(tasks, "bogus", false); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sizeHashtag bool
+	sizeComment bool
+	sizeFormat  string
+)
+
+// sizeHashtagPrefix starts the hashtag `gerry size --hashtag` sets, e.g.
+// "size/XL".
+const sizeHashtagPrefix = "size/"
+
+var sizeCmd = &cobra.Command{
+	Use:   "size <change-id>",
+	Short: "Classify a change as XS, S, M, L, or XL by lines changed",
+	Long: `Classify the current patchset of a change by the number of lines it
+inserts and deletes, ignoring the commit message:
+
+  XS  up to 10 lines
+  S   up to 50 lines
+  M   up to 250 lines
+  L   up to 1000 lines
+  XL  anything bigger
+
+Override the limits in ~/.gerry/config.json:
+
+  "size_thresholds": {"xs": 20, "s": 100, "m": 400, "l": 2000}
+
+--hashtag tags the change "size/<class>" (replacing any earlier size/ tag) and
+--comment posts the classification as a change message, so processes such as
+requiring extra reviewers on XL changes can key off it.
+
+Examples:
+  gerry size 12345
+  gerry size 12345 --hashtag
+  gerry size 12345 --comment --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSize,
+}
+
+func init() {
+	sizeCmd.Flags().BoolVar(&sizeHashtag, "hashtag", false, "Tag the change with size/<class>")
+	sizeCmd.Flags().BoolVar(&sizeComment, "comment", false, "Post the classification as a change message")
+	sizeCmd.Flags().StringVar(&sizeFormat, "format", "table", "Output format: table, json")
+}
+
+// changeSize is the output of `gerry size`.
+type changeSize struct {
+	Change     string `json:"change"`
+	Class      string `json:"class"`
+	Files      int    `json:"files"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Lines      int    `json:"lines"`
+}
+
+func runSize(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+	if sizeFormat != "table" && sizeFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", sizeFormat)
+	}
+	if sizeFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	var thresholds config.SizeThresholds
+	if cfg.SizeThresholds != nil {
+		if err := cfg.SizeThresholds.Validate(); err != nil {
+			return fmt.Errorf("invalid size_thresholds: %w", err)
+		}
+		thresholds = *cfg.SizeThresholds
+	}
+
+	files, err := client.GetChangeFiles(changeID, "current")
+	if err != nil {
+		return fmt.Errorf("failed to get files: %w", err)
+	}
+
+	size := measureChange(files, thresholds.WithDefaults())
+	size.Change = changeID
+
+	if sizeFormat == "json" {
+		data, err := json.MarshalIndent(size, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("%s %s: %s\n", utils.BoldCyan("Change"), utils.BoldWhite(changeID), colorSizeClass(size.Class))
+		fmt.Printf("  %d file(s), %s %s, %d line(s) changed\n",
+			size.Files, utils.Green(fmt.Sprintf("+%d", size.Insertions)), utils.Red(fmt.Sprintf("-%d", size.Deletions)), size.Lines)
+	}
+
+	if sizeHashtag {
+		current, err := client.GetHashtags(changeID)
+		if err != nil {
+			return fmt.Errorf("failed to get hashtags: %w", err)
+		}
+		add, remove := sizeHashtagUpdate(current, size.Class)
+		if len(add) > 0 || len(remove) > 0 {
+			if _, err := client.SetHashtags(changeID, add, remove); err != nil {
+				return fmt.Errorf("failed to set hashtag: %w", err)
+			}
+		}
+		if sizeFormat != "json" {
+			fmt.Printf("%s Tagged change %s with %s%s\n", utils.Green("✓"), changeID, sizeHashtagPrefix, size.Class)
+		}
+	}
+
+	if sizeComment {
+		if err := client.PostReview(changeID, "current", gerrit.ReviewInput{Message: sizeMessage(size)}); err != nil {
+			return fmt.Errorf("failed to post comment: %w", err)
+		}
+		if sizeFormat != "json" {
+			fmt.Printf("%s Posted size to change %s\n", utils.Green("✓"), changeID)
+		}
+	}
+	return nil
+}
+
+// measureChange totals the diffstat of a revision's files and classifies it.
+func measureChange(files map[string]gerrit.FileInfo, thresholds config.SizeThresholds) changeSize {
+	var size changeSize
+	for _, path := range reviewablePaths(files) {
+		fi := files[path]
+		size.Files++
+		size.Insertions += fi.LinesInserted
+		size.Deletions += fi.LinesDeleted
+	}
+	size.Lines = size.Insertions + size.Deletions
+	size.Class = classifySize(size.Lines, thresholds)
+	return size
+}
+
+// classifySize maps a changed-line count to XS, S, M, L, or XL.
+func classifySize(lines int, thresholds config.SizeThresholds) string {
+	switch {
+	case lines <= thresholds.XS:
+		return "XS"
+	case lines <= thresholds.S:
+		return "S"
+	case lines <= thresholds.M:
+		return "M"
+	case lines <= thresholds.L:
+		return "L"
+	default:
+		return "XL"
+	}
+}
+
+// sizeHashtagUpdate returns the hashtags to add and remove so the change
+// carries exactly one size hashtag, for class.
+func sizeHashtagUpdate(current []string, class string) (add, remove []string) {
+	want := sizeHashtagPrefix + class
+	has := false
+	for _, tag := range current {
+		switch {
+		case tag == want:
+			has = true
+		case strings.HasPrefix(tag, sizeHashtagPrefix):
+			remove = append(remove, tag)
+		}
+	}
+	if !has {
+		add = []string{want}
+	}
+	return add, remove
+}
+
+func sizeMessage(size changeSize) string {
+	return fmt.Sprintf("Change size: %s (%d line(s) changed in %d file(s): +%d -%d)",
+		size.Class, size.Lines, size.Files, size.Insertions, size.Deletions)
+}
+
+func colorSizeClass(class string) string {
+	switch class {
+	case "XL":
+		return utils.Red(class)
+	case "L":
+		return utils.Yellow(class)
+	default:
+		return utils.Green(class)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestClassifySize(t *testing.T) {
+	defaults := config.SizeThresholds{}.WithDefaults()
+	custom := config.SizeThresholds{XS: 5, L: 500}.WithDefaults()
+
+	tests := []struct {
+		lines      int
+		thresholds config.SizeThresholds
+		want       string
+	}{
+		{0, defaults, "XS"},
+		{10, defaults, "XS"},
+		{11, defaults, "S"},
+		{50, defaults, "S"},
+		{250, defaults, "M"},
+		{1000, defaults, "L"},
+		{1001, defaults, "XL"},
+		{6, custom, "S"},
+		{600, custom, "XL"},
+	}
+	for _, tt := range tests {
+		if got := classifySize(tt.lines, tt.thresholds); got != tt.want {
+			t.Errorf("classifySize(%d, %+v) = %s, want %s", tt.lines, tt.thresholds, got, tt.want)
+		}
+	}
+}
+
+func TestMeasureChange(t *testing.T) {
+	files := map[string]gerrit.FileInfo{
+		"/COMMIT_MSG": {LinesInserted: 12},
+		"a.go":        {LinesInserted: 30, LinesDeleted: 5},
+		"b.go":        {LinesDeleted: 20},
+	}
+	got := measureChange(files, config.SizeThresholds{}.WithDefaults())
+	want := changeSize{Class: "M", Files: 2, Insertions: 30, Deletions: 25, Lines: 55}
+	if got != want {
+		t.Errorf("measureChange() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSizeHashtagUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    []string
+		class      string
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{"untagged", []string{"feature"}, "M", []string{"size/M"}, nil},
+		{"already tagged", []string{"size/M", "feature"}, "M", nil, nil},
+		{"resized", []string{"size/S"}, "XL", []string{"size/XL"}, []string{"size/S"}},
+		{"duplicates", []string{"size/S", "size/XL"}, "XL", nil, []string{"size/S"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := sizeHashtagUpdate(tt.current, tt.class)
+			if !reflect.DeepEqual(add, tt.wantAdd) || !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("sizeHashtagUpdate(%v, %s) = %v, %v; want %v, %v", tt.current, tt.class, add, remove, tt.wantAdd, tt.wantRemove)
+			}
+		})
+	}
+}
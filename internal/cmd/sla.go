@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	slaQuery     string
+	slaThreshold string
+	slaLimit     int
+	slaFormat    string
+)
+
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "List changes breaching the review-response SLA",
+	Long: `List changes that have waited longer than the threshold for a reviewer to
+respond since the owner's last activity (upload, reply, or creation).
+
+A change stops waiting as soon as anyone other than the owner posts a message.
+Messages tagged autogenerated:* (CI, bots) do not count as a response, and
+work-in-progress changes are skipped.
+
+Examples:
+  gerry sla
+  gerry sla --query "project:canvas-lms status:open" --threshold 48h
+  gerry sla --threshold 2d --format json > sla.json`,
+	Args: cobra.NoArgs,
+	RunE: runSLA,
+}
+
+func init() {
+	slaCmd.Flags().StringVarP(&slaQuery, "query", "q", "status:open -is:wip", "Gerrit search query selecting the changes to check")
+	slaCmd.Flags().StringVarP(&slaThreshold, "threshold", "t", "48h", "Maximum wait for a reviewer response (e.g. 36h, 2d, 1w)")
+	slaCmd.Flags().IntVarP(&slaLimit, "limit", "n", 200, "Maximum number of changes to check")
+	slaCmd.Flags().StringVar(&slaFormat, "format", "table", "Output format: table, json")
+}
+
+// slaBreach is a change waiting on reviewers longer than the threshold.
+type slaBreach struct {
+	Change       int     `json:"change"`
+	Project      string  `json:"project"`
+	Owner        string  `json:"owner"`
+	Subject      string  `json:"subject"`
+	WaitingSince string  `json:"waiting_since"`
+	WaitingHours float64 `json:"waiting_hours"`
+
+	waiting time.Duration
+}
+
+func runSLA(cmd *cobra.Command, args []string) error {
+	threshold, err := utils.ParseDuration(slaThreshold)
+	if err != nil {
+		return err
+	}
+	if slaFormat != "table" && slaFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", slaFormat)
+	}
+	if slaFormat == "json" {
+		utils.DisableProgress()
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	spinner := utils.NewSpinner("Checking review response times...")
+	utils.Debugf("Query: %s", slaQuery)
+	changes, err := client.ListChangesWithOptions(url.QueryEscape(slaQuery), slaLimit, "MESSAGES")
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list changes: %w", err)
+	}
+
+	now := time.Now().UTC()
+	breaches := findSLABreaches(changes, threshold, now)
+
+	if slaFormat == "json" {
+		if breaches == nil {
+			breaches = []slaBreach{}
+		}
+		data, err := json.MarshalIndent(breaches, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(breaches) == 0 {
+		fmt.Printf("%s No changes waiting longer than %s (%d checked)\n", utils.Green("✓"), slaThreshold, len(changes))
+		return nil
+	}
+
+	headers := []string{"Change", "Waiting", "Owner", "Project", "Subject"}
+	var rows [][]string
+	for _, b := range breaches {
+		rows = append(rows, []string{
+			utils.BoldCyan(fmt.Sprintf("%d", b.Change)),
+			utils.Red(utils.FormatDuration(b.waiting)),
+			utils.TruncateString(b.Owner, 20),
+			b.Project,
+			utils.TruncateString(b.Subject, 50),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d of %d change(s) waiting longer than %s\n", len(breaches), len(changes), slaThreshold)
+	return nil
+}
+
+// findSLABreaches returns changes awaiting a reviewer response for longer
+// than threshold, longest wait first.
+func findSLABreaches(changes []gerrit.Change, threshold time.Duration, now time.Time) []slaBreach {
+	var breaches []slaBreach
+	for _, change := range changes {
+		if change.WorkInProgress {
+			continue
+		}
+		since, awaiting := reviewWaitingSince(change)
+		if !awaiting || since.IsZero() {
+			continue
+		}
+		waiting := now.Sub(since)
+		if waiting <= threshold {
+			continue
+		}
+		breaches = append(breaches, slaBreach{
+			Change:       change.ChangeNumber(),
+			Project:      change.Project,
+			Owner:        change.Owner.DisplayName(),
+			Subject:      change.Subject,
+			WaitingSince: since.Format(time.RFC3339),
+			WaitingHours: float64(int(waiting.Hours()*10)) / 10,
+			waiting:      waiting,
+		})
+	}
+
+	sort.SliceStable(breaches, func(i, j int) bool {
+		return breaches[i].waiting > breaches[j].waiting
+	})
+	return breaches
+}
+
+// reviewWaitingSince walks the change messages and reports when the owner
+// last acted and whether nobody else has responded since.
+func reviewWaitingSince(change gerrit.Change) (since time.Time, awaiting bool) {
+	since, _ = utils.ParseGerritTime(change.Created)
+	awaiting = true
+
+	for _, msg := range change.Messages {
+		// Messages without an author are posted by the server itself.
+		if msg.Author == (gerrit.Account{}) {
+			continue
+		}
+		if strings.HasPrefix(msg.Tag, "autogenerated:") && !isSameAccount(msg.Author, change.Owner) {
+			continue
+		}
+		if isSameAccount(msg.Author, change.Owner) {
+			if t, err := utils.ParseGerritTime(msg.Date); err == nil {
+				since = t
+			}
+			awaiting = true
+			continue
+		}
+		awaiting = false
+	}
+	return since, awaiting
+}
+
+func isSameAccount(a, b gerrit.Account) bool {
+	if a.AccountID != 0 && b.AccountID != 0 {
+		return a.AccountID == b.AccountID
+	}
+	if a.Username != "" && b.Username != "" {
+		return a.Username == b.Username
+	}
+	return a.Email != "" && a.Email == b.Email
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestFindSLABreaches(t *testing.T) {
+	owner := gerrit.Account{AccountID: 1, Name: "Owner"}
+	reviewer := gerrit.Account{AccountID: 2, Name: "Reviewer"}
+	bot := gerrit.Account{AccountID: 3, Name: "Jenkins"}
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	changes := []gerrit.Change{
+		{
+			// Uploaded 3 days ago, only CI has commented: breach.
+			Number:  1,
+			Owner:   owner,
+			Created: "2024-05-07 12:00:00.000000000",
+			Messages: []gerrit.ChangeMessageInfo{
+				{Author: owner, Date: "2024-05-07 12:00:00.000000000", Tag: "autogenerated:gerrit:newPatchSet"},
+				{Author: bot, Date: "2024-05-07 13:00:00.000000000", Tag: "autogenerated:ci"},
+			},
+		},
+		{
+			// Reviewer responded after the owner's last upload.
+			Number:  2,
+			Owner:   owner,
+			Created: "2024-05-01 12:00:00.000000000",
+			Messages: []gerrit.ChangeMessageInfo{
+				{Author: owner, Date: "2024-05-01 12:00:00.000000000"},
+				{Author: reviewer, Date: "2024-05-02 12:00:00.000000000"},
+			},
+		},
+		{
+			// Owner replied to the reviewer 5 days ago and is waiting again.
+			Number:  3,
+			Owner:   owner,
+			Created: "2024-05-01 12:00:00.000000000",
+			Messages: []gerrit.ChangeMessageInfo{
+				{Author: reviewer, Date: "2024-05-02 12:00:00.000000000"},
+				{Author: owner, Date: "2024-05-05 12:00:00.000000000"},
+			},
+		},
+		{
+			// Within the threshold.
+			Number:  4,
+			Owner:   owner,
+			Created: "2024-05-09 12:00:00.000000000",
+		},
+		{
+			// WIP changes are not waiting on anyone.
+			Number:         5,
+			Owner:          owner,
+			Created:        "2024-04-01 12:00:00.000000000",
+			WorkInProgress: true,
+		},
+	}
+
+	breaches := findSLABreaches(changes, 48*time.Hour, now)
+	if len(breaches) != 2 {
+		t.Fatalf("got %d breaches, want 2: %+v", len(breaches), breaches)
+	}
+	if breaches[0].Change != 3 || breaches[1].Change != 1 {
+		t.Errorf("got changes %d, %d; want 3, 1 (longest wait first)", breaches[0].Change, breaches[1].Change)
+	}
+	if breaches[0].WaitingHours != 120 {
+		t.Errorf("WaitingHours = %v, want 120", breaches[0].WaitingHours)
+	}
+}
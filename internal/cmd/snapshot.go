@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotQuery string
+	snapshotLimit int
+)
+
+// queueHistoryFileName is the review-queue time series inside the config
+// dir, one JSON snapshot per line.
+const queueHistoryFileName = "queue_history.jsonl"
+
+// defaultQueueQuery selects the review queue `gerry snapshot` and `gerry
+// trend` track unless told otherwise.
+const defaultQueueQuery = "status:open -is:wip"
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record the current review queue for gerry trend",
+	Long: `Record the size and age of the open review queue to
+~/.gerry/queue_history.jsonl, so gerry trend can show how the backlog evolves.
+
+A change counts as awaiting review when nobody but its owner has posted since
+the owner's last upload or reply (the same rule gerry sla uses). Run it on a
+schedule, e.g. daily from cron:
+
+  0 9 * * * gerry snapshot --query "project:canvas-lms status:open -is:wip"
+
+Examples:
+  gerry snapshot
+  gerry snapshot --query "project:canvas-lms status:open -is:wip"`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshot,
+}
+
+func init() {
+	snapshotCmd.Flags().StringVarP(&snapshotQuery, "query", "q", defaultQueueQuery, "Gerrit search query selecting the review queue")
+	snapshotCmd.Flags().IntVarP(&snapshotLimit, "limit", "n", 500, "Maximum number of changes to count")
+}
+
+// queueSnapshot is one point of the review-queue time series. Wait times are
+// over the changes awaiting review.
+type queueSnapshot struct {
+	Time            string  `json:"time"`
+	Server          string  `json:"server"`
+	Query           string  `json:"query"`
+	Open            int     `json:"open"`
+	Awaiting        int     `json:"awaiting_review"`
+	MedianWaitHours float64 `json:"median_wait_hours"`
+	P90WaitHours    float64 `json:"p90_wait_hours"`
+	MaxWaitHours    float64 `json:"max_wait_hours"`
+	Over1d          int     `json:"waiting_over_1d"`
+	Over3d          int     `json:"waiting_over_3d"`
+	Over7d          int     `json:"waiting_over_7d"`
+	Truncated       bool    `json:"truncated,omitempty"`
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	spinner := utils.NewSpinner("Measuring the review queue...")
+	utils.Debugf("Query: %s", snapshotQuery)
+	changes, err := client.ListChangesWithOptions(url.QueryEscape(snapshotQuery), snapshotLimit, "MESSAGES")
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list changes: %w", err)
+	}
+
+	snap := measureQueue(changes, time.Now().UTC())
+	snap.Server = cfg.Server
+	snap.Query = snapshotQuery
+	if len(changes) > 0 && changes[len(changes)-1].MoreChanges {
+		snap.Truncated = true
+		utils.Warnf("More than %d changes match; raise --limit to count them all", snapshotLimit)
+	}
+
+	if err := appendQueueSnapshot(snap); err != nil {
+		return fmt.Errorf("failed to record snapshot: %w", err)
+	}
+	fmt.Printf("%s Recorded %d open change(s), %d awaiting review (median wait %s)\n",
+		utils.Green("✓"), snap.Open, snap.Awaiting, utils.FormatDuration(hoursDuration(snap.MedianWaitHours)))
+	return nil
+}
+
+// measureQueue summarizes the open changes as of now.
+func measureQueue(changes []gerrit.Change, now time.Time) queueSnapshot {
+	snap := queueSnapshot{Time: now.Format(time.RFC3339), Open: len(changes)}
+
+	var waits []float64
+	for _, change := range changes {
+		if change.WorkInProgress {
+			continue
+		}
+		since, awaiting := reviewWaitingSince(change)
+		if !awaiting || since.IsZero() {
+			continue
+		}
+		wait := now.Sub(since)
+		waits = append(waits, wait.Hours())
+		switch {
+		case wait > 7*24*time.Hour:
+			snap.Over7d++
+			fallthrough
+		case wait > 3*24*time.Hour:
+			snap.Over3d++
+			fallthrough
+		case wait > 24*time.Hour:
+			snap.Over1d++
+		}
+	}
+
+	snap.Awaiting = len(waits)
+	if len(waits) > 0 {
+		sort.Float64s(waits)
+		snap.MedianWaitHours = roundHours(percentile(waits, 50))
+		snap.P90WaitHours = roundHours(percentile(waits, 90))
+		snap.MaxWaitHours = roundHours(waits[len(waits)-1])
+	}
+	return snap
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func roundHours(h float64) float64 {
+	return math.Round(h*10) / 10
+}
+
+func hoursDuration(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
+
+func queueHistoryPath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, queueHistoryFileName), nil
+}
+
+func appendQueueSnapshot(snap queueSnapshot) error {
+	path, err := queueHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// loadQueueHistory reads every recorded snapshot, oldest first; a missing
+// file is an empty history.
+func loadQueueHistory() ([]queueSnapshot, error) {
+	path, err := queueHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history []queueSnapshot
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var snap queueSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil {
+			return nil, fmt.Errorf("failed to parse %s line %d: %w", path, line, err)
+		}
+		history = append(history, snap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return history, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestMeasureQueue(t *testing.T) {
+	now := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	owner := gerrit.Account{Username: "alice"}
+	reviewer := gerrit.Account{Username: "bob"}
+	waiting := func(created string) gerrit.Change {
+		return gerrit.Change{Owner: owner, Created: created}
+	}
+
+	changes := []gerrit.Change{
+		waiting("2024-01-20 00:00:00.000000000"), // 12h
+		waiting("2024-01-18 12:00:00.000000000"), // 2d
+		waiting("2024-01-15 12:00:00.000000000"), // 5d
+		waiting("2024-01-10 12:00:00.000000000"), // 10d
+		{Owner: owner, Created: "2024-01-01 00:00:00.000000000", Messages: []gerrit.ChangeMessageInfo{
+			{Author: reviewer, Date: "2024-01-02 00:00:00.000000000", Message: "Patch Set 1: Code-Review-1"},
+		}},
+		{Owner: owner, Created: "2024-01-01 00:00:00.000000000", WorkInProgress: true},
+	}
+
+	got := measureQueue(changes, now)
+	want := queueSnapshot{
+		Time:            "2024-01-20T12:00:00Z",
+		Open:            6,
+		Awaiting:        4,
+		MedianWaitHours: 48,
+		P90WaitHours:    240,
+		MaxWaitHours:    240,
+		Over1d:          3,
+		Over3d:          2,
+		Over7d:          1,
+	}
+	if got != want {
+		t.Errorf("measureQueue() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeasureQueueEmpty(t *testing.T) {
+	got := measureQueue(nil, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	if got.Open != 0 || got.Awaiting != 0 || got.MedianWaitHours != 0 {
+		t.Errorf("measureQueue(nil) = %+v, want zero counts", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{50, 5},
+		{90, 9},
+		{100, 10},
+	}
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
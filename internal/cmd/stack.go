@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Work with a stack of dependent changes",
+	Long: `Manage the stack of commits on the current branch as a chain of Gerrit
+changes.
+
+The stack is every commit on HEAD that is not on its base: the branch the
+current one tracks, else <remote>/<branch> for the target branch 'gerry push'
+would use. Each commit is matched to its change on the target branch by its
+Change-Id footer. Requires REST API access.
+
+Subcommands:
+  list    List the commits of the stack and their changes
+  status  Show which commits are out of date on the server
+  push    Upload the stack as a relation chain
+  rebase  Fetch the base and rebase the stack onto it`,
+}
+
+var stackListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the commits of the stack and their changes",
+	Long: `List the commits of the stack, newest first, with the change each one
+belongs to.
+
+Examples:
+  gerry stack list
+  gerry stack list --output json`,
+	Args: cobra.NoArgs,
+	RunE: runStackList,
+}
+
+var stackStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which commits of the stack are out of date on the server",
+	Long: `Show each commit of the stack, newest first, against its change on the
+server:
+
+  up to date   the commit is the change's current patch set
+  needs push   the commit was amended or rebased locally and not uploaded
+  outdated     the server has a newer patch set than the commit
+  new          no change has the commit's Change-Id yet
+  merged       the change was merged; 'gerry stack rebase' drops it
+
+Votes and unresolved comments are shown for each change. With --workspace the
+stack of every checkout in the workspace manifest is shown (see 'gerry
+workspace').
+
+Examples:
+  gerry stack status
+  gerry stack status --workspace
+  gerry stack status --output json`,
+	Args: cobra.NoArgs,
+	RunE: runStackStatus,
+}
+
+var stackStatusWorkspace bool
+
+func init() {
+	stackStatusCmd.Flags().BoolVar(&stackStatusWorkspace, "workspace", false, "Show the stack of every workspace checkout")
+
+	stackCmd.AddCommand(stackListCmd)
+	stackCmd.AddCommand(stackStatusCmd)
+	stackCmd.AddCommand(stackPushCmd)
+	stackCmd.AddCommand(stackRebaseCmd)
+}
+
+// States of a stack commit against its change on the server.
+const (
+	stackNoChangeID = "no Change-Id"
+	stackNew        = "new"
+	stackUpToDate   = "up to date"
+	stackNeedsPush  = "needs push"
+	stackOutdated   = "outdated"
+	stackMerged     = "merged"
+	stackAbandoned  = "abandoned"
+)
+
+// stackEntry is a commit of the stack and the change it belongs to, as
+// --output json or yaml writes it.
+type stackEntry struct {
+	Commit         string `json:"commit"`
+	Subject        string `json:"subject"`
+	ChangeID       string `json:"change_id,omitempty"`
+	Number         int    `json:"change,omitempty"`
+	LocalPatchset  int    `json:"local_patchset,omitempty"`
+	LatestPatchset int    `json:"latest_patchset,omitempty"`
+	State          string `json:"state,omitempty"`
+
+	change *gerrit.Change
+}
+
+// stack is the current branch's stack, oldest commit first. Project is the
+// Gerrit project of the checkout, if known.
+type stack struct {
+	Root    string
+	Project string
+	Base    string
+	Branch  string
+	Entries []stackEntry
+}
+
+// loadStack reads the stack of the current branch from git. Entries are
+// not matched to changes yet; see matchStackChanges.
+func loadStack(cfg *config.Config) (*stack, error) {
+	root, err := getGitRepoRoot()
+	if err != nil {
+		return nil, fmt.Errorf("not in a git repository")
+	}
+	return loadStackAt(cfg, root)
+}
+
+// loadStackAt reads the stack of the branch checked out at root.
+func loadStackAt(cfg *config.Config, root string) (*stack, error) {
+	branch := defaultPushBranch(cfg, root)
+	base, err := stackBase(cfg, root, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := gitOutput(root, "log", "--reverse", "--format=%H%x00%P%x00%B%x1e", base+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the commits on top of %s: %s", base, gitErrorMessage(err))
+	}
+	entries, err := parseStackLog(output)
+	if err != nil {
+		return nil, err
+	}
+	project, _ := checkoutProject(cfg, root)
+	return &stack{Root: root, Project: project, Base: base, Branch: branch, Entries: entries}, nil
+}
+
+// stackBase returns the ref the stack sits on: the tracked branch, else the
+// target branch on the Gerrit remote.
+func stackBase(cfg *config.Config, root, branch string) (string, error) {
+	if upstream, err := gitOutput(root, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		return upstream, nil
+	}
+	// detectPushRemote falls back to a URL, which has no tracking refs.
+	if remote, err := detectPushRemote(cfg, root); err == nil && !strings.Contains(remote, ":") {
+		ref := remote + "/" + branch
+		if _, err := gitOutput(root, "rev-parse", "--verify", "--quiet", ref); err == nil {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find the base of the stack; set the branch's upstream with 'git branch --set-upstream-to <remote>/%s'", branch)
+}
+
+// parseStackLog parses 'git log --format=%H%x00%P%x00%B%x1e' output into
+// stack entries, whose state is unknown until matched unless they have no
+// Change-Id. Stacks must be linear.
+func parseStackLog(output string) ([]stackEntry, error) {
+	var entries []stackEntry
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		if len(strings.Fields(fields[1])) > 1 {
+			return nil, fmt.Errorf("the stack contains merge commit %.7s; stacks must be linear", fields[0])
+		}
+		e := stackEntry{
+			Commit:   fields[0],
+			Subject:  firstLine(strings.TrimSpace(fields[2])),
+			ChangeID: parseChangeIDFooter(fields[2]),
+		}
+		if e.ChangeID == "" {
+			e.State = stackNoChangeID
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// matchStackChanges looks up the changes of the stack's Change-Ids on its
+// target branch and sets each entry's state.
+func matchStackChanges(cfg *config.Config, s *stack) error {
+	query, count := stackQuery(s)
+	if count == 0 {
+		return nil
+	}
+	utils.Debugf("Query: %s", query)
+
+	client := gerrit.NewRESTClient(cfg)
+	changes, err := client.ListChangesWithOptions(url.QueryEscape(query), count, "ALL_REVISIONS")
+	if err != nil {
+		return fmt.Errorf("failed to look up the stack's changes: %w", err)
+	}
+	byKey := make(map[string]*gerrit.Change, len(changes))
+	for i := range changes {
+		byKey[changes[i].ChangeKey()] = &changes[i]
+	}
+	for i := range s.Entries {
+		classifyStackEntry(&s.Entries[i], byKey[s.Entries[i].ChangeID])
+	}
+	return nil
+}
+
+// stackQuery searches for the changes of the stack's Change-Ids on its
+// target branch, in its project if known, and returns how many Change-Ids
+// it asks for.
+func stackQuery(s *stack) (string, int) {
+	var terms []string
+	for _, e := range s.Entries {
+		if e.ChangeID != "" {
+			terms = append(terms, "change:"+e.ChangeID)
+		}
+	}
+	if len(terms) == 0 {
+		return "", 0
+	}
+	query := fmt.Sprintf("branch:%s (%s)", s.Branch, strings.Join(terms, " OR "))
+	if s.Project != "" {
+		query = "project:" + s.Project + " " + query
+	}
+	return query, len(terms)
+}
+
+// classifyStackEntry sets an entry's change and its state against it;
+// change is nil when no change has the entry's Change-Id.
+func classifyStackEntry(e *stackEntry, change *gerrit.Change) {
+	e.change = change
+	switch {
+	case e.ChangeID == "":
+		e.State = stackNoChangeID
+		return
+	case change == nil:
+		e.State = stackNew
+		return
+	}
+
+	e.Number = change.ChangeNumber()
+	e.LatestPatchset = change.CurrentPatchSetNumber()
+	rev, uploaded := change.Revisions[e.Commit]
+	if uploaded {
+		e.LocalPatchset = rev.Number
+	}
+	switch {
+	case change.Status == "MERGED":
+		e.State = stackMerged
+	case change.Status == "ABANDONED":
+		e.State = stackAbandoned
+	case e.Commit == change.CurrentRevision:
+		e.State = stackUpToDate
+	case uploaded:
+		e.State = stackOutdated
+	default:
+		e.State = stackNeedsPush
+	}
+}
+
+// loadMatchedStack loads the stack and matches it to its changes. Unless
+// required, a failed lookup is only a warning and leaves the stack unmatched.
+func loadMatchedStack(required bool) (*config.Config, *stack, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	s, err := loadStack(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	spinner := utils.NewSpinner("Matching the stack to its changes...")
+	err = matchStackChanges(cfg, s)
+	spinner.Stop()
+	if err != nil {
+		if required {
+			return nil, nil, err
+		}
+		utils.Warnf("%v", err)
+	}
+	return cfg, s, nil
+}
+
+func runStackList(cmd *cobra.Command, args []string) error {
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	_, s, err := loadMatchedStack(false)
+	if err != nil {
+		return err
+	}
+	if structured {
+		return renderOutput(os.Stdout, stackEntriesOrEmpty(s))
+	}
+	if len(s.Entries) == 0 {
+		fmt.Printf("No commits on top of %s\n", s.Base)
+		return nil
+	}
+
+	headers := []string{"Change", "Commit", "Subject"}
+	var rows [][]string
+	for i := len(s.Entries) - 1; i >= 0; i-- {
+		e := s.Entries[i]
+		rows = append(rows, []string{stackChangeCell(e), utils.Gray(shortSHA(e.Commit)), utils.TruncateString(e.Subject, 60)})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("%s\n", utils.Gray(fmt.Sprintf("on %s, for %s", s.Base, s.Branch)))
+	return nil
+}
+
+func runStackStatus(cmd *cobra.Command, args []string) error {
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	if stackStatusWorkspace {
+		return showWorkspaceStacks(structured)
+	}
+	_, s, err := loadMatchedStack(true)
+	if err != nil {
+		return err
+	}
+	if structured {
+		return renderOutput(os.Stdout, stackEntriesOrEmpty(s))
+	}
+	printStackStatus(s)
+	return nil
+}
+
+// workspaceStack is the stack of one workspace checkout, as --workspace
+// --output json or yaml writes it.
+type workspaceStack struct {
+	Project string       `json:"project"`
+	Path    string       `json:"path"`
+	Base    string       `json:"base,omitempty"`
+	Branch  string       `json:"branch,omitempty"`
+	Entries []stackEntry `json:"entries"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// showWorkspaceStacks shows the stack status of every workspace checkout. A
+// checkout whose stack can't be loaded or matched reports its error and
+// the others are still shown.
+func showWorkspaceStacks(structured bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	repos, err := loadWorkspace(cfg)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]workspaceStack, 0, len(repos))
+	for i, r := range repos {
+		entry := workspaceStack{Project: r.Project, Path: r.Path, Entries: []stackEntry{}}
+		var s *stack
+		if _, err = gitOutput(r.ExpandedPath(), "rev-parse", "--show-toplevel"); err != nil {
+			err = fmt.Errorf("not a git checkout")
+		} else {
+			s, err = loadStackAt(cfg, r.ExpandedPath())
+		}
+		if err == nil {
+			s.Project = r.Project
+			spinner := utils.NewSpinner(fmt.Sprintf("Matching the stack of %s to its changes...", r.Project))
+			err = matchStackChanges(cfg, s)
+			spinner.Stop()
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Base, entry.Branch, entry.Entries = s.Base, s.Branch, stackEntriesOrEmpty(s)
+		}
+
+		if structured {
+			entries = append(entries, entry)
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", utils.BoldCyan(r.Project), utils.Gray("("+r.Path+")"))
+		if err != nil {
+			fmt.Printf("  %s\n", utils.Red(err.Error()))
+			continue
+		}
+		printStackStatus(s)
+	}
+	if structured {
+		return renderOutput(os.Stdout, entries)
+	}
+	return nil
+}
+
+// printStackStatus prints the status table of s and what to run next.
+func printStackStatus(s *stack) {
+	if len(s.Entries) == 0 {
+		fmt.Printf("No commits on top of %s\n", s.Base)
+		return
+	}
+
+	headers := []string{"Change", "Commit", "PS", "State", "CR", "V", "UC", "Subject"}
+	var rows [][]string
+	for i := len(s.Entries) - 1; i >= 0; i-- {
+		e := s.Entries[i]
+		cr, v, uc := "", "", ""
+		if e.change != nil {
+			cr = getLabelStatus(*e.change, "Code-Review")
+			v = getLabelStatus(*e.change, "Verified")
+			uc = unresolvedIndicator(*e.change)
+		}
+		rows = append(rows, []string{
+			stackChangeCell(e),
+			utils.Gray(shortSHA(e.Commit)),
+			stackPatchsetCell(e),
+			formatStackState(e.State),
+			cr, v, uc,
+			utils.TruncateString(e.Subject, 50),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("%s\n", utils.Gray(fmt.Sprintf("on %s, for %s", s.Base, s.Branch)))
+
+	counts := make(map[string]int)
+	for _, e := range s.Entries {
+		counts[e.State]++
+	}
+	switch {
+	case counts[stackOutdated] > 0:
+		fmt.Printf("\n%s The server has newer patch sets of %d change(s); pushing would replace them\n",
+			utils.Yellow("⚠"), counts[stackOutdated])
+	case counts[stackNeedsPush]+counts[stackNew] > 0:
+		fmt.Printf("\nRun 'gerry stack push' to upload %d change(s)\n", counts[stackNeedsPush]+counts[stackNew])
+	}
+	if counts[stackMerged] > 0 {
+		fmt.Printf("Run 'gerry stack rebase' to drop %d merged change(s)\n", counts[stackMerged])
+	}
+}
+
+// stackEntriesOrEmpty returns the entries newest first, as the tables show
+// them, and never nil so an empty stack is written as [].
+func stackEntriesOrEmpty(s *stack) []stackEntry {
+	entries := make([]stackEntry, 0, len(s.Entries))
+	for i := len(s.Entries) - 1; i >= 0; i-- {
+		entries = append(entries, s.Entries[i])
+	}
+	return entries
+}
+
+func stackChangeCell(e stackEntry) string {
+	if e.Number == 0 {
+		return utils.Gray("—")
+	}
+	return utils.BoldCyan(fmt.Sprintf("%d", e.Number))
+}
+
+// stackPatchsetCell renders the local patch set against the latest, e.g.
+// "2/3", or "*/3" for a commit that was never uploaded.
+func stackPatchsetCell(e stackEntry) string {
+	if e.LatestPatchset == 0 {
+		return ""
+	}
+	local := "*"
+	if e.LocalPatchset > 0 {
+		local = fmt.Sprintf("%d", e.LocalPatchset)
+	}
+	return fmt.Sprintf("%s/%d", local, e.LatestPatchset)
+}
+
+func formatStackState(state string) string {
+	switch state {
+	case stackUpToDate:
+		return utils.Green(state)
+	case stackNeedsPush, stackNew:
+		return utils.Yellow(state)
+	case stackOutdated, stackNoChangeID, stackAbandoned:
+		return utils.Red(state)
+	default:
+		return utils.Gray(state)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stackPushTopic     string
+	stackPushWIP       bool
+	stackPushReviewers []string
+	stackPushHashtags  []string
+	stackPushForce     bool
+	stackPushDryRun    bool
+)
+
+var stackPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload the stack as a relation chain",
+	Long: `Push HEAD to refs/for/<branch>, uploading every commit of the stack that
+changed as a new patch set and every commit without a change as a new change.
+Gerrit links them into a relation chain in stack order.
+
+The push is refused when a commit has no Change-Id footer, when a change in
+the stack is already merged (run 'gerry stack rebase' first), or, unless
+--force is given, when the server has a newer patch set of a change than the
+stack, which the push would replace.
+
+Examples:
+  gerry stack push
+  gerry stack push --topic grading-fix -r alice
+  gerry stack push --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runStackPush,
+}
+
+func init() {
+	stackPushCmd.Flags().StringVarP(&stackPushTopic, "topic", "t", "", "Set the topic of every change in the stack")
+	stackPushCmd.Flags().BoolVar(&stackPushWIP, "wip", false, "Upload as work in progress")
+	stackPushCmd.Flags().StringArrayVarP(&stackPushReviewers, "reviewer", "r", nil, "Add a reviewer to every change (repeatable)")
+	stackPushCmd.Flags().StringArrayVar(&stackPushHashtags, "hashtag", nil, "Add a hashtag to every change (repeatable)")
+	stackPushCmd.Flags().BoolVar(&stackPushForce, "force", false, "Push even if it replaces newer patch sets on the server")
+	stackPushCmd.Flags().BoolVar(&stackPushDryRun, "dry-run", false, "Show what would be uploaded and the git push command without running it")
+}
+
+func runStackPush(cmd *cobra.Command, args []string) error {
+	cfg, s, err := loadMatchedStack(true)
+	if err != nil {
+		return err
+	}
+	if len(s.Entries) == 0 {
+		return fmt.Errorf("nothing to push: there are no commits on top of %s", s.Base)
+	}
+	if err := checkStackPushable(s.Entries, stackPushForce); err != nil {
+		return err
+	}
+
+	var uploads []stackEntry
+	for _, e := range s.Entries {
+		if e.State != stackUpToDate {
+			uploads = append(uploads, e)
+		}
+	}
+	if len(uploads) == 0 {
+		fmt.Printf("%s Every change in the stack is up to date on the server\n", utils.Green("✓"))
+		return nil
+	}
+
+	refspec, err := buildPushRefspec(s.Branch, pushOptions{
+		Topic:     stackPushTopic,
+		WIP:       stackPushWIP,
+		Reviewers: stackPushReviewers,
+		Hashtags:  stackPushHashtags,
+	})
+	if err != nil {
+		return err
+	}
+	remote, err := detectPushRemote(cfg, s.Root)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Uploading %d of %d commit(s) to %s:\n", len(uploads), len(s.Entries), utils.BoldCyan("refs/for/"+s.Branch))
+	for _, e := range uploads {
+		change := "new change"
+		if e.Number > 0 {
+			change = fmt.Sprintf("change %d", e.Number)
+		}
+		fmt.Printf("  %s %s %s\n", utils.Gray(shortSHA(e.Commit)), utils.TruncateString(e.Subject, 60), utils.Gray("("+change+")"))
+	}
+
+	if stackPushDryRun {
+		fmt.Printf("\ngit push %s %s\n", remote, refspec)
+		return nil
+	}
+
+	// git's own report, with the change URLs, is shown once the upload is
+	// done rather than under the spinner.
+	var output bytes.Buffer
+	push := exec.Command("git", "push", remote, refspec)
+	push.Dir = s.Root
+	push.Env = gitRemoteEnv(cfg, s.Root, remote)
+	push.Stdout = &output
+	push.Stderr = &output
+	spinner := utils.NewSpinner(fmt.Sprintf("Pushing %d commit(s) to %s...", len(uploads), remote))
+	err = push.Run()
+	spinner.Stop()
+	os.Stderr.Write(output.Bytes())
+	if err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+	return nil
+}
+
+// checkStackPushable refuses stacks that cannot be pushed as they are, or
+// that would replace newer patch sets on the server unless force is set.
+func checkStackPushable(entries []stackEntry, force bool) error {
+	var missing, merged, outdated []string
+	for _, e := range entries {
+		switch e.State {
+		case stackNoChangeID:
+			missing = append(missing, shortSHA(e.Commit))
+		case stackMerged:
+			merged = append(merged, fmt.Sprintf("%d", e.Number))
+		case stackOutdated:
+			outdated = append(outdated, fmt.Sprintf("%d (patch set %d, server has %d)", e.Number, e.LocalPatchset, e.LatestPatchset))
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		return fmt.Errorf("commit(s) %s have no Change-Id footer; install Gerrit's commit-msg hook and amend them", strings.Join(missing, ", "))
+	case len(merged) > 0:
+		return fmt.Errorf("change(s) %s are already merged; run 'gerry stack rebase' first", strings.Join(merged, ", "))
+	case len(outdated) > 0 && !force:
+		return fmt.Errorf("the server has newer patch sets of %s; pushing would replace them (pass --force to push anyway)", strings.Join(outdated, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var stackRebaseNoFetch bool
+
+var stackRebaseCmd = &cobra.Command{
+	Use:   "rebase",
+	Short: "Fetch the base and rebase the stack onto it",
+	Long: `Fetch the stack's base from its remote and rebase the whole stack onto it.
+Changes at the bottom of the stack that have been merged are dropped rather
+than replayed, since Gerrit may have merged them as a different commit.
+
+When the rebase stops on a conflict, resolve it and run 'git rebase
+--continue' as usual. Upload the result with 'gerry stack push'.
+
+Examples:
+  gerry stack rebase
+  gerry stack rebase --no-fetch`,
+	Args: cobra.NoArgs,
+	RunE: runStackRebase,
+}
+
+func init() {
+	stackRebaseCmd.Flags().BoolVar(&stackRebaseNoFetch, "no-fetch", false, "Rebase onto the base as it is, without fetching it first")
+}
+
+func runStackRebase(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	s, err := loadStack(cfg)
+	if err != nil {
+		return err
+	}
+	if hasUncommittedChanges(s.Root) {
+		return fmt.Errorf("you have uncommitted changes. Please commit or stash them before rebasing")
+	}
+
+	// A local base branch (upstream ".") has nothing to fetch.
+	if remote, branch, ok := strings.Cut(s.Base, "/"); ok && !stackRebaseNoFetch {
+		fmt.Printf("Fetching %s...\n", utils.BoldCyan(s.Base))
+		if err := gitRunEnv(s.Root, gitRemoteEnv(cfg, s.Root, remote), "fetch", remote, branch); err != nil {
+			return fmt.Errorf("git fetch failed: %w", err)
+		}
+	}
+
+	merged := 0
+	if err := matchStackChanges(cfg, s); err != nil {
+		utils.Warnf("Could not check for merged changes: %v", err)
+	} else {
+		merged = mergedStackPrefix(s.Entries)
+	}
+
+	rebaseArgs := []string{"rebase", s.Base}
+	if merged > 0 {
+		fmt.Printf("Dropping %d merged change(s) from the bottom of the stack\n", merged)
+		rebaseArgs = []string{"rebase", "--onto", s.Base, s.Entries[merged-1].Commit}
+	}
+	fmt.Printf("Rebasing %d commit(s) onto %s...\n", len(s.Entries)-merged, utils.BoldCyan(s.Base))
+	if err := gitRun(s.Root, rebaseArgs...); err != nil {
+		return fmt.Errorf("rebase stopped: resolve the conflicts and run 'git rebase --continue', or 'git rebase --abort' to undo it")
+	}
+
+	fmt.Printf("%s Rebased the stack onto %s; upload it with 'gerry stack push'\n", utils.Green("✓"), s.Base)
+	return nil
+}
+
+// mergedStackPrefix counts the merged changes at the bottom of the stack,
+// which a rebase can drop wholesale.
+func mergedStackPrefix(entries []stackEntry) int {
+	n := 0
+	for _, e := range entries {
+		if e.State != stackMerged {
+			break
+		}
+		n++
+	}
+	return n
+}
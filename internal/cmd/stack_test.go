@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestParseStackLog(t *testing.T) {
+	output := "aaa\x00base\x00Add parser\n\nChange-Id: I1111111111111111111111111111111111111111\n\x1e\n" +
+		"bbb\x00aaa\x00WIP: no footer yet\n\x1e\n"
+	entries, err := parseStackLog(output)
+	if err != nil {
+		t.Fatalf("parseStackLog() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("parseStackLog() returned %d entries, want 2", len(entries))
+	}
+	if e := entries[0]; e.Commit != "aaa" || e.Subject != "Add parser" || e.ChangeID != "I1111111111111111111111111111111111111111" || e.State != "" {
+		t.Errorf("entries[0] = %+v", e)
+	}
+	if e := entries[1]; e.Commit != "bbb" || e.ChangeID != "" || e.State != stackNoChangeID {
+		t.Errorf("entries[1] = %+v", e)
+	}
+
+	if _, err := parseStackLog("ccc\x00aaa bbb\x00Merge branch\n\x1e"); err == nil || !strings.Contains(err.Error(), "merge commit") {
+		t.Errorf("parseStackLog(merge) error = %v, want merge commit error", err)
+	}
+}
+
+func TestClassifyStackEntry(t *testing.T) {
+	change := func(status string) *gerrit.Change {
+		return &gerrit.Change{
+			Number:          12345,
+			Status:          status,
+			CurrentRevision: "ps2",
+			Revisions: map[string]gerrit.RevisionInfo{
+				"ps1": {Number: 1},
+				"ps2": {Number: 2},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		commit    string
+		changeID  string
+		change    *gerrit.Change
+		wantState string
+		wantLocal int
+	}{
+		{"no Change-Id", "x", "", nil, stackNoChangeID, 0},
+		{"not uploaded", "x", "I1", nil, stackNew, 0},
+		{"current patchset", "ps2", "I1", change("NEW"), stackUpToDate, 2},
+		{"older patchset", "ps1", "I1", change("NEW"), stackOutdated, 1},
+		{"amended locally", "x", "I1", change("NEW"), stackNeedsPush, 0},
+		{"merged", "ps2", "I1", change("MERGED"), stackMerged, 2},
+		{"abandoned", "x", "I1", change("ABANDONED"), stackAbandoned, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := stackEntry{Commit: tt.commit, ChangeID: tt.changeID}
+			classifyStackEntry(&e, tt.change)
+			if e.State != tt.wantState || e.LocalPatchset != tt.wantLocal {
+				t.Errorf("state = %q, local patchset %d; want %q, %d", e.State, e.LocalPatchset, tt.wantState, tt.wantLocal)
+			}
+			if tt.change != nil && (e.Number != 12345 || e.LatestPatchset != 2) {
+				t.Errorf("change = %d, latest patchset %d; want 12345, 2", e.Number, e.LatestPatchset)
+			}
+		})
+	}
+}
+
+func TestCheckStackPushable(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []string
+		force   bool
+		wantErr string
+	}{
+		{"pushable", []string{stackUpToDate, stackNeedsPush, stackNew}, false, ""},
+		{"missing Change-Id", []string{stackNeedsPush, stackNoChangeID}, true, "no Change-Id"},
+		{"merged", []string{stackMerged, stackNeedsPush}, true, "already merged"},
+		{"outdated", []string{stackOutdated}, false, "newer patch sets"},
+		{"outdated forced", []string{stackOutdated}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]stackEntry, len(tt.states))
+			for i, state := range tt.states {
+				entries[i] = stackEntry{Commit: "0123456789", Number: 100 + i, State: state}
+			}
+			err := checkStackPushable(entries, tt.force)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("checkStackPushable() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkStackPushable() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergedStackPrefix(t *testing.T) {
+	entries := []stackEntry{{State: stackMerged}, {State: stackMerged}, {State: stackUpToDate}, {State: stackMerged}}
+	if got := mergedStackPrefix(entries); got != 2 {
+		t.Errorf("mergedStackPrefix() = %d, want 2", got)
+	}
+	if got := mergedStackPrefix(nil); got != 0 {
+		t.Errorf("mergedStackPrefix(nil) = %d, want 0", got)
+	}
+}
+
+func TestStackQuery(t *testing.T) {
+	s := &stack{Project: "outcomes", Branch: "main", Entries: []stackEntry{
+		{Commit: shaA, ChangeID: "Iaaa"},
+		{Commit: shaB},
+		{Commit: shaA, ChangeID: "Ibbb"},
+	}}
+	query, count := stackQuery(s)
+	if want := "project:outcomes branch:main (change:Iaaa OR change:Ibbb)"; query != want || count != 2 {
+		t.Errorf("stackQuery() = %q, %d, want %q, 2", query, count, want)
+	}
+
+	s.Project = ""
+	if query, _ := stackQuery(s); query != "branch:main (change:Iaaa OR change:Ibbb)" {
+		t.Errorf("stackQuery() without a project = %q", query)
+	}
+
+	if _, count := stackQuery(&stack{Branch: "main", Entries: []stackEntry{{Commit: shaB}}}); count != 0 {
+		t.Errorf("stackQuery() without Change-Ids asks for %d changes", count)
+	}
+}
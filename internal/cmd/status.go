@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the review state of the checked-out change",
+	Long: `Show the Gerrit side of the change checked out in the current repository,
+like 'git status' for the review: whether HEAD is the latest patchset on the
+server, the label votes, unresolved comments, submit requirements, and
+whether the change needs a rebase onto its branch.
+
+The change is found as for 'gerry details .': from the change ref HEAD was
+fetched from, or else the Change-Id footer of the HEAD commit. Requires REST
+API access. Submit requirements are shown on Gerrit 3.5 and later; older
+servers only report whether the change is submittable.
+
+Examples:
+  gerry status
+  gerry status --output json`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+// checkoutStatus is the review state of the checked-out change, as --output
+// json or yaml writes it.
+type checkoutStatus struct {
+	Change  int    `json:"change"`
+	Subject string `json:"subject"`
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+
+	// LocalPatchset is the patchset HEAD is, or 0 when HEAD is a commit
+	// that was never uploaded.
+	LocalPatchset  int    `json:"local_patchset"`
+	LatestPatchset int    `json:"latest_patchset"`
+	Head           string `json:"head"`
+
+	Labels             []labelScore                         `json:"labels"`
+	Unresolved         int                                  `json:"unresolved_comments"`
+	SubmitRequirements []gerrit.SubmitRequirementResultInfo `json:"submit_requirements,omitempty"`
+	Submittable        bool                                 `json:"submittable"`
+	Blockers           []string                             `json:"blockers,omitempty"`
+
+	// NeedsRebase and Mergeable are nil when the server didn't say.
+	NeedsRebase *bool `json:"needs_rebase,omitempty"`
+	Mergeable   *bool `json:"mergeable,omitempty"`
+}
+
+// labelScore is a label's effective score on a change.
+type labelScore struct {
+	Label string `json:"label"`
+	Score int    `json:"score"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	changeID, err := currentChangeID()
+	if err != nil {
+		return err
+	}
+	head, err := gitOutput("", "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+
+	utils.Debugf("Fetching status of change %s", changeID)
+	client := gerrit.NewRESTClient(cfg)
+	spinner := utils.NewSpinner("Loading change...")
+	change, err := client.GetChangeWithOptions(changeID, "ALL_REVISIONS", "SUBMITTABLE", "SUBMIT_REQUIREMENTS")
+	if err != nil {
+		// Servers before 3.5 reject the SUBMIT_REQUIREMENTS option.
+		utils.Debugf("Retrying without submit requirements: %v", err)
+		change, err = client.GetChangeWithOptions(changeID, "ALL_REVISIONS", "SUBMITTABLE")
+	}
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to get change %s: %w", changeID, err)
+	}
+
+	branchTip := ""
+	if branch, err := client.GetBranch(change.Project, change.Branch); err != nil {
+		utils.Debugf("Failed to get branch %s: %v", change.Branch, err)
+	} else {
+		branchTip = branch.Revision
+	}
+	spinner.Stop()
+
+	status := buildCheckoutStatus(change, head, branchTip)
+	status.URL = changeURL(cfg, *change)
+	if structured {
+		return renderOutput(os.Stdout, status)
+	}
+	displayCheckoutStatus(status)
+	return nil
+}
+
+// buildCheckoutStatus compares the checked-out commit head with the change
+// on the server. branchTip is the commit the change's branch points to, or
+// empty when unknown.
+func buildCheckoutStatus(change *gerrit.Change, head, branchTip string) checkoutStatus {
+	status := checkoutStatus{
+		Change:         change.ChangeNumber(),
+		Subject:        change.Subject,
+		Project:        change.Project,
+		Branch:         change.Branch,
+		Status:         change.Status,
+		Head:           head,
+		LatestPatchset: change.CurrentPatchSetNumber(),
+		Labels:         []labelScore{},
+		Unresolved:     change.UnresolvedCommentCount,
+		Submittable:    change.Submittable,
+		Mergeable:      change.Mergeable,
+	}
+	if rev, ok := change.Revisions[head]; ok {
+		status.LocalPatchset = rev.Number
+	}
+
+	names := make([]string, 0, len(change.Labels))
+	for name := range change.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if score, present := getLabelScore(*change, name); present {
+			status.Labels = append(status.Labels, labelScore{Label: name, Score: score})
+		}
+	}
+
+	for _, req := range change.SubmitRequirements {
+		if req.Status != "NOT_APPLICABLE" {
+			status.SubmitRequirements = append(status.SubmitRequirements, req)
+		}
+	}
+	if change.Status == "NEW" && !change.Submittable {
+		status.Blockers = submitBlockers(*change)
+	}
+
+	if needed, known := needsRebase(change, branchTip); known {
+		status.NeedsRebase = &needed
+	}
+	return status
+}
+
+// needsRebase reports whether the change's current patchset is based on an
+// older commit than branchTip. known is false when either side is unknown.
+func needsRebase(change *gerrit.Change, branchTip string) (needed, known bool) {
+	if branchTip == "" || change.Status != "NEW" {
+		return false, false
+	}
+	rev, ok := change.Revisions[change.CurrentRevision]
+	if !ok || len(rev.Commit.Parents) == 0 {
+		return false, false
+	}
+	return rev.Commit.Parents[0].Commit != branchTip, true
+}
+
+func displayCheckoutStatus(s checkoutStatus) {
+	fmt.Printf("%s %s  %s\n", utils.BoldCyan("Change"), utils.BoldWhite(fmt.Sprintf("%d", s.Change)), s.Subject)
+	fmt.Printf("%s\n\n", utils.Gray(fmt.Sprintf("%s · %s · %s", s.Project, s.Branch, s.URL)))
+
+	fmt.Printf("%s %s\n", utils.BoldCyan("Status:"), utils.FormatChangeStatus(s.Status))
+	fmt.Printf("%s %s\n", utils.BoldCyan("Patch set:"), formatLocalPatchset(s))
+
+	var votes []string
+	for _, l := range s.Labels {
+		votes = append(votes, fmt.Sprintf("%s %s", l.Label, utils.FormatScore(l.Label, l.Score)))
+	}
+	if len(votes) == 0 {
+		votes = append(votes, utils.Gray("none"))
+	}
+	fmt.Printf("%s %s\n", utils.BoldCyan("Labels:"), strings.Join(votes, ", "))
+
+	unresolved := utils.Green("none")
+	if s.Unresolved > 0 {
+		unresolved = utils.BoldYellow(fmt.Sprintf("%d (see 'gerry comments .')", s.Unresolved))
+	}
+	fmt.Printf("%s %s\n", utils.BoldCyan("Unresolved:"), unresolved)
+	fmt.Printf("%s %s\n", utils.BoldCyan("Rebase:"), formatRebaseStatus(s))
+
+	if s.Status != "NEW" {
+		return
+	}
+	submittable := utils.Green("yes")
+	if !s.Submittable {
+		submittable = utils.Red("no")
+		if len(s.Blockers) > 0 {
+			submittable += utils.Gray(" (" + strings.Join(s.Blockers, ", ") + ")")
+		}
+	}
+	fmt.Printf("%s %s\n", utils.BoldCyan("Submittable:"), submittable)
+	if len(s.SubmitRequirements) > 0 {
+		fmt.Printf("%s\n", utils.BoldCyan("Submit requirements:"))
+		for _, req := range s.SubmitRequirements {
+			fmt.Printf("  %s %s %s\n", requirementIndicator(req.Status), req.Name, utils.Gray(strings.ToLower(req.Status)))
+		}
+	}
+}
+
+// formatLocalPatchset describes how HEAD relates to the latest patchset.
+func formatLocalPatchset(s checkoutStatus) string {
+	switch {
+	case s.LocalPatchset == 0:
+		return utils.Yellow(fmt.Sprintf("HEAD is not an uploaded patch set (latest is %d); push to upload it", s.LatestPatchset))
+	case s.LocalPatchset == s.LatestPatchset:
+		return utils.Green(fmt.Sprintf("%d, up to date", s.LocalPatchset))
+	default:
+		return utils.Yellow(fmt.Sprintf("%d, latest is %d (run 'gerry fetch %d' to update)", s.LocalPatchset, s.LatestPatchset, s.Change))
+	}
+}
+
+func formatRebaseStatus(s checkoutStatus) string {
+	switch {
+	case s.NeedsRebase == nil:
+		return utils.Gray("unknown")
+	case !*s.NeedsRebase:
+		return utils.Green("not needed")
+	}
+	if s.Mergeable != nil && !*s.Mergeable {
+		return utils.Red(fmt.Sprintf("needed, %s has moved on and there are merge conflicts", s.Branch))
+	}
+	return utils.Yellow(fmt.Sprintf("needed, %s has moved on (run 'gerry rebase %d')", s.Branch, s.Change))
+}
+
+// requirementIndicator marks a submit requirement's status.
+func requirementIndicator(status string) string {
+	switch status {
+	case "SATISFIED", "OVERRIDDEN", "FORCED":
+		return utils.Green("✓")
+	case "UNSATISFIED":
+		return utils.Red("✗")
+	default:
+		return utils.Yellow("!")
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func statusTestChange() *gerrit.Change {
+	return &gerrit.Change{
+		Number:          12345,
+		Status:          "NEW",
+		CurrentRevision: "ccc",
+		Revisions: map[string]gerrit.RevisionInfo{
+			"aaa": {Number: 1, Commit: gerrit.CommitInfo{Parents: []gerrit.CommitInfo{{Commit: "base1"}}}},
+			"ccc": {Number: 2, Commit: gerrit.CommitInfo{Parents: []gerrit.CommitInfo{{Commit: "base2"}}}},
+		},
+		Labels: map[string]interface{}{
+			"Verified":    labelVotes(1),
+			"Code-Review": labelVotes(-1),
+		},
+		SubmitRequirements: []gerrit.SubmitRequirementResultInfo{
+			{Name: "Code-Review", Status: "UNSATISFIED"},
+			{Name: "No-Unresolved-Comments", Status: "NOT_APPLICABLE"},
+		},
+	}
+}
+
+func TestNeedsRebase(t *testing.T) {
+	tests := []struct {
+		name       string
+		branchTip  string
+		status     string
+		wantNeeded bool
+		wantKnown  bool
+	}{
+		{"based on tip", "base2", "NEW", false, true},
+		{"branch moved on", "base3", "NEW", true, true},
+		{"tip unknown", "", "NEW", false, false},
+		{"merged", "base3", "MERGED", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := statusTestChange()
+			change.Status = tt.status
+			needed, known := needsRebase(change, tt.branchTip)
+			if needed != tt.wantNeeded || known != tt.wantKnown {
+				t.Errorf("needsRebase() = %v, %v, want %v, %v", needed, known, tt.wantNeeded, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestBuildCheckoutStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		head      string
+		wantLocal int
+	}{
+		{"latest patchset", "ccc", 2},
+		{"older patchset", "aaa", 1},
+		{"not uploaded", "ddd", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := buildCheckoutStatus(statusTestChange(), tt.head, "base3")
+			if s.LocalPatchset != tt.wantLocal || s.LatestPatchset != 2 {
+				t.Errorf("patchsets = %d of %d, want %d of 2", s.LocalPatchset, s.LatestPatchset, tt.wantLocal)
+			}
+			if len(s.Labels) != 2 || s.Labels[0] != (labelScore{"Code-Review", -1}) || s.Labels[1] != (labelScore{"Verified", 1}) {
+				t.Errorf("Labels = %v", s.Labels)
+			}
+			if len(s.SubmitRequirements) != 1 || s.SubmitRequirements[0].Name != "Code-Review" {
+				t.Errorf("SubmitRequirements = %v, want only Code-Review", s.SubmitRequirements)
+			}
+			if s.NeedsRebase == nil || !*s.NeedsRebase {
+				t.Errorf("NeedsRebase = %v, want true", s.NeedsRebase)
+			}
+			if len(s.Blockers) == 0 {
+				t.Error("Blockers is empty for an unsubmittable change")
+			}
+		})
+	}
+}
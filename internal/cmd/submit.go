@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const submitTopicLimit = 100
+
+var (
+	submitTopic  string
+	submitDryRun bool
+	submitYes    bool
+)
+
+var submitCmd = &cobra.Command{
+	Use:   "submit [change-id]",
+	Short: "Submit a change or a whole topic",
+	Long: `Submit a change, or with --topic every open change in a topic.
+
+Before submitting, gerry asks Gerrit which changes would be merged together
+(ancestors and, for topics, the other members across projects), checks that
+every one of them is submittable, and shows the plan. If any change is blocked
+nothing is submitted.
+
+Topics are only submitted when the server merges them atomically
+(change.submitWholeTopic); otherwise gerry refuses rather than merging the
+topic one change at a time.
+
+Examples:
+  gerry submit 12345
+  gerry submit --topic my-feature --dry-run
+  gerry submit --topic my-feature --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSubmit,
+}
+
+func init() {
+	submitCmd.Flags().StringVarP(&submitTopic, "topic", "t", "", "Submit every open change in this topic")
+	submitCmd.Flags().BoolVar(&submitDryRun, "dry-run", false, "Show the submit plan without submitting")
+	submitCmd.Flags().BoolVarP(&submitYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+func runSubmit(cmd *cobra.Command, args []string) error {
+	if (len(args) == 0) == (submitTopic == "") {
+		return fmt.Errorf("provide either a change-id or --topic")
+	}
+	if len(args) == 1 {
+		if err := utils.ValidateChangeID(args[0]); err != nil {
+			return fmt.Errorf("invalid change ID: %w", err)
+		}
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	query := "change:" + strings.Join(args, "")
+	if submitTopic != "" {
+		query = fmt.Sprintf("topic:\"%s\" status:open", submitTopic)
+	}
+	utils.Debugf("Query: %s", query)
+	requested, err := client.ListChangesWithOptions(url.QueryEscape(query), submitTopicLimit, "SUBMITTABLE")
+	if err != nil {
+		return fmt.Errorf("failed to list changes: %w", err)
+	}
+	if len(requested) == 0 {
+		if submitTopic != "" {
+			return fmt.Errorf("no open changes in topic %q", submitTopic)
+		}
+		return fmt.Errorf("change %s not found", args[0])
+	}
+
+	anchor := requested[0].ChangeNumberStr()
+	together, err := client.GetSubmittedTogether(anchor, "SUBMITTABLE", "DETAILED_LABELS", "CURRENT_REVISION")
+	if err != nil {
+		return fmt.Errorf("failed to compute submit plan: %w", err)
+	}
+
+	// An empty result means the change would be submitted on its own.
+	plan := together.Changes
+	if len(plan) == 0 {
+		plan = requested
+	}
+	sortSubmitPlan(plan)
+	displaySubmitPlan(plan)
+
+	if together.NonVisibleChanges > 0 {
+		return fmt.Errorf("%d change(s) you cannot see would be submitted together; nothing submitted", together.NonVisibleChanges)
+	}
+	if missing := missingFromPlan(requested, plan); len(missing) > 0 {
+		return fmt.Errorf("server would not submit change(s) %s together with %s (is change.submitWholeTopic enabled?); nothing submitted",
+			strings.Join(missing, ", "), anchor)
+	}
+	blocked := 0
+	for _, change := range plan {
+		if len(submitBlockers(change)) > 0 {
+			blocked++
+		}
+	}
+	if blocked > 0 {
+		return fmt.Errorf("%d change(s) blocked; nothing submitted", blocked)
+	}
+
+	if submitDryRun {
+		fmt.Printf("\nDry run: %d change(s) would be submitted\n", len(plan))
+		return nil
+	}
+
+	if !submitYes {
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("Submit %d change(s)?", len(plan)),
+		}
+		if err := askOne(prompt, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	if _, err := client.SubmitChange(anchor); err != nil {
+		return fmt.Errorf("failed to submit: %w", err)
+	}
+
+	fmt.Printf("%s Submitted %d change(s)\n", utils.Green("✓"), len(plan))
+	return nil
+}
+
+// submitBlockers explains why a change cannot be submitted; it is empty for
+// submittable changes.
+func submitBlockers(change gerrit.Change) []string {
+	if change.Submittable {
+		return nil
+	}
+
+	var reasons []string
+	if change.Status != "" && change.Status != "NEW" {
+		reasons = append(reasons, strings.ToLower(change.Status))
+	}
+	if change.WorkInProgress {
+		reasons = append(reasons, "work in progress")
+	}
+	if known, mergeable := change.MergeableState(); known && !mergeable {
+		reasons = append(reasons, "merge conflict")
+	}
+
+	var labels []string
+	for name, data := range change.Labels {
+		if labelData, ok := data.(map[string]interface{}); ok {
+			if blocking, _ := labelData["blocking"].(bool); blocking {
+				labels = append(labels, name+" blocked")
+			}
+		}
+	}
+	sort.Strings(labels)
+	reasons = append(reasons, labels...)
+
+	if len(reasons) == 0 {
+		reasons = append(reasons, "submit requirements not met")
+	}
+	return reasons
+}
+
+// missingFromPlan returns the numbers of requested changes that the server
+// would not submit as part of plan.
+func missingFromPlan(requested, plan []gerrit.Change) []string {
+	inPlan := make(map[int]bool, len(plan))
+	for _, change := range plan {
+		inPlan[change.ChangeNumber()] = true
+	}
+
+	var missing []string
+	for _, change := range requested {
+		if !inPlan[change.ChangeNumber()] {
+			missing = append(missing, change.ChangeNumberStr())
+		}
+	}
+	return missing
+}
+
+func sortSubmitPlan(plan []gerrit.Change) {
+	sort.SliceStable(plan, func(i, j int) bool {
+		if plan[i].Project != plan[j].Project {
+			return plan[i].Project < plan[j].Project
+		}
+		if plan[i].Branch != plan[j].Branch {
+			return plan[i].Branch < plan[j].Branch
+		}
+		return plan[i].ChangeNumber() < plan[j].ChangeNumber()
+	})
+}
+
+func displaySubmitPlan(plan []gerrit.Change) {
+	headers := []string{"Change", "Project", "Branch", "Subject", "Status"}
+	var rows [][]string
+	for _, change := range plan {
+		status := utils.Green("ready")
+		if reasons := submitBlockers(change); len(reasons) > 0 {
+			status = utils.Red(strings.Join(reasons, ", "))
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(change.ChangeNumberStr()),
+			change.Project,
+			change.Branch,
+			utils.TruncateString(change.Subject, 50),
+			status,
+		})
+	}
+
+	fmt.Printf("%s\n\n", utils.BoldWhite(fmt.Sprintf("Submit plan (%d change(s)):", len(plan))))
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestSubmitBlockers(t *testing.T) {
+	conflict := false
+	tests := []struct {
+		name   string
+		change gerrit.Change
+		want   []string
+	}{
+		{
+			name:   "submittable",
+			change: gerrit.Change{Status: "NEW", Submittable: true, WorkInProgress: true},
+			want:   nil,
+		},
+		{
+			name:   "no specific reason",
+			change: gerrit.Change{Status: "NEW"},
+			want:   []string{"submit requirements not met"},
+		},
+		{
+			name: "wip, conflict and blocking labels",
+			change: gerrit.Change{
+				Status:         "NEW",
+				WorkInProgress: true,
+				Mergeable:      &conflict,
+				Labels: map[string]interface{}{
+					"Verified":    map[string]interface{}{"blocking": true},
+					"Code-Review": map[string]interface{}{"blocking": true},
+					"QA-Review":   map[string]interface{}{},
+				},
+			},
+			want: []string{"work in progress", "merge conflict", "Code-Review blocked", "Verified blocked"},
+		},
+		{
+			name:   "abandoned",
+			change: gerrit.Change{Status: "ABANDONED"},
+			want:   []string{"abandoned"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := submitBlockers(tt.change); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("submitBlockers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingFromPlan(t *testing.T) {
+	requested := []gerrit.Change{{Number: 1}, {Number: 2}, {Number: 3}}
+	plan := []gerrit.Change{{Number: 1}, {Number: 3}, {Number: 4}}
+
+	got := missingFromPlan(requested, plan)
+	if want := []string{"2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("missingFromPlan() = %v, want %v", got, want)
+	}
+	if got := missingFromPlan(requested, requested); got != nil {
+		t.Errorf("missingFromPlan(same) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// reviewLoadQueryLimit caps how many changes are counted per load query.
+const reviewLoadQueryLimit = 100
+
+var (
+	suggestLimit    int
+	suggestBalanced bool
+	suggestWindow   string
+)
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest <change-id>",
+	Short: "Suggest reviewers for a change",
+	Long: `Suggest reviewers for a change. Code owners of the touched files (when the
+code-owners plugin is installed) are preferred, followed by Gerrit's own
+recommendations based on past reviews. The change owner and existing
+reviewers are excluded.
+
+With --balanced, candidates are additionally ranked by their current review
+load — open changes they are a reviewer on, plus half the changes they were
+asked to review within --window — so review work spreads across the team
+instead of always landing on the same people. Code owners still come first.
+Candidates whose load cannot be counted are listed after the others, with a
+warning.
+
+Examples:
+  gerry suggest 12345
+  gerry suggest 12345 --balanced
+  gerry suggest 12345 --balanced --window 30d -n 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSuggest,
+}
+
+func init() {
+	suggestCmd.Flags().IntVarP(&suggestLimit, "limit", "n", 5, "Number of reviewers to suggest")
+	suggestCmd.Flags().BoolVar(&suggestBalanced, "balanced", false, "Prefer reviewers with a lighter review load")
+	suggestCmd.Flags().StringVar(&suggestWindow, "window", "14d", "Window for recent review volume with --balanced (e.g. 7d, 2w)")
+}
+
+// reviewerCandidate is a potential reviewer with the signals used to rank it.
+type reviewerCandidate struct {
+	Account       gerrit.Account
+	OwnedFiles    int // files of the change this account is a code owner of
+	SuggestRank   int // 1-based position in Gerrit's suggestions, 0 if absent
+	OpenReviews   int
+	RecentReviews int
+	LoadUnknown   bool // the review load could not be counted
+}
+
+// load weighs open reviews fully and recent review volume at half.
+func (c *reviewerCandidate) load() float64 {
+	return float64(c.OpenReviews) + float64(c.RecentReviews)/2
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	window, err := utils.ParseDuration(suggestWindow)
+	if err != nil {
+		return err
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	spinner := utils.NewSpinner("Finding reviewers...")
+	change, err := client.GetChange(changeID)
+	if err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to get change: %w", err)
+	}
+
+	var owners map[string][]gerrit.Account
+	if status, err := client.GetCodeOwnersStatus(changeID); err != nil {
+		utils.Debugf("Code owners unavailable: %v", err)
+	} else {
+		var paths []string
+		for _, f := range flattenOwnerStatuses(status.FileCodeOwnerStatuses) {
+			paths = append(paths, f.Path)
+		}
+		owners = suggestCodeOwners(client, changeID, paths, ownerSuggestLimit)
+	}
+
+	suggestions, err := client.SuggestReviewers(changeID, "", suggestLimit*3)
+	spinner.Stop()
+	if err != nil {
+		utils.Warnf("Reviewer suggestions unavailable: %v", err)
+	}
+
+	candidates := collectReviewerCandidates(*change, owners, suggestions)
+	if len(candidates) == 0 {
+		fmt.Println("No reviewer suggestions for this change.")
+		return nil
+	}
+
+	if suggestBalanced {
+		days := int(window.Hours() / 24)
+		if days < 1 {
+			days = 1
+		}
+		progress := utils.NewProgressBar("Checking review load", len(candidates))
+		var unknown []string
+		var lastErr error
+		for i, c := range candidates {
+			id := accountQueryID(c.Account)
+			var openErr, recentErr error
+			c.OpenReviews, openErr = countChanges(client, fmt.Sprintf("reviewer:%s -owner:%s status:open", id, id))
+			c.RecentReviews, recentErr = countChanges(client, fmt.Sprintf("reviewer:%s -owner:%s -age:%dd", id, id, days))
+			if openErr != nil || recentErr != nil {
+				c.LoadUnknown = true
+				unknown = append(unknown, c.Account.DisplayName())
+				if lastErr = openErr; lastErr == nil {
+					lastErr = recentErr
+				}
+			}
+			progress.Set(i + 1)
+		}
+		progress.Stop()
+		if len(unknown) > 0 {
+			utils.Warnf("Review load unknown for %s (%v); listing them last", strings.Join(unknown, ", "), lastErr)
+		}
+	}
+
+	rankReviewerCandidates(candidates, suggestBalanced)
+	if len(candidates) > suggestLimit {
+		candidates = candidates[:suggestLimit]
+	}
+
+	headers := []string{"#", "Reviewer", "Why"}
+	if suggestBalanced {
+		headers = append(headers, "Open", "Recent")
+	}
+	var rows [][]string
+	for i, c := range candidates {
+		why := utils.Gray("suggested by Gerrit")
+		if c.OwnedFiles > 0 {
+			why = utils.Green(fmt.Sprintf("code owner of %d file(s)", c.OwnedFiles))
+		}
+		row := []string{strconv.Itoa(i + 1), utils.BoldCyan(c.Account.DisplayName()), why}
+		if suggestBalanced {
+			if c.LoadUnknown {
+				row = append(row, "?", "?")
+			} else {
+				row = append(row, formatReviewCount(c.OpenReviews), formatReviewCount(c.RecentReviews))
+			}
+		}
+		rows = append(rows, row)
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+
+	if top := candidates[0].Account; top.Username != "" || top.Email != "" {
+		name := top.Username
+		if name == "" {
+			name = top.Email
+		}
+		fmt.Printf("\nAdd with: gerry share %s -r %s\n", changeID, name)
+	}
+	return nil
+}
+
+// collectReviewerCandidates merges code owners and Gerrit suggestions into
+// one candidate per account, skipping the owner and existing reviewers.
+func collectReviewerCandidates(change gerrit.Change, owners map[string][]gerrit.Account, suggestions []gerrit.SuggestedReviewerInfo) []*reviewerCandidate {
+	excluded := []gerrit.Account{change.Owner}
+	for _, accounts := range change.Reviewers {
+		excluded = append(excluded, accounts...)
+	}
+	isExcluded := func(a gerrit.Account) bool {
+		for _, e := range excluded {
+			if isSameAccount(a, e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var candidates []*reviewerCandidate
+	find := func(a gerrit.Account) *reviewerCandidate {
+		for _, c := range candidates {
+			if isSameAccount(c.Account, a) {
+				return c
+			}
+		}
+		c := &reviewerCandidate{Account: a}
+		candidates = append(candidates, c)
+		return c
+	}
+
+	// Iterate paths in a stable order so ties rank deterministically.
+	paths := make([]string, 0, len(owners))
+	for path := range owners {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, a := range owners[path] {
+			if !isExcluded(a) {
+				find(a).OwnedFiles++
+			}
+		}
+	}
+
+	rank := 0
+	for _, s := range suggestions {
+		if s.Account == nil || isExcluded(*s.Account) {
+			continue
+		}
+		rank++
+		if c := find(*s.Account); c.SuggestRank == 0 {
+			c.SuggestRank = rank
+		}
+	}
+	return candidates
+}
+
+// rankReviewerCandidates orders code owners first, then (when balanced) by
+// lightest load with unknown loads last, then by files owned and Gerrit's
+// own ranking.
+func rankReviewerCandidates(candidates []*reviewerCandidate, balanced bool) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.OwnedFiles > 0) != (b.OwnedFiles > 0) {
+			return a.OwnedFiles > 0
+		}
+		if balanced && a.LoadUnknown != b.LoadUnknown {
+			return !a.LoadUnknown
+		}
+		if balanced && a.load() != b.load() {
+			return a.load() < b.load()
+		}
+		if a.OwnedFiles != b.OwnedFiles {
+			return a.OwnedFiles > b.OwnedFiles
+		}
+		if (a.SuggestRank == 0) != (b.SuggestRank == 0) {
+			return a.SuggestRank != 0
+		}
+		return a.SuggestRank < b.SuggestRank
+	})
+}
+
+// accountQueryID returns the most precise identifier for search operators.
+func accountQueryID(a gerrit.Account) string {
+	switch {
+	case a.AccountID != 0:
+		return strconv.Itoa(a.AccountID)
+	case a.Username != "":
+		return a.Username
+	default:
+		return a.Email
+	}
+}
+
+// countChanges returns the number of changes matching query, capped at
+// reviewLoadQueryLimit.
+func countChanges(client *gerrit.RESTClient, query string) (int, error) {
+	utils.Debugf("Query: %s", query)
+	changes, err := client.ListChanges(url.QueryEscape(query), reviewLoadQueryLimit)
+	if err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+func formatReviewCount(n int) string {
+	if n >= reviewLoadQueryLimit {
+		return fmt.Sprintf("%d+", reviewLoadQueryLimit)
+	}
+	return strconv.Itoa(n)
+}
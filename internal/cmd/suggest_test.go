@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestSuggestReviewerRanking(t *testing.T) {
+	owner := gerrit.Account{AccountID: 1, Username: "owner"}
+	existing := gerrit.Account{AccountID: 2, Username: "existing"}
+	alice := gerrit.Account{AccountID: 3, Username: "alice"}
+	bob := gerrit.Account{AccountID: 4, Username: "bob"}
+	carol := gerrit.Account{AccountID: 5, Username: "carol"}
+	dave := gerrit.Account{AccountID: 6, Username: "dave"}
+
+	change := gerrit.Change{
+		Owner:     owner,
+		Reviewers: map[string][]gerrit.Account{"REVIEWER": {existing}},
+	}
+	owners := map[string][]gerrit.Account{
+		"a.go": {alice, bob, owner},
+		"b.go": {alice},
+	}
+	suggestions := []gerrit.SuggestedReviewerInfo{
+		{Account: &existing},
+		{Account: &carol},
+		{Group: &gerrit.GroupBaseInfo{Name: "team"}},
+		{Account: &dave},
+		{Account: &bob},
+	}
+
+	candidates := collectReviewerCandidates(change, owners, suggestions)
+	names := func() []string {
+		var out []string
+		for _, c := range candidates {
+			out = append(out, c.Account.Username)
+		}
+		return out
+	}
+
+	rankReviewerCandidates(candidates, false)
+	if got, want := names(), []string{"alice", "bob", "carol", "dave"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unbalanced ranking = %v, want %v", got, want)
+	}
+
+	// alice is overloaded; dave is idle. Owners still come first.
+	for _, c := range candidates {
+		switch c.Account.Username {
+		case "alice":
+			c.OpenReviews, c.RecentReviews = 12, 20
+		case "bob":
+			c.OpenReviews = 2
+		case "carol":
+			c.OpenReviews = 5
+		}
+	}
+	rankReviewerCandidates(candidates, true)
+	if got, want := names(), []string{"bob", "alice", "dave", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("balanced ranking = %v, want %v", got, want)
+	}
+
+	// dave's load could not be counted, so dave no longer looks idle.
+	for _, c := range candidates {
+		if c.Account.Username == "dave" {
+			c.LoadUnknown = true
+		}
+	}
+	rankReviewerCandidates(candidates, true)
+	if got, want := names(), []string{"bob", "alice", "carol", "dave"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("balanced ranking with unknown load = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncDryRun bool
+	syncPrune  bool
+	syncForce  bool
+)
+
+// syncBranchPrefix namespaces the local branches gerry sync manages.
+const syncBranchPrefix = "review/"
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [.]",
+	Short: "Keep a local review/<number> branch for each of your open changes",
+	Long: `Fetch the current patchset of every open change you own into a local branch
+named review/<number>, in the checkout that holds the change's project. Branches
+are created as needed and moved when a new patchset is uploaded; branches that
+are already at the current patchset are left alone.
+
+Checkouts are the workspace manifest (see 'gerry workspace') plus the current
+checkout. Changes in projects with no local checkout are skipped.
+
+A branch that is checked out is never moved; switch away from it and sync
+again. With --prune, review/ branches whose change has been merged or abandoned
+are deleted.
+
+'gerry sync .' instead updates the current checkout (or worktree) to the latest
+patchset of the change checked out in it (see 'gerry details'), for when a new
+patchset lands mid-review. A checked-out branch is moved with 'git reset
+--keep', a detached HEAD is checked out anew; local modifications are kept
+unless they conflict. If HEAD is not an uploaded patchset, it holds local
+commits that syncing would leave behind, and --force is needed.
+
+Examples:
+  gerry sync
+  gerry sync --dry-run
+  gerry sync --prune
+  gerry sync .`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSync,
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would change without fetching or moving branches")
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Delete review/ branches of merged or abandoned changes")
+	syncCmd.Flags().BoolVar(&syncForce, "force", false, "With '.', update even if HEAD has commits that were never uploaded")
+}
+
+// syncResult is the outcome for one change or pruned branch.
+type syncResult struct {
+	Number   int
+	Project  string
+	Branch   string
+	Patchset int
+	Result   string
+	Failed   bool
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		if args[0] != currentChangeArg {
+			return fmt.Errorf("unexpected argument %q: sync takes no arguments, or '.' to update the current checkout", args[0])
+		}
+		if syncPrune {
+			return fmt.Errorf("--prune cannot be combined with '.'")
+		}
+		return runSyncCurrent()
+	}
+	if syncForce {
+		return fmt.Errorf("--force only applies to 'gerry sync .'")
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	root, err := gitOutput(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		root = ""
+	}
+	checkouts := syncCheckouts(cfg, filepath.FromSlash(root))
+	if len(checkouts) == 0 {
+		return fmt.Errorf("no local checkouts to sync into; run inside one or add them with 'gerry workspace add <path>'")
+	}
+	projects := make([]config.WorkspaceRepo, 0, len(checkouts))
+	for project, dir := range checkouts {
+		projects = append(projects, config.WorkspaceRepo{Path: dir, Project: project})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Project < projects[j].Project })
+
+	query := fmt.Sprintf("owner:%s status:open %s", cfg.User, workspaceQuery(projects))
+	utils.Debugf("Query: %s", query)
+	spinner := utils.NewSpinner("Fetching your open changes...")
+	changes, err := client.ListChanges(url.QueryEscape(query), 500)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list your changes: %w", err)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ChangeNumber() < changes[j].ChangeNumber() })
+
+	var results []syncResult
+	open := make(map[string]map[int]bool)
+	for _, change := range changes {
+		dir, ok := checkouts[change.Project]
+		if !ok {
+			continue
+		}
+		if open[dir] == nil {
+			open[dir] = make(map[int]bool)
+		}
+		open[dir][change.ChangeNumber()] = true
+		results = append(results, syncChange(cfg, dir, change))
+	}
+
+	if syncPrune {
+		for _, repo := range projects {
+			results = append(results, pruneReviewBranches(client, repo, open[repo.Path])...)
+		}
+	}
+
+	if len(results) == 0 {
+		utils.Info("No open changes to sync")
+		return nil
+	}
+	displaySyncResults(results)
+
+	for _, r := range results {
+		if r.Failed {
+			return fmt.Errorf("some changes could not be synced")
+		}
+	}
+	return nil
+}
+
+// syncCheckouts maps each project gerry sync can write to onto the checkout
+// that holds it: the workspace manifest, plus the current checkout at root,
+// if any.
+func syncCheckouts(cfg *config.Config, root string) map[string]string {
+	checkouts := make(map[string]string)
+	for _, r := range cfg.Workspace {
+		checkouts[r.Project] = r.ExpandedPath()
+	}
+	if root == "" {
+		return checkouts
+	}
+
+	project, err := checkoutProject(cfg, root)
+	if err != nil {
+		utils.Debugf("Skipping current checkout: %v", err)
+		return checkouts
+	}
+	if _, ok := checkouts[project]; !ok {
+		checkouts[project] = root
+	}
+	return checkouts
+}
+
+// syncChange brings review/<number> in dir up to the change's current
+// patchset.
+func syncChange(cfg *config.Config, dir string, change gerrit.Change) syncResult {
+	number := change.ChangeNumber()
+	r := syncResult{
+		Number:   number,
+		Project:  change.Project,
+		Branch:   syncBranchPrefix + strconv.Itoa(number),
+		Patchset: change.CurrentPatchSetNumber(),
+	}
+	fail := func(format string, args ...interface{}) syncResult {
+		r.Result = fmt.Sprintf(format, args...)
+		r.Failed = true
+		return r
+	}
+
+	if change.CurrentRevision == "" || r.Patchset == 0 {
+		return fail("no current patchset")
+	}
+
+	existing, _ := gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+r.Branch)
+	action := syncAction(existing, change.CurrentRevision)
+	if action == "up to date" {
+		r.Result = action
+		return r
+	}
+	if head, err := gitOutput(dir, "symbolic-ref", "--short", "HEAD"); err == nil && head == r.Branch {
+		return fail("checked out; not moved")
+	}
+	if syncDryRun {
+		r.Result = "would be " + action
+		return r
+	}
+
+	projectCfg := *cfg
+	projectCfg.Project = change.Project
+	refsPath := fmt.Sprintf("refs/changes/%s/%d/%d", getChangePrefix(strconv.Itoa(number)), number, r.Patchset)
+	utils.Debugf("Fetching %s into %s in %s", refsPath, r.Branch, dir)
+	remote := buildRemoteURL(&projectCfg)
+	if _, err := gitOutputEnv(dir, gitRemoteEnv(cfg, dir, remote), "fetch", "--quiet", remote, refsPath); err != nil {
+		return fail("fetch failed: %s", gitErrorMessage(err))
+	}
+	if _, err := gitOutput(dir, "branch", "--force", r.Branch, change.CurrentRevision); err != nil {
+		return fail("branch failed: %s", gitErrorMessage(err))
+	}
+	r.Result = action
+	return r
+}
+
+// syncAction decides what sync does with a branch currently at existing
+// (empty if it doesn't exist) for a change whose current revision is
+// revision.
+func syncAction(existing, revision string) string {
+	switch existing {
+	case "":
+		return "created"
+	case revision:
+		return "up to date"
+	default:
+		return "updated"
+	}
+}
+
+// pruneReviewBranches deletes the review/ branches in repo whose change is
+// no longer open. Branches of changes in open are kept without asking Gerrit.
+func pruneReviewBranches(client *gerrit.RESTClient, repo config.WorkspaceRepo, open map[int]bool) []syncResult {
+	output, err := gitOutput(repo.Path, "for-each-ref", "--format=%(refname:short)", "refs/heads/"+syncBranchPrefix)
+	if err != nil || output == "" {
+		return nil
+	}
+	head, _ := gitOutput(repo.Path, "symbolic-ref", "--short", "HEAD")
+
+	// Every branch of a change that isn't open is looked up on its own.
+	var candidates []string
+	for _, branch := range strings.Split(output, "\n") {
+		if number, ok := reviewBranchNumber(branch); ok && !open[number] {
+			candidates = append(candidates, branch)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	bar := utils.NewProgressBar("Checking review branches in "+repo.Project, len(candidates))
+	defer bar.Stop()
+
+	var results []syncResult
+	for i, branch := range candidates {
+		bar.Set(i + 1)
+		number, _ := reviewBranchNumber(branch)
+		change, err := client.GetChange(strconv.Itoa(number))
+		if err != nil {
+			utils.Debugf("Keeping %s: %v", branch, err)
+			continue
+		}
+		if change.Status != "MERGED" && change.Status != "ABANDONED" {
+			continue
+		}
+
+		r := syncResult{Number: number, Project: repo.Project, Branch: branch}
+		status := strings.ToLower(change.Status)
+		switch {
+		case branch == head:
+			r.Result = status + "; checked out, not deleted"
+			r.Failed = true
+		case syncDryRun:
+			r.Result = status + "; would be deleted"
+		default:
+			if _, err := gitOutput(repo.Path, "branch", "-D", branch); err != nil {
+				r.Result = "delete failed: " + gitErrorMessage(err)
+				r.Failed = true
+			} else {
+				r.Result = status + "; deleted"
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// reviewBranchNumber parses the change number out of a review/<number>
+// branch name.
+func reviewBranchNumber(branch string) (int, bool) {
+	rest, ok := strings.CutPrefix(branch, syncBranchPrefix)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.Atoi(rest)
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// gitErrorMessage returns the first line git wrote to stderr, falling back to
+// the error itself.
+func gitErrorMessage(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := firstLine(strings.TrimSpace(string(exitErr.Stderr))); msg != "" {
+			return msg
+		}
+	}
+	return err.Error()
+}
+
+func displaySyncResults(results []syncResult) {
+	headers := []string{"Change", "Project", "Branch", "Patchset", "Result"}
+	var rows [][]string
+	for _, r := range results {
+		patchset := ""
+		if r.Patchset > 0 {
+			patchset = strconv.Itoa(r.Patchset)
+		}
+		result := r.Result
+		switch {
+		case r.Failed:
+			result = utils.Red(result)
+		case r.Result == "created" || r.Result == "updated":
+			result = utils.Green(result)
+		case r.Result == "up to date":
+			result = utils.Gray(result)
+		default:
+			result = utils.Yellow(result)
+		}
+		rows = append(rows, []string{
+			utils.BoldCyan(strconv.Itoa(r.Number)),
+			r.Project,
+			r.Branch,
+			patchset,
+			result,
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+}
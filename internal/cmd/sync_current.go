@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// runSyncCurrent updates the current checkout to the latest patchset of the
+// change checked out in it.
+func runSyncCurrent() error {
+	changeID, err := currentChangeID()
+	if err != nil {
+		return err
+	}
+	head, err := gitOutput("", "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	utils.Debugf("Fetching patchsets of change %s", changeID)
+	change, err := client.GetChangeWithOptions(changeID, "ALL_REVISIONS")
+	if err != nil {
+		return fmt.Errorf("failed to get change %s: %w", changeID, err)
+	}
+	number, latest := change.ChangeNumber(), change.CurrentPatchSetNumber()
+
+	local, err := currentSyncFrom(change, head, syncForce)
+	if err != nil {
+		return err
+	}
+	if local == latest {
+		fmt.Printf("%s Change %d is already at the latest patch set (%d)\n", utils.Green("✓"), number, latest)
+		return nil
+	}
+	from := "a commit that was never uploaded"
+	if local > 0 {
+		from = fmt.Sprintf("patch set %d", local)
+	}
+
+	if dirty, err := gitOutput("", "status", "--porcelain", "--untracked-files=no"); err == nil && dirty != "" {
+		utils.Warnf("You have local modifications; they are kept unless they conflict with patch set %d", latest)
+	}
+	if syncDryRun {
+		fmt.Printf("Would update change %d from %s to patch set %d\n", number, from, latest)
+		return nil
+	}
+
+	projectCfg := *cfg
+	projectCfg.Project = change.Project
+	refsPath := fmt.Sprintf("refs/changes/%s/%d/%d", getChangePrefix(strconv.Itoa(number)), number, latest)
+	fmt.Printf("Fetching patch set %s of change %s...\n", utils.BoldYellow(strconv.Itoa(latest)), utils.BoldCyan(strconv.Itoa(number)))
+	remote := buildRemoteURL(&projectCfg)
+	if _, err := gitOutputEnv("", gitRemoteEnv(cfg, "", remote), "fetch", "--quiet", remote, refsPath); err != nil {
+		return fmt.Errorf("git fetch failed: %s", gitErrorMessage(err))
+	}
+
+	// A checked-out branch (e.g. review/<number>) follows the change; a
+	// detached HEAD, as 'gerry fetch' leaves it, stays detached.
+	if branch, err := gitOutput("", "symbolic-ref", "--short", "--quiet", "HEAD"); err == nil {
+		if _, err := gitOutput("", "reset", "--keep", change.CurrentRevision); err != nil {
+			return fmt.Errorf("failed to move %s to patch set %d: %s", branch, latest, gitErrorMessage(err))
+		}
+		fmt.Printf("%s Moved %s from %s to patch set %d\n", utils.Green("✓"), branch, from, latest)
+		return nil
+	}
+	if _, err := gitOutput("", "checkout", "--quiet", "--detach", change.CurrentRevision); err != nil {
+		return fmt.Errorf("failed to check out patch set %d: %s", latest, gitErrorMessage(err))
+	}
+	fmt.Printf("%s Updated from %s to patch set %d\n", utils.Green("✓"), from, latest)
+	return nil
+}
+
+// currentSyncFrom returns the patchset of change that head is, or 0 when
+// head is a commit that was never uploaded, which is only allowed with
+// force since syncing would leave it behind.
+func currentSyncFrom(change *gerrit.Change, head string, force bool) (int, error) {
+	if rev, ok := change.Revisions[head]; ok {
+		return rev.Number, nil
+	}
+	if !force {
+		return 0, fmt.Errorf("HEAD (%.7s) is not an uploaded patch set of change %d; push your local commits or pass --force to leave them behind",
+			head, change.ChangeNumber())
+	}
+	return 0, nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestSyncAction(t *testing.T) {
+	tests := []struct {
+		existing string
+		want     string
+	}{
+		{"", "created"},
+		{"abc123", "up to date"},
+		{"def456", "updated"},
+	}
+
+	for _, tt := range tests {
+		if got := syncAction(tt.existing, "abc123"); got != tt.want {
+			t.Errorf("syncAction(%q) = %q, want %q", tt.existing, got, tt.want)
+		}
+	}
+}
+
+func TestReviewBranchNumber(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   int
+		ok     bool
+	}{
+		{"review/12345", 12345, true},
+		{"review/0", 0, false},
+		{"review/12345-fixup", 0, false},
+		{"feature/12345", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := reviewBranchNumber(tt.branch)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("reviewBranchNumber(%q) = %d, %v, want %d, %v", tt.branch, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCurrentSyncFrom(t *testing.T) {
+	change := &gerrit.Change{
+		Number:          12345,
+		CurrentRevision: "ccc",
+		Revisions: map[string]gerrit.RevisionInfo{
+			"aaa": {Number: 1},
+			"ccc": {Number: 2},
+		},
+	}
+	tests := []struct {
+		name    string
+		head    string
+		force   bool
+		want    int
+		wantErr bool
+	}{
+		{"latest", "ccc", false, 2, false},
+		{"older patchset", "aaa", false, 1, false},
+		{"local commit", "ddd", false, 0, true},
+		{"local commit forced", "ddd", true, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := currentSyncFrom(change, tt.head, tt.force)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("currentSyncFrom() = %d, %v, want %d (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSyncCheckouts(t *testing.T) {
+	cfg := &config.Config{Project: "canvas-lms"}
+
+	// The checkout's own project wins over the configured default.
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitreview"), []byte("[gerrit]\nhost=review.example.com\nproject=outcomes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := syncCheckouts(cfg, root), map[string]string{"outcomes": root}; !reflect.DeepEqual(got, want) {
+		t.Errorf("syncCheckouts() = %v, want %v", got, want)
+	}
+
+	// The default only applies when the checkout's project is unknown.
+	unknown := t.TempDir()
+	if got, want := syncCheckouts(cfg, unknown), map[string]string{"canvas-lms": unknown}; !reflect.DeepEqual(got, want) {
+		t.Errorf("syncCheckouts() without .gitreview = %v, want %v", got, want)
+	}
+
+	if got := syncCheckouts(&config.Config{}, unknown); len(got) != 0 {
+		t.Errorf("syncCheckouts() of an unknown project without a default = %v, want none", got)
+	}
+}
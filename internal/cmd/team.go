@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"net/url"
+	"os"
+	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
@@ -16,21 +18,38 @@ var (
 	teamStatus      string
 	teamAllVerified bool
 	teamFilter      string
+	teamColumns     string
+	teamSort        string
+	teamWatch       string
+	teamAll         bool
+	teamName        string
 )
 
 var teamCmd = &cobra.Command{
 	Use:   "team",
 	Short: "Show changes where you are a reviewer or CC'd",
-	Long:  `Show changes where you are either a reviewer or CC'd on.`,
+	Long: `Show changes where you are either a reviewer or CC'd on.
+
+With --team NAME, show instead every change owned by or awaiting review from
+the members of a team configured under "teams" in ~/.gerry/config.json,
+grouped by member:
+
+  {"teams": {"lx": ["alice", "bob", "carol@example.com"]}}`,
 	RunE: runTeam,
 }
 
 func init() {
 	teamCmd.Flags().BoolVar(&teamDetailed, "detailed", false, "Show detailed information")
-	teamCmd.Flags().IntVarP(&teamLimit, "limit", "n", 25, "Maximum number of changes to show")
+	teamCmd.Flags().IntVarP(&teamLimit, "limit", "n", 25, "Maximum number of changes to show (0 for all)")
+	teamCmd.Flags().BoolVar(&teamAll, "all", false, "Show every matching change, fetched page by page (same as --limit 0)")
 	teamCmd.Flags().StringVar(&teamStatus, "status", "open", "Filter by status (open, merged, abandoned)")
 	teamCmd.Flags().BoolVar(&teamAllVerified, "all-verified", false, "Include changes with all verified states (default: only Verified+1)")
 	teamCmd.Flags().StringVarP(&teamFilter, "filter", "f", "", "Additional Gerrit query filter (e.g., 'ownerin:learning-experience' or '-owner:user@example.com')")
+	teamCmd.Flags().StringVar(&teamColumns, "columns", "", "Comma-separated table columns: change, attention, subject, owner, project, branch, status, cr, qr, lr, v, unresolved, mergeable, updated")
+	teamCmd.Flags().StringVar(&teamSort, "sort", "", "Sort changes by updated, number, project, or attention (your turn first) (default: Gerrit's order)")
+	teamCmd.Flags().StringVar(&teamWatch, "watch", "", "Refresh the table every interval, e.g. --watch or --watch=60 (default 30s)")
+	teamCmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval
+	teamCmd.Flags().StringVar(&teamName, "team", "", "Show changes owned by or awaiting review from the members of this configured team")
 }
 
 func runTeam(cmd *cobra.Command, args []string) error {
@@ -42,6 +61,42 @@ func runTeam(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+	limit, err := changeLimit(cmd, teamLimit, teamAll)
+	if err != nil {
+		return err
+	}
+	if teamColumns != "" && (structured || teamDetailed) {
+		return fmt.Errorf("--columns only applies to the table view")
+	}
+	table := changeTableSpec(cfg.User, 45, teamTableDefaults...)
+	columns, err := table.ParseColumns(teamColumns)
+	if err != nil {
+		return err
+	}
+	less, err := table.SortOrder(teamSort)
+	if err != nil {
+		return err
+	}
+	var interval time.Duration
+	if teamWatch != "" {
+		if structured || teamDetailed {
+			return fmt.Errorf("--watch only applies to the table view, without --detailed")
+		}
+		if interval, err = parseWatchInterval(teamWatch); err != nil {
+			return err
+		}
+	}
+
+	var members []string
+	if teamName != "" {
+		if members, err = namedTeam(cfg, teamName); err != nil {
+			return err
+		}
+	}
 
 	verifiedFilter := ""
 	if !teamAllVerified {
@@ -49,7 +104,9 @@ func runTeam(cmd *cobra.Command, args []string) error {
 	}
 
 	var query string
-	if teamStatus == "open" {
+	if len(members) > 0 {
+		query = fmt.Sprintf("status:%s %s", teamStatus, memberQuery([]string{"owner", "reviewer"}, members))
+	} else if teamStatus == "open" {
 		query = fmt.Sprintf("(is:open -is:ignored -is:wip -status:merged%s cc:%s OR is:open -owner:%s -is:wip -is:ignored -status:merged%s reviewer:%s)",
 			verifiedFilter, cfg.User, cfg.User, verifiedFilter, cfg.User)
 	} else if teamStatus == "merged" {
@@ -64,63 +121,76 @@ func runTeam(cmd *cobra.Command, args []string) error {
 
 	utils.Debugf("Query: %s", query)
 
-	changes, err := listTeamChangesREST(cfg, query, teamLimit)
-	if err != nil {
-		utils.Warnf("REST API failed: %v", err)
-		utils.Info("Falling back to SSH...")
-		changes, err = listTeamChangesSSH(cfg, query, teamLimit)
+	// Each --watch refresh must see the server's current state, and Ctrl+C
+	// stops watching.
+	ctx := context.Background()
+	client := gerrit.NewRESTClient(cfg)
+	if interval > 0 {
+		var stop context.CancelFunc
+		ctx, stop = interruptContext(cmd)
+		defer stop()
+		client = client.WithoutCache().WithContext(ctx)
+	}
+	fetch := func() ([]gerrit.Change, error) {
+		changes, err := listChangesPaged(client, query, limit)
+		if ctx.Err() != nil {
+			// Interrupted; there is nothing to fall back for.
+			return nil, ctx.Err()
+		}
 		if err != nil {
-			return fmt.Errorf("failed to list changes: %w", err)
+			utils.Warnf("REST API failed: %v", err)
+			utils.Info("Falling back to SSH...")
+			changes, err = listChangesSSH(cfg, query, limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list changes: %w", err)
+			}
 		}
+		sortChanges(changes, less)
+		return changes, nil
+	}
+
+	emptyMessage := "No changes found where you are a reviewer or CC'd."
+	if len(members) > 0 {
+		emptyMessage = fmt.Sprintf("No changes found for team %s.", teamName)
+	}
+	if interval > 0 {
+		w := &changeTableWatcher{
+			title:    "gerry team",
+			interval: interval,
+			fetch:    fetch,
+			format: func(changes []gerrit.Change) string {
+				return formatChangeTable(table, columns, changes)
+			},
+			empty: emptyMessage,
+			out:   os.Stdout,
+			clear: utils.IsTerminal(os.Stdout),
+		}
+		return w.run(ctx)
+	}
+
+	changes, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	if structured {
+		if changes == nil {
+			changes = []gerrit.Change{}
+		}
+		return renderOutput(os.Stdout, changes)
 	}
 
 	if len(changes) == 0 {
-		fmt.Println("No changes found where you are a reviewer or CC'd.")
+		fmt.Println(emptyMessage)
 		return nil
 	}
 
-	if teamDetailed {
+	if len(members) > 0 {
+		displayMemberChanges(groupByMember(members, changes, true), teamDetailed, table, columns)
+	} else if teamDetailed {
 		displayDetailedChanges(changes)
 	} else {
-		displayTeamSimpleChanges(changes)
+		displayChangeTable(table, columns, changes)
 	}
 	return nil
 }
-
-func listTeamChangesREST(cfg *config.Config, query string, limit int) ([]gerrit.Change, error) {
-	client := gerrit.NewRESTClient(cfg)
-	encodedQuery := url.QueryEscape(query)
-	return client.ListChanges(encodedQuery, limit)
-}
-
-func listTeamChangesSSH(cfg *config.Config, query string, limit int) ([]gerrit.Change, error) {
-	client := gerrit.NewSSHClient(cfg)
-
-	output, err := client.ExecuteCommandArgs("query", "--format=JSON", "--current-patch-set", fmt.Sprintf("limit:%d", limit), query)
-	if err != nil {
-		return nil, err
-	}
-
-	return parseSSHChanges(output), nil
-}
-
-func displayTeamSimpleChanges(changes []gerrit.Change) {
-	headers := []string{"Change", "Subject", "Owner", "CR", "QR", "LR", "V", "M", "Updated"}
-	var rows [][]string
-
-	for _, change := range changes {
-		rows = append(rows, []string{
-			utils.BoldCyan(change.ChangeNumberStr()),
-			utils.TruncateString(change.Subject, 45),
-			change.Owner.DisplayName(),
-			getLabelStatus(change, "Code-Review"),
-			getLabelStatus(change, "QA-Review"),
-			getLabelStatus(change, "Lint-Review"),
-			getLabelStatus(change, "Verified"),
-			getMergeableStatus(change),
-			utils.FormatTimeAgoShort(change.UpdatedTime()),
-		})
-	}
-
-	fmt.Print(utils.FormatTable(headers, rows, 2))
-}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// namedTeam returns the members of a team configured under "teams", in
+// order and without duplicates.
+func namedTeam(cfg *config.Config, name string) ([]string, error) {
+	team, ok := cfg.Teams[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown team %q (%s)", name, teamNames(cfg.Teams))
+	}
+	seen := make(map[string]bool)
+	var members []string
+	for _, member := range team {
+		member = strings.TrimSpace(member)
+		if member == "" || seen[strings.ToLower(member)] {
+			continue
+		}
+		seen[strings.ToLower(member)] = true
+		members = append(members, member)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("team %q has no members", name)
+	}
+	return members, nil
+}
+
+func teamNames(teams map[string][]string) string {
+	if len(teams) == 0 {
+		return "none defined; add teams to ~/.gerry/config.json"
+	}
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "available: " + strings.Join(names, ", ")
+}
+
+// memberQuery ORs every operator over every member, e.g.
+// (owner:alice OR owner:bob OR reviewer:alice OR reviewer:bob).
+func memberQuery(operators []string, members []string) string {
+	var terms []string
+	for _, op := range operators {
+		for _, member := range members {
+			terms = append(terms, op+":"+queryValue(member))
+		}
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// isMember reports whether member, as written in the team, names the
+// account by email, username, or full name.
+func isMember(member string, a gerrit.Account) bool {
+	member = strings.ToLower(member)
+	for _, key := range accountKeys(a) {
+		if key == member {
+			return true
+		}
+	}
+	return false
+}
+
+// memberChanges are the changes shown under one team member.
+type memberChanges struct {
+	Member  string
+	Changes []gerrit.Change
+}
+
+// groupByMember returns, per member in team order, the changes the member
+// owns or, with reviewing set, is a reviewer on. A change appears under
+// every member it involves; members without changes are left out.
+func groupByMember(members []string, changes []gerrit.Change, reviewing bool) []memberChanges {
+	var groups []memberChanges
+	for _, member := range members {
+		group := memberChanges{Member: member}
+		for _, change := range changes {
+			if isMember(member, change.Owner) || (reviewing && isChangeReviewer(member, change)) {
+				group.Changes = append(group.Changes, change)
+			}
+		}
+		if len(group.Changes) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// isChangeReviewer reports whether member is a reviewer (not just CC'd) on
+// the change. REST only: SSH results carry no reviewer list.
+func isChangeReviewer(member string, change gerrit.Change) bool {
+	for _, a := range change.Reviewers["REVIEWER"] {
+		if isMember(member, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayMemberChanges prints one section per team member.
+func displayMemberChanges(groups []memberChanges, detailedView bool, table utils.TableSpec, columns []utils.TableColumn) {
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", utils.BoldCyan(group.Member), utils.Gray(fmt.Sprintf("(%d)", len(group.Changes))))
+		if detailedView {
+			displayDetailedChanges(group.Changes)
+		} else {
+			displayChangeTable(table, columns, group.Changes)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestNamedTeam(t *testing.T) {
+	cfg := &config.Config{Teams: map[string][]string{
+		"lx":    {"alice", " bob ", "Alice", "carol@example.com"},
+		"empty": {},
+	}}
+	got, err := namedTeam(cfg, "lx")
+	if err != nil {
+		t.Fatalf("namedTeam(lx): %v", err)
+	}
+	if want := []string{"alice", "bob", "carol@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("namedTeam(lx) = %v, want %v", got, want)
+	}
+	if _, err := namedTeam(cfg, "empty"); err == nil || !strings.Contains(err.Error(), "no members") {
+		t.Errorf("namedTeam(empty) error = %v", err)
+	}
+	if _, err := namedTeam(cfg, "infra"); err == nil || !strings.Contains(err.Error(), "available: empty, lx") {
+		t.Errorf("namedTeam(infra) error = %v", err)
+	}
+}
+
+func TestMemberQuery(t *testing.T) {
+	got := memberQuery([]string{"owner", "reviewer"}, []string{"alice", "Bob Smith"})
+	want := `(owner:alice OR owner:"Bob Smith" OR reviewer:alice OR reviewer:"Bob Smith")`
+	if got != want {
+		t.Errorf("memberQuery() = %s, want %s", got, want)
+	}
+}
+
+func TestGroupByMember(t *testing.T) {
+	alice := gerrit.Account{Username: "alice", Email: "alice@example.com"}
+	bob := gerrit.Account{Username: "bob", Name: "Bob Smith"}
+	changes := []gerrit.Change{
+		{Number: 1, Owner: alice},
+		{Number: 2, Owner: bob, Reviewers: map[string][]gerrit.Account{"REVIEWER": {alice}}},
+		{Number: 3, Owner: gerrit.Account{Username: "dave"}, Reviewers: map[string][]gerrit.Account{"CC": {bob}}},
+	}
+	members := []string{"ALICE@example.com", "bob smith", "carol"}
+
+	numbers := func(groups []memberChanges) map[string][]int {
+		got := map[string][]int{}
+		for _, g := range groups {
+			for _, c := range g.Changes {
+				got[g.Member] = append(got[g.Member], c.Number)
+			}
+		}
+		return got
+	}
+
+	owned := numbers(groupByMember(members, changes, false))
+	if want := map[string][]int{"ALICE@example.com": {1}, "bob smith": {2}}; !reflect.DeepEqual(owned, want) {
+		t.Errorf("groupByMember(owners) = %v, want %v", owned, want)
+	}
+	involved := numbers(groupByMember(members, changes, true))
+	if want := map[string][]int{"ALICE@example.com": {1, 2}, "bob smith": {2}}; !reflect.DeepEqual(involved, want) {
+		t.Errorf("groupByMember(reviewers) = %v, want %v", involved, want)
+	}
+}
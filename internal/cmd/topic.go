@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var topicLimit int
+
+var topicCmd = &cobra.Command{
+	Use:   "topic",
+	Short: "Set, clear, and list change topics",
+	Long: `Manage change topics, which group related changes, often across projects,
+so they can be reviewed and submitted together.
+
+Examples:
+  gerry topic set 12345 grading-fix
+  gerry topic clear 12345
+  gerry topic list grading-fix`,
+}
+
+var topicSetCmd = &cobra.Command{
+	Use:   "set <change-id> <topic>",
+	Short: "Set the topic of a change",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTopicSet,
+}
+
+var topicClearCmd = &cobra.Command{
+	Use:   "clear <change-id>",
+	Short: "Remove the topic of a change",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTopicClear,
+}
+
+var topicListCmd = &cobra.Command{
+	Use:   "list <topic>",
+	Short: "List the changes in a topic",
+	Long: `List every change in a topic, whatever its status, grouped by project.
+
+Uses the REST API, falling back to SSH.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTopicList,
+}
+
+func init() {
+	topicListCmd.Flags().IntVarP(&topicLimit, "limit", "n", 100, "Maximum number of changes to show")
+
+	topicCmd.AddCommand(topicSetCmd)
+	topicCmd.AddCommand(topicClearCmd)
+	topicCmd.AddCommand(topicListCmd)
+}
+
+func runTopicSet(cmd *cobra.Command, args []string) error {
+	topic := strings.TrimSpace(args[1])
+	if topic == "" {
+		return fmt.Errorf("topic cannot be empty; use 'gerry topic clear' to remove it")
+	}
+	return updateTopic(args[0], topic)
+}
+
+func runTopicClear(cmd *cobra.Command, args []string) error {
+	return updateTopic(args[0], "")
+}
+
+func updateTopic(changeID, topic string) error {
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetTopic(changeID, topic); err != nil {
+		return fmt.Errorf("failed to update topic: %w", err)
+	}
+
+	if topic == "" {
+		fmt.Printf("%s Cleared the topic of %s\n", utils.Green("✓"), changeID)
+	} else {
+		fmt.Printf("%s Set the topic of %s to %s\n", utils.Green("✓"), changeID, utils.BoldCyan(topic))
+	}
+	return nil
+}
+
+func runTopicList(cmd *cobra.Command, args []string) error {
+	topic := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	query := fmt.Sprintf("topic:%q", topic)
+	utils.Debugf("Query: %s", query)
+
+	changes, err := listChangesREST(cfg, query, topicLimit)
+	if err != nil {
+		utils.Warnf("REST API failed: %v", err)
+		utils.Info("Falling back to SSH...")
+		changes, err = listChangesSSH(cfg, query, topicLimit)
+		if err != nil {
+			return fmt.Errorf("failed to list changes: %w", err)
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("No changes in topic %s.\n", topic)
+		return nil
+	}
+
+	sortTopicChanges(changes)
+
+	headers := []string{"Change", "Project", "Branch", "Status", "Subject", "CR", "V", "Owner"}
+	var rows [][]string
+	projects := make(map[string]bool)
+	for _, change := range changes {
+		projects[change.Project] = true
+		rows = append(rows, []string{
+			utils.BoldCyan(change.ChangeNumberStr()),
+			change.Project,
+			change.Branch,
+			utils.FormatChangeStatus(change.Status),
+			utils.TruncateString(change.Subject, 50),
+			getLabelStatus(change, "Code-Review"),
+			getLabelStatus(change, "Verified"),
+			change.Owner.DisplayName(),
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+	fmt.Printf("\n%d change(s) across %d project(s) in topic %s\n", len(changes), len(projects), utils.BoldCyan(topic))
+	return nil
+}
+
+// sortTopicChanges groups a topic's changes by project and branch, oldest
+// change first within each.
+func sortTopicChanges(changes []gerrit.Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Branch != b.Branch {
+			return a.Branch < b.Branch
+		}
+		return a.ChangeNumber() < b.ChangeNumber()
+	})
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestSortTopicChanges(t *testing.T) {
+	changes := []gerrit.Change{
+		{Number: 30, Project: "web", Branch: "main"},
+		{Number: 12, Project: "api", Branch: "release"},
+		{Number: 20, Project: "api", Branch: "main"},
+		{Number: 10, Project: "api", Branch: "main"},
+	}
+	sortTopicChanges(changes)
+
+	var got []int
+	for _, c := range changes {
+		got = append(got, c.Number)
+	}
+	if want := []int{10, 20, 12, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortTopicChanges() order = %v, want %v", got, want)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -28,7 +30,14 @@ This allows you to review changes or work on new features in isolation
 without affecting your main working directory.
 
 Use --name to create a worktree for new work without a change-id.
-Otherwise, provide a change-id to fetch and review an existing change.`,
+Otherwise, provide a change-id to fetch and review an existing change, or
+leave it out to pick one of your open or incoming changes from a list.
+
+With --workspace the worktree is created in the workspace checkout that holds
+the change's project (see 'gerry workspace'), so it can be run from anywhere.
+
+With --with-parents the change is stacked on the open changes it depends on,
+as 'gerry fetch --with-parents' does.`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runTreeSetup,
 }
@@ -44,10 +53,23 @@ If a path is provided, removes the worktree at that path.`,
 	RunE: runTreeCleanup,
 }
 
+var treePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove worktrees of merged and abandoned changes",
+	Long: `Remove the change worktrees created by 'gerry tree setup' whose changes have
+been merged or abandoned. Worktrees with uncommitted changes, custom-named
+worktrees and the current worktree are kept.
+
+Use --dry-run to only report what would be removed.`,
+	Args: cobra.NoArgs,
+	RunE: runTreePrune,
+}
+
 var treesCmd = &cobra.Command{
 	Use:   "trees",
 	Short: "List all git worktrees",
-	Long:  `List all git worktrees in the current repository.`,
+	Long: `List all git worktrees in the current repository, or with --workspace in
+every checkout of the workspace manifest (see 'gerry workspace').`,
 	RunE: runTrees,
 }
 
@@ -55,7 +77,7 @@ var treeRebaseCmd = &cobra.Command{
 	Use:   "rebase [branch]",
 	Short: "Rebase current worktree",
 	Long: `Rebase the current worktree onto the specified branch or main branch.
-If no branch is specified, rebases onto the main branch.
+If no branch is specified, rebases onto the configured default_branch, or main.
 Must be run from within a worktree.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runTreeRebase,
@@ -66,6 +88,13 @@ var (
 	forceCleanup      bool
 	worktreeName      string
 	interactiveRebase bool
+	treeWorkspace     bool
+	treeWithParents   bool
+	treeRemote        string
+	treeVia           string
+	treesWorkspace    bool
+	treePruneDryRun   bool
+	treePruneForce    bool
 )
 
 func init() {
@@ -73,13 +102,37 @@ func init() {
 	treeSetupCmd.Flags().StringVarP(&worktreeName, "name", "n", "", "Custom name for worktree (for new work without change-id)")
 	treeCleanupCmd.Flags().BoolVarP(&forceCleanup, "force", "f", false, "Force cleanup even if worktree has uncommitted changes")
 	treeRebaseCmd.Flags().BoolVarP(&interactiveRebase, "interactive", "i", false, "Run interactive rebase")
+	treeSetupCmd.Flags().BoolVar(&treeWorkspace, "workspace", false, "Create the worktree in the workspace checkout of the change's project")
+	treeSetupCmd.Flags().BoolVar(&treeWithParents, "with-parents", false, "Stack the change on its open parent changes at their latest patch sets")
+	treeSetupCmd.Flags().StringVar(&treeRemote, "remote", "", "Git remote or URL to fetch from (default: detected)")
+	treeSetupCmd.Flags().StringVar(&treeVia, "via", "", "Fetch over ssh or http (default: fetch_protocol from the config, else ssh)")
+	treePruneCmd.Flags().BoolVar(&treePruneDryRun, "dry-run", false, "Only report the worktrees that would be removed")
+	treePruneCmd.Flags().BoolVarP(&treePruneForce, "force", "f", false, "Also remove worktrees with uncommitted changes")
+	treesCmd.Flags().BoolVar(&treesWorkspace, "workspace", false, "List the worktrees of every workspace checkout")
 
 	treeCmd.AddCommand(treeSetupCmd)
 	treeCmd.AddCommand(treeCleanupCmd)
 	treeCmd.AddCommand(treeRebaseCmd)
+	treeCmd.AddCommand(treePruneCmd)
 }
 
 func runTreeSetup(cmd *cobra.Command, args []string) error {
+	if worktreeName == "" {
+		var err error
+		if args, err = withPickedChange(args); err != nil {
+			return err
+		}
+	}
+
+	if treeWorkspace {
+		if len(args) == 0 || worktreeName != "" {
+			return fmt.Errorf("--workspace needs a change-id")
+		}
+		if err := enterWorkspaceCheckout(args[0]); err != nil {
+			return err
+		}
+	}
+
 	if !isGitRepository() {
 		return fmt.Errorf("not in a git repository")
 	}
@@ -117,6 +170,9 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			return fmt.Errorf("cannot specify change-id when using --name flag")
 		}
+		if treeWithParents {
+			return fmt.Errorf("--with-parents needs a change-id and cannot be combined with --name")
+		}
 
 		// Validate worktree name
 		safeName, err := utils.SanitizeFilename(worktreeName)
@@ -140,16 +196,7 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to create worktree: %w", err)
 		}
 		fmt.Println(color.GreenString("SUCCESS"))
-
-		fmt.Printf("\n%s Worktree created successfully!\n", color.GreenString("✓"))
-		fmt.Printf("Path: %s\n", utils.BoldGreen(worktreePath))
-
-		// Change to the worktree directory
-		if err := os.Chdir(worktreePath); err != nil {
-			fmt.Printf("%s Warning: Failed to change to worktree directory: %v\n", color.YellowString("⚠"), err)
-		} else {
-			fmt.Printf("Changed to worktree directory\n")
-		}
+		enterNewWorktree(worktreePath)
 		return nil
 	}
 
@@ -181,6 +228,9 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
+	if err := applyFetchVia(cfg, treeVia); err != nil {
+		return err
+	}
 
 	// Get change details
 	change, err := getChangeForFetch(cfg, changeID)
@@ -208,18 +258,25 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 		utils.BoldCyan(changeID),
 		utils.BoldYellow(patchsetNum))
 
+	if treeWithParents {
+		if err := createWorktreeChain(cfg, change, patchsetNum, worktreePath); err != nil {
+			return err
+		}
+		enterNewWorktree(worktreePath)
+		return nil
+	}
+
 	// Build the refs path
 	refsPath := fmt.Sprintf("refs/changes/%s/%s/%s",
 		getChangePrefix(changeID),
 		changeID,
 		patchsetNum)
 
-	// Get git remote URL
-	remoteURL := buildRemoteURL(cfg)
+	remoteURL := fetchRemote(cfg, treeRemote)
 
 	// Fetch the change first
 	fmt.Print("Fetching change... ")
-	if err := gitFetch(remoteURL, refsPath); err != nil {
+	if err := gitFetch(cfg, remoteURL, refsPath); err != nil {
 		fmt.Println(color.RedString("FAILED"))
 		return fmt.Errorf("git fetch failed: %w", err)
 	}
@@ -232,7 +289,34 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 	fmt.Println(color.GreenString("SUCCESS"))
+	enterNewWorktree(worktreePath)
+	return nil
+}
 
+// createWorktreeChain creates a worktree holding a change stacked on its
+// open parents (see 'gerry fetch --with-parents').
+func createWorktreeChain(cfg *config.Config, change *gerrit.Change, patchset, worktreePath string) error {
+	number, err := strconv.Atoi(patchset)
+	if err != nil {
+		return fmt.Errorf("invalid patchset number: %s", patchset)
+	}
+	chain, err := changeChain(cfg, change, number)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Setting up worktree for change %s with %d parent change(s)...\n",
+		utils.BoldCyan(change.ChangeNumberStr()), len(chain)-1)
+	return buildChain(cfg, fetchRemote(cfg, treeRemote), chain, func(commit string) (string, error) {
+		if err := createWorktree(worktreePath, commit); err != nil {
+			return "", fmt.Errorf("failed to create worktree: %w", err)
+		}
+		return worktreePath, nil
+	})
+}
+
+// enterNewWorktree reports a created worktree and changes to it.
+func enterNewWorktree(worktreePath string) {
 	fmt.Printf("\n%s Worktree created successfully!\n", color.GreenString("✓"))
 	fmt.Printf("Path: %s\n", utils.BoldGreen(worktreePath))
 
@@ -242,7 +326,6 @@ func runTreeSetup(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("Changed to worktree directory\n")
 	}
-	return nil
 }
 
 func runTreeCleanup(cmd *cobra.Command, args []string) error {
@@ -258,7 +341,7 @@ func runTreeCleanup(cmd *cobra.Command, args []string) error {
 
 	// Check if target is a path, change-id, or custom name
 	var worktreePath string
-	if strings.HasPrefix(target, "/") || strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") {
+	if isPathArg(target) {
 		// Treat as path
 		worktreePath = target
 	} else {
@@ -305,14 +388,255 @@ func runTreeCleanup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTreePrune(cmd *cobra.Command, args []string) error {
+	if !isGitRepository() {
+		return fmt.Errorf("not in a git repository")
+	}
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+	current, err := getGitRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+	output, err := gitOutput("", "worktree", "list", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	type candidate struct {
+		path     string
+		changeID string
+	}
+	var candidates []candidate
+	for _, tree := range parseWorktreeList(output) {
+		if tree.Bare || filepath.Clean(tree.Path) == filepath.Clean(current) {
+			continue
+		}
+		if changeID, ok := worktreeChangeID(tree.Path); ok {
+			candidates = append(candidates, candidate{tree.Path, changeID})
+		}
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No change worktrees to prune")
+		return nil
+	}
+
+	// Look every change up first so removal output doesn't tear the bar.
+	type closed struct {
+		candidate
+		status string
+	}
+	var prunable []closed
+	bar := utils.NewProgressBar("Checking change worktrees", len(candidates))
+	for i, c := range candidates {
+		bar.Set(i + 1)
+		change, err := client.GetChange(c.changeID)
+		if err != nil {
+			utils.Debugf("Keeping %s: %v", c.path, err)
+			continue
+		}
+		if change.Status == "MERGED" || change.Status == "ABANDONED" {
+			prunable = append(prunable, closed{c, strings.ToLower(change.Status)})
+		}
+	}
+	bar.Stop()
+
+	if len(prunable) == 0 {
+		fmt.Printf("None of the %d change worktree(s) belong to merged or abandoned changes\n", len(candidates))
+		return nil
+	}
+
+	var failed int
+	for _, c := range prunable {
+		switch {
+		case !treePruneForce && hasUncommittedChanges(c.path):
+			fmt.Printf("%s %s (%s): has uncommitted changes, kept\n", color.YellowString("⚠"), c.path, c.status)
+			failed++
+		case treePruneDryRun:
+			fmt.Printf("%s (%s): would be removed\n", c.path, c.status)
+		default:
+			args := []string{"worktree", "remove", c.path}
+			if treePruneForce {
+				args = append(args, "--force")
+			}
+			if _, err := gitOutput("", args...); err != nil {
+				fmt.Printf("%s %s (%s): %s\n", color.RedString("✗"), c.path, c.status, gitErrorMessage(err))
+				failed++
+				continue
+			}
+			fmt.Printf("%s %s (%s): removed\n", color.GreenString("✓"), c.path, c.status)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d worktree(s) could not be pruned", failed)
+	}
+	return nil
+}
+
+// worktreeChangeID returns the change a worktree created by 'gerry tree
+// setup' belongs to, from its change-<id> directory name.
+func worktreeChangeID(path string) (string, bool) {
+	changeID, ok := strings.CutPrefix(filepath.Base(path), "change-")
+	if !ok || utils.ValidateChangeID(changeID) != nil {
+		return "", false
+	}
+	return changeID, true
+}
+
 func runTrees(cmd *cobra.Command, args []string) error {
+	structured, err := structuredOutput()
+	if err != nil {
+		return err
+	}
+
+	if treesWorkspace {
+		return listWorkspaceWorktrees(structured)
+	}
+
 	if !isGitRepository() {
 		return fmt.Errorf("not in a git repository")
 	}
 
+	if structured {
+		output, err := gitOutput("", "worktree", "list", "--porcelain")
+		if err != nil {
+			return fmt.Errorf("failed to list worktrees: %w", err)
+		}
+		return renderOutput(os.Stdout, parseWorktreeList(output))
+	}
 	return listWorktrees()
 }
 
+// worktreeInfo is one entry of 'git worktree list --porcelain'.
+type worktreeInfo struct {
+	Path     string `json:"path"`
+	Head     string `json:"head,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Bare     bool   `json:"bare,omitempty"`
+	Detached bool   `json:"detached,omitempty"`
+}
+
+// workspaceWorktrees is one checkout's entry in 'gerry trees --workspace'
+// structured output.
+type workspaceWorktrees struct {
+	Project   string         `json:"project"`
+	Path      string         `json:"path"`
+	Worktrees []worktreeInfo `json:"worktrees"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// parseWorktreeList parses 'git worktree list --porcelain' output, where
+// each worktree is a block of "key value" lines separated by blank lines.
+func parseWorktreeList(output string) []worktreeInfo {
+	trees := []worktreeInfo{}
+	var current *worktreeInfo
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "worktree":
+			trees = append(trees, worktreeInfo{Path: filepath.FromSlash(value)})
+			current = &trees[len(trees)-1]
+		case "HEAD":
+			if current != nil {
+				current.Head = value
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "bare":
+			if current != nil {
+				current.Bare = true
+			}
+		case "detached":
+			if current != nil {
+				current.Detached = true
+			}
+		}
+	}
+	return trees
+}
+
+// enterWorkspaceCheckout changes to the workspace checkout holding the
+// change's project.
+func enterWorkspaceCheckout(changeID string) error {
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if _, err := loadWorkspace(cfg); err != nil {
+		return err
+	}
+
+	change, err := getChangeForFetch(cfg, changeID)
+	if err != nil {
+		return fmt.Errorf("failed to get change details: %w", err)
+	}
+	repo, ok := cfg.WorkspaceRepoFor(change.Project)
+	if !ok {
+		return fmt.Errorf("project %s is not in your workspace; add its checkout with 'gerry workspace add <path>'", change.Project)
+	}
+	if err := os.Chdir(repo.ExpandedPath()); err != nil {
+		return fmt.Errorf("failed to enter %s: %w", repo.Path, err)
+	}
+	utils.Debugf("Using workspace checkout %s for %s", repo.Path, change.Project)
+	return nil
+}
+
+func listWorkspaceWorktrees(structured bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	repos, err := loadWorkspace(cfg)
+	if err != nil {
+		return err
+	}
+
+	if structured {
+		entries := make([]workspaceWorktrees, 0, len(repos))
+		for _, r := range repos {
+			entry := workspaceWorktrees{Project: r.Project, Path: r.Path, Worktrees: []worktreeInfo{}}
+			output, err := gitOutput(r.ExpandedPath(), "worktree", "list", "--porcelain")
+			if err != nil {
+				entry.Error = "not a git checkout"
+			} else {
+				entry.Worktrees = parseWorktreeList(output)
+			}
+			entries = append(entries, entry)
+		}
+		return renderOutput(os.Stdout, entries)
+	}
+
+	for i, r := range repos {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", utils.BoldCyan(r.Project), utils.Gray("("+r.Path+")"))
+		output, err := gitOutput(r.ExpandedPath(), "worktree", "list")
+		if err != nil {
+			fmt.Printf("  %s\n", utils.Red("not a git checkout"))
+			continue
+		}
+		for _, line := range strings.Split(output, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	return nil
+}
+
 func createWorktree(path, commitish string) error {
 	cmd := exec.Command("git", "worktree", "add", path, commitish)
 	cmd.Stdout = os.Stdout
@@ -354,7 +678,23 @@ func getGitRepoRoot() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	// git prints forward slashes even on Windows.
+	return filepath.FromSlash(strings.TrimSpace(string(output))), nil
+}
+
+// isPathArg reports whether a cleanup target is a filesystem path rather than
+// a change-id or worktree name: absolute, or explicitly relative with either
+// separator so Windows paths like .\worktrees\foo work too.
+func isPathArg(target string) bool {
+	if filepath.IsAbs(target) || strings.HasPrefix(target, "/") {
+		return true
+	}
+	for _, prefix := range []string{"./", "../", `.\`, `..\`} {
+		if strings.HasPrefix(target, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func runTreeRebase(cmd *cobra.Command, args []string) error {
@@ -374,6 +714,9 @@ func runTreeRebase(cmd *cobra.Command, args []string) error {
 
 	// Determine target branch
 	targetBranch := "main"
+	if cfg, err := config.Load(); err == nil && cfg.DefaultBranch != "" {
+		targetBranch = cfg.DefaultBranch
+	}
 	if len(args) > 0 {
 		targetBranch = args[0]
 	}
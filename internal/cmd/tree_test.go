@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsPathArg(t *testing.T) {
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"/home/me/worktrees/change-12345", true},
+		{"./worktrees/foo", true},
+		{"../worktrees/foo", true},
+		{`.\worktrees\foo`, true},
+		{`..\worktrees\foo`, true},
+		{"12345", false},
+		{"my-feature", false},
+		{".hidden", false},
+	}
+	for _, tt := range tests {
+		if got := isPathArg(tt.target); got != tt.want {
+			t.Errorf("isPathArg(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /src/canvas-lms
+HEAD 1a2b3c
+branch refs/heads/master
+
+worktree /src/worktrees/change-12345
+HEAD 4d5e6f
+detached
+
+worktree /src/bare.git
+bare`
+	got := parseWorktreeList(output)
+	want := []worktreeInfo{
+		{Path: filepath.FromSlash("/src/canvas-lms"), Head: "1a2b3c", Branch: "master"},
+		{Path: filepath.FromSlash("/src/worktrees/change-12345"), Head: "4d5e6f", Detached: true},
+		{Path: filepath.FromSlash("/src/bare.git"), Bare: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorktreeList() = %+v, want %+v", got, want)
+	}
+	if got := parseWorktreeList(""); got == nil || len(got) != 0 {
+		t.Errorf("parseWorktreeList(\"\") = %#v, want an empty slice", got)
+	}
+}
+
+func TestWorktreeChangeID(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"/src/worktrees/change-12345", "12345", true},
+		{"/src/worktrees/change-I8473b95934b5732ac55d26311a706c9c2bde9940", "I8473b95934b5732ac55d26311a706c9c2bde9940", true},
+		{"/src/worktrees/my-feature", "", false},
+		{"/src/worktrees/change-", "", false},
+		{"/src/canvas-lms", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := worktreeChangeID(filepath.FromSlash(tt.path))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("worktreeChangeID(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendQuery  string
+	trendSince  string
+	trendFormat string
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend",
+	Short: "Show how the review queue evolved across snapshots",
+	Long: `Chart the review-queue snapshots recorded by gerry snapshot: queue size,
+changes awaiting review, and how long they have been waiting, with the change
+from the first to the last snapshot in the window.
+
+Only snapshots of the configured server taken with the same query are shown.
+
+Examples:
+  gerry trend
+  gerry trend --since 90d --query "project:canvas-lms status:open -is:wip"
+  gerry trend --format json`,
+	Args: cobra.NoArgs,
+	RunE: runTrend,
+}
+
+func init() {
+	trendCmd.Flags().StringVarP(&trendQuery, "query", "q", defaultQueueQuery, "Query the snapshots were taken with")
+	trendCmd.Flags().StringVar(&trendSince, "since", "30d", "How far back to look (e.g. 14d, 12w)")
+	trendCmd.Flags().StringVar(&trendFormat, "format", "table", "Output format: table, json")
+}
+
+func runTrend(cmd *cobra.Command, args []string) error {
+	window, err := utils.ParseDuration(trendSince)
+	if err != nil {
+		return err
+	}
+	if trendFormat != "table" && trendFormat != "json" {
+		return fmt.Errorf("unknown format: %s (supported: table, json)", trendFormat)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	history, err := loadQueueHistory()
+	if err != nil {
+		return err
+	}
+	series := selectQueueSeries(history, cfg.Server, trendQuery, time.Now().UTC().Add(-window))
+
+	if trendFormat == "json" {
+		if series == nil {
+			series = []queueSnapshot{}
+		}
+		data, err := json.MarshalIndent(series, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(series) == 0 {
+		utils.Infof("No snapshots for %q in the last %s; record some with 'gerry snapshot'", trendQuery, trendSince)
+		return nil
+	}
+
+	headers := []string{"Date", "Open", "Awaiting", "Median Wait", "P90 Wait", "Waiting >3d"}
+	var rows [][]string
+	for _, s := range series {
+		date := s.Time
+		if t, err := time.Parse(time.RFC3339, s.Time); err == nil {
+			date = t.Local().Format("2006-01-02 15:04")
+		}
+		over3d := strconv.Itoa(s.Over3d)
+		if s.Over3d > 0 {
+			over3d = utils.Red(over3d)
+		}
+		rows = append(rows, []string{
+			date,
+			strconv.Itoa(s.Open),
+			strconv.Itoa(s.Awaiting),
+			utils.FormatDuration(hoursDuration(s.MedianWaitHours)),
+			utils.FormatDuration(hoursDuration(s.P90WaitHours)),
+			over3d,
+		})
+	}
+	fmt.Print(utils.FormatTable(headers, rows, 2))
+
+	fmt.Println()
+	charts := []struct {
+		label string
+		value func(queueSnapshot) float64
+		hours bool
+	}{
+		{"Awaiting review", func(s queueSnapshot) float64 { return float64(s.Awaiting) }, false},
+		{"Median wait", func(s queueSnapshot) float64 { return s.MedianWaitHours }, true},
+		{"Waiting >3d", func(s queueSnapshot) float64 { return float64(s.Over3d) }, false},
+	}
+	for _, chart := range charts {
+		values := make([]float64, len(series))
+		for i, s := range series {
+			values[i] = chart.value(s)
+		}
+		first, last := values[0], values[len(values)-1]
+		format := func(v float64) string { return strconv.Itoa(int(v)) }
+		if chart.hours {
+			format = func(v float64) string { return utils.FormatDuration(hoursDuration(v)) }
+		}
+		fmt.Printf("%s %s  %s → %s %s\n", utils.PadString(chart.label+":", 17), utils.Cyan(utils.Sparkline(values)),
+			format(first), format(last), trendDelta(first, last))
+	}
+	return nil
+}
+
+// selectQueueSeries returns the snapshots of server and query taken at or
+// after since, oldest first.
+func selectQueueSeries(history []queueSnapshot, server, query string, since time.Time) []queueSnapshot {
+	var series []queueSnapshot
+	for _, s := range history {
+		if s.Server != server || s.Query != query {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s.Time)
+		if err != nil || t.Before(since) {
+			continue
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// trendDelta colors the change in a queue metric: growth is bad, shrinking
+// is good.
+func trendDelta(first, last float64) string {
+	switch {
+	case last > first:
+		return utils.Red("▲")
+	case last < first:
+		return utils.Green("▼")
+	default:
+		return utils.Gray("=")
+	}
+}
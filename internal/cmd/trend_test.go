@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectQueueSeries(t *testing.T) {
+	history := []queueSnapshot{
+		{Time: "2024-01-01T09:00:00Z", Server: "gerrit.example.com", Query: defaultQueueQuery, Awaiting: 1},
+		{Time: "2024-01-10T09:00:00Z", Server: "gerrit.example.com", Query: defaultQueueQuery, Awaiting: 2},
+		{Time: "2024-01-10T09:00:00Z", Server: "gerrit.example.com", Query: "project:other", Awaiting: 3},
+		{Time: "2024-01-10T09:00:00Z", Server: "other.example.com", Query: defaultQueueQuery, Awaiting: 4},
+		{Time: "2024-01-11T09:00:00Z", Server: "gerrit.example.com", Query: defaultQueueQuery, Awaiting: 5},
+		{Time: "garbage", Server: "gerrit.example.com", Query: defaultQueueQuery, Awaiting: 6},
+	}
+	since := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	got := selectQueueSeries(history, "gerrit.example.com", defaultQueueQuery, since)
+	if len(got) != 2 || got[0].Awaiting != 2 || got[1].Awaiting != 5 {
+		t.Errorf("selectQueueSeries() = %+v, want the snapshots with 2 and 5 awaiting", got)
+	}
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/fatih/color"
@@ -20,7 +23,10 @@ var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update gerry to the latest version",
 	Long: `Update gerry to the latest version by pulling from git and rebuilding.
-This command must be run from within the gerry source directory.`,
+This command must be run from within the gerry source directory.
+
+Builds with make when it is available and with go build otherwise, so it also
+works on Windows.`,
 	RunE: runUpdate,
 }
 
@@ -36,9 +42,9 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a git repository. Please run this command from the gerry source directory")
 	}
 
-	// Check if Makefile exists
-	if !fileExists("Makefile") {
-		return fmt.Errorf("Makefile not found. Please run this command from the gerry source directory")
+	// Check that this is the gerry source tree
+	if !fileExists(filepath.Join("cmd", "gerry")) {
+		return fmt.Errorf("cmd/gerry not found. Please run this command from the gerry source directory")
 	}
 
 	if !skipPull {
@@ -52,15 +58,8 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Clean and rebuild
-	fmt.Print("Cleaning previous build... ")
-	if err := runCommand("make", "clean"); err != nil {
-		fmt.Println(color.RedString("FAILED"))
-		return fmt.Errorf("failed to clean: %w", err)
-	}
-	fmt.Println(color.GreenString("SUCCESS"))
-
 	fmt.Print("Building gerry... ")
-	if err := runCommand("make", "build"); err != nil {
+	if err := buildBinary(); err != nil {
 		fmt.Println(color.RedString("FAILED"))
 		return fmt.Errorf("failed to build: %w", err)
 	}
@@ -75,12 +74,14 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Println(color.GreenString("SUCCESS"))
 
 	// Clear shell hash cache to ensure we get the new binary
-	fmt.Print("Clearing shell cache... ")
-	if err := runCommandQuiet("hash", "-r"); err != nil {
-		// hash -r might not exist on all shells, so don't fail
-		utils.Debugf("Failed to clear hash cache: %v", err)
+	if runtime.GOOS != "windows" {
+		fmt.Print("Clearing shell cache... ")
+		if err := runCommandQuiet("hash", "-r"); err != nil {
+			// hash -r might not exist on all shells, so don't fail
+			utils.Debugf("Failed to clear hash cache: %v", err)
+		}
+		fmt.Println(color.GreenString("SUCCESS"))
 	}
-	fmt.Println(color.GreenString("SUCCESS"))
 
 	// Simple verification - just check if the binary exists and is executable
 	fmt.Print("Verifying installation... ")
@@ -118,43 +119,88 @@ func runCommand(name string, args ...string) error {
 	return cmd.Run()
 }
 
-func installBinary() error {
-	binaryPath := "./bin/gerry"
-
-	// Determine install location
-	var installPath string
+// binaryName is the executable's file name on this platform.
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "gerry.exe"
+	}
+	return "gerry"
+}
 
-	// Check if user has write access to /usr/local/bin
-	if isWritable("/usr/local/bin") {
-		installPath = "/usr/local/bin/gerry"
-	} else {
-		// Fall back to user's bin directory
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+// buildBinary builds bin/gerry with make where available, and with go build
+// directly otherwise (e.g. on Windows, which usually has no make).
+func buildBinary() error {
+	if _, err := exec.LookPath("make"); err == nil && runtime.GOOS != "windows" {
+		if err := runCommand("make", "clean"); err != nil {
+			return fmt.Errorf("make clean: %w", err)
 		}
+		return runCommand("make", "build")
+	}
+
+	version, err := exec.Command("git", "describe", "--tags", "--always", "--dirty").Output()
+	if err != nil {
+		utils.Debugf("Failed to describe version: %v", err)
+	}
+	ldflags := fmt.Sprintf("-X main.Version=%s -X main.BuildTime=%s",
+		strings.TrimSpace(string(version)), time.Now().UTC().Format("2006-01-02_15:04:05"))
+	return runCommand("go", "build", "-ldflags", ldflags, "-o", filepath.Join("bin", binaryName()), "./cmd/gerry")
+}
+
+func installBinary() error {
+	binaryPath := filepath.Join("bin", binaryName())
 
-		userBin := filepath.Join(homeDir, "bin")
+	installPath, err := getInstallPath()
+	if err != nil {
+		return err
+	}
+
+	// Fall back to user's bin directory
+	userBin := filepath.Dir(installPath)
+	if userBin != "/usr/local/bin" {
 		if err := os.MkdirAll(userBin, 0755); err != nil {
-			return fmt.Errorf("failed to create ~/bin directory: %w", err)
+			return fmt.Errorf("failed to create %s: %w", userBin, err)
 		}
 
-		installPath = filepath.Join(userBin, "gerry")
-
 		// Warn user about PATH
-		fmt.Printf("\n%s Installing to ~/bin/gerry. Make sure ~/bin is in your PATH.\n", color.YellowString("⚠"))
+		fmt.Printf("\n%s Installing to %s. Make sure %s is in your PATH.\n", color.YellowString("⚠"), installPath, userBin)
 		fmt.Println("Add this to your shell profile if needed:")
-		fmt.Printf("  %s\n", utils.Cyan("export PATH=\"$HOME/bin:$PATH\""))
+		if runtime.GOOS == "windows" {
+			fmt.Printf("  %s\n", utils.Cyan(`setx PATH "%PATH%;%USERPROFILE%\bin"`))
+		} else {
+			fmt.Printf("  %s\n", utils.Cyan("export PATH=\"$HOME/bin:$PATH\""))
+		}
 	}
 
-	// Copy binary
+	return copyExecutable(binaryPath, installPath)
+}
+
+// copyExecutable installs src at dst. Windows refuses to overwrite a running
+// executable but allows renaming it, so there the old binary is moved aside
+// to dst.old first (and removed on the next update).
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
 	if runtime.GOOS == "windows" {
-		installPath += ".exe"
-		binaryPath += ".exe"
+		old := dst + ".old"
+		os.Remove(old)
+		if err := os.Rename(dst, old); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to move aside %s: %w", dst, err)
+		}
 	}
 
-	// Use cp command for copying
-	return runCommandQuiet("cp", binaryPath, installPath)
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
 }
 
 func runCommandQuiet(name string, args ...string) error {
@@ -174,16 +220,18 @@ func isWritable(path string) bool {
 	return true
 }
 
+// getInstallPath returns where gerry is installed: /usr/local/bin when
+// writable, otherwise the bin directory under the user's home (%USERPROFILE%
+// on Windows).
 func getInstallPath() (string, error) {
-	// Determine where gerry was installed
-	if isWritable("/usr/local/bin") {
-		return "/usr/local/bin/gerry", nil
+	if runtime.GOOS != "windows" && isWritable("/usr/local/bin") {
+		return filepath.Join("/usr/local/bin", binaryName()), nil
 	}
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	return filepath.Join(homeDir, "bin", "gerry"), nil
+	return filepath.Join(homeDir, "bin", binaryName()), nil
 }
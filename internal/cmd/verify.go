@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyValue   int
+	verifyLabel   string
+	verifyTag     string
+	verifyMessage string
+	verifyURL     string
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <change-id> [patchset]",
+	Short: "Post a tagged Verified vote (for CI systems)",
+	Long: `Post a Verified vote on a specific patchset with a review tag, an optional
+message, and an optional link to the build — a replacement for hand-rolled curl
+scripts in CI jobs. The patchset defaults to the current one, but CI should pass
+the patchset it actually tested so a vote never lands on a newer upload.
+
+Tagged messages (autogenerated:...) are grouped by Gerrit and can be hidden in
+the web UI. Uses the REST API when HTTP credentials are configured, falling
+back to the SSH 'gerrit review' command.
+
+Examples:
+  gerry verify 12345 3 --value +1 --message "pipeline #123 passed" --url https://ci.example.com/123
+  gerry verify 12345 3 --value -1 --tag autogenerated:jenkins --message "unit tests failed"
+  gerry verify 12345 --label Lint-Review --value +1`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().IntVar(&verifyValue, "value", 0, "Vote value, e.g. +1 or -1")
+	verifyCmd.Flags().StringVar(&verifyLabel, "label", "Verified", "Label to vote on")
+	verifyCmd.Flags().StringVar(&verifyTag, "tag", "autogenerated:ci", "Review tag attached to the vote")
+	verifyCmd.Flags().StringVarP(&verifyMessage, "message", "m", "", "Message to attach")
+	verifyCmd.Flags().StringVar(&verifyURL, "url", "", "Build URL appended to the message")
+	verifyCmd.MarkFlagRequired("value")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	changeID := args[0]
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	patchset := ""
+	if len(args) == 2 {
+		patchset = args[1]
+		if n, err := strconv.Atoi(patchset); err != nil || n <= 0 {
+			return fmt.Errorf("invalid patchset: %s", patchset)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	message := buildVerifyMessage(verifyMessage, verifyURL)
+	labels := map[string]int{verifyLabel: verifyValue}
+
+	var restErr error
+	if cfg.HTTPPassword != "" {
+		revision := patchset
+		if revision == "" {
+			revision = "current"
+		}
+		restErr = gerrit.NewRESTClient(cfg).PostTaggedVote(changeID, revision, message, verifyTag, labels)
+		if restErr != nil {
+			utils.Warnf("REST API failed: %v", restErr)
+			utils.Info("Falling back to SSH...")
+		}
+	}
+
+	if cfg.HTTPPassword == "" || restErr != nil {
+		if patchset == "" {
+			change, err := getChangeForFetch(cfg, changeID)
+			if err != nil {
+				return fmt.Errorf("failed to get change details: %w", err)
+			}
+			patchset = getCurrentPatchsetNumber(change)
+			if patchset == "" {
+				return fmt.Errorf("could not determine current patchset")
+			}
+		}
+		if err := gerrit.NewSSHClient(cfg).PostTaggedVote(changeID, patchset, message, verifyTag, labels); err != nil {
+			return fmt.Errorf("failed to post vote: %w", err)
+		}
+	}
+
+	target := changeID
+	if patchset != "" {
+		target += "/" + patchset
+	}
+	fmt.Printf("%s Voted %s%s on %s\n", utils.Green("✓"), verifyLabel, formatVote(verifyValue), target)
+	return nil
+}
+
+// buildVerifyMessage joins the message and build URL into one change message.
+func buildVerifyMessage(message, buildURL string) string {
+	switch {
+	case buildURL == "":
+		return message
+	case message == "":
+		return buildURL
+	default:
+		return message + "\n\n" + buildURL
+	}
+}
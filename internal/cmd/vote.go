@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/drakeaharper/gerrit-cli/internal/config"
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -26,17 +27,33 @@ var (
 )
 
 var voteCmd = &cobra.Command{
-	Use:   "vote <change-id>",
+	Use:   "vote <change-id> [shorthand...]",
 	Short: "Vote on review labels for a change",
 	Long: `Post label votes (Code-Review, QA-Review, Product-Review, Lint-Review, Verified)
 on a Gerrit change. Use shortcut flags for common labels or -l NAME=VALUE for any label.
 
+Votes can also be given as shorthand arguments:
+
+  +2        Code-Review +2 (a bare value votes on the "cr" label)
+  v+1       Verified +1 (alias followed by a value)
+  -v        Verified -1 (a sign before an alias means ±1)
+
+Put negative shorthands after -- so they aren't read as flags. The aliases
+cr, qa, pr, lint, and v (or verified) map to Code-Review, QA-Review,
+Product-Review, Lint-Review, and Verified. Servers with custom labels can
+remap them, or add new ones, in ~/.gerry/config.json; the shortcut flags
+follow the same mapping:
+
+  "label_aliases": {"qa": "QA-Approval", "sec": "Security-Review"}
+
 Examples:
   gerry vote 12345 --cr +2
   gerry vote 12345 --cr +1 --qa +1 -m "LGTM"
   gerry vote 12345 --pr +1 --verified +1
-  gerry vote 12345 -l Code-Review=+2 -l QA-Review=+1`,
-	Args: cobra.ExactArgs(1),
+  gerry vote 12345 -l Code-Review=+2 -l QA-Review=+1
+  gerry vote 12345 +2 v+1
+  gerry vote 12345 -- -v`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runVote,
 }
 
@@ -56,46 +73,46 @@ func runVote(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid change ID: %w", err)
 	}
 
-	labels := map[string]int{}
-	if cmd.Flags().Changed("cr") {
-		labels["Code-Review"] = voteCR
-	}
-	if cmd.Flags().Changed("qa") {
-		labels["QA-Review"] = voteQA
-	}
-	if cmd.Flags().Changed("pr") {
-		labels["Product-Review"] = votePR
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
 	}
-	if cmd.Flags().Changed("lint") {
-		labels["Lint-Review"] = voteLint
+	aliases := labelAliases(cfg)
+
+	labels := map[string]int{}
+	flagVotes := []struct {
+		flag  string
+		alias string
+		value int
+	}{
+		{"cr", "cr", voteCR},
+		{"qa", "qa", voteQA},
+		{"pr", "pr", votePR},
+		{"lint", "lint", voteLint},
+		{"verified", "v", voteVerified},
 	}
-	if cmd.Flags().Changed("verified") {
-		labels["Verified"] = voteVerified
+	for _, fv := range flagVotes {
+		if cmd.Flags().Changed(fv.flag) {
+			labels[aliases[fv.alias]] = fv.value
+		}
 	}
 	for _, raw := range voteLabels {
-		name, val, ok := strings.Cut(raw, "=")
-		if !ok {
-			return fmt.Errorf("invalid --label value %q (expected NAME=VALUE)", raw)
-		}
-		name = strings.TrimSpace(name)
-		val = strings.TrimSpace(val)
-		if name == "" {
-			return fmt.Errorf("invalid --label value %q (empty name)", raw)
+		name, n, err := parseLabelAssignment(raw)
+		if err != nil {
+			return err
 		}
-		n, err := strconv.Atoi(strings.TrimPrefix(val, "+"))
+		labels[name] = n
+	}
+	for _, raw := range args[1:] {
+		name, n, err := parseShorthandVote(raw, aliases)
 		if err != nil {
-			return fmt.Errorf("invalid --label value %q: %w", raw, err)
+			return err
 		}
 		labels[name] = n
 	}
 
 	if len(labels) == 0 {
-		return fmt.Errorf("at least one vote flag is required (--cr, --qa, --pr, --lint, --verified, or -l NAME=VALUE)")
-	}
-
-	_, client, err := loadConfigAndClient()
-	if err != nil {
-		return err
+		return fmt.Errorf("at least one vote is required (--cr, --qa, --pr, --lint, --verified, -l NAME=VALUE, or a shorthand like +2)")
 	}
 
 	revision, err := getCurrentRevision(client, changeID)
@@ -107,6 +124,12 @@ func runVote(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to post vote: %w", err)
 	}
 
+	fmt.Printf("%s Voted on %s: %s\n", utils.Green("✓"), changeID, formatVotes(labels))
+	return nil
+}
+
+// formatVotes renders label votes sorted by name, e.g. "Code-Review+2, Verified+1".
+func formatVotes(labels map[string]int) string {
 	names := make([]string, 0, len(labels))
 	for name := range labels {
 		names = append(names, name)
@@ -116,8 +139,87 @@ func runVote(cmd *cobra.Command, args []string) error {
 	for _, name := range names {
 		parts = append(parts, fmt.Sprintf("%s%s", name, formatVote(labels[name])))
 	}
-	fmt.Printf("%s Voted on %s: %s\n", utils.Green("✓"), changeID, strings.Join(parts, ", "))
-	return nil
+	return strings.Join(parts, ", ")
+}
+
+// parseLabelAssignment reads a --label NAME=VALUE vote.
+func parseLabelAssignment(raw string) (string, int, error) {
+	name, val, ok := strings.Cut(raw, "=")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid --label value %q (expected NAME=VALUE)", raw)
+	}
+	name = strings.TrimSpace(name)
+	val = strings.TrimSpace(val)
+	if name == "" {
+		return "", 0, fmt.Errorf("invalid --label value %q (empty name)", raw)
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(val, "+"))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid --label value %q: %w", raw, err)
+	}
+	return name, n, nil
+}
+
+// defaultLabelAliases are the vote shorthands gerry knows without
+// configuration.
+var defaultLabelAliases = map[string]string{
+	"cr":       "Code-Review",
+	"qa":       "QA-Review",
+	"pr":       "Product-Review",
+	"lint":     "Lint-Review",
+	"v":        "Verified",
+	"verified": "Verified",
+}
+
+// labelAliases merges the configured label_aliases over the defaults.
+// Aliases are case-insensitive.
+func labelAliases(cfg *config.Config) map[string]string {
+	aliases := make(map[string]string, len(defaultLabelAliases)+len(cfg.LabelAliases))
+	for alias, label := range defaultLabelAliases {
+		aliases[alias] = label
+	}
+	for alias, label := range cfg.LabelAliases {
+		aliases[strings.ToLower(alias)] = label
+	}
+	return aliases
+}
+
+// parseShorthandVote reads a shorthand vote: "+2" (the cr label), "v+1"
+// (alias then value), or "-v" (sign then alias, meaning ±1).
+func parseShorthandVote(raw string, aliases map[string]string) (string, int, error) {
+	if raw == "" {
+		return "", 0, fmt.Errorf("invalid vote %q", raw)
+	}
+
+	alias, value := "cr", raw
+	if raw[0] == '+' || raw[0] == '-' {
+		if rest := raw[1:]; rest != "" && !isDigits(rest) {
+			alias, value = rest, raw[:1]+"1"
+		}
+	} else if i := strings.LastIndexAny(raw, "+-"); i > 0 {
+		alias, value = raw[:i], raw[i:]
+	} else {
+		return "", 0, fmt.Errorf("invalid vote %q (expected e.g. +2, v+1, or -v)", raw)
+	}
+
+	label, ok := aliases[strings.ToLower(alias)]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown label alias %q in vote %q (add it to label_aliases in ~/.gerry/config.json)", alias, raw)
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(value, "+"))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid vote %q (expected e.g. +2, v+1, or -v)", raw)
+	}
+	return label, n, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
 }
 
 func formatVote(v int) string {
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestParseShorthandVote(t *testing.T) {
+	aliases := labelAliases(&config.Config{LabelAliases: map[string]string{"QA": "QA-Approval", "sec": "Security-Review"}})
+
+	tests := []struct {
+		raw       string
+		wantLabel string
+		wantValue int
+		wantErr   bool
+	}{
+		{"+2", "Code-Review", 2, false},
+		{"-1", "Code-Review", -1, false},
+		{"v+1", "Verified", 1, false},
+		{"-v", "Verified", -1, false},
+		{"+verified", "Verified", 1, false},
+		{"qa+1", "QA-Approval", 1, false},
+		{"sec-2", "Security-Review", -2, false},
+		{"CR+2", "Code-Review", 2, false},
+		{"2", "", 0, true},
+		{"v+", "", 0, true},
+		{"x+1", "", 0, true},
+		{"", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		label, value, err := parseShorthandVote(tt.raw, aliases)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseShorthandVote(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if label != tt.wantLabel || value != tt.wantValue {
+			t.Errorf("parseShorthandVote(%q) = %s%+d, want %s%+d", tt.raw, label, value, tt.wantLabel, tt.wantValue)
+		}
+	}
+}
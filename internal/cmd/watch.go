@@ -0,0 +1,460 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchRuleNames []string
+	watchProjects  []string
+	watchBranch    string
+	watchOwner     string
+	watchEvents    []string
+	watchFormat    string
+	watchHeartbeat time.Duration
+)
+
+// watchReconnectDelay is how long to wait before reopening a dropped stream.
+const watchReconnectDelay = 10 * time.Second
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch the Gerrit event stream and act on matching events",
+	Long: `Follow 'gerrit stream-events' over SSH and route each event through watch
+rules. Requires the Stream Events capability on the server.
+
+Rules live under "watch_rules" in ~/.gerry/config.json. Every rule whose
+filters match an event runs its action; empty filters match everything.
+
+  {
+    "watch_rules": [
+      {"name": "mine", "owner": "self", "events": ["comment-added"], "action": "notify"},
+      {"name": "deploy", "projects": ["infra/*"], "branch": "main",
+       "events": ["change-merged"], "action": "exec", "command": "./deploy.sh"}
+    ]
+  }
+
+Filters:
+  projects  project name globs (path.Match syntax; * does not cross /)
+  branch    branch name glob
+  owner     change owner username, email, or name; "self" for you
+  events    event types, e.g. patchset-created, comment-added, change-merged
+
+Actions:
+  print   print a one-line summary (default)
+  notify  show a desktop notification
+  exec    run command with sh -c (cmd /C on Windows); the event JSON is
+          on stdin and GERRY_EVENT, GERRY_CHANGE, GERRY_PROJECT,
+          GERRY_BRANCH, GERRY_SUBJECT, GERRY_URL, and GERRY_ACTOR are set
+
+With no rules configured every event is printed. Filter flags replace the
+configured rules with a single print rule.
+
+--format jsonl prints matching events exactly as the server sent them, one
+JSON object per line, for piping into jq or another daemon. Connection state
+is reported inline as synthetic records whose type starts with "gerry-":
+gerry-connect, gerry-disconnect (with "error"), and gerry-heartbeat (every
+--heartbeat while the stream is open). Exec output goes to stderr.
+
+Examples:
+  gerry watch
+  gerry watch --rule mine --rule deploy
+  gerry watch --project "canvas-lms" --event change-merged
+  gerry watch --format jsonl | jq 'select(.type == "comment-added")'`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().StringArrayVar(&watchRuleNames, "rule", nil, "Only run the named config rule (repeatable)")
+	watchCmd.Flags().StringArrayVarP(&watchProjects, "project", "p", nil, "Print events for projects matching this glob (repeatable)")
+	watchCmd.Flags().StringVarP(&watchBranch, "branch", "b", "", "Print events for branches matching this glob")
+	watchCmd.Flags().StringVar(&watchOwner, "owner", "", "Print events for changes owned by this user (\"self\" for you)")
+	watchCmd.Flags().StringArrayVarP(&watchEvents, "event", "e", nil, "Print events of this type (repeatable)")
+	watchCmd.Flags().StringVar(&watchFormat, "format", "text", "Output format: text, jsonl")
+	watchCmd.Flags().DurationVar(&watchHeartbeat, "heartbeat", 30*time.Second, "Interval between gerry-heartbeat records with --format jsonl (0 disables)")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchFormat != "text" && watchFormat != "jsonl" {
+		return fmt.Errorf("unknown format: %s (supported: text, jsonl)", watchFormat)
+	}
+	if watchFormat == "jsonl" {
+		utils.DisableProgress()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	rules, err := selectWatchRules(cfg.WatchRules, watchRuleNames)
+	if err != nil {
+		return err
+	}
+	if len(watchProjects) > 0 || watchBranch != "" || watchOwner != "" || len(watchEvents) > 0 {
+		if len(watchRuleNames) > 0 {
+			return fmt.Errorf("--rule cannot be combined with filter flags")
+		}
+		rules = []config.WatchRule{{
+			Name:     "command line",
+			Projects: watchProjects,
+			Branch:   watchBranch,
+			Owner:    watchOwner,
+			Events:   watchEvents,
+		}}
+	}
+	if len(rules) == 0 {
+		rules = []config.WatchRule{{Name: "default"}}
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid watch rule %s: %w", watchRuleLabel(rule), err)
+		}
+	}
+
+	w := &eventWatcher{cfg: cfg, rules: rules, jsonl: watchFormat == "jsonl", out: os.Stdout}
+	client := gerrit.NewSSHClient(cfg)
+	if w.jsonl {
+		if watchHeartbeat > 0 {
+			go w.heartbeat(watchHeartbeat)
+		}
+	} else {
+		utils.Infof("Watching %s (%d rule(s))...", cfg.Server, len(rules))
+	}
+
+	// Give up only if the very first session never delivered anything; once
+	// the stream has worked, keep retrying through outages.
+	everReceived := false
+	for {
+		w.status("gerry-connect", nil)
+		received, err := w.stream(client)
+		everReceived = everReceived || received
+		if !everReceived {
+			if err == nil {
+				err = fmt.Errorf("stream closed")
+			}
+			return fmt.Errorf("stream-events failed: %w", err)
+		}
+		if err == nil {
+			err = fmt.Errorf("stream closed")
+		}
+		w.status("gerry-disconnect", err)
+		if !w.jsonl {
+			utils.Warnf("Event stream dropped: %v", err)
+			utils.Infof("Reconnecting in %s...", watchReconnectDelay)
+		}
+		time.Sleep(watchReconnectDelay)
+	}
+}
+
+// selectWatchRules returns the configured rules named in names, or all of
+// them when names is empty.
+func selectWatchRules(rules []config.WatchRule, names []string) ([]config.WatchRule, error) {
+	if len(names) == 0 {
+		return rules, nil
+	}
+	var selected []config.WatchRule
+	for _, name := range names {
+		found := false
+		for _, rule := range rules {
+			if rule.Name == name {
+				selected = append(selected, rule)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no watch rule named %q in configuration", name)
+		}
+	}
+	return selected, nil
+}
+
+// eventWatcher dispatches stream events to the actions of matching rules.
+type eventWatcher struct {
+	cfg   *config.Config
+	rules []config.WatchRule
+	jsonl bool
+	out   io.Writer
+
+	mu        sync.Mutex // serializes writes to out and guards connected
+	connected bool
+}
+
+// watchStatusRecord is a synthetic jsonl record describing the connection.
+type watchStatusRecord struct {
+	Type           string `json:"type"`
+	Server         string `json:"server"`
+	Error          string `json:"error,omitempty"`
+	EventCreatedOn int64  `json:"eventCreatedOn"`
+}
+
+// writeLine writes one line to the output, safe for concurrent use.
+func (w *eventWatcher) writeLine(line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintln(w.out, line)
+}
+
+// status records a connection state change. Only jsonl output shows it.
+func (w *eventWatcher) status(kind string, err error) {
+	w.mu.Lock()
+	w.connected = kind != "gerry-disconnect"
+	w.mu.Unlock()
+
+	if !w.jsonl {
+		return
+	}
+	rec := watchStatusRecord{Type: kind, Server: w.cfg.Server, EventCreatedOn: time.Now().Unix()}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	data, _ := json.Marshal(rec)
+	w.writeLine(string(data))
+}
+
+// heartbeat emits gerry-heartbeat records while the stream is open, so a
+// consumer can tell a quiet server from a dead pipe.
+func (w *eventWatcher) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		w.mu.Lock()
+		connected := w.connected
+		w.mu.Unlock()
+		if connected {
+			w.status("gerry-heartbeat", nil)
+		}
+	}
+}
+
+// warnf reports a rule failure without corrupting jsonl output.
+func (w *eventWatcher) warnf(format string, args ...interface{}) {
+	if w.jsonl {
+		fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+		return
+	}
+	utils.Warnf(format, args...)
+}
+
+// stream follows one stream-events session until it ends. received reports
+// whether any event arrived, which tells a dropped connection from one that
+// never worked (e.g. missing capability).
+func (w *eventWatcher) stream(client *gerrit.SSHClient) (received bool, err error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(client.StreamCommandArgs(pw, "stream-events"))
+	}()
+	defer pr.Close()
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		received = true
+
+		var ev gerrit.StreamEvent
+		if err := json.Unmarshal(line, &ev); err != nil || ev.Type == "" {
+			utils.Debugf("Failed to parse event: %s", line)
+			continue
+		}
+		w.handle(ev, line)
+	}
+	return received, scanner.Err()
+}
+
+// handle runs the action of every rule matching ev. Matching print rules
+// print the event once, however many there are.
+func (w *eventWatcher) handle(ev gerrit.StreamEvent, raw []byte) {
+	printed := false
+	for _, rule := range w.rules {
+		if !matchWatchRule(rule, ev, w.cfg.User) {
+			continue
+		}
+		utils.Debugf("Event %s matched rule %s", ev.Type, watchRuleLabel(rule))
+
+		switch rule.Action {
+		case "notify":
+			title, message := watchNotification(ev)
+			if err := utils.Notify(title, message); err != nil {
+				w.warnf("Rule %s: %v", watchRuleLabel(rule), err)
+			}
+		case "exec":
+			if err := w.runCommand(rule.Command, ev, raw); err != nil {
+				w.warnf("Rule %s: command failed: %v", watchRuleLabel(rule), err)
+			}
+		default:
+			if printed {
+				continue
+			}
+			printed = true
+			if w.jsonl {
+				w.writeLine(string(raw))
+			} else {
+				w.writeLine(formatWatchEvent(ev))
+			}
+		}
+	}
+}
+
+// matchWatchRule reports whether ev passes every filter of rule. Project,
+// branch, and owner filters only match events about a change.
+func matchWatchRule(rule config.WatchRule, ev gerrit.StreamEvent, self string) bool {
+	if len(rule.Events) > 0 && !containsString(rule.Events, ev.Type) {
+		return false
+	}
+	if len(rule.Projects) == 0 && rule.Branch == "" && rule.Owner == "" {
+		return true
+	}
+	if ev.Change == nil {
+		return false
+	}
+
+	if len(rule.Projects) > 0 {
+		matched := false
+		for _, pattern := range rule.Projects {
+			if ok, _ := path.Match(pattern, ev.Change.Project); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if rule.Branch != "" {
+		if ok, _ := path.Match(rule.Branch, ev.Change.Branch); !ok {
+			return false
+		}
+	}
+	if rule.Owner != "" {
+		owner := rule.Owner
+		if owner == "self" {
+			owner = self
+		}
+		o := ev.Change.Owner
+		if !strings.EqualFold(owner, o.Username) && !strings.EqualFold(owner, o.Email) && !strings.EqualFold(owner, o.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func watchRuleLabel(rule config.WatchRule) string {
+	if rule.Name == "" {
+		return "(unnamed)"
+	}
+	return rule.Name
+}
+
+// watchEventTime returns when the event happened, falling back to now.
+func watchEventTime(ev gerrit.StreamEvent) time.Time {
+	if ev.EventCreatedOn > 0 {
+		return time.Unix(ev.EventCreatedOn, 0)
+	}
+	return time.Now()
+}
+
+// formatWatchEvent renders an event as a single line for the terminal.
+func formatWatchEvent(ev gerrit.StreamEvent) string {
+	parts := []string{
+		utils.Gray(watchEventTime(ev).Format("15:04:05")),
+		utils.BoldYellow(ev.Type),
+	}
+	if ev.Change != nil {
+		parts = append(parts,
+			utils.BoldCyan(ev.Change.ChangeNumberStr()),
+			fmt.Sprintf("%s (%s)", ev.Change.Project, ev.Change.Branch),
+			ev.Change.Subject)
+	}
+	if actor := ev.Actor(); actor != nil {
+		parts = append(parts, utils.Gray("— "+actor.DisplayName()))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// watchNotification returns the title and body for a desktop notification.
+func watchNotification(ev gerrit.StreamEvent) (string, string) {
+	title := "Gerrit: " + ev.Type
+	if ev.Change == nil {
+		return title, ev.Type
+	}
+	title = fmt.Sprintf("Gerrit %s: %s", ev.Change.ChangeNumberStr(), ev.Type)
+	message := ev.Change.Subject
+	if actor := ev.Actor(); actor != nil {
+		message += " (" + actor.DisplayName() + ")"
+	}
+	return title, message
+}
+
+// runCommand runs an exec action with the raw event JSON on stdin and the
+// event's key fields in the environment. With jsonl output the command's
+// stdout goes to stderr so it cannot interleave with records.
+func (w *eventWatcher) runCommand(command string, ev gerrit.StreamEvent, raw []byte) error {
+	cmd := shellCommand(command)
+	cmd.Stdin = strings.NewReader(string(raw) + "\n")
+	cmd.Stdout = os.Stdout
+	if w.jsonl {
+		cmd.Stdout = os.Stderr
+	}
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), watchCommandEnv(ev, w.cfg)...)
+	return cmd.Run()
+}
+
+// shellCommand runs command through the platform's shell: sh, or cmd.exe on
+// Windows.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+func watchCommandEnv(ev gerrit.StreamEvent, cfg *config.Config) []string {
+	env := []string{"GERRY_EVENT=" + ev.Type}
+	if ev.Change != nil {
+		env = append(env,
+			"GERRY_CHANGE="+strconv.Itoa(ev.Change.ChangeNumber()),
+			"GERRY_PROJECT="+ev.Change.Project,
+			"GERRY_BRANCH="+ev.Change.Branch,
+			"GERRY_SUBJECT="+ev.Change.Subject,
+			"GERRY_URL="+changeURL(cfg, *ev.Change))
+	}
+	if actor := ev.Actor(); actor != nil {
+		env = append(env, "GERRY_ACTOR="+actor.DisplayName())
+	}
+	return env
+}
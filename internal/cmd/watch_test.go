@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/gerrit"
+)
+
+func TestMatchWatchRule(t *testing.T) {
+	merged := gerrit.StreamEvent{
+		Type: "change-merged",
+		Change: &gerrit.Change{
+			Project: "infra/deploy",
+			Branch:  "main",
+			Owner:   gerrit.Account{Username: "alice", Email: "alice@example.com"},
+		},
+	}
+	refUpdated := gerrit.StreamEvent{Type: "ref-updated"}
+
+	tests := []struct {
+		name string
+		rule config.WatchRule
+		ev   gerrit.StreamEvent
+		want bool
+	}{
+		{"empty rule matches everything", config.WatchRule{}, refUpdated, true},
+		{"event type", config.WatchRule{Events: []string{"change-merged"}}, merged, true},
+		{"other event type", config.WatchRule{Events: []string{"comment-added"}}, merged, false},
+		{"project glob", config.WatchRule{Projects: []string{"web", "infra/*"}}, merged, true},
+		{"glob does not cross slash", config.WatchRule{Projects: []string{"*"}}, merged, false},
+		{"branch glob", config.WatchRule{Branch: "release-*"}, merged, false},
+		{"owner by email", config.WatchRule{Owner: "Alice@example.com"}, merged, true},
+		{"owner self", config.WatchRule{Owner: "self"}, merged, true},
+		{"other owner", config.WatchRule{Owner: "bob"}, merged, false},
+		{"change filter on changeless event", config.WatchRule{Branch: "main"}, refUpdated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchWatchRule(tt.rule, tt.ev, "alice"); got != tt.want {
+				t.Errorf("matchWatchRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectWatchRules(t *testing.T) {
+	rules := []config.WatchRule{{Name: "mine"}, {Name: "deploy"}}
+
+	got, err := selectWatchRules(rules, nil)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("selectWatchRules(nil) = %v, %v; want all rules", got, err)
+	}
+
+	got, err = selectWatchRules(rules, []string{"deploy"})
+	if err != nil || len(got) != 1 || got[0].Name != "deploy" {
+		t.Fatalf("selectWatchRules(deploy) = %v, %v", got, err)
+	}
+
+	if _, err := selectWatchRules(rules, []string{"missing"}); err == nil {
+		t.Error("selectWatchRules(missing) succeeded, want error")
+	}
+}
+
+func TestEventWatcherJSONL(t *testing.T) {
+	var out bytes.Buffer
+	w := &eventWatcher{
+		cfg:   &config.Config{Server: "gerrit.example.com", User: "alice"},
+		rules: []config.WatchRule{{Events: []string{"change-merged"}}, {Projects: []string{"infra/*"}}},
+		jsonl: true,
+		out:   &out,
+	}
+
+	raw := `{"type":"change-merged","change":{"project":"infra/deploy","branch":"main","number":1}}`
+	var ev gerrit.StreamEvent
+	if err := json.Unmarshal([]byte(raw), &ev); err != nil {
+		t.Fatal(err)
+	}
+	w.handle(ev, []byte(raw))
+	w.handle(gerrit.StreamEvent{Type: "ref-updated"}, []byte(`{"type":"ref-updated"}`))
+
+	// Both rules match the merge but it is written once; ref-updated matches neither.
+	if got, want := out.String(), raw+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	w.status("gerry-disconnect", errors.New("EOF"))
+	var rec watchStatusRecord
+	if err := json.Unmarshal(out.Bytes(), &rec); err != nil {
+		t.Fatalf("status record is not JSON: %v (%q)", err, out.String())
+	}
+	if rec.Type != "gerry-disconnect" || rec.Error != "EOF" || rec.Server != "gerrit.example.com" {
+		t.Errorf("status record = %+v", rec)
+	}
+	if w.connected {
+		t.Error("connected = true after gerry-disconnect")
+	}
+}
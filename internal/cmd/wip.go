@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wipMessage   string
+	readyMessage string
+)
+
+var wipCmd = &cobra.Command{
+	Use:   "wip <change-id>",
+	Short: "Mark a change work in progress",
+	Long: `Mark a change work in progress, hiding it from reviewers' dashboards and
+stopping notifications until it is ready again.
+
+Examples:
+  gerry wip 12345
+  gerry wip 12345 -m "Reworking after the API discussion"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWIP,
+}
+
+var readyCmd = &cobra.Command{
+	Use:   "ready <change-id>",
+	Short: "Mark a work-in-progress change ready for review",
+	Long: `Mark a work-in-progress change ready for review, notifying its reviewers.
+
+Examples:
+  gerry ready 12345
+  gerry ready 12345 -m "Addressed all comments, PTAL"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReady,
+}
+
+func init() {
+	wipCmd.Flags().StringVarP(&wipMessage, "message", "m", "", "Message to post with the change")
+	readyCmd.Flags().StringVarP(&readyMessage, "message", "m", "", "Message to post with the change")
+}
+
+func runWIP(cmd *cobra.Command, args []string) error {
+	return setWIPState(args[0], true, wipMessage)
+}
+
+func runReady(cmd *cobra.Command, args []string) error {
+	return setWIPState(args[0], false, readyMessage)
+}
+
+func setWIPState(changeID string, wip bool, message string) error {
+	if err := utils.ValidateChangeID(changeID); err != nil {
+		return fmt.Errorf("invalid change ID: %w", err)
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	message = strings.TrimSpace(message)
+	if wip {
+		err = client.SetWorkInProgress(changeID, message)
+	} else {
+		err = client.SetReadyForReview(changeID, message)
+	}
+	if err != nil {
+		// Gerrit rejects a no-op toggle with 409 Conflict.
+		if isConflictError(err) {
+			if wip {
+				return fmt.Errorf("change %s is already work in progress", changeID)
+			}
+			return fmt.Errorf("change %s is already ready for review", changeID)
+		}
+		return fmt.Errorf("failed to update change: %w", err)
+	}
+
+	if wip {
+		fmt.Printf("%s Marked %s work in progress\n", utils.Green("✓"), changeID)
+	} else {
+		fmt.Printf("%s Marked %s ready for review\n", utils.Green("✓"), changeID)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var workspaceProject string
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage the checkouts --workspace commands operate on",
+	Long: `Manage the workspace manifest: the local checkouts you work in and the
+Gerrit project each one holds. It lives under "workspace" in
+~/.gerry/config.json:
+
+  "workspace": [
+    {"path": "~/src/canvas-lms", "project": "canvas-lms"},
+    {"path": "~/src/quizzes", "project": "quizzes"}
+  ]
+
+With the manifest in place, 'gerry list --workspace' shows your changes
+across all those projects, 'gerry trees --workspace' lists the worktrees of
+every checkout, and 'gerry tree setup --workspace <change-id>' creates the
+worktree in whichever checkout holds the change's project, from any directory.
+
+Examples:
+  gerry workspace add ~/src/canvas-lms
+  gerry workspace add . --project quizzes
+  gerry workspace list
+  gerry workspace remove quizzes`,
+}
+
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add [path]",
+	Short: "Add a checkout to the workspace",
+	Long: `Add a git checkout (default: the current one) to the workspace. The project
+is taken from --project, the checkout's .gitreview, or its origin remote URL,
+in that order. Adding a checkout again updates its project.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWorkspaceAdd,
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the workspace checkouts",
+	Args:    cobra.NoArgs,
+	RunE:    runWorkspaceList,
+}
+
+var workspaceRemoveCmd = &cobra.Command{
+	Use:     "remove <project|path>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a checkout from the workspace",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWorkspaceRemove,
+}
+
+func init() {
+	workspaceAddCmd.Flags().StringVar(&workspaceProject, "project", "", "Gerrit project the checkout holds")
+
+	workspaceCmd.AddCommand(workspaceAddCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+	workspaceCmd.AddCommand(workspaceRemoveCmd)
+}
+
+func runWorkspaceAdd(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	root, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return fmt.Errorf("%s is not a git checkout", dir)
+	}
+	root = filepath.FromSlash(root)
+
+	project := workspaceProject
+	if project == "" {
+		project, err = detectCheckoutProject(root)
+		if err != nil {
+			return err
+		}
+	}
+
+	replaced := false
+	err = config.Update(func(c *config.Config) error {
+		for i, r := range c.Workspace {
+			if r.ExpandedPath() == root {
+				c.Workspace[i].Project = project
+				replaced = true
+				return nil
+			}
+		}
+		c.Workspace = append(c.Workspace, config.WorkspaceRepo{Path: root, Project: project})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save workspace: %w", err)
+	}
+
+	verb := "Added"
+	if replaced {
+		verb = "Updated"
+	}
+	fmt.Printf("%s %s %s (%s)\n", utils.Green("✓"), verb, utils.BoldCyan(project), root)
+	return nil
+}
+
+func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if len(cfg.Workspace) == 0 {
+		fmt.Println("No workspace checkouts. Add one with 'gerry workspace add <path>'.")
+		return nil
+	}
+
+	var rows [][]string
+	for _, r := range cfg.Workspace {
+		branch := utils.Red("missing")
+		if _, err := os.Stat(r.ExpandedPath()); err == nil {
+			branch = utils.Gray("(detached)")
+			if b, err := gitOutput(r.ExpandedPath(), "symbolic-ref", "--short", "HEAD"); err == nil {
+				branch = b
+			}
+		}
+		rows = append(rows, []string{utils.BoldCyan(r.Project), r.Path, branch})
+	}
+	fmt.Print(utils.FormatTable([]string{"Project", "Path", "Branch"}, rows, 2))
+	return nil
+}
+
+func runWorkspaceRemove(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	abs, _ := filepath.Abs(target)
+
+	var removed config.WorkspaceRepo
+	err := config.Update(func(c *config.Config) error {
+		for i, r := range c.Workspace {
+			if r.Project == target || r.Path == target || r.ExpandedPath() == abs {
+				removed = r
+				c.Workspace = append(c.Workspace[:i], c.Workspace[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("no workspace checkout for %q (see 'gerry workspace list')", target)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Removed %s (%s) from the workspace\n", utils.Green("✓"), utils.BoldCyan(removed.Project), removed.Path)
+	return nil
+}
+
+// loadWorkspace returns the configured workspace, or an error explaining how
+// to create one.
+func loadWorkspace(cfg *config.Config) ([]config.WorkspaceRepo, error) {
+	if len(cfg.Workspace) == 0 {
+		return nil, fmt.Errorf("no workspace configured; add checkouts with 'gerry workspace add <path>'")
+	}
+	return cfg.Workspace, nil
+}
+
+// workspaceQuery restricts a Gerrit query to the workspace projects.
+func workspaceQuery(repos []config.WorkspaceRepo) string {
+	terms := make([]string, 0, len(repos))
+	for _, r := range repos {
+		terms = append(terms, "project:"+r.Project)
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// detectCheckoutProject works out the Gerrit project of a checkout from its
+// .gitreview or, failing that, its origin remote.
+func detectCheckoutProject(root string) (string, error) {
+	if data, err := os.ReadFile(filepath.Join(root, ".gitreview")); err == nil {
+		if review, err := parseGitReview(string(data)); err == nil && review.Project != "" {
+			return review.Project, nil
+		}
+	}
+	remote, err := gitOutput(root, "remote", "get-url", "origin")
+	if err == nil {
+		if project := projectFromRemoteURL(remote); project != "" {
+			return project, nil
+		}
+	}
+	return "", fmt.Errorf("could not determine the Gerrit project of %s; pass --project", root)
+}
+
+// checkoutProject returns the Gerrit project of the checkout at root, or the
+// configured default project when the checkout's can't be detected.
+func checkoutProject(cfg *config.Config, root string) (string, error) {
+	project, err := detectCheckoutProject(root)
+	if err != nil && cfg.Project != "" {
+		return cfg.Project, nil
+	}
+	return project, err
+}
+
+// projectFromRemoteURL extracts the project from a Gerrit remote URL such as
+// ssh://user@host:29418/team/project.git, https://host/a/team/project, or
+// user@host:team/project.git.
+func projectFromRemoteURL(remote string) string {
+	var path string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		path = u.Path
+		if u.Scheme == "http" || u.Scheme == "https" {
+			// Authenticated HTTP remotes go through Gerrit's /a/ prefix.
+			path = strings.TrimPrefix(path, "/a/")
+		}
+	} else if i := strings.Index(remote, ":"); i > 0 && !strings.Contains(remote[:i], "/") {
+		path = remote[i+1:]
+	} else {
+		return ""
+	}
+	return strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestWorkspaceQuery(t *testing.T) {
+	one := []config.WorkspaceRepo{{Path: "~/src/a", Project: "canvas-lms"}}
+	if got := workspaceQuery(one); got != "project:canvas-lms" {
+		t.Errorf("workspaceQuery(one) = %q", got)
+	}
+	two := append(one, config.WorkspaceRepo{Path: "~/src/b", Project: "tools/quizzes"})
+	if got, want := workspaceQuery(two), "(project:canvas-lms OR project:tools/quizzes)"; got != want {
+		t.Errorf("workspaceQuery(two) = %q, want %q", got, want)
+	}
+}
+
+func TestProjectFromRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"ssh://jdoe@gerrit.example.com:29418/canvas-lms", "canvas-lms"},
+		{"ssh://jdoe@gerrit.example.com:29418/team/project.git", "team/project"},
+		{"https://gerrit.example.com/a/team/project", "team/project"},
+		{"https://gerrit.example.com/canvas-lms.git/", "canvas-lms"},
+		{"jdoe@gerrit.example.com:team/project.git", "team/project"},
+		{"/srv/git/project.git", ""},
+	}
+	for _, tt := range tests {
+		if got := projectFromRemoteURL(tt.remote); got != tt.want {
+			t.Errorf("projectFromRemoteURL(%q) = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestCheckoutProject(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitreview"), []byte("[gerrit]\nhost=review.example.com\nproject=outcomes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	unknown := t.TempDir()
+
+	tests := []struct {
+		name    string
+		root    string
+		project string
+		want    string
+		wantErr bool
+	}{
+		{"detected over the default", root, "canvas-lms", "outcomes", false},
+		{"detected without a default", root, "", "outcomes", false},
+		{"default when undetected", unknown, "canvas-lms", "canvas-lms", false},
+		{"neither", unknown, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkoutProject(&config.Config{Project: tt.project}, tt.root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkoutProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkoutProject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
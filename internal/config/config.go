@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/utils"
 )
@@ -16,6 +19,156 @@ type Config struct {
 	User         string `json:"user"`
 	HTTPPassword string `json:"http_password,omitempty"`
 	Project      string `json:"project,omitempty"`
+
+	// DefaultBranch is the project's integration branch (e.g. "master"),
+	// used instead of "main" where a command needs one.
+	DefaultBranch string `json:"default_branch,omitempty"`
+
+	// SSHProxyJump is passed to ssh as -J, e.g. "bastion.example.com" or
+	// "user@bastion:2222", for servers only reachable through a jump host.
+	SSHProxyJump string `json:"ssh_proxy_jump,omitempty"`
+
+	// FetchProtocol is how git fetches changes from the server: "ssh" (the
+	// default) or "http" for the authenticated HTTP(S) remote, for networks
+	// that block the SSH port.
+	FetchProtocol string `json:"fetch_protocol,omitempty"`
+
+	// CacheTTL is how long REST GET responses are reused from
+	// ~/.gerry/cache, e.g. "1m". Empty disables the cache.
+	CacheTTL string `json:"cache_ttl,omitempty"`
+
+	// DiffPager is a shell command `gerry diff` pipes its output through
+	// when writing to a terminal, e.g. "less -R" or "delta".
+	DiffPager string `json:"diff_pager,omitempty"`
+
+	// WatchRules route stream events for `gerry watch`.
+	WatchRules []WatchRule `json:"watch_rules,omitempty"`
+
+	// Searches are named queries for `gerry search run`.
+	Searches map[string]string `json:"searches,omitempty"`
+
+	// LabelAliases map vote shorthands (e.g. "cr", "v", "qa") to this
+	// server's label names, overriding gerry's defaults for `gerry vote`.
+	LabelAliases map[string]string `json:"label_aliases,omitempty"`
+
+	// AuthorAliases map an account's email, username, or name to the name
+	// `gerry analyze` credits it under, so one person's several accounts
+	// count together. Keys match case-insensitively.
+	AuthorAliases map[string]string `json:"author_aliases,omitempty"`
+
+	// BotPatterns are path.Match globs (e.g. "*jenkins*") matched,
+	// case-insensitively, against an account's name, username, and email.
+	// `gerry analyze` leaves out changes owned by matching accounts and
+	// doesn't count their messages as reviews.
+	BotPatterns []string `json:"bot_patterns,omitempty"`
+
+	// ReviewerSets are named bundles of reviewers (users or groups) for
+	// `gerry share --set`.
+	ReviewerSets map[string][]string `json:"reviewer_sets,omitempty"`
+
+	// Teams are named groups of accounts (usernames or emails) for
+	// `gerry team --team` and `gerry list --owner-team`.
+	Teams map[string][]string `json:"teams,omitempty"`
+
+	// TestReports tell `gerry failures` how to read test results that CI
+	// bots post in change messages.
+	TestReports []TestReportFormat `json:"test_reports,omitempty"`
+
+	// SizeThresholds override the limits `gerry size` classifies changes by.
+	SizeThresholds *SizeThresholds `json:"size_thresholds,omitempty"`
+
+	// SubmitQueue tells `gerry queue` where to read the submit queue's state.
+	SubmitQueue *SubmitQueue `json:"submit_queue,omitempty"`
+
+	// Exporter configures the queries `gerry exporter` publishes metrics for.
+	Exporter *Exporter `json:"exporter,omitempty"`
+
+	// Workspace lists local checkouts for commands run with --workspace.
+	Workspace []WorkspaceRepo `json:"workspace,omitempty"`
+}
+
+// WatchRule selects stream events and says what to do with them. Empty
+// filters match everything; Projects and Branch are path.Match globs.
+type WatchRule struct {
+	Name     string   `json:"name,omitempty"`
+	Projects []string `json:"projects,omitempty"`
+	Branch   string   `json:"branch,omitempty"`
+	Owner    string   `json:"owner,omitempty"`
+	Events   []string `json:"events,omitempty"`
+	Action   string   `json:"action,omitempty"` // print (default), notify, or exec
+	Command  string   `json:"command,omitempty"`
+}
+
+// TestReportFormat describes one bot's test summary. Exactly one of Pattern,
+// a per-line regexp with a named group "name" and optional "status" and
+// "duration" groups, or JSONMarker, the line that precedes a JSON block of
+// the form {"tests": [{"name": ..., "status": ..., "duration": seconds}]},
+// is set. Author limits the format to messages from matching bots.
+type TestReportFormat struct {
+	Name       string `json:"name,omitempty"`
+	Author     string `json:"author,omitempty"`
+	Pattern    string `json:"pattern,omitempty"`
+	JSONMarker string `json:"json_marker,omitempty"`
+}
+
+// SizeThresholds are the largest number of changed lines (inserted plus
+// deleted) a change may have to be classified XS, S, M, or L; anything
+// bigger is XL. Zero fields keep their defaults.
+type SizeThresholds struct {
+	XS int `json:"xs,omitempty"`
+	S  int `json:"s,omitempty"`
+	M  int `json:"m,omitempty"`
+	L  int `json:"l,omitempty"`
+}
+
+// SubmitQueue locates a Zuul-style status endpoint, e.g.
+// https://zuul.example.com/api/tenant/main/status. Pipelines limits which
+// pipelines count as the submit queue; the default is "gate".
+type SubmitQueue struct {
+	StatusURL string   `json:"status_url"`
+	Pipelines []string `json:"pipelines,omitempty"`
+}
+
+// Exporter lists the queries `gerry exporter` evaluates and how often, as
+// a duration such as "5m" (the default).
+type Exporter struct {
+	Interval string          `json:"interval,omitempty"`
+	Queries  []ExporterQuery `json:"queries,omitempty"`
+}
+
+// ExporterQuery is a Gerrit search whose changes are measured under Name,
+// the value of the metrics' query label.
+type ExporterQuery struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// WorkspaceRepo maps a local checkout to the Gerrit project it holds. Path
+// may start with "~/".
+type WorkspaceRepo struct {
+	Path    string `json:"path"`
+	Project string `json:"project"`
+}
+
+// ExpandedPath returns Path with a leading "~/" replaced by the home
+// directory.
+func (r WorkspaceRepo) ExpandedPath() string {
+	if r.Path == "~" || strings.HasPrefix(r.Path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(r.Path, "~"))
+		}
+	}
+	return r.Path
+}
+
+// WorkspaceRepoFor returns the workspace checkout of project.
+func (c *Config) WorkspaceRepoFor(project string) (WorkspaceRepo, bool) {
+	for _, r := range c.Workspace {
+		if r.Project == project {
+			return r, true
+		}
+	}
+	return WorkspaceRepo{}, false
 }
 
 const (
@@ -51,18 +204,22 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	var config Config
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("config file not found at %s, run 'gerry init' to create one", configPath)
-	}
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
+		// CI jobs typically have no config file; the environment is enough
+		// when it names at least the server and user.
+		if os.Getenv("GERRIT_SERVER") == "" || os.Getenv("GERRIT_USER") == "" {
+			return nil, fmt.Errorf("config file not found at %s, run 'gerry init' to create one (or set GERRIT_SERVER and GERRIT_USER)", configPath)
+		}
+	} else {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
 	}
 
 	// Apply defaults
@@ -77,6 +234,9 @@ func Load() (*Config, error) {
 	if port := os.Getenv("GERRIT_PORT"); port != "" {
 		fmt.Sscanf(port, "%d", &config.Port)
 	}
+	if httpPort := os.Getenv("GERRIT_HTTP_PORT"); httpPort != "" {
+		fmt.Sscanf(httpPort, "%d", &config.HTTPPort)
+	}
 	if user := os.Getenv("GERRIT_USER"); user != "" {
 		config.User = user
 	}
@@ -86,6 +246,12 @@ func Load() (*Config, error) {
 	if project := os.Getenv("GERRIT_PROJECT"); project != "" {
 		config.Project = project
 	}
+	if jump := os.Getenv("GERRIT_SSH_PROXY_JUMP"); jump != "" {
+		config.SSHProxyJump = jump
+	}
+	if protocol := os.Getenv("GERRIT_FETCH_PROTOCOL"); protocol != "" {
+		config.FetchProtocol = protocol
+	}
 
 	return &config, nil
 }
@@ -129,6 +295,43 @@ func Save(config *Config) error {
 	return nil
 }
 
+// Update applies fn to the configuration file as stored, without defaults or
+// environment overrides, and writes it back. Use it for settings commands so
+// values taken from the environment (e.g. GERRIT_HTTP_PASSWORD) are never
+// persisted. A missing file is treated as empty.
+func Update(fn func(*Config) error) error {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	var config Config
+	data, err := os.ReadFile(configPath)
+	if err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("failed to parse config file: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := fn(&config); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
 func (c *Config) Validate() error {
 	if err := utils.ValidateServerURL(c.Server); err != nil {
 		return fmt.Errorf("invalid server: %w", err)
@@ -148,9 +351,127 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.CacheTTL != "" {
+		if _, err := utils.ParseDuration(c.CacheTTL); err != nil {
+			return fmt.Errorf("invalid cache_ttl: %w", err)
+		}
+	}
+
+	for _, pattern := range c.BotPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid bot_patterns entry %q: %w", pattern, err)
+		}
+	}
+	for from, to := range c.AuthorAliases {
+		if strings.TrimSpace(to) == "" {
+			return fmt.Errorf("invalid author_aliases entry %q: name must not be empty", from)
+		}
+	}
+	for name, members := range c.Teams {
+		for _, member := range members {
+			if strings.TrimSpace(member) == "" {
+				return fmt.Errorf("invalid teams entry %q: members must not be empty", name)
+			}
+		}
+	}
+
+	if err := ValidateFetchProtocol(c.FetchProtocol); err != nil {
+		return err
+	}
+
+	// The jump host also ends up in GIT_SSH_COMMAND, which git runs through
+	// a shell.
+	if strings.ContainsAny(c.SSHProxyJump, " \t\n'\"\\$`;|&<>()*?") {
+		return fmt.Errorf("invalid ssh_proxy_jump %q: must be [user@]host[:port][,...]", c.SSHProxyJump)
+	}
+
 	return nil
 }
 
+// ValidateFetchProtocol checks a fetch_protocol setting or --via flag.
+func ValidateFetchProtocol(protocol string) error {
+	switch protocol {
+	case "", "ssh", "http":
+		return nil
+	}
+	return fmt.Errorf("invalid fetch protocol %q (supported: ssh, http)", protocol)
+}
+
+// FetchesOverHTTP reports whether git should fetch from the HTTP(S) remote.
+func (c *Config) FetchesOverHTTP() bool {
+	return c.FetchProtocol == "http"
+}
+
+// Validate checks that the rule's action is known and its globs are well formed.
+func (r WatchRule) Validate() error {
+	switch r.Action {
+	case "", "print", "notify":
+	case "exec":
+		if r.Command == "" {
+			return fmt.Errorf("exec action requires a command")
+		}
+	default:
+		return fmt.Errorf("unknown action %q (supported: print, notify, exec)", r.Action)
+	}
+	for _, pattern := range append([]string{r.Branch}, r.Projects...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that exactly one of Pattern and JSONMarker is set and that
+// Pattern compiles with a "name" group.
+func (f TestReportFormat) Validate() error {
+	if (f.Pattern == "") == (f.JSONMarker == "") {
+		return fmt.Errorf("exactly one of pattern and json_marker must be set")
+	}
+	if f.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(f.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	if re.SubexpIndex("name") < 0 {
+		return fmt.Errorf("pattern must have a named group (?P<name>...)")
+	}
+	return nil
+}
+
+// Validate checks that the thresholds are non-negative and increasing once
+// defaults are filled in.
+func (t SizeThresholds) Validate() error {
+	if t.XS < 0 || t.S < 0 || t.M < 0 || t.L < 0 {
+		return fmt.Errorf("size thresholds must not be negative")
+	}
+	w := t.WithDefaults()
+	if !(w.XS < w.S && w.S < w.M && w.M < w.L) {
+		return fmt.Errorf("size thresholds must increase: xs=%d s=%d m=%d l=%d", w.XS, w.S, w.M, w.L)
+	}
+	return nil
+}
+
+// WithDefaults fills zero thresholds with the defaults: 10, 50, 250, and
+// 1000 lines.
+func (t SizeThresholds) WithDefaults() SizeThresholds {
+	defaults := SizeThresholds{XS: 10, S: 50, M: 250, L: 1000}
+	if t.XS == 0 {
+		t.XS = defaults.XS
+	}
+	if t.S == 0 {
+		t.S = defaults.S
+	}
+	if t.M == 0 {
+		t.M = defaults.M
+	}
+	if t.L == 0 {
+		t.L = defaults.L
+	}
+	return t
+}
+
 func (c *Config) GetSSHCommand() string {
 	return fmt.Sprintf("ssh -p %d %s@%s gerrit", c.Port, c.User, c.Server)
 }
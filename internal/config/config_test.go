@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUpdateIgnoresEnvironment(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stored := `{"server": "gerrit.example.com", "port": 29418, "user": "alice"}`
+	if err := os.WriteFile(configPath, []byte(stored), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GERRIT_SERVER", "env.example.com")
+	t.Setenv("GERRIT_USER", "ci-bot")
+	t.Setenv("GERRIT_HTTP_PASSWORD", "s3cret")
+	t.Setenv("GERRIT_PROJECT", "canvas-lms")
+
+	err = Update(func(c *Config) error {
+		if c.Server != "gerrit.example.com" || c.User != "alice" || c.HTTPPassword != "" {
+			t.Errorf("Update() config = %+v, want the stored values only", c)
+		}
+		c.Searches = map[string]string{"hotfixes": "branch:stable/* status:open"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"env.example.com", "ci-bot", "s3cret", "canvas-lms"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("config file contains %q from the environment:\n%s", leaked, data)
+		}
+	}
+
+	// The saved search is there once the environment is out of the way.
+	for _, name := range []string{"GERRIT_SERVER", "GERRIT_USER", "GERRIT_HTTP_PASSWORD", "GERRIT_PROJECT"} {
+		t.Setenv(name, "")
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := map[string]string{"hotfixes": "branch:stable/* status:open"}; !reflect.DeepEqual(cfg.Searches, want) {
+		t.Errorf("Searches = %v, want %v", cfg.Searches, want)
+	}
+	if cfg.Server != "gerrit.example.com" || cfg.User != "alice" {
+		t.Errorf("Load() = %+v, want the stored server and user", cfg)
+	}
+}
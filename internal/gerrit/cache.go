@@ -0,0 +1,137 @@
+package gerrit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
+)
+
+// cacheDisabled is set by --no-cache.
+var cacheDisabled bool
+
+// DisableCache turns off the response cache for clients created afterwards.
+func DisableCache() {
+	cacheDisabled = true
+}
+
+// CacheDir returns the directory GET responses are cached in.
+func CacheDir() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// ClearCache deletes every cached response and returns how many there were.
+func ClearCache() (int, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return 0, err
+	}
+	return (&responseCache{dir: dir}).clear()
+}
+
+// responseCache keeps GET response bodies on disk for ttl, one file per
+// request named by a hash of the account and URL. A nil cache caches nothing.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// newResponseCache returns the cache configured by cache_ttl, or nil when
+// caching is off.
+func newResponseCache(cfg *config.Config) *responseCache {
+	if cacheDisabled || cfg.CacheTTL == "" {
+		return nil
+	}
+	ttl, err := utils.ParseDuration(cfg.CacheTTL)
+	if err != nil || ttl <= 0 {
+		return nil
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return nil
+	}
+	return &responseCache{dir: dir, ttl: ttl}
+}
+
+func (rc *responseCache) path(user, url string) string {
+	sum := sha256.Sum256([]byte(user + "\n" + url))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:]))
+}
+
+// get returns the cached body for url if it is younger than the TTL.
+func (rc *responseCache) get(user, url string, now time.Time) ([]byte, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	file := rc.path(user, url)
+	info, err := os.Stat(file)
+	if err != nil || now.Sub(info.ModTime()) >= rc.ttl {
+		return nil, false
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// put stores body for url. Failures only cost a cache miss later.
+func (rc *responseCache) put(user, url string, body []byte) {
+	if rc == nil {
+		return
+	}
+	if err := os.MkdirAll(rc.dir, 0700); err != nil {
+		utils.Debugf("Cache: %v", err)
+		return
+	}
+	tmp, err := os.CreateTemp(rc.dir, ".tmp-*")
+	if err != nil {
+		utils.Debugf("Cache: %v", err)
+		return
+	}
+	_, err = tmp.Write(body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), rc.path(user, url))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		utils.Debugf("Cache: %v", err)
+	}
+}
+
+// clear deletes every cached response and returns how many there were.
+func (rc *responseCache) clear() (int, error) {
+	if rc == nil {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(rc.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache: %w", err)
+	}
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(rc.dir, e.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to clear cache: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,126 @@
+package gerrit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestResponseCache(t *testing.T) {
+	rc := &responseCache{dir: t.TempDir(), ttl: time.Minute}
+	now := time.Now()
+
+	if _, ok := rc.get("alice", "https://g/a/changes/1", now); ok {
+		t.Fatal("get() hit on an empty cache")
+	}
+
+	rc.put("alice", "https://g/a/changes/1", []byte("one"))
+	if body, ok := rc.get("alice", "https://g/a/changes/1", now); !ok || string(body) != "one" {
+		t.Errorf("get() = %q, %v, want \"one\", true", body, ok)
+	}
+	if _, ok := rc.get("bob", "https://g/a/changes/1", now); ok {
+		t.Error("get() hit for a different account")
+	}
+	if _, ok := rc.get("alice", "https://g/a/changes/1", now.Add(2*time.Minute)); ok {
+		t.Error("get() hit after the TTL expired")
+	}
+
+	rc.put("alice", "https://g/a/changes/2", []byte("two"))
+	removed, err := rc.clear()
+	if err != nil || removed != 2 {
+		t.Errorf("clear() = %d, %v, want 2, nil", removed, err)
+	}
+	if _, ok := rc.get("alice", "https://g/a/changes/2", now); ok {
+		t.Error("get() hit after clear()")
+	}
+
+	var off *responseCache
+	off.put("alice", "https://g/a/changes/1", []byte("one"))
+	if _, ok := off.get("alice", "https://g/a/changes/1", now); ok {
+		t.Error("nil cache returned a hit")
+	}
+}
+
+func TestClientCachesGets(t *testing.T) {
+	var gets int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		io.WriteString(w, ")]}'\n{\"n\":1}")
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+	client.cache = &responseCache{dir: t.TempDir(), ttl: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		body, err := client.Get("changes/1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if strings.TrimSpace(string(body)) != `{"n":1}` {
+			t.Errorf("Get() = %q, want the body without the XSSI prefix", body)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("server saw %d GETs, want 1", gets)
+	}
+
+	if _, err := client.Post("changes/1/topic", map[string]string{"topic": "x"}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if _, err := client.Get("changes/1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if gets != 2 {
+		t.Errorf("server saw %d GETs after a write, want 2", gets)
+	}
+}
+
+func TestClientWithoutCachePollsServer(t *testing.T) {
+	var gets int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		io.WriteString(w, ")]}'\n{\"n\":"+strconv.Itoa(gets)+"}")
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+	client.cache = &responseCache{dir: t.TempDir(), ttl: time.Hour}
+
+	if _, err := client.Get("changes/1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	poller := client.WithoutCache()
+	for want := 2; want <= 3; want++ {
+		body, err := poller.Get("changes/1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got := strings.TrimSpace(string(body)); got != `{"n":`+strconv.Itoa(want)+`}` {
+			t.Errorf("poll %d = %s, want fresh state from the server", want-1, got)
+		}
+	}
+	if gets != 3 {
+		t.Errorf("server saw %d GETs, want 3", gets)
+	}
+
+	// The original client keeps its cache.
+	if body, _ := client.Get("changes/1"); strings.TrimSpace(string(body)) != `{"n":1}` {
+		t.Errorf("cached Get() = %s, want the cached body", body)
+	}
+}
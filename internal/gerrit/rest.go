@@ -2,20 +2,30 @@ package gerrit
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
 )
 
 type RESTClient struct {
 	config     *config.Config
 	httpClient *http.Client
+
+	// ctx bounds every request; nil means context.Background().
+	ctx context.Context
+
+	// cache holds recent GET responses; nil when caching is off.
+	cache *responseCache
 }
 
 func NewRESTClient(cfg *config.Config) *RESTClient {
@@ -28,47 +38,139 @@ func NewRESTClientWithTimeout(cfg *config.Config, timeout time.Duration) *RESTCl
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
+		cache: newResponseCache(cfg),
 	}
 }
 
+// WithContext returns a copy of the client whose requests, including waits
+// between throttled retries, are aborted when ctx is cancelled.
+func (c *RESTClient) WithContext(ctx context.Context) *RESTClient {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// WithoutCache returns a copy of the client that always asks the server,
+// for polling loops that must see new state within the cache TTL.
+func (c *RESTClient) WithoutCache() *RESTClient {
+	clone := *c
+	clone.cache = nil
+	return &clone
+}
+
+func (c *RESTClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Throttled requests (429 or 503) are retried up to maxThrottleRetries
+// times, waiting as long as the server's Retry-After asks, capped at
+// maxRetryWait.
+const (
+	maxThrottleRetries = 5
+	maxRetryWait       = 2 * time.Minute
+)
+
 func (c *RESTClient) doRequest(method, path string, body io.Reader) (*http.Response, error) {
 	url := c.config.GetRESTURL(strings.TrimPrefix(path, "/"))
 
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, err
+	// Keep the body so a throttled request can be sent again.
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
 	}
 
-	// Add basic auth
-	auth := base64.StdEncoding.EncodeToString([]byte(c.config.User + ":" + c.config.HTTPPassword))
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/json")
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(c.context(), method, url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request to %s failed: %w", url, err)
-	}
+		// Add basic auth
+		auth := base64.StdEncoding.EncodeToString([]byte(c.config.User + ":" + c.config.HTTPPassword))
+		req.Header.Set("Authorization", "Basic "+auth)
+		req.Header.Set("Content-Type", "application/json")
 
-	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request to %s failed: %w", url, err)
+		}
 
-		switch resp.StatusCode {
-		case 401:
-			return nil, fmt.Errorf("authentication failed (401) - check your HTTP password")
-		case 403:
-			return nil, fmt.Errorf("access forbidden (403) - check your permissions")
-		case 404:
-			return nil, fmt.Errorf("endpoint not found (404) - check server URL and port")
-		default:
-			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		throttled := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+		if throttled && attempt < maxThrottleRetries {
+			wait := retryAfter(resp.Header.Get("Retry-After"), attempt, time.Now())
+			resp.Body.Close()
+			utils.Notef("Server busy (%d), retrying in %s (attempt %d/%d)...", resp.StatusCode, wait, attempt+1, maxThrottleRetries)
+			timer := time.NewTimer(wait)
+			select {
+			case <-c.context().Done():
+				timer.Stop()
+				return nil, fmt.Errorf("request to %s failed: %w", url, c.context().Err())
+			case <-timer.C:
+			}
+			continue
 		}
+
+		if resp.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			switch resp.StatusCode {
+			case 401:
+				return nil, fmt.Errorf("authentication failed (401) - check your HTTP password")
+			case 403:
+				return nil, fmt.Errorf("access forbidden (403) - check your permissions")
+			case 404:
+				return nil, fmt.Errorf("endpoint not found (404) - check server URL and port")
+			case 429:
+				return nil, fmt.Errorf("rate limited (429) - still throttled after %d retries, try again later", maxThrottleRetries)
+			default:
+				return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+			}
+		}
+
+		// A write may change anything a cached GET returned.
+		if method != "GET" {
+			if _, err := c.cache.clear(); err != nil {
+				utils.Debugf("Cache: %v", err)
+			}
+		}
+
+		return resp, nil
 	}
+}
 
-	return resp, nil
+// retryAfter returns how long to wait before retrying a throttled request:
+// the Retry-After header in seconds or as an HTTP date, or exponential
+// backoff from one second when the header is missing or invalid.
+func retryAfter(header string, attempt int, now time.Time) time.Duration {
+	wait := time.Second << attempt
+	if secs, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && secs >= 0 {
+		wait = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(header); err == nil {
+		wait = at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+	}
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	return wait
 }
 
 func (c *RESTClient) Get(path string) ([]byte, error) {
+	url := c.config.GetRESTURL(strings.TrimPrefix(path, "/"))
+	if body, ok := c.cache.get(c.config.User, url, time.Now()); ok {
+		utils.Debugf("Cache hit: %s", url)
+		return body, nil
+	}
+
 	resp, err := c.doRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -85,6 +187,7 @@ func (c *RESTClient) Get(path string) ([]byte, error) {
 		body = body[4:]
 	}
 
+	c.cache.put(c.config.User, url, body)
 	return body, nil
 }
 
@@ -166,7 +269,17 @@ func (c *RESTClient) TestConnection() error {
 
 // GetChange retrieves a change by ID
 func (c *RESTClient) GetChange(changeID string) (*Change, error) {
-	resp, err := c.Get(fmt.Sprintf("changes/%s?o=DETAILED_LABELS&o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_ACCOUNTS", changeID))
+	return c.GetChangeWithOptions(changeID)
+}
+
+// GetChangeWithOptions retrieves a change, requesting extra ListChangesOption
+// values (e.g. "ALL_REVISIONS") on top of the defaults.
+func (c *RESTClient) GetChangeWithOptions(changeID string, options ...string) (*Change, error) {
+	path := fmt.Sprintf("changes/%s?o=DETAILED_LABELS&o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_ACCOUNTS", changeID)
+	for _, opt := range options {
+		path += "&o=" + opt
+	}
+	resp, err := c.Get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +309,20 @@ func (c *RESTClient) GetChangeComments(changeID string) (map[string][]CommentInf
 
 // ListChanges lists changes based on query
 func (c *RESTClient) ListChanges(query string, limit int) ([]Change, error) {
-	path := fmt.Sprintf("changes/?q=%s&n=%d&o=DETAILED_LABELS&o=CURRENT_REVISION&o=DETAILED_ACCOUNTS", query, limit)
+	return c.ListChangesWithOptions(query, limit)
+}
+
+// DefaultChangeOptions are the ListChangesOption values ListChanges
+// requests: enough for the change tables.
+var DefaultChangeOptions = []string{"DETAILED_LABELS", "CURRENT_REVISION", "DETAILED_ACCOUNTS"}
+
+// ListChangesWithOptions lists changes based on query, requesting extra
+// ListChangesOption values (e.g. "SUBMITTABLE") on top of the defaults.
+func (c *RESTClient) ListChangesWithOptions(query string, limit int, options ...string) ([]Change, error) {
+	path := fmt.Sprintf("changes/?q=%s&n=%d", query, limit)
+	for _, opt := range append(append([]string(nil), DefaultChangeOptions...), options...) {
+		path += "&o=" + opt
+	}
 	resp, err := c.Get(path)
 	if err != nil {
 		return nil, err
@@ -226,6 +352,140 @@ func (c *RESTClient) GetChangeFiles(changeID string, revision string) (map[strin
 	return files, nil
 }
 
+// GetChangeFilesAgainst returns the files modified in a revision relative to
+// base, an earlier patchset number of the same change.
+func (c *RESTClient) GetChangeFilesAgainst(changeID, revision, base string) (map[string]FileInfo, error) {
+	path := fmt.Sprintf("changes/%s/revisions/%s/files?base=%s", changeID, revision, url.QueryEscape(base))
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string]FileInfo
+	if err := json.Unmarshal(resp, &files); err != nil {
+		return nil, fmt.Errorf("failed to parse files: %w", err)
+	}
+
+	return files, nil
+}
+
+// GetFileDiff returns the diff of a file between base, an earlier patchset of
+// the change, and revision, with context lines of unchanged context.
+func (c *RESTClient) GetFileDiff(changeID, revision, path, base string, context int) (*DiffInfo, error) {
+	endpoint := fmt.Sprintf("changes/%s/revisions/%s/files/%s/diff?base=%s&context=%d",
+		changeID, revision, url.PathEscape(path), url.QueryEscape(base), context)
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff DiffInfo
+	if err := json.Unmarshal(resp, &diff); err != nil {
+		return nil, fmt.Errorf("failed to parse diff: %w", err)
+	}
+
+	return &diff, nil
+}
+
+// GetAttentionSet returns the users in a change's attention set.
+func (c *RESTClient) GetAttentionSet(changeID string) ([]AttentionSetInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/attention", changeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var set []AttentionSetInfo
+	if err := json.Unmarshal(resp, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse attention set: %w", err)
+	}
+
+	return set, nil
+}
+
+// AddToAttentionSet adds a user to a change's attention set. Gerrit requires
+// a reason, which is shown in the web UI.
+func (c *RESTClient) AddToAttentionSet(changeID, user, reason string) error {
+	_, err := c.Post(fmt.Sprintf("changes/%s/attention", changeID), map[string]string{
+		"user":   user,
+		"reason": reason,
+	})
+	return err
+}
+
+// RemoveFromAttentionSet removes a user from a change's attention set.
+func (c *RESTClient) RemoveFromAttentionSet(changeID, user, reason string) error {
+	_, err := c.Post(fmt.Sprintf("changes/%s/attention/%s/delete", changeID, url.PathEscape(user)), map[string]string{
+		"reason": reason,
+	})
+	return err
+}
+
+// GetCommitMessage returns the full commit message of a change's current
+// patchset.
+func (c *RESTClient) GetCommitMessage(changeID string) (string, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/message", changeID))
+	if err != nil {
+		return "", err
+	}
+
+	var info struct {
+		FullMessage string `json:"full_message"`
+	}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return "", fmt.Errorf("failed to parse commit message: %w", err)
+	}
+
+	return info.FullMessage, nil
+}
+
+// SetCommitMessage uploads a new patchset that changes only the commit
+// message.
+func (c *RESTClient) SetCommitMessage(changeID, message string) error {
+	_, err := c.Put(fmt.Sprintf("changes/%s/message", changeID), map[string]string{"message": message})
+	return err
+}
+
+// GetHashtags returns a change's hashtags.
+func (c *RESTClient) GetHashtags(changeID string) ([]string, error) {
+	path := fmt.Sprintf("changes/%s/hashtags", changeID)
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashtags []string
+	if err := json.Unmarshal(resp, &hashtags); err != nil {
+		return nil, fmt.Errorf("failed to parse hashtags: %w", err)
+	}
+
+	return hashtags, nil
+}
+
+// SetHashtags adds and removes hashtags on a change and returns the
+// resulting set.
+func (c *RESTClient) SetHashtags(changeID string, add, remove []string) ([]string, error) {
+	path := fmt.Sprintf("changes/%s/hashtags", changeID)
+	data := map[string]interface{}{}
+	if len(add) > 0 {
+		data["add"] = add
+	}
+	if len(remove) > 0 {
+		data["remove"] = remove
+	}
+
+	resp, err := c.Post(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashtags []string
+	if err := json.Unmarshal(resp, &hashtags); err != nil {
+		return nil, fmt.Errorf("failed to parse hashtags: %w", err)
+	}
+
+	return hashtags, nil
+}
+
 // GetChangeMessages retrieves all messages for a change
 func (c *RESTClient) GetChangeMessages(changeID string) ([]ChangeMessageInfo, error) {
 	path := fmt.Sprintf("changes/%s/messages", changeID)
@@ -242,19 +502,33 @@ func (c *RESTClient) GetChangeMessages(changeID string) ([]ChangeMessageInfo, er
 	return messages, nil
 }
 
-// PostReview posts a review comment on a change
-func (c *RESTClient) PostReview(changeID string, revision string, message string) error {
-	path := fmt.Sprintf("changes/%s/revisions/%s/review", changeID, revision)
-	data := map[string]interface{}{
-		"message": message,
-	}
+// ReviewInput is the body of the Set Review endpoint: an optional message,
+// label votes, inline comments, what to do with the caller's drafts, and a
+// work-in-progress toggle. Ready and WorkInProgress are mutually exclusive.
+type ReviewInput struct {
+	Message        string                     `json:"message,omitempty"`
+	Labels         map[string]int             `json:"labels,omitempty"`
+	Comments       map[string][]ReviewComment `json:"comments,omitempty"`
+	Tag            string                     `json:"tag,omitempty"`
+	Drafts         string                     `json:"drafts,omitempty"`
+	Ready          bool                       `json:"ready,omitempty"`
+	WorkInProgress bool                       `json:"work_in_progress,omitempty"`
+}
 
-	_, err := c.Post(path, data)
+// PostReview posts a review (message, votes, comments, WIP state) on a
+// revision via the Set Review endpoint.
+func (c *RESTClient) PostReview(changeID string, revision string, input ReviewInput) error {
+	if input.Ready && input.WorkInProgress {
+		return fmt.Errorf("a review cannot both mark a change ready and work in progress")
+	}
+	path := fmt.Sprintf("changes/%s/revisions/%s/review", changeID, revision)
+	_, err := c.Post(path, input)
 	return err
 }
 
 // ReviewComment represents an inline comment to post via the Set Review API.
 type ReviewComment struct {
+	Path       string `json:"path,omitempty"` // only for drafts; review comments are keyed by path
 	InReplyTo  string `json:"in_reply_to,omitempty"`
 	Line       int    `json:"line,omitempty"`
 	Message    string `json:"message"`
@@ -263,30 +537,21 @@ type ReviewComment struct {
 
 // PostReviewWithComments posts inline comments via the Set Review endpoint.
 func (c *RESTClient) PostReviewWithComments(changeID, revision string, comments map[string][]ReviewComment) error {
-	path := fmt.Sprintf("changes/%s/revisions/%s/review", changeID, revision)
-	data := map[string]interface{}{
-		"comments": comments,
-	}
-
-	_, err := c.Post(path, data)
-	return err
+	return c.PostReview(changeID, revision, ReviewInput{Comments: comments})
 }
 
 // PostVote posts label votes (and optional message) via the Set Review endpoint.
 func (c *RESTClient) PostVote(changeID, revision, message string, labels map[string]int) error {
+	return c.PostTaggedVote(changeID, revision, message, "", labels)
+}
+
+// PostTaggedVote posts label votes with a review tag (e.g. "autogenerated:ci"),
+// which Gerrit uses to group and filter bot messages.
+func (c *RESTClient) PostTaggedVote(changeID, revision, message, tag string, labels map[string]int) error {
 	if len(labels) == 0 {
 		return fmt.Errorf("at least one label vote is required")
 	}
-	path := fmt.Sprintf("changes/%s/revisions/%s/review", changeID, revision)
-	data := map[string]interface{}{
-		"labels": labels,
-	}
-	if message != "" {
-		data["message"] = message
-	}
-
-	_, err := c.Post(path, data)
-	return err
+	return c.PostReview(changeID, revision, ReviewInput{Message: message, Labels: labels, Tag: tag})
 }
 
 // AddReviewer adds a reviewer or CC to a change
@@ -302,6 +567,12 @@ func (c *RESTClient) AddReviewer(changeID string, reviewer string, state string)
 	return err
 }
 
+// RemoveReviewer removes a reviewer or CC from a change
+func (c *RESTClient) RemoveReviewer(changeID string, reviewer string) error {
+	path := fmt.Sprintf("changes/%s/reviewers/%s", changeID, url.PathEscape(reviewer))
+	return c.Delete(path)
+}
+
 // RebaseChange rebases a change onto a new base
 // base can be empty (rebase onto target branch HEAD), a commit SHA-1, or "change~patchset"
 // allowConflicts allows rebasing even with conflicts (creates conflict markers)
@@ -328,3 +599,413 @@ func (c *RESTClient) RebaseChange(changeID string, base string, allowConflicts b
 
 	return &change, nil
 }
+
+// CherryPickInput is the body of a cherry-pick request. Destination is the
+// target branch; an empty Message keeps the original commit message.
+type CherryPickInput struct {
+	Destination    string `json:"destination"`
+	Message        string `json:"message,omitempty"`
+	AllowConflicts bool   `json:"allow_conflicts,omitempty"`
+}
+
+// CherryPick creates a new change on input.Destination from a revision of
+// changeID. Without AllowConflicts the server refuses (409) when the pick
+// doesn't apply cleanly.
+func (c *RESTClient) CherryPick(changeID, revision string, input CherryPickInput) (*Change, error) {
+	path := fmt.Sprintf("changes/%s/revisions/%s/cherrypick", changeID, revision)
+	resp, err := c.Post(path, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if err := json.Unmarshal(resp, &change); err != nil {
+		return nil, fmt.Errorf("failed to parse cherry-pick response: %w", err)
+	}
+
+	return &change, nil
+}
+
+// SetTopic sets a change's topic; an empty topic removes it.
+func (c *RESTClient) SetTopic(changeID, topic string) error {
+	path := fmt.Sprintf("changes/%s/topic", changeID)
+	_, err := c.Put(path, map[string]interface{}{"topic": topic})
+	return err
+}
+
+// IndexChange asks the server to reindex a single change.
+// Requires the Maintain Server capability.
+func (c *RESTClient) IndexChange(changeID string) error {
+	path := fmt.Sprintf("changes/%s/index", changeID)
+	_, err := c.Post(path, map[string]interface{}{})
+	return err
+}
+
+// SetWorkInProgress marks a change work in progress, with an optional
+// message.
+func (c *RESTClient) SetWorkInProgress(changeID, message string) error {
+	_, err := c.Post(fmt.Sprintf("changes/%s/wip", changeID), map[string]interface{}{"message": message})
+	return err
+}
+
+// SetReadyForReview marks a work-in-progress change ready for review, with an
+// optional message.
+func (c *RESTClient) SetReadyForReview(changeID, message string) error {
+	_, err := c.Post(fmt.Sprintf("changes/%s/ready", changeID), map[string]interface{}{"message": message})
+	return err
+}
+
+// SubmitChange submits a change. When the server has change.submitWholeTopic
+// enabled, every change submitted together with it is merged atomically.
+func (c *RESTClient) SubmitChange(changeID string) (*Change, error) {
+	path := fmt.Sprintf("changes/%s/submit", changeID)
+	resp, err := c.Post(path, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if err := json.Unmarshal(resp, &change); err != nil {
+		return nil, fmt.Errorf("failed to parse submit response: %w", err)
+	}
+
+	return &change, nil
+}
+
+// SubmittedTogetherInfo lists the changes a submit would merge in one go.
+type SubmittedTogetherInfo struct {
+	Changes           []Change `json:"changes"`
+	NonVisibleChanges int      `json:"non_visible_changes,omitempty"`
+}
+
+// GetSubmittedTogether returns every change that would be submitted together
+// with changeID (ancestors and, with submitWholeTopic, the rest of its topic),
+// requesting extra ListChangesOption values for each change.
+func (c *RESTClient) GetSubmittedTogether(changeID string, options ...string) (*SubmittedTogetherInfo, error) {
+	path := fmt.Sprintf("changes/%s/submitted_together?o=NON_VISIBLE_CHANGES", changeID)
+	for _, opt := range options {
+		path += "&o=" + opt
+	}
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var info SubmittedTogetherInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse submitted together: %w", err)
+	}
+
+	return &info, nil
+}
+
+// RelatedChangeAndCommitInfo is one change in a revision's relation chain.
+// Commit is the patchset in the chain, which need not be the change's
+// current one.
+type RelatedChangeAndCommitInfo struct {
+	Project               string     `json:"project"`
+	ChangeID              string     `json:"change_id,omitempty"`
+	Commit                CommitInfo `json:"commit"`
+	Number                int        `json:"_change_number,omitempty"`
+	RevisionNumber        int        `json:"_revision_number,omitempty"`
+	CurrentRevisionNumber int        `json:"_current_revision_number,omitempty"`
+	Status                string     `json:"status,omitempty"`
+}
+
+// GetRelatedChanges returns the relation chain of a revision: its
+// descendants and ancestors, newest first, including the revision itself.
+// It is empty for a change with no open relations.
+func (c *RESTClient) GetRelatedChanges(changeID, revision string) ([]RelatedChangeAndCommitInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/revisions/%s/related", changeID, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Changes []RelatedChangeAndCommitInfo `json:"changes"`
+	}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse related changes: %w", err)
+	}
+
+	return info.Changes, nil
+}
+
+// GetCodeOwnersStatus returns per-file code owner approval status from the
+// code-owners plugin. It fails with a 404 when the plugin is not installed.
+func (c *RESTClient) GetCodeOwnersStatus(changeID string) (*CodeOwnerStatusInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/code_owners.status", changeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var status CodeOwnerStatusInfo
+	if err := json.Unmarshal(resp, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse code owners status: %w", err)
+	}
+
+	return &status, nil
+}
+
+// SuggestCodeOwners returns up to limit code owners for a path in a revision,
+// best-scored first.
+func (c *RESTClient) SuggestCodeOwners(changeID, revision, path string, limit int) (*CodeOwnersInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/revisions/%s/code_owners/%s?limit=%d",
+		changeID, revision, url.PathEscape(path), limit))
+	if err != nil {
+		return nil, err
+	}
+
+	var owners CodeOwnersInfo
+	if err := json.Unmarshal(resp, &owners); err != nil {
+		return nil, fmt.Errorf("failed to parse code owners: %w", err)
+	}
+
+	return &owners, nil
+}
+
+// SuggestReviewers returns reviewers Gerrit recommends for a change. With an
+// empty query the server ranks candidates by their past reviews of similar
+// changes.
+func (c *RESTClient) SuggestReviewers(changeID, query string, limit int) ([]SuggestedReviewerInfo, error) {
+	path := fmt.Sprintf("changes/%s/suggest_reviewers?n=%d", changeID, limit)
+	if query != "" {
+		path += "&q=" + url.QueryEscape(query)
+	}
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []SuggestedReviewerInfo
+	if err := json.Unmarshal(resp, &suggestions); err != nil {
+		return nil, fmt.Errorf("failed to parse reviewer suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// GetFileContent returns the content of a file in a revision.
+func (c *RESTClient) GetFileContent(changeID, revision, path string) (string, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/revisions/%s/files/%s/content", changeID, revision, url.PathEscape(path)))
+	if err != nil {
+		return "", err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(resp)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode file content: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// GetPatch returns a revision as a git format-patch, limited to one file
+// when path is set.
+func (c *RESTClient) GetPatch(changeID, revision, path string) (string, error) {
+	endpoint := fmt.Sprintf("changes/%s/revisions/%s/patch", changeID, revision)
+	if path != "" {
+		endpoint += "?path=" + url.QueryEscape(path)
+	}
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(resp)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode patch: %w", err)
+	}
+
+	return string(patch), nil
+}
+
+// CreateDraft saves a draft comment on a revision.
+func (c *RESTClient) CreateDraft(changeID, revision string, comment ReviewComment) error {
+	path := fmt.Sprintf("changes/%s/revisions/%s/drafts", changeID, revision)
+	_, err := c.Put(path, comment)
+	return err
+}
+
+// PublishDrafts publishes all of the caller's drafts, across every patchset,
+// as a single review with an optional message.
+func (c *RESTClient) PublishDrafts(changeID, revision, message string) error {
+	return c.PostReview(changeID, revision, ReviewInput{Message: message, Drafts: "PUBLISH_ALL_REVISIONS"})
+}
+
+// ListDrafts returns the caller's unpublished draft comments on a change,
+// keyed by file path.
+func (c *RESTClient) ListDrafts(changeID string) (map[string][]CommentInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/drafts", changeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts map[string][]CommentInfo
+	if err := json.Unmarshal(resp, &drafts); err != nil {
+		return nil, fmt.Errorf("failed to parse drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+// DeleteDraft deletes one of the caller's draft comments.
+func (c *RESTClient) DeleteDraft(changeID, revision, draftID string) error {
+	return c.Delete(fmt.Sprintf("changes/%s/revisions/%s/drafts/%s", changeID, revision, url.PathEscape(draftID)))
+}
+
+// GetReviewedFiles returns the paths the caller has marked as reviewed in a
+// revision.
+func (c *RESTClient) GetReviewedFiles(changeID, revision string) ([]string, error) {
+	resp, err := c.Get(fmt.Sprintf("changes/%s/revisions/%s/files?reviewed", changeID, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if err := json.Unmarshal(resp, &paths); err != nil {
+		return nil, fmt.Errorf("failed to parse reviewed files: %w", err)
+	}
+
+	return paths, nil
+}
+
+// SetReviewed sets or clears the caller's reviewed flag on a file.
+func (c *RESTClient) SetReviewed(changeID, revision, path string, reviewed bool) error {
+	endpoint := fmt.Sprintf("changes/%s/revisions/%s/files/%s/reviewed", changeID, revision, url.PathEscape(path))
+	if !reviewed {
+		return c.Delete(endpoint)
+	}
+	_, err := c.Put(endpoint, nil)
+	return err
+}
+
+// AddGroupMember adds an account to a group. Only group owners and
+// administrators may do this.
+func (c *RESTClient) AddGroupMember(group, account string) (*Account, error) {
+	resp, err := c.Put(fmt.Sprintf("groups/%s/members/%s", url.PathEscape(group), url.PathEscape(account)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var member Account
+	if err := json.Unmarshal(resp, &member); err != nil {
+		return nil, fmt.Errorf("failed to parse account: %w", err)
+	}
+
+	return &member, nil
+}
+
+// RemoveGroupMember removes an account from a group.
+func (c *RESTClient) RemoveGroupMember(group, account string) error {
+	return c.Delete(fmt.Sprintf("groups/%s/members/%s", url.PathEscape(group), url.PathEscape(account)))
+}
+
+// GetProject returns a project's description, parent, and state.
+func (c *RESTClient) GetProject(name string) (*ProjectInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("projects/%s", url.PathEscape(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var project ProjectInfo
+	if err := json.Unmarshal(resp, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	return &project, nil
+}
+
+// GetProjectConfig returns a project's effective configuration.
+func (c *RESTClient) GetProjectConfig(name string) (*ConfigInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("projects/%s/config", url.PathEscape(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ConfigInfo
+	if err := json.Unmarshal(resp, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse project config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// GetBranch returns a branch of a project, including the commit it points
+// to.
+func (c *RESTClient) GetBranch(project, branch string) (*BranchInfo, error) {
+	resp, err := c.Get(fmt.Sprintf("projects/%s/branches/%s", url.PathEscape(project), url.PathEscape(branch)))
+	if err != nil {
+		return nil, err
+	}
+
+	var info BranchInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse branch: %w", err)
+	}
+
+	return &info, nil
+}
+
+// GetProjectHead returns the ref HEAD points to, i.e. the default branch.
+func (c *RESTClient) GetProjectHead(name string) (string, error) {
+	resp, err := c.Get(fmt.Sprintf("projects/%s/HEAD", url.PathEscape(name)))
+	if err != nil {
+		return "", err
+	}
+
+	var ref string
+	if err := json.Unmarshal(resp, &ref); err != nil {
+		return "", fmt.Errorf("failed to parse HEAD: %w", err)
+	}
+
+	return ref, nil
+}
+
+// GetServerInfo returns the server's public configuration, including the
+// UI plugins it has loaded.
+func (c *RESTClient) GetServerInfo() (*ServerInfo, error) {
+	resp, err := c.Get("config/server/info")
+	if err != nil {
+		return nil, err
+	}
+
+	var info ServerInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse server info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// GetCapabilities returns the global capabilities granted to the caller,
+// limited to names when any are given. Capabilities not granted are absent.
+func (c *RESTClient) GetCapabilities(names ...string) (map[string]interface{}, error) {
+	endpoint := "accounts/self/capabilities"
+	for i, name := range names {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		endpoint += sep + "q=" + url.QueryEscape(name)
+	}
+
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := map[string]interface{}{}
+	if err := json.Unmarshal(resp, &caps); err != nil {
+		return nil, fmt.Errorf("failed to parse capabilities: %w", err)
+	}
+
+	return caps, nil
+}
+
+// UpdatePreferences sets the given fields of the caller's preferences;
+// fields not in prefs are left unchanged.
+func (c *RESTClient) UpdatePreferences(prefs map[string]interface{}) error {
+	_, err := c.Put("accounts/self/preferences", prefs)
+	return err
+}
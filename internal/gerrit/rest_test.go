@@ -0,0 +1,175 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"seconds", "7", 0, 7 * time.Second},
+		{"zero", "0", 3, 0},
+		{"http date", "Mon, 01 Jan 2024 12:00:30 GMT", 0, 30 * time.Second},
+		{"date in the past", "Mon, 01 Jan 2024 11:00:00 GMT", 0, 0},
+		{"missing, first attempt", "", 0, time.Second},
+		{"missing, backs off", "", 3, 8 * time.Second},
+		{"garbage", "soon", 1, 2 * time.Second},
+		{"capped", "86400", 0, maxRetryWait},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header, tt.attempt, now); got != tt.want {
+				t.Errorf("retryAfter(%q, %d) = %s, want %s", tt.header, tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetriesThrottled(t *testing.T) {
+	var calls int
+	var bodies []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, ")]}'\n{}")
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+
+	if _, err := client.Post("changes/1/revisions/current/review", map[string]string{"message": "hi"}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("server saw %d requests, want 3", calls)
+	}
+	for i, b := range bodies {
+		if b != `{"message":"hi"}` {
+			t.Errorf("request %d body = %q, want the original payload", i+1, b)
+		}
+	}
+}
+
+func TestRequestsStopWhenContextCancelled(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ask for a long wait so only cancellation can end the retry loop.
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.WithContext(ctx).Get("changes/")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() took %s after cancellation", elapsed)
+	}
+
+	cancelled, stop := context.WithCancel(context.Background())
+	stop()
+	if _, err := client.WithContext(cancelled).Get("changes/"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() with a cancelled context error = %v, want context.Canceled", err)
+	}
+	if client.ctx != nil {
+		t.Error("WithContext() modified the original client")
+	}
+}
+
+func TestPostReviewBody(t *testing.T) {
+	var path, body string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		io.WriteString(w, ")]}'\n{}")
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+
+	input := ReviewInput{Message: "LGTM", Labels: map[string]int{"Code-Review": 2}, Ready: true}
+	if err := client.PostReview("12345", "current", input); err != nil {
+		t.Fatalf("PostReview() error = %v", err)
+	}
+	if want := "/a/changes/12345/revisions/current/review"; path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if want := `{"message":"LGTM","labels":{"Code-Review":2},"ready":true}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	if err := client.PostReview("12345", "current", ReviewInput{Ready: true, WorkInProgress: true}); err == nil {
+		t.Error("PostReview() accepted both ready and work_in_progress")
+	}
+}
+
+func TestReviewerRequests(t *testing.T) {
+	var requests []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+" "+string(body))
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, ")]}'\n{}")
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client := NewRESTClient(&config.Config{Server: u.Hostname(), HTTPPort: port, User: "alice"})
+	client.httpClient = server.Client()
+
+	if err := client.AddReviewer("12345", "bob", "CC"); err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+	if err := client.RemoveReviewer("12345", "Web Team"); err != nil {
+		t.Fatalf("RemoveReviewer() error = %v", err)
+	}
+	want := []string{
+		`POST /a/changes/12345/reviewers {"reviewer":"bob","state":"CC"}`,
+		`DELETE /a/changes/12345/reviewers/Web%20Team `,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
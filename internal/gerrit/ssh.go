@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/drakeaharper/gerrit-cli/internal/config"
+	"github.com/drakeaharper/gerrit-cli/internal/utils"
 )
 
 type SSHClient struct {
@@ -21,24 +22,39 @@ func NewSSHClient(cfg *config.Config) *SSHClient {
 	}
 }
 
-// ExecuteCommandArgs executes a Gerrit command with properly separated arguments
-func (c *SSHClient) ExecuteCommandArgs(args ...string) (string, error) {
+// sshArgs builds the ssh command line for a Gerrit command. In CI mode
+// BatchMode makes ssh fail rather than prompt for a passphrase or password.
+// Host entries in ~/.ssh/config still apply; the port, user, and jump host
+// from the gerry configuration take precedence.
+func (c *SSHClient) sshArgs(args ...string) []string {
 	sshArgs := []string{
 		"-p", fmt.Sprintf("%d", c.config.Port),
 		"-o", "StrictHostKeyChecking=accept-new",
 		"-o", "UserKnownHostsFile=~/.ssh/known_hosts",
-		fmt.Sprintf("%s@%s", c.config.User, c.config.Server),
-		"gerrit",
 	}
-	sshArgs = append(sshArgs, args...)
+	if utils.CIMode() {
+		sshArgs = append(sshArgs, "-o", "BatchMode=yes")
+	}
+	if c.config.SSHProxyJump != "" {
+		sshArgs = append(sshArgs, "-J", c.config.SSHProxyJump)
+	}
+	sshArgs = append(sshArgs, fmt.Sprintf("%s@%s", c.config.User, c.config.Server), "gerrit")
+	return append(sshArgs, args...)
+}
 
-	cmd := exec.Command("ssh", sshArgs...)
+// ExecuteCommandArgs executes a Gerrit command with properly separated arguments
+func (c *SSHClient) ExecuteCommandArgs(args ...string) (string, error) {
+	cmd := exec.Command("ssh", c.sshArgs(args...)...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
 	if err != nil {
+		if strings.Contains(stderr.String(), "Host key verification failed") {
+			return "", fmt.Errorf("SSH host key verification failed for %s; run 'gerry hostkey show' to inspect the server's key and 'gerry hostkey trust' to accept it\nStderr: %s",
+				c.config.Server, stderr.String())
+		}
 		return "", fmt.Errorf("SSH command failed: %w\nStderr: %s", err, stderr.String())
 	}
 
@@ -60,16 +76,7 @@ func (c *SSHClient) TestConnection() error {
 
 // StreamCommandArgs streams output from a Gerrit command with properly separated arguments
 func (c *SSHClient) StreamCommandArgs(output io.Writer, args ...string) error {
-	sshArgs := []string{
-		"-p", fmt.Sprintf("%d", c.config.Port),
-		"-o", "StrictHostKeyChecking=accept-new",
-		"-o", "UserKnownHostsFile=~/.ssh/known_hosts",
-		fmt.Sprintf("%s@%s", c.config.User, c.config.Server),
-		"gerrit",
-	}
-	sshArgs = append(sshArgs, args...)
-
-	cmd := exec.Command("ssh", sshArgs...)
+	cmd := exec.Command("ssh", c.sshArgs(args...)...)
 	cmd.Stdout = output
 	cmd.Stderr = os.Stderr
 
@@ -95,3 +102,122 @@ func (c *SSHClient) GetChangeDetails(changeID string) (string, error) {
 func (c *SSHClient) GetVersion() (string, error) {
 	return c.ExecuteCommandArgs("version")
 }
+
+// ShowQueue returns the wide (untruncated) output of `gerrit show-queue`.
+// Requires the View Queue or Administrate Server capability.
+func (c *SSHClient) ShowQueue() (string, error) {
+	return c.ExecuteCommandArgs("show-queue", "-w")
+}
+
+// ShowCaches returns the output of `gerrit show-caches`.
+func (c *SSHClient) ShowCaches() (string, error) {
+	return c.ExecuteCommandArgs("show-caches")
+}
+
+// FlushCaches flushes the named caches, or every cache when names is empty.
+func (c *SSHClient) FlushCaches(names ...string) error {
+	args := []string{"flush-caches"}
+	if len(names) == 0 {
+		args = append(args, "--all")
+	}
+	for _, name := range names {
+		args = append(args, "--cache", name)
+	}
+	_, err := c.ExecuteCommandArgs(args...)
+	return err
+}
+
+// ShowConnections returns the wide output of `gerrit show-connections`.
+func (c *SSHClient) ShowConnections() (string, error) {
+	return c.ExecuteCommandArgs("show-connections", "-w")
+}
+
+// IndexChanges reindexes the given changes via `gerrit index changes`.
+func (c *SSHClient) IndexChanges(changeIDs ...string) error {
+	args := append([]string{"index", "changes"}, changeIDs...)
+	_, err := c.ExecuteCommandArgs(args...)
+	return err
+}
+
+// SetReviewers adds and removes reviewers via `gerrit set-reviewers`.
+// The SSH command has no notion of CCs; everyone added becomes a reviewer.
+func (c *SSHClient) SetReviewers(changeID string, add, remove []string) error {
+	args := []string{"set-reviewers"}
+	for _, r := range add {
+		args = append(args, "--add", r)
+	}
+	for _, r := range remove {
+		args = append(args, "--remove", r)
+	}
+	args = append(args, changeID)
+	_, err := c.ExecuteCommandArgs(args...)
+	return err
+}
+
+// PostReviewMessage posts a change message on a patchset via `gerrit review`.
+// The message is quoted because Gerrit re-splits the remote command line.
+func (c *SSHClient) PostReviewMessage(changeID, patchset, message string) error {
+	_, err := c.ExecuteCommandArgs("review", "--message", quoteSSHArg(message), changeID+","+patchset)
+	return err
+}
+
+// quoteSSHArg single-quotes s so Gerrit's command-line splitter keeps it as
+// one argument.
+func quoteSSHArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// PostTaggedVote posts label votes on a patchset via `gerrit review`.
+func (c *SSHClient) PostTaggedVote(changeID, patchset, message, tag string, labels map[string]int) error {
+	args := []string{"review"}
+	for name, value := range labels {
+		args = append(args, "--label", fmt.Sprintf("%s=%+d", name, value))
+	}
+	if message != "" {
+		args = append(args, "--message", quoteSSHArg(message))
+	}
+	if tag != "" {
+		args = append(args, "--tag", tag)
+	}
+	args = append(args, changeID+","+patchset)
+	_, err := c.ExecuteCommandArgs(args...)
+	return err
+}
+
+// GC runs `gerrit gc` on the given projects (or every project when all is
+// set), streaming its progress output to output.
+func (c *SSHClient) GC(output io.Writer, all, aggressive bool, projects ...string) error {
+	args := []string{"gc", "--show-progress"}
+	if aggressive {
+		args = append(args, "--aggressive")
+	}
+	if all {
+		args = append(args, "--all")
+	}
+	for _, p := range projects {
+		args = append(args, quoteSSHArg(p))
+	}
+	return c.StreamCommandArgs(output, args...)
+}
+
+// BanCommit bans commits from a project via `gerrit ban-commit`, so they are
+// rejected if anyone pushes them again. Returns the command's report.
+func (c *SSHClient) BanCommit(project, reason string, commits ...string) (string, error) {
+	args := []string{"ban-commit"}
+	if reason != "" {
+		args = append(args, "--message", quoteSSHArg(reason))
+	}
+	args = append(args, quoteSSHArg(project))
+	args = append(args, commits...)
+	return c.ExecuteCommandArgs(args...)
+}
+
+// ListUserRefs lists the refs of a project visible to a user via
+// `gerrit ls-user-refs`, one ref per line.
+func (c *SSHClient) ListUserRefs(project, user string, onlyHeads bool) (string, error) {
+	args := []string{"ls-user-refs", "--project", quoteSSHArg(project), "--user", quoteSSHArg(user)}
+	if onlyHeads {
+		args = append(args, "--only-refs-heads")
+	}
+	return c.ExecuteCommandArgs(args...)
+}
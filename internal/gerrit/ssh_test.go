@@ -0,0 +1,41 @@
+package gerrit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drakeaharper/gerrit-cli/internal/config"
+)
+
+func TestQuoteSSHArg(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"rebase please", "'rebase please'"},
+		{"", "''"},
+		{"it's done", `'it'\''s done'`},
+		{`say "hi"`, `'say "hi"'`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteSSHArg(tt.in); got != tt.want {
+			t.Errorf("quoteSSHArg(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSSHArgsProxyJump(t *testing.T) {
+	cfg := &config.Config{Server: "gerrit.example.com", Port: 29418, User: "alice", SSHProxyJump: "bastion.example.com"}
+	got := NewSSHClient(cfg).sshArgs("version")
+	want := []string{
+		"-p", "29418",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "UserKnownHostsFile=~/.ssh/known_hosts",
+		"-J", "bastion.example.com",
+		"alice@gerrit.example.com", "gerrit", "version",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sshArgs() = %q, want %q", got, want)
+	}
+}
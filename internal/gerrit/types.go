@@ -2,6 +2,7 @@ package gerrit
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -37,15 +38,42 @@ type ApprovalInfo struct {
 
 // CommitInfo represents a git commit.
 type CommitInfo struct {
+	Commit  string `json:"commit,omitempty"`
 	Subject string `json:"subject,omitempty"`
 	Message string `json:"message,omitempty"`
+
+	// Parents holds the parent commits, with only Commit and Subject set.
+	Parents []CommitInfo `json:"parents,omitempty"`
+}
+
+// SubmitRequirementResultInfo is the state of one submit requirement on a
+// change (Gerrit 3.5+, with the SUBMIT_REQUIREMENTS option). Status is one
+// of SATISFIED, UNSATISFIED, OVERRIDDEN, NOT_APPLICABLE, ERROR, or FORCED.
+type SubmitRequirementResultInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	IsLegacy    bool   `json:"is_legacy,omitempty"`
+}
+
+// BranchInfo describes a branch of a project.
+type BranchInfo struct {
+	Ref      string `json:"ref"`
+	Revision string `json:"revision"`
 }
 
 // RevisionInfo represents a single patchset revision.
 type RevisionInfo struct {
-	Kind   string     `json:"kind,omitempty"`
-	Number int        `json:"_number"`
-	Commit CommitInfo `json:"commit,omitempty"`
+	Kind     string     `json:"kind,omitempty"`
+	Number   int        `json:"_number"`
+	Created  string     `json:"created,omitempty"`
+	Uploader Account    `json:"uploader,omitempty"`
+	Ref      string     `json:"ref,omitempty"`
+	Commit   CommitInfo `json:"commit,omitempty"`
+
+	// Files is only populated for the current revision when CURRENT_FILES
+	// is requested.
+	Files map[string]FileInfo `json:"files,omitempty"`
 }
 
 // FileInfo describes a changed file in a revision.
@@ -58,6 +86,39 @@ type FileInfo struct {
 	OldPath       string `json:"old_path,omitempty"`
 }
 
+// DiffInfo is the diff of one file between two revisions. Content is a
+// sequence of chunks: lines common to both sides (AB), lines only on one
+// side (A, B), or Skip common lines left out beyond the requested context.
+type DiffInfo struct {
+	MetaA      *DiffFileMeta `json:"meta_a,omitempty"`
+	MetaB      *DiffFileMeta `json:"meta_b,omitempty"`
+	ChangeType string        `json:"change_type"`
+	Content    []DiffContent `json:"content"`
+	Binary     bool          `json:"binary,omitempty"`
+}
+
+// DiffFileMeta describes one side of a DiffInfo.
+type DiffFileMeta struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Lines       int    `json:"lines,omitempty"`
+}
+
+// DiffContent is one chunk of a DiffInfo.
+type DiffContent struct {
+	A    []string `json:"a,omitempty"`
+	B    []string `json:"b,omitempty"`
+	AB   []string `json:"ab,omitempty"`
+	Skip int      `json:"skip,omitempty"`
+}
+
+// AttentionSetInfo is one user in a change's attention set.
+type AttentionSetInfo struct {
+	Account    Account `json:"account"`
+	LastUpdate string  `json:"last_update,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
 // SSHPatchSet represents the currentPatchSet field from SSH query output.
 type SSHPatchSet struct {
 	Number    int            `json:"number"`
@@ -70,6 +131,7 @@ type SSHPatchSet struct {
 type Change struct {
 	// REST API fields
 	Number          int                     `json:"_number,omitempty"`
+	ChangeID        string                  `json:"change_id,omitempty"`
 	Project         string                  `json:"project"`
 	Branch          string                  `json:"branch"`
 	Topic           string                  `json:"topic,omitempty"`
@@ -85,12 +147,38 @@ type Change struct {
 	MoreChanges     bool                    `json:"_more_changes,omitempty"`
 	URL             string                  `json:"url,omitempty"`
 	Mergeable       *bool                   `json:"mergeable,omitempty"`
+	Submittable     bool                    `json:"submittable,omitempty"`
+	WorkInProgress  bool                    `json:"work_in_progress,omitempty"`
+
+	// SubmitRequirements is only populated when the SUBMIT_REQUIREMENTS
+	// option is requested, and only by Gerrit 3.5 and later.
+	SubmitRequirements []SubmitRequirementResultInfo `json:"submit_requirements,omitempty"`
+
+	// ContainsGitConflicts is set on changes created with conflicts allowed
+	// (e.g. a cherry-pick) whose files contain conflict markers.
+	ContainsGitConflicts bool `json:"contains_git_conflicts,omitempty"`
+
+	// AttentionSet holds the users who need to act on the change, keyed by
+	// account ID. REST only.
+	AttentionSet map[string]AttentionSetInfo `json:"attention_set,omitempty"`
+
+	// Messages is only populated when the MESSAGES option is requested.
+	Messages []ChangeMessageInfo `json:"messages,omitempty"`
+
+	// Insertions and Deletions count the lines changed by the current
+	// patchset. REST only.
+	Insertions int `json:"insertions,omitempty"`
+	Deletions  int `json:"deletions,omitempty"`
+
+	// UnresolvedCommentCount is always returned by REST; zero on the SSH path.
+	UnresolvedCommentCount int `json:"unresolved_comment_count,omitempty"`
 
 	// Labels kept as untyped map — internal structure varies across Gerrit versions
 	Labels map[string]interface{} `json:"labels,omitempty"`
 
 	// SSH-specific fields (mutually exclusive with REST equivalents)
 	NumberSSH       int          `json:"number,omitempty"`
+	ID              string       `json:"id,omitempty"`
 	LastUpdated     int64        `json:"lastUpdated,omitempty"`
 	CurrentPatchSet *SSHPatchSet `json:"currentPatchSet,omitempty"`
 	CommitMessage   string       `json:"commitMessage,omitempty"`
@@ -109,6 +197,18 @@ func (c Change) ChangeNumberStr() string {
 	return fmt.Sprintf("%d", c.ChangeNumber())
 }
 
+// ChangeKey returns the Change-Id (I...) regardless of API source. Over
+// REST, "id" is a project~branch~Change-Id triplet, so change_id is used.
+func (c Change) ChangeKey() string {
+	if c.ChangeID != "" {
+		return c.ChangeID
+	}
+	if strings.HasPrefix(c.ID, "I") {
+		return c.ID
+	}
+	return ""
+}
+
 // UpdatedTime returns the updated timestamp regardless of API source.
 func (c Change) UpdatedTime() string {
 	if c.Updated != "" {
@@ -142,6 +242,17 @@ func (c Change) CurrentPatchSetNumber() int {
 	return 0
 }
 
+// RevisionByNumber returns the commit SHA and revision of a patchset.
+// Only revisions included in the response (see ALL_REVISIONS) are found.
+func (c Change) RevisionByNumber(number int) (string, RevisionInfo, bool) {
+	for sha, rev := range c.Revisions {
+		if rev.Number == number {
+			return sha, rev, true
+		}
+	}
+	return "", RevisionInfo{}, false
+}
+
 // CommentInfo represents an inline comment on a change.
 type CommentInfo struct {
 	ID         string  `json:"id,omitempty"`
@@ -156,9 +267,155 @@ type CommentInfo struct {
 
 // ChangeMessageInfo represents a change-level message.
 type ChangeMessageInfo struct {
-	ID      string  `json:"id,omitempty"`
-	Author  Account `json:"author"`
-	Message string  `json:"message"`
-	Date    string  `json:"date,omitempty"`
-	Tag     string  `json:"tag,omitempty"`
+	ID             string  `json:"id,omitempty"`
+	Author         Account `json:"author"`
+	Message        string  `json:"message"`
+	Date           string  `json:"date,omitempty"`
+	Tag            string  `json:"tag,omitempty"`
+	RevisionNumber int     `json:"_revision_number,omitempty"`
+}
+
+// CodeOwnerStatusInfo is the code-owners plugin's approval status for a change.
+type CodeOwnerStatusInfo struct {
+	PatchSetNumber        int                       `json:"patch_set_number"`
+	FileCodeOwnerStatuses []FileCodeOwnerStatusInfo `json:"file_code_owner_statuses"`
+	Accounts              map[string]Account        `json:"accounts,omitempty"`
+	MoreFileOwnerStatuses bool                      `json:"more,omitempty"`
+}
+
+// FileCodeOwnerStatusInfo holds the owner status of one file. Renames carry
+// both an old and a new path status; additions and deletions only one.
+type FileCodeOwnerStatusInfo struct {
+	ChangeType    string               `json:"change_type,omitempty"`
+	OldPathStatus *PathCodeOwnerStatus `json:"old_path_status,omitempty"`
+	NewPathStatus *PathCodeOwnerStatus `json:"new_path_status,omitempty"`
+}
+
+// PathCodeOwnerStatus is INSUFFICIENT_REVIEWERS, PENDING, or APPROVED.
+type PathCodeOwnerStatus struct {
+	Path    string   `json:"path"`
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// CodeOwnersInfo lists suggested code owners for a path.
+type CodeOwnersInfo struct {
+	CodeOwners      []CodeOwnerInfo `json:"code_owners"`
+	OwnedByAllUsers bool            `json:"owned_by_all_users,omitempty"`
+}
+
+// CodeOwnerInfo is a single suggested code owner.
+type CodeOwnerInfo struct {
+	Account Account `json:"account"`
+}
+
+// SuggestedReviewerInfo is one entry from the suggest_reviewers endpoint.
+// Either Account or Group is set.
+type SuggestedReviewerInfo struct {
+	Account *Account       `json:"account,omitempty"`
+	Group   *GroupBaseInfo `json:"group,omitempty"`
+	Count   int            `json:"count,omitempty"`
+}
+
+// GroupBaseInfo identifies a group.
+type GroupBaseInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// StreamEvent is one event from `gerrit stream-events`. Which fields are
+// set depends on Type (patchset-created, comment-added, change-merged, ...).
+type StreamEvent struct {
+	Type           string       `json:"type"`
+	Change         *Change      `json:"change,omitempty"`
+	PatchSet       *SSHPatchSet `json:"patchSet,omitempty"`
+	Uploader       *Account     `json:"uploader,omitempty"`
+	Author         *Account     `json:"author,omitempty"`
+	Submitter      *Account     `json:"submitter,omitempty"`
+	Abandoner      *Account     `json:"abandoner,omitempty"`
+	Restorer       *Account     `json:"restorer,omitempty"`
+	Changer        *Account     `json:"changer,omitempty"`
+	Editor         *Account     `json:"editor,omitempty"`
+	Comment        string       `json:"comment,omitempty"`
+	EventCreatedOn int64        `json:"eventCreatedOn,omitempty"`
+}
+
+// Actor returns the account that caused the event, if the event names one.
+func (e StreamEvent) Actor() *Account {
+	for _, a := range []*Account{e.Uploader, e.Author, e.Submitter, e.Abandoner, e.Restorer, e.Changer, e.Editor} {
+		if a != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// ProjectInfo describes a project.
+type ProjectInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+// ConfigInfo is a project's effective configuration. Settings that can be
+// inherited report both the configured and the effective value.
+type ConfigInfo struct {
+	Description                      string                  `json:"description,omitempty"`
+	State                            string                  `json:"state,omitempty"`
+	SubmitType                       string                  `json:"submit_type,omitempty"`
+	DefaultSubmitType                *SubmitTypeInfo         `json:"default_submit_type,omitempty"`
+	UseContentMerge                  *InheritedBooleanInfo   `json:"use_content_merge,omitempty"`
+	RequireChangeID                  *InheritedBooleanInfo   `json:"require_change_id,omitempty"`
+	UseSignedOffBy                   *InheritedBooleanInfo   `json:"use_signed_off_by,omitempty"`
+	UseContributorAgreements         *InheritedBooleanInfo   `json:"use_contributor_agreements,omitempty"`
+	CreateNewChangeForAllNotInTarget *InheritedBooleanInfo   `json:"create_new_change_for_all_not_in_target,omitempty"`
+	RejectImplicitMerges             *InheritedBooleanInfo   `json:"reject_implicit_merges,omitempty"`
+	PrivateByDefault                 *InheritedBooleanInfo   `json:"private_by_default,omitempty"`
+	WorkInProgressByDefault          *InheritedBooleanInfo   `json:"work_in_progress_by_default,omitempty"`
+	MaxObjectSizeLimit               *MaxObjectSizeLimitInfo `json:"max_object_size_limit,omitempty"`
+}
+
+// SubmitTypeInfo is the effective, configured, and inherited submit type.
+type SubmitTypeInfo struct {
+	Value           string `json:"value"`
+	ConfiguredValue string `json:"configured_value"`
+	InheritedValue  string `json:"inherited_value,omitempty"`
+}
+
+// InheritedBooleanInfo is a boolean project setting. ConfiguredValue is
+// TRUE, FALSE, or INHERIT.
+type InheritedBooleanInfo struct {
+	Value           bool   `json:"value"`
+	ConfiguredValue string `json:"configured_value"`
+	InheritedValue  *bool  `json:"inherited_value,omitempty"`
+}
+
+// MaxObjectSizeLimitInfo is the effective push size limit.
+type MaxObjectSizeLimitInfo struct {
+	Value           string `json:"value,omitempty"`
+	ConfiguredValue string `json:"configured_value,omitempty"`
+	Summary         string `json:"summary,omitempty"`
+}
+
+// ServerInfo is the subset of the server's public configuration gerry uses.
+type ServerInfo struct {
+	Plugin PluginConfigInfo `json:"plugin"`
+}
+
+// PluginConfigInfo lists the UI plugins the server has loaded.
+type PluginConfigInfo struct {
+	HasAvatars      bool     `json:"has_avatars,omitempty"`
+	JsResourcePaths []string `json:"js_resource_paths,omitempty"`
+}
+
+// HasPlugin reports whether a UI plugin with the given name is loaded.
+func (p PluginConfigInfo) HasPlugin(name string) bool {
+	for _, path := range p.JsResourcePaths {
+		if strings.HasPrefix(path, "plugins/"+name+"/") {
+			return true
+		}
+	}
+	return false
 }
@@ -44,6 +44,25 @@ func TestChangeNumber(t *testing.T) {
 	}
 }
 
+func TestChangeKey(t *testing.T) {
+	const key = "I0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{Change{ChangeID: key, ID: "proj~main~" + key}, key}, // REST
+		{Change{ID: key}, key},                               // SSH
+		{Change{ID: "proj~12345"}, ""},
+		{Change{}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.ChangeKey(); got != tt.want {
+			t.Errorf("Change%+v.ChangeKey() = %q, want %q", tt.change, got, tt.want)
+		}
+	}
+}
+
 func TestChangeUpdatedTime(t *testing.T) {
 	c1 := Change{Updated: "2025-01-01 12:00:00"}
 	if got := c1.UpdatedTime(); got != "2025-01-01 12:00:00" {
@@ -203,3 +222,47 @@ func TestChangeUnmarshalSSH(t *testing.T) {
 		t.Errorf("Approval value = %d, want 2", change.CurrentPatchSet.Approvals[0].Value)
 	}
 }
+
+func TestStreamEventActor(t *testing.T) {
+	line := `{"type":"patchset-created","change":{"project":"tools/gerry","branch":"main","number":12345,"subject":"Fix it","owner":{"name":"Alice","username":"alice"}},"uploader":{"name":"Bob","username":"bob"},"eventCreatedOn":1700000000}`
+
+	var ev StreamEvent
+	if err := json.Unmarshal([]byte(line), &ev); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if ev.Change == nil || ev.Change.ChangeNumber() != 12345 {
+		t.Fatalf("Change = %+v, want number 12345", ev.Change)
+	}
+	if actor := ev.Actor(); actor == nil || actor.Username != "bob" {
+		t.Errorf("Actor() = %+v, want bob", actor)
+	}
+	if actor := (StreamEvent{Type: "ref-updated"}).Actor(); actor != nil {
+		t.Errorf("Actor() = %+v, want nil", actor)
+	}
+}
+
+func TestRevisionByNumber(t *testing.T) {
+	change := Change{Revisions: map[string]RevisionInfo{
+		"aaa": {Number: 1},
+		"bbb": {Number: 2},
+	}}
+	if sha, rev, ok := change.RevisionByNumber(2); !ok || sha != "bbb" || rev.Number != 2 {
+		t.Errorf("RevisionByNumber(2) = %q, %+v, %v; want bbb", sha, rev, ok)
+	}
+	if _, _, ok := change.RevisionByNumber(3); ok {
+		t.Error("RevisionByNumber(3) found a revision, want none")
+	}
+}
+
+func TestPluginConfigInfoHasPlugin(t *testing.T) {
+	var info ServerInfo
+	data := `{"plugin":{"has_avatars":true,"js_resource_paths":["plugins/checks/static/checks.js","plugins/code-owners/static/code-owners.js"]}}`
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for name, want := range map[string]bool{"checks": true, "code-owners": true, "code": false, "webhooks": false} {
+		if got := info.Plugin.HasPlugin(name); got != want {
+			t.Errorf("HasPlugin(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
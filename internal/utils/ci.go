@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// ErrInteractiveDisabled is returned when a command would prompt while
+// non-interactive (CI) mode is active.
+var ErrInteractiveDisabled = errors.New("interactive prompt required but non-interactive mode is enabled")
+
+// CIGitSSHCommand is the GIT_SSH_COMMAND used in CI mode unless the
+// environment already sets one.
+const CIGitSSHCommand = "ssh -o BatchMode=yes -o StrictHostKeyChecking=accept-new"
+
+var (
+	ciMu      sync.Mutex
+	ciEnabled bool
+)
+
+// CIModeFromEnv reports whether GERRY_NONINTERACTIVE requests CI mode.
+func CIModeFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("GERRY_NONINTERACTIVE"))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// EnableCIMode switches to strict non-interactive mode: no prompts, no
+// colors, no progress output, and SSH/git never wait on a terminal.
+func EnableCIMode() {
+	ciMu.Lock()
+	ciEnabled = true
+	ciMu.Unlock()
+
+	color.NoColor = true
+	DisableProgress()
+
+	// git spawns its own ssh for fetch/push; make it fail instead of asking
+	// for passphrases, passwords, or host key confirmation.
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		os.Setenv("GIT_SSH_COMMAND", CIGitSSHCommand)
+	}
+	os.Setenv("GIT_TERMINAL_PROMPT", "0")
+}
+
+// CIMode reports whether strict non-interactive mode is active.
+func CIMode() bool {
+	ciMu.Lock()
+	defer ciMu.Unlock()
+	return ciEnabled
+}
+
+// WriteJSONError writes err as a single-line JSON object, the error format
+// used in CI mode so pipelines can parse failures.
+func WriteJSONError(w io.Writer, err error) {
+	data, marshalErr := json.Marshal(map[string]string{"error": err.Error()})
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
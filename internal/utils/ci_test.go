@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCIModeFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"1", true},
+		{"true", true},
+		{"YES", true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("GERRY_NONINTERACTIVE", tt.value)
+		if got := CIModeFromEnv(); got != tt.want {
+			t.Errorf("CIModeFromEnv() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var buf bytes.Buffer
+	WriteJSONError(&buf, errors.New(`failed to load "config"`))
+
+	want := `{"error":"failed to load \"config\""}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteJSONError() = %q, want %q", got, want)
+	}
+}
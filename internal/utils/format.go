@@ -71,6 +71,54 @@ func FormatScore(label string, value interface{}) string {
 	}
 }
 
+// ParseGerritTime parses a Gerrit REST timestamp ("2006-01-02 15:04:05.000000000", UTC).
+func ParseGerritTime(value string) (time.Time, error) {
+	formats := []string{
+		"2006-01-02 15:04:05.000000000",
+		"2006-01-02 15:04:05",
+		time.RFC3339,
+	}
+	for _, format := range formats {
+		if parsed, err := time.ParseInLocation(format, value, time.UTC); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp: %q", value)
+}
+
+// ParseDuration extends time.ParseDuration with day ("d") and week ("w")
+// units, e.g. "2d", "1w", or "36h".
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.ParseFloat(n, 64)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration: %q", value)
+			}
+			return time.Duration(count * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %q", value)
+	}
+	return d, nil
+}
+
+// FormatDuration renders a duration compactly as days and hours, e.g. "3d 4h".
+func FormatDuration(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if days == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
 func FormatTimeAgo(timestamp interface{}) string {
 	var t time.Time
 
@@ -206,6 +254,35 @@ func timeAgo(t time.Time) string {
 	}
 }
 
+// Sparkline renders values as a row of block characters scaled between the
+// smallest and largest value, e.g. "▁▃▅█".
+func Sparkline(values []float64) string {
+	const blocks = "▁▂▃▄▅▆▇█"
+	levels := []rune(blocks)
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(levels)-1))
+		}
+		sb.WriteRune(levels[level])
+	}
+	return sb.String()
+}
+
 func TruncateString(s string, maxLen int) string {
 	runes := []rune(s)
 	if len(runes) <= maxLen {
@@ -131,3 +131,66 @@ func TestFormatTable(t *testing.T) {
 		t.Errorf("FormatTable with no rows should be empty, got %q", result)
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"48h", 48 * time.Hour, false},
+		{"2d", 48 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{30 * time.Minute, "30m"},
+		{5 * time.Hour, "5h"},
+		{76 * time.Hour, "3d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.input); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		input []float64
+		want  string
+	}{
+		{nil, ""},
+		{[]float64{5}, "▁"},
+		{[]float64{3, 3, 3}, "▁▁▁"},
+		{[]float64{0, 7, 14}, "▁▄█"},
+		{[]float64{10, 0}, "█▁"},
+	}
+
+	for _, tt := range tests {
+		if got := Sparkline(tt.input); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
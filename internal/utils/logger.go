@@ -44,6 +44,12 @@ func SetLogLevel(level LogLevel) {
 	defaultLogger.level = level
 }
 
+// SetLogOutput sends log messages to w, e.g. stderr when stdout carries
+// machine-readable output.
+func SetLogOutput(w io.Writer) {
+	defaultLogger = NewLogger(defaultLogger.level, w)
+}
+
 func SetLogLevelFromString(levelStr string) {
 	switch levelStr {
 	case "debug":
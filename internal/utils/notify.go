@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// Notify shows a desktop notification.
+func Notify(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("notify-send", title, message)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+	// progressOutput is where progress is drawn. It is stderr so that piped
+	// stdout (reports, JSON) is never interleaved with redraws.
+	progressOutput io.Writer = os.Stderr
+
+	progressMu       sync.Mutex
+	progressDisabled bool
+)
+
+// DisableProgress silences all spinners and progress bars, e.g. when the
+// command is producing machine-readable output.
+func DisableProgress() {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	progressDisabled = true
+}
+
+// progressEnabled reports whether progress should be drawn: not disabled and
+// stderr is an interactive terminal.
+func progressEnabled() bool {
+	progressMu.Lock()
+	disabled := progressDisabled
+	progressMu.Unlock()
+	if disabled {
+		return false
+	}
+	f, ok := progressOutput.(*os.File)
+	if !ok {
+		return false
+	}
+	return IsTerminal(f)
+}
+
+// IsTerminal reports whether f is an interactive terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Progress is a single-line terminal progress indicator. A spinner animates
+// while a single request is in flight; a bar tracks a count, optionally
+// against a known total. All methods are no-ops when progress is disabled.
+type Progress struct {
+	mu      sync.Mutex
+	out     io.Writer
+	enabled bool
+	message string
+	current int
+	total   int
+	spinner bool
+	frame   int
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// NewSpinner starts an animated spinner with the given message.
+func NewSpinner(message string) *Progress {
+	p := &Progress{
+		out:     progressOutput,
+		enabled: progressEnabled(),
+		message: message,
+		spinner: true,
+	}
+	if !p.enabled {
+		return p
+	}
+
+	p.stop = make(chan struct{})
+	p.stopped.Add(1)
+	go func() {
+		defer p.stopped.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			p.mu.Lock()
+			p.render()
+			p.frame++
+			p.mu.Unlock()
+
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return p
+}
+
+// NewProgressBar starts a progress bar. When total is zero or less the bar
+// is indeterminate and shows only the running count.
+func NewProgressBar(message string, total int) *Progress {
+	p := &Progress{
+		out:     progressOutput,
+		enabled: progressEnabled(),
+		message: message,
+		total:   total,
+	}
+	p.mu.Lock()
+	p.render()
+	p.mu.Unlock()
+	return p
+}
+
+// Set updates the current count and redraws.
+func (p *Progress) Set(current int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = current
+	if !p.spinner {
+		p.render()
+	}
+}
+
+// SetMessage replaces the message shown next to the indicator.
+func (p *Progress) SetMessage(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.message = message
+	if !p.spinner {
+		p.render()
+	}
+}
+
+// Done stops the indicator and leaves a final summary line. An empty summary
+// clears the line instead.
+func (p *Progress) Done(summary string) {
+	p.halt()
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if summary == "" {
+		fmt.Fprint(p.out, "\r\033[K")
+		return
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s\n", summary)
+}
+
+// Stop stops the indicator and clears its line, e.g. before printing an error.
+func (p *Progress) Stop() {
+	p.Done("")
+}
+
+func (p *Progress) halt() {
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	p.stopped.Wait()
+	p.stop = nil
+}
+
+// render draws the current state. Callers must hold p.mu.
+func (p *Progress) render() {
+	if !p.enabled {
+		return
+	}
+	var line string
+	switch {
+	case p.spinner:
+		line = fmt.Sprintf("%s %s", Cyan(spinnerFrames[p.frame%len(spinnerFrames)]), p.message)
+	case p.total > 0:
+		line = fmt.Sprintf("%s %s %d/%d", p.message, renderBar(p.current, p.total, 30), p.current, p.total)
+	default:
+		line = fmt.Sprintf("%s... %d so far", p.message, p.current)
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s", line)
+}
+
+// renderBar draws a fixed-width bar such as "[=======>      ]".
+func renderBar(current, total, width int) string {
+	if total <= 0 || width <= 0 {
+		return ""
+	}
+	if current > total {
+		current = total
+	}
+	if current < 0 {
+		current = 0
+	}
+	filled := current * width / total
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(strings.Repeat("=", filled))
+	if filled < width {
+		sb.WriteString(">")
+		sb.WriteString(strings.Repeat(" ", width-filled-1))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// Notef prints a status line on the progress stream (stderr), clearing any
+// progress line being drawn so the two don't overlap. Use it for transient
+// conditions, such as a retry, that shouldn't mix with report output.
+func Notef(format string, args ...interface{}) {
+	prefix := ""
+	if progressEnabled() {
+		prefix = "\r\033[K"
+	}
+	fmt.Fprintf(progressOutput, "%s%s\n", prefix, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderBar(t *testing.T) {
+	tests := []struct {
+		current, total, width int
+		want                  string
+	}{
+		{0, 10, 10, "[>         ]"},
+		{5, 10, 10, "[=====>    ]"},
+		{10, 10, 10, "[==========]"},
+		{15, 10, 10, "[==========]"},
+		{-1, 10, 4, "[>   ]"},
+		{3, 0, 10, ""},
+	}
+
+	for _, tt := range tests {
+		got := renderBar(tt.current, tt.total, tt.width)
+		if got != tt.want {
+			t.Errorf("renderBar(%d, %d, %d) = %q, want %q", tt.current, tt.total, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestProgressSilentWhenNotTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	orig := progressOutput
+	progressOutput = &buf
+	defer func() { progressOutput = orig }()
+
+	bar := NewProgressBar("Fetching", 10)
+	bar.Set(5)
+	bar.Done("done")
+
+	spinner := NewSpinner("Loading")
+	spinner.Stop()
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for non-terminal writer, got %q", buf.String())
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableColumn is a column a TableSpec can show. Value renders its cell for
+// one row.
+type TableColumn struct {
+	Name   string
+	Header string
+	Value  func(row interface{}) string
+}
+
+// TableSort is a named row order. Less reports whether row a comes before b.
+type TableSort struct {
+	Name string
+	Less func(a, b interface{}) bool
+}
+
+// TableSpec describes a table whose columns and row order are picked by
+// name at run time, e.g. from --columns and --sort flags.
+type TableSpec struct {
+	Columns  []TableColumn
+	Defaults []string
+	Sorts    []TableSort
+}
+
+// ParseColumns resolves a comma-separated list of column names, in the
+// order given. An empty list selects the default columns.
+func (s TableSpec) ParseColumns(list string) ([]TableColumn, error) {
+	names := s.Defaults
+	if strings.TrimSpace(list) != "" {
+		names = strings.Split(list, ",")
+	}
+
+	var columns []TableColumn
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		column, ok := s.column(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column: %s (supported: %s)", name, strings.Join(s.columnNames(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column: %s", name)
+		}
+		seen[name] = true
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return columns, nil
+}
+
+// SortOrder returns the Less function of the named order, or nil for an
+// empty name, which keeps rows as they are.
+func (s TableSpec) SortOrder(name string) (func(a, b interface{}) bool, error) {
+	if name == "" {
+		return nil, nil
+	}
+	order, ok := s.sort(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown sort: %s (supported: %s)", name, strings.Join(s.sortNames(), ", "))
+	}
+	return order.Less, nil
+}
+
+// Format renders rows with the given columns, as FormatTable does.
+func (s TableSpec) Format(columns []TableColumn, rows []interface{}, padding int) string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			cells[i][j] = column.Value(row)
+		}
+	}
+	return FormatTable(headers, cells, padding)
+}
+
+func (s TableSpec) column(name string) (TableColumn, bool) {
+	for _, column := range s.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return TableColumn{}, false
+}
+
+func (s TableSpec) columnNames() []string {
+	names := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+func (s TableSpec) sort(name string) (TableSort, bool) {
+	for _, order := range s.Sorts {
+		if order.Name == strings.ToLower(name) {
+			return order, true
+		}
+	}
+	return TableSort{}, false
+}
+
+func (s TableSpec) sortNames() []string {
+	names := make([]string, len(s.Sorts))
+	for i, order := range s.Sorts {
+		names[i] = order.Name
+	}
+	return names
+}
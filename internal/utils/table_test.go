@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func testTableSpec() TableSpec {
+	return TableSpec{
+		Columns: []TableColumn{
+			{Name: "name", Header: "Name", Value: func(row interface{}) string { return row.(string) }},
+			{Name: "length", Header: "Len", Value: func(row interface{}) string { return strings.Repeat("#", len(row.(string))) }},
+		},
+		Defaults: []string{"name"},
+		Sorts: []TableSort{
+			{Name: "name", Less: func(a, b interface{}) bool { return a.(string) < b.(string) }},
+		},
+	}
+}
+
+func TestTableSpecParseColumns(t *testing.T) {
+	spec := testTableSpec()
+	tests := []struct {
+		list    string
+		want    []string
+		wantErr string
+	}{
+		{"", []string{"name"}, ""},
+		{"length,name", []string{"length", "name"}, ""},
+		{" Length , ", []string{"length"}, ""},
+		{"name,size", nil, "unknown column: size (supported: name, length)"},
+		{"name,name", nil, "duplicate column: name"},
+		{",", nil, "no columns selected"},
+	}
+	for _, tt := range tests {
+		columns, err := spec.ParseColumns(tt.list)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParseColumns(%q) error = %v, want %q", tt.list, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseColumns(%q): %v", tt.list, err)
+		}
+		var got []string
+		for _, column := range columns {
+			got = append(got, column.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("ParseColumns(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestTableSpecSortOrder(t *testing.T) {
+	spec := testTableSpec()
+	if less, err := spec.SortOrder(""); less != nil || err != nil {
+		t.Errorf("SortOrder(\"\") returned an order or error %v, want neither", err)
+	}
+	if less, err := spec.SortOrder("NAME"); err != nil || !less("a", "b") {
+		t.Errorf("SortOrder(NAME) error = %v, or its order is not by name", err)
+	}
+	if _, err := spec.SortOrder("size"); err == nil || !strings.Contains(err.Error(), "unknown sort: size") {
+		t.Errorf("SortOrder(size) error = %v", err)
+	}
+}
+
+func TestTableSpecFormat(t *testing.T) {
+	spec := testTableSpec()
+	columns, err := spec.ParseColumns("length,name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := stripANSI(spec.Format(columns, []interface{}{"ab", "abcd"}, 1))
+	want := "Len  Name\n---- ----\n##   ab  \n#### abcd\n"
+	if got != want {
+		t.Errorf("Format() =\n%q\nwant\n%q", got, want)
+	}
+}